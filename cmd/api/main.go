@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/app"
 	"maglev.onebusaway.org/internal/appconf"
 	"maglev.onebusaway.org/internal/gtfs"
@@ -23,11 +24,15 @@ func main() {
 	var cfg appconf.Config
 	var gtfsCfg gtfs.Config
 	var apiKeysFlag string
+	var adminAPIKeysFlag string
 	var envFlag string
+	var feedsConfigFlag string
+	var alarmCallbackHostsFlag string
 
 	flag.IntVar(&cfg.Port, "port", 4000, "API server port")
 	flag.StringVar(&envFlag, "env", "development", "Environment (development|test|production)")
 	flag.StringVar(&apiKeysFlag, "api-keys", "test", "Comma Separated API Keys (test, etc)")
+	flag.StringVar(&adminAPIKeysFlag, "admin-api-keys", "", "Comma Separated API Keys for the admin endpoints (disabled when empty)")
 	flag.IntVar(&cfg.RateLimit, "rate-limit", 100, "Requests per second per API key for rate limiting")
 	flag.StringVar(&gtfsCfg.GtfsURL, "gtfs-url", "https://www.soundtransit.org/GTFS-rail/40_gtfs.zip", "URL for a static GTFS zip file")
 	flag.StringVar(&gtfsCfg.TripUpdatesURL, "trip-updates-url", "https://api.pugetsound.onebusaway.org/api/gtfs_realtime/trip-updates-for-agency/40.pb?key=org.onebusaway.iphone", "URL for a GTFS-RT trip updates feed")
@@ -36,6 +41,11 @@ func main() {
 	flag.StringVar(&gtfsCfg.RealTimeAuthHeaderValue, "realtime-auth-header-value", "", "Optional header value for GTFS-RT auth")
 	flag.StringVar(&gtfsCfg.ServiceAlertsURL, "service-alerts-url", "", "URL for a GTFS-RT service alerts feed")
 	flag.StringVar(&gtfsCfg.GTFSDataPath, "data-path", "./gtfs.db", "Path to the SQLite database containing GTFS data")
+	flag.DurationVar(&gtfsCfg.StaticDownload.Timeout, "gtfs-download-timeout", gtfsdb.DefaultDownloadTimeout, "Time limit on each attempt to download a static GTFS feed")
+	flag.Int64Var(&gtfsCfg.StaticDownload.MaxBytes, "gtfs-max-download-bytes", gtfsdb.DefaultMaxDownloadBytes, "Largest static GTFS feed accepted, in bytes")
+	flag.IntVar(&gtfsCfg.StaticDownload.MaxAttempts, "gtfs-download-attempts", gtfsdb.DefaultDownloadAttempts, "Attempts made to download a static GTFS feed, with exponential backoff between them")
+	flag.StringVar(&alarmCallbackHostsFlag, "alarm-callback-allowed-hosts", "", "Comma Separated hosts that arrival alarm callbacks may be sent to although they aren't public (localhost, etc)")
+	flag.StringVar(&feedsConfigFlag, "feeds-config", "", "Path to a JSON file listing several GTFS feeds to serve, in place of the single feed flags")
 	flag.Parse()
 
 	gtfsCfg.Verbose = true
@@ -48,10 +58,33 @@ func main() {
 		}
 	}
 
+	if adminAPIKeysFlag != "" {
+		cfg.AdminApiKeys = strings.Split(adminAPIKeysFlag, ",")
+		for i := range cfg.AdminApiKeys {
+			cfg.AdminApiKeys[i] = strings.TrimSpace(cfg.AdminApiKeys[i])
+		}
+	}
+
+	if alarmCallbackHostsFlag != "" {
+		gtfsCfg.AlarmCallbackAllowedHosts = strings.Split(alarmCallbackHostsFlag, ",")
+		for i := range gtfsCfg.AlarmCallbackAllowedHosts {
+			gtfsCfg.AlarmCallbackAllowedHosts[i] = strings.TrimSpace(gtfsCfg.AlarmCallbackAllowedHosts[i])
+		}
+	}
+
 	cfg.Env = appconf.EnvFlagToEnvironment(envFlag)
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
+	if feedsConfigFlag != "" {
+		feeds, err := gtfs.LoadFeedsConfig(feedsConfigFlag)
+		if err != nil {
+			logger.Error("failed to load feeds config", "error", err)
+			os.Exit(1)
+		}
+		gtfsCfg.Feeds = feeds
+	}
+
 	gtfsManager, err := gtfs.InitGTFSManager(gtfsCfg)
 	if err != nil {
 		logger.Error("failed to initialize GTFS manager", "error", err)
@@ -59,7 +92,7 @@ func main() {
 
 	var directionCalculator *gtfs.DirectionCalculator
 	if gtfsManager != nil {
-		directionCalculator = gtfs.NewDirectionCalculator(gtfsManager.GtfsDB.Queries)
+		directionCalculator = gtfs.NewDirectionCalculator(gtfsManager)
 	}
 
 	coreApp := &app.Application{
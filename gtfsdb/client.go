@@ -3,20 +3,34 @@ package gtfsdb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
+	"sync"
 	"time"
 )
 
+// DefaultFeedID identifies the feed imported by DownloadAndStore and ImportFromFile.
+const DefaultFeedID = "default"
+
 // Client is the main entry point for the library
 type Client struct {
-	config        Config
-	DB            *sql.DB
-	Queries       *Queries
+	config  Config
+	DB      *sql.DB
+	Queries *Queries
+	// UserDB holds the user tables, such as arrival alarms and problem reports. It is never
+	// rebuilt, so every client of one database shares it.
+	UserDB        *sql.DB
+	UserQueries   *Queries
 	importRuntime time.Duration
+	importMutex   sync.Mutex // Serializes feed imports
+
+	// vocabularies caches the terms of each fts5vocab table for fuzzy search, until the search
+	// indexes are next rebuilt
+	vocabularyMutex      sync.Mutex
+	vocabularies         map[string][]string
+	vocabularyGeneration int
 }
 
 // NewClient creates a new Client with the provided configuration
@@ -28,45 +42,92 @@ func NewClient(config Config) (*Client, error) {
 		log.Println("Successfully created tables")
 	}
 
-	queries := New(db)
+	if config.userDSN == "" {
+		config.userDSN = databaseDSN(userDBPath(config.DBPath))
+	}
+	userDB, err := createUserDB(config.userDSN)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to create user DB: %w", err)
+	}
+	if err := moveUserTables(context.Background(), db, userDB); err != nil {
+		_ = db.Close()
+		_ = userDB.Close()
+		return nil, fmt.Errorf("error moving user tables: %w", err)
+	}
 
 	client := &Client{
-		config:  config,
-		DB:      db,
-		Queries: queries,
+		config:      config,
+		DB:          db,
+		Queries:     New(db),
+		UserDB:      userDB,
+		UserQueries: New(userDB),
 	}
 	return client, nil
 }
 
 func (c *Client) Close() error {
-	return c.DB.Close()
+	return errors.Join(c.DB.Close(), c.UserDB.Close())
 }
 
 // DownloadAndStore downloads GTFS data from the given URL and stores it in the database
 func (c *Client) DownloadAndStore(ctx context.Context, url string) error {
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
+	return c.DownloadAndStoreFeed(ctx, DefaultFeedID, url)
+}
+
+// DownloadAndStoreFeed downloads GTFS data from the given URL and stores it in the database as
+// the feed with the given ID, replacing that feed's previous data only. The download is
+// conditional on the feed having changed since it was last imported from url.
+func (c *Client) DownloadAndStoreFeed(ctx context.Context, feedID, url string) error {
+	var validators FeedValidators
+	if metadata, err := c.Queries.GetImportMetadata(ctx, feedID); err == nil && metadata.FileSource == url {
+		validators = metadata.Validators()
 	}
 
-	b, err := io.ReadAll(resp.Body)
+	download, err := DownloadFeed(ctx, url, validators, c.config.Download)
 	if err != nil {
 		return err
 	}
+	if download.NotModified {
+		return nil
+	}
 
-	err = c.processAndStoreGTFSDataWithSource(b, url)
+	return c.ImportFeedDownload(ctx, feedID, download, url)
+}
 
-	return err
+// ImportFeedDownload imports a feed already downloaded from source into the database as the
+// feed with the given ID, replacing that feed's previous data only, and records the download's
+// validators for the next conditional request.
+func (c *Client) ImportFeedDownload(ctx context.Context, feedID string, download *FeedDownload, source string) error {
+	if err := c.processAndStoreFeedData(feedID, download.Data, source); err != nil {
+		return err
+	}
+	return c.UpdateFeedValidators(ctx, feedID, download.FeedValidators)
+}
+
+// UpdateFeedValidators records the validators of the latest download of an imported feed.
+func (c *Client) UpdateFeedValidators(ctx context.Context, feedID string, validators FeedValidators) error {
+	return c.Queries.UpdateImportMetadataValidators(ctx, UpdateImportMetadataValidatorsParams{
+		Etag:         toNullString(validators.ETag),
+		LastModified: toNullString(validators.LastModified),
+		FeedID:       feedID,
+	})
 }
 
 // ImportFromFile imports GTFS data from a local zip file into the database
 func (c *Client) ImportFromFile(ctx context.Context, path string) error {
+	return c.ImportFeedFromFile(ctx, DefaultFeedID, path)
+}
+
+// ImportFeedFromFile imports GTFS data from a local zip file into the database as the feed with
+// the given ID, replacing that feed's previous data only
+func (c *Client) ImportFeedFromFile(ctx context.Context, feedID, path string) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return err
 	}
 
-	err = c.processAndStoreGTFSDataWithSource(data, path)
+	err = c.processAndStoreFeedData(feedID, data, path)
 
 	return err
 }
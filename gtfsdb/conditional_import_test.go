@@ -61,7 +61,7 @@ func TestConditionalImport_InitialImport(t *testing.T) {
 	require.NoError(t, err, "Initial import should succeed")
 
 	// Verify metadata was stored
-	metadata, err := client.Queries.GetImportMetadata(ctx)
+	metadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve import metadata")
 
 	assert.NotEmpty(t, metadata.FileHash, "File hash should be stored")
@@ -94,7 +94,7 @@ func TestConditionalImport_SkipUnchangedData(t *testing.T) {
 	require.NoError(t, err, "Initial import should succeed")
 
 	// Get initial metadata
-	initialMetadata, err := client.Queries.GetImportMetadata(ctx)
+	initialMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve initial metadata")
 
 	// Get initial agency count
@@ -115,7 +115,7 @@ func TestConditionalImport_SkipUnchangedData(t *testing.T) {
 	assert.Less(t, duration, 100*time.Millisecond, "Import should be very fast when skipped")
 
 	// Verify metadata unchanged
-	finalMetadata, err := client.Queries.GetImportMetadata(ctx)
+	finalMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve final metadata")
 
 	assert.Equal(t, initialMetadata.FileHash, finalMetadata.FileHash, "File hash should be unchanged")
@@ -148,7 +148,7 @@ func TestConditionalImport_ReloadChangedData(t *testing.T) {
 	require.NoError(t, err, "Initial import should succeed")
 
 	// Get initial metadata
-	initialMetadata, err := client.Queries.GetImportMetadata(ctx)
+	initialMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve initial metadata")
 
 	// Perform import with modified data
@@ -156,7 +156,7 @@ func TestConditionalImport_ReloadChangedData(t *testing.T) {
 	require.NoError(t, err, "Import with modified data should succeed")
 
 	// Verify metadata was updated
-	finalMetadata, err := client.Queries.GetImportMetadata(ctx)
+	finalMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve final metadata")
 
 	assert.NotEqual(t, initialMetadata.FileHash, finalMetadata.FileHash, "File hash should have changed")
@@ -191,7 +191,7 @@ func TestConditionalImport_DifferentSources(t *testing.T) {
 	require.NoError(t, err, "Initial import should succeed")
 
 	// Get initial metadata
-	initialMetadata, err := client.Queries.GetImportMetadata(ctx)
+	initialMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve initial metadata")
 
 	// Perform import with same data but different source
@@ -199,7 +199,7 @@ func TestConditionalImport_DifferentSources(t *testing.T) {
 	require.NoError(t, err, "Import with different source should succeed")
 
 	// Verify metadata was updated (different source should trigger reimport)
-	finalMetadata, err := client.Queries.GetImportMetadata(ctx)
+	finalMetadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve final metadata")
 
 	assert.Equal(t, initialMetadata.FileHash, finalMetadata.FileHash, "File hash should be the same")
@@ -227,7 +227,7 @@ func TestConditionalImport_FileImport(t *testing.T) {
 	require.NoError(t, err, "File import should succeed")
 
 	// Verify metadata was stored with file path as source
-	metadata, err := client.Queries.GetImportMetadata(ctx)
+	metadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Should be able to retrieve import metadata")
 
 	assert.NotEmpty(t, metadata.FileHash, "File hash should be stored")
@@ -249,7 +249,7 @@ func TestConditionalImport_FileImport(t *testing.T) {
 	assert.Less(t, duration, 100*time.Millisecond, "Second import should be very fast when skipped")
 }
 
-func TestClearAllGTFSData(t *testing.T) {
+func TestClearFeedGTFSData(t *testing.T) {
 	// Create in-memory database
 	config := Config{
 		DBPath:  ":memory:",
@@ -273,9 +273,9 @@ func TestClearAllGTFSData(t *testing.T) {
 	require.NoError(t, err, "Should be able to retrieve agencies")
 	assert.Greater(t, len(agencies), 0, "Should have agencies before clear")
 
-	// Clear all data
-	err = client.clearAllGTFSData(ctx)
-	require.NoError(t, err, "Should be able to clear all GTFS data")
+	// Clear the feed's data
+	err = client.clearFeedGTFSData(ctx, DefaultFeedID)
+	require.NoError(t, err, "Should be able to clear the feed's GTFS data")
 
 	// Verify all data was cleared
 	agenciesAfter, err := client.Queries.ListAgencies(ctx)
@@ -286,8 +286,8 @@ func TestClearAllGTFSData(t *testing.T) {
 	require.NoError(t, err, "Should be able to query routes after clear")
 	assert.Equal(t, 0, len(routesAfter), "Should have no routes after clear")
 
-	// Note: Import metadata should NOT be cleared by clearAllGTFSData
-	metadata, err := client.Queries.GetImportMetadata(ctx)
+	// Note: Import metadata should NOT be cleared by clearFeedGTFSData
+	metadata, err := client.Queries.GetImportMetadata(ctx, DefaultFeedID)
 	require.NoError(t, err, "Import metadata should still exist after clear")
 	assert.NotEmpty(t, metadata.FileHash, "Import metadata should not be cleared")
 }
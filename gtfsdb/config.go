@@ -8,6 +8,13 @@ type Config struct {
 	DBPath  string              // Path to SQLite database file
 	verbose bool                // Verbose logging
 	Env     appconf.Environment // Environment name: development, test, production.
+
+	// Download limits feed downloads by DownloadAndStoreFeed
+	Download DownloadConfig
+
+	// userDSN is the data source name of the user database, which is derived from DBPath
+	// unless set, such as for a rebuild sharing the live database's
+	userDSN string
 }
 
 func NewConfig(dbPath string, env appconf.Environment, verbose bool) Config {
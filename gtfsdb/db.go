@@ -24,51 +24,183 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.claimArrivalAlarmStmt, err = db.PrepareContext(ctx, claimArrivalAlarm); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimArrivalAlarm: %w", err)
+	}
 	if q.clearAgenciesStmt, err = db.PrepareContext(ctx, clearAgencies); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearAgencies: %w", err)
 	}
+	if q.clearAreasStmt, err = db.PrepareContext(ctx, clearAreas); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearAreas: %w", err)
+	}
 	if q.clearCalendarStmt, err = db.PrepareContext(ctx, clearCalendar); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearCalendar: %w", err)
 	}
+	if q.clearFareAttributesStmt, err = db.PrepareContext(ctx, clearFareAttributes); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareAttributes: %w", err)
+	}
+	if q.clearFareLegRulesStmt, err = db.PrepareContext(ctx, clearFareLegRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareLegRules: %w", err)
+	}
+	if q.clearFareMediaStmt, err = db.PrepareContext(ctx, clearFareMedia); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareMedia: %w", err)
+	}
+	if q.clearFareProductsStmt, err = db.PrepareContext(ctx, clearFareProducts); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareProducts: %w", err)
+	}
+	if q.clearFareRulesStmt, err = db.PrepareContext(ctx, clearFareRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareRules: %w", err)
+	}
+	if q.clearFareTransferRulesStmt, err = db.PrepareContext(ctx, clearFareTransferRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFareTransferRules: %w", err)
+	}
+	if q.clearFeedInfoStmt, err = db.PrepareContext(ctx, clearFeedInfo); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFeedInfo: %w", err)
+	}
+	if q.clearFrequenciesStmt, err = db.PrepareContext(ctx, clearFrequencies); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearFrequencies: %w", err)
+	}
+	if q.clearLevelsStmt, err = db.PrepareContext(ctx, clearLevels); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearLevels: %w", err)
+	}
+	if q.clearNetworksStmt, err = db.PrepareContext(ctx, clearNetworks); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearNetworks: %w", err)
+	}
+	if q.clearPathwaysStmt, err = db.PrepareContext(ctx, clearPathways); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearPathways: %w", err)
+	}
+	if q.clearRouteNetworksStmt, err = db.PrepareContext(ctx, clearRouteNetworks); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearRouteNetworks: %w", err)
+	}
 	if q.clearRoutesStmt, err = db.PrepareContext(ctx, clearRoutes); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearRoutes: %w", err)
 	}
 	if q.clearShapesStmt, err = db.PrepareContext(ctx, clearShapes); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearShapes: %w", err)
 	}
+	if q.clearStopAreasStmt, err = db.PrepareContext(ctx, clearStopAreas); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearStopAreas: %w", err)
+	}
 	if q.clearStopTimesStmt, err = db.PrepareContext(ctx, clearStopTimes); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearStopTimes: %w", err)
 	}
 	if q.clearStopsStmt, err = db.PrepareContext(ctx, clearStops); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearStops: %w", err)
 	}
+	if q.clearTimeframesStmt, err = db.PrepareContext(ctx, clearTimeframes); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearTimeframes: %w", err)
+	}
+	if q.clearTransfersStmt, err = db.PrepareContext(ctx, clearTransfers); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearTransfers: %w", err)
+	}
+	if q.clearTranslationsStmt, err = db.PrepareContext(ctx, clearTranslations); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearTranslations: %w", err)
+	}
 	if q.clearTripsStmt, err = db.PrepareContext(ctx, clearTrips); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearTrips: %w", err)
 	}
+	if q.clearValidationFindingsStmt, err = db.PrepareContext(ctx, clearValidationFindings); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearValidationFindings: %w", err)
+	}
+	if q.countArrivalAlarmsStmt, err = db.PrepareContext(ctx, countArrivalAlarms); err != nil {
+		return nil, fmt.Errorf("error preparing query CountArrivalAlarms: %w", err)
+	}
+	if q.countArrivalAlarmsForClientStmt, err = db.PrepareContext(ctx, countArrivalAlarmsForClient); err != nil {
+		return nil, fmt.Errorf("error preparing query CountArrivalAlarmsForClient: %w", err)
+	}
+	if q.countValidationFindingsStmt, err = db.PrepareContext(ctx, countValidationFindings); err != nil {
+		return nil, fmt.Errorf("error preparing query CountValidationFindings: %w", err)
+	}
 	if q.createAgencyStmt, err = db.PrepareContext(ctx, createAgency); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateAgency: %w", err)
 	}
+	if q.createAreaStmt, err = db.PrepareContext(ctx, createArea); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateArea: %w", err)
+	}
+	if q.createArrivalAlarmStmt, err = db.PrepareContext(ctx, createArrivalAlarm); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateArrivalAlarm: %w", err)
+	}
 	if q.createCalendarStmt, err = db.PrepareContext(ctx, createCalendar); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCalendar: %w", err)
 	}
 	if q.createCalendarDateStmt, err = db.PrepareContext(ctx, createCalendarDate); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCalendarDate: %w", err)
 	}
+	if q.createFareAttributeStmt, err = db.PrepareContext(ctx, createFareAttribute); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareAttribute: %w", err)
+	}
+	if q.createFareLegRuleStmt, err = db.PrepareContext(ctx, createFareLegRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareLegRule: %w", err)
+	}
+	if q.createFareMediaStmt, err = db.PrepareContext(ctx, createFareMedia); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareMedia: %w", err)
+	}
+	if q.createFareProductStmt, err = db.PrepareContext(ctx, createFareProduct); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareProduct: %w", err)
+	}
+	if q.createFareRuleStmt, err = db.PrepareContext(ctx, createFareRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareRule: %w", err)
+	}
+	if q.createFareTransferRuleStmt, err = db.PrepareContext(ctx, createFareTransferRule); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFareTransferRule: %w", err)
+	}
+	if q.createFeedInfoStmt, err = db.PrepareContext(ctx, createFeedInfo); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFeedInfo: %w", err)
+	}
+	if q.createFrequencyStmt, err = db.PrepareContext(ctx, createFrequency); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateFrequency: %w", err)
+	}
+	if q.createLevelStmt, err = db.PrepareContext(ctx, createLevel); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateLevel: %w", err)
+	}
+	if q.createNetworkStmt, err = db.PrepareContext(ctx, createNetwork); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateNetwork: %w", err)
+	}
+	if q.createPathwayStmt, err = db.PrepareContext(ctx, createPathway); err != nil {
+		return nil, fmt.Errorf("error preparing query CreatePathway: %w", err)
+	}
+	if q.createProblemReportStmt, err = db.PrepareContext(ctx, createProblemReport); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateProblemReport: %w", err)
+	}
 	if q.createRouteStmt, err = db.PrepareContext(ctx, createRoute); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateRoute: %w", err)
 	}
+	if q.createRouteNetworkStmt, err = db.PrepareContext(ctx, createRouteNetwork); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateRouteNetwork: %w", err)
+	}
 	if q.createShapeStmt, err = db.PrepareContext(ctx, createShape); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateShape: %w", err)
 	}
 	if q.createStopStmt, err = db.PrepareContext(ctx, createStop); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateStop: %w", err)
 	}
+	if q.createStopAreaStmt, err = db.PrepareContext(ctx, createStopArea); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateStopArea: %w", err)
+	}
 	if q.createStopTimeStmt, err = db.PrepareContext(ctx, createStopTime); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateStopTime: %w", err)
 	}
+	if q.createTimeframeStmt, err = db.PrepareContext(ctx, createTimeframe); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTimeframe: %w", err)
+	}
+	if q.createTransferStmt, err = db.PrepareContext(ctx, createTransfer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTransfer: %w", err)
+	}
+	if q.createTranslationStmt, err = db.PrepareContext(ctx, createTranslation); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateTranslation: %w", err)
+	}
 	if q.createTripStmt, err = db.PrepareContext(ctx, createTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateTrip: %w", err)
 	}
+	if q.createValidationFindingStmt, err = db.PrepareContext(ctx, createValidationFinding); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateValidationFinding: %w", err)
+	}
+	if q.deleteArrivalAlarmStmt, err = db.PrepareContext(ctx, deleteArrivalAlarm); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteArrivalAlarm: %w", err)
+	}
+	if q.deleteImportMetadataStmt, err = db.PrepareContext(ctx, deleteImportMetadata); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteImportMetadata: %w", err)
+	}
 	if q.getActiveServiceIDsForDateStmt, err = db.PrepareContext(ctx, getActiveServiceIDsForDate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetActiveServiceIDsForDate: %w", err)
 	}
@@ -81,12 +213,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAgencyForStopStmt, err = db.PrepareContext(ctx, getAgencyForStop); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAgencyForStop: %w", err)
 	}
+	if q.getAgencyIDsForTripsStmt, err = db.PrepareContext(ctx, getAgencyIDsForTrips); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAgencyIDsForTrips: %w", err)
+	}
 	if q.getAllShapesStmt, err = db.PrepareContext(ctx, getAllShapes); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllShapes: %w", err)
 	}
 	if q.getAllTripsForRouteStmt, err = db.PrepareContext(ctx, getAllTripsForRoute); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllTripsForRoute: %w", err)
 	}
+	if q.getAreasForStopsStmt, err = db.PrepareContext(ctx, getAreasForStops); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAreasForStops: %w", err)
+	}
+	if q.getArrivalAlarmStmt, err = db.PrepareContext(ctx, getArrivalAlarm); err != nil {
+		return nil, fmt.Errorf("error preparing query GetArrivalAlarm: %w", err)
+	}
 	if q.getArrivalsAndDeparturesForStopStmt, err = db.PrepareContext(ctx, getArrivalsAndDeparturesForStop); err != nil {
 		return nil, fmt.Errorf("error preparing query GetArrivalsAndDeparturesForStop: %w", err)
 	}
@@ -96,21 +237,51 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getBlockIDByTripIDStmt, err = db.PrepareContext(ctx, getBlockIDByTripID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetBlockIDByTripID: %w", err)
 	}
+	if q.getBlockIDsForTripsStmt, err = db.PrepareContext(ctx, getBlockIDsForTrips); err != nil {
+		return nil, fmt.Errorf("error preparing query GetBlockIDsForTrips: %w", err)
+	}
 	if q.getCalendarByServiceIDStmt, err = db.PrepareContext(ctx, getCalendarByServiceID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCalendarByServiceID: %w", err)
 	}
 	if q.getCalendarDateExceptionsForServiceIDStmt, err = db.PrepareContext(ctx, getCalendarDateExceptionsForServiceID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCalendarDateExceptionsForServiceID: %w", err)
 	}
+	if q.getCalendarDateRangeStmt, err = db.PrepareContext(ctx, getCalendarDateRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCalendarDateRange: %w", err)
+	}
+	if q.getChildStopsStmt, err = db.PrepareContext(ctx, getChildStops); err != nil {
+		return nil, fmt.Errorf("error preparing query GetChildStops: %w", err)
+	}
+	if q.getFeedInfoStmt, err = db.PrepareContext(ctx, getFeedInfo); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFeedInfo: %w", err)
+	}
+	if q.getFrequenciesForTripStmt, err = db.PrepareContext(ctx, getFrequenciesForTrip); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFrequenciesForTrip: %w", err)
+	}
+	if q.getFrequenciesForTripsStmt, err = db.PrepareContext(ctx, getFrequenciesForTrips); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFrequenciesForTrips: %w", err)
+	}
 	if q.getImportMetadataStmt, err = db.PrepareContext(ctx, getImportMetadata); err != nil {
 		return nil, fmt.Errorf("error preparing query GetImportMetadata: %w", err)
 	}
+	if q.getLevelsByIDsStmt, err = db.PrepareContext(ctx, getLevelsByIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLevelsByIDs: %w", err)
+	}
+	if q.getNetworksForRoutesStmt, err = db.PrepareContext(ctx, getNetworksForRoutes); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNetworksForRoutes: %w", err)
+	}
 	if q.getNextStopInTripStmt, err = db.PrepareContext(ctx, getNextStopInTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query GetNextStopInTrip: %w", err)
 	}
 	if q.getOrderedStopIDsForTripStmt, err = db.PrepareContext(ctx, getOrderedStopIDsForTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrderedStopIDsForTrip: %w", err)
 	}
+	if q.getPathwaysForStopsStmt, err = db.PrepareContext(ctx, getPathwaysForStops); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPathwaysForStops: %w", err)
+	}
+	if q.getProblemReportStmt, err = db.PrepareContext(ctx, getProblemReport); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProblemReport: %w", err)
+	}
 	if q.getRouteStmt, err = db.PrepareContext(ctx, getRoute); err != nil {
 		return nil, fmt.Errorf("error preparing query GetRoute: %w", err)
 	}
@@ -159,12 +330,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getStopIDsForTripStmt, err = db.PrepareContext(ctx, getStopIDsForTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStopIDsForTrip: %w", err)
 	}
+	if q.getStopIDsForTripsStmt, err = db.PrepareContext(ctx, getStopIDsForTrips); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStopIDsForTrips: %w", err)
+	}
 	if q.getStopTimesByStopIDsStmt, err = db.PrepareContext(ctx, getStopTimesByStopIDs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStopTimesByStopIDs: %w", err)
 	}
 	if q.getStopTimesForTripStmt, err = db.PrepareContext(ctx, getStopTimesForTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStopTimesForTrip: %w", err)
 	}
+	if q.getStopZonesForTripStmt, err = db.PrepareContext(ctx, getStopZonesForTrip); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStopZonesForTrip: %w", err)
+	}
 	if q.getStopsByIDsStmt, err = db.PrepareContext(ctx, getStopsByIDs); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStopsByIDs: %w", err)
 	}
@@ -177,6 +354,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getStopsWithinBoundsStmt, err = db.PrepareContext(ctx, getStopsWithinBounds); err != nil {
 		return nil, fmt.Errorf("error preparing query GetStopsWithinBounds: %w", err)
 	}
+	if q.getTransfersForStopStmt, err = db.PrepareContext(ctx, getTransfersForStop); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransfersForStop: %w", err)
+	}
+	if q.getTransfersFromStopsStmt, err = db.PrepareContext(ctx, getTransfersFromStops); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTransfersFromStops: %w", err)
+	}
 	if q.getTripStmt, err = db.PrepareContext(ctx, getTrip); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTrip: %w", err)
 	}
@@ -195,12 +378,63 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.listAgenciesStmt, err = db.PrepareContext(ctx, listAgencies); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAgencies: %w", err)
 	}
+	if q.listArrivalAlarmsStmt, err = db.PrepareContext(ctx, listArrivalAlarms); err != nil {
+		return nil, fmt.Errorf("error preparing query ListArrivalAlarms: %w", err)
+	}
+	if q.listCalendarDatesStmt, err = db.PrepareContext(ctx, listCalendarDates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalendarDates: %w", err)
+	}
+	if q.listCalendarsStmt, err = db.PrepareContext(ctx, listCalendars); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCalendars: %w", err)
+	}
+	if q.listFareAttributesStmt, err = db.PrepareContext(ctx, listFareAttributes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFareAttributes: %w", err)
+	}
+	if q.listFareLegRulesStmt, err = db.PrepareContext(ctx, listFareLegRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFareLegRules: %w", err)
+	}
+	if q.listFareProductsStmt, err = db.PrepareContext(ctx, listFareProducts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFareProducts: %w", err)
+	}
+	if q.listFareRulesStmt, err = db.PrepareContext(ctx, listFareRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFareRules: %w", err)
+	}
+	if q.listFareTransferRulesStmt, err = db.PrepareContext(ctx, listFareTransferRules); err != nil {
+		return nil, fmt.Errorf("error preparing query ListFareTransferRules: %w", err)
+	}
+	if q.listImportMetadataStmt, err = db.PrepareContext(ctx, listImportMetadata); err != nil {
+		return nil, fmt.Errorf("error preparing query ListImportMetadata: %w", err)
+	}
+	if q.listProblemReportsStmt, err = db.PrepareContext(ctx, listProblemReports); err != nil {
+		return nil, fmt.Errorf("error preparing query ListProblemReports: %w", err)
+	}
 	if q.listRoutesStmt, err = db.PrepareContext(ctx, listRoutes); err != nil {
 		return nil, fmt.Errorf("error preparing query ListRoutes: %w", err)
 	}
+	if q.listTimeframesStmt, err = db.PrepareContext(ctx, listTimeframes); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTimeframes: %w", err)
+	}
+	if q.listTranslationsStmt, err = db.PrepareContext(ctx, listTranslations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTranslations: %w", err)
+	}
 	if q.listTripsStmt, err = db.PrepareContext(ctx, listTrips); err != nil {
 		return nil, fmt.Errorf("error preparing query ListTrips: %w", err)
 	}
+	if q.listValidationFindingsStmt, err = db.PrepareContext(ctx, listValidationFindings); err != nil {
+		return nil, fmt.Errorf("error preparing query ListValidationFindings: %w", err)
+	}
+	if q.rebuildRoutesSearchIndexStmt, err = db.PrepareContext(ctx, rebuildRoutesSearchIndex); err != nil {
+		return nil, fmt.Errorf("error preparing query RebuildRoutesSearchIndex: %w", err)
+	}
+	if q.rebuildStopsSearchIndexStmt, err = db.PrepareContext(ctx, rebuildStopsSearchIndex); err != nil {
+		return nil, fmt.Errorf("error preparing query RebuildStopsSearchIndex: %w", err)
+	}
+	if q.resolveProblemReportStmt, err = db.PrepareContext(ctx, resolveProblemReport); err != nil {
+		return nil, fmt.Errorf("error preparing query ResolveProblemReport: %w", err)
+	}
+	if q.updateImportMetadataValidatorsStmt, err = db.PrepareContext(ctx, updateImportMetadataValidators); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateImportMetadataValidators: %w", err)
+	}
 	if q.upsertImportMetadataStmt, err = db.PrepareContext(ctx, upsertImportMetadata); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertImportMetadata: %w", err)
 	}
@@ -209,16 +443,86 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.claimArrivalAlarmStmt != nil {
+		if cerr := q.claimArrivalAlarmStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimArrivalAlarmStmt: %w", cerr)
+		}
+	}
 	if q.clearAgenciesStmt != nil {
 		if cerr := q.clearAgenciesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearAgenciesStmt: %w", cerr)
 		}
 	}
+	if q.clearAreasStmt != nil {
+		if cerr := q.clearAreasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearAreasStmt: %w", cerr)
+		}
+	}
 	if q.clearCalendarStmt != nil {
 		if cerr := q.clearCalendarStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearCalendarStmt: %w", cerr)
 		}
 	}
+	if q.clearFareAttributesStmt != nil {
+		if cerr := q.clearFareAttributesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareAttributesStmt: %w", cerr)
+		}
+	}
+	if q.clearFareLegRulesStmt != nil {
+		if cerr := q.clearFareLegRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareLegRulesStmt: %w", cerr)
+		}
+	}
+	if q.clearFareMediaStmt != nil {
+		if cerr := q.clearFareMediaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareMediaStmt: %w", cerr)
+		}
+	}
+	if q.clearFareProductsStmt != nil {
+		if cerr := q.clearFareProductsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareProductsStmt: %w", cerr)
+		}
+	}
+	if q.clearFareRulesStmt != nil {
+		if cerr := q.clearFareRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareRulesStmt: %w", cerr)
+		}
+	}
+	if q.clearFareTransferRulesStmt != nil {
+		if cerr := q.clearFareTransferRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFareTransferRulesStmt: %w", cerr)
+		}
+	}
+	if q.clearFeedInfoStmt != nil {
+		if cerr := q.clearFeedInfoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFeedInfoStmt: %w", cerr)
+		}
+	}
+	if q.clearFrequenciesStmt != nil {
+		if cerr := q.clearFrequenciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearFrequenciesStmt: %w", cerr)
+		}
+	}
+	if q.clearLevelsStmt != nil {
+		if cerr := q.clearLevelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearLevelsStmt: %w", cerr)
+		}
+	}
+	if q.clearNetworksStmt != nil {
+		if cerr := q.clearNetworksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearNetworksStmt: %w", cerr)
+		}
+	}
+	if q.clearPathwaysStmt != nil {
+		if cerr := q.clearPathwaysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearPathwaysStmt: %w", cerr)
+		}
+	}
+	if q.clearRouteNetworksStmt != nil {
+		if cerr := q.clearRouteNetworksStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearRouteNetworksStmt: %w", cerr)
+		}
+	}
 	if q.clearRoutesStmt != nil {
 		if cerr := q.clearRoutesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearRoutesStmt: %w", cerr)
@@ -229,6 +533,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing clearShapesStmt: %w", cerr)
 		}
 	}
+	if q.clearStopAreasStmt != nil {
+		if cerr := q.clearStopAreasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearStopAreasStmt: %w", cerr)
+		}
+	}
 	if q.clearStopTimesStmt != nil {
 		if cerr := q.clearStopTimesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearStopTimesStmt: %w", cerr)
@@ -239,16 +548,61 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing clearStopsStmt: %w", cerr)
 		}
 	}
+	if q.clearTimeframesStmt != nil {
+		if cerr := q.clearTimeframesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearTimeframesStmt: %w", cerr)
+		}
+	}
+	if q.clearTransfersStmt != nil {
+		if cerr := q.clearTransfersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearTransfersStmt: %w", cerr)
+		}
+	}
+	if q.clearTranslationsStmt != nil {
+		if cerr := q.clearTranslationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearTranslationsStmt: %w", cerr)
+		}
+	}
 	if q.clearTripsStmt != nil {
 		if cerr := q.clearTripsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearTripsStmt: %w", cerr)
 		}
 	}
+	if q.clearValidationFindingsStmt != nil {
+		if cerr := q.clearValidationFindingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearValidationFindingsStmt: %w", cerr)
+		}
+	}
+	if q.countArrivalAlarmsStmt != nil {
+		if cerr := q.countArrivalAlarmsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countArrivalAlarmsStmt: %w", cerr)
+		}
+	}
+	if q.countArrivalAlarmsForClientStmt != nil {
+		if cerr := q.countArrivalAlarmsForClientStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countArrivalAlarmsForClientStmt: %w", cerr)
+		}
+	}
+	if q.countValidationFindingsStmt != nil {
+		if cerr := q.countValidationFindingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countValidationFindingsStmt: %w", cerr)
+		}
+	}
 	if q.createAgencyStmt != nil {
 		if cerr := q.createAgencyStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createAgencyStmt: %w", cerr)
 		}
 	}
+	if q.createAreaStmt != nil {
+		if cerr := q.createAreaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAreaStmt: %w", cerr)
+		}
+	}
+	if q.createArrivalAlarmStmt != nil {
+		if cerr := q.createArrivalAlarmStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createArrivalAlarmStmt: %w", cerr)
+		}
+	}
 	if q.createCalendarStmt != nil {
 		if cerr := q.createCalendarStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCalendarStmt: %w", cerr)
@@ -259,11 +613,76 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createCalendarDateStmt: %w", cerr)
 		}
 	}
+	if q.createFareAttributeStmt != nil {
+		if cerr := q.createFareAttributeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareAttributeStmt: %w", cerr)
+		}
+	}
+	if q.createFareLegRuleStmt != nil {
+		if cerr := q.createFareLegRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareLegRuleStmt: %w", cerr)
+		}
+	}
+	if q.createFareMediaStmt != nil {
+		if cerr := q.createFareMediaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareMediaStmt: %w", cerr)
+		}
+	}
+	if q.createFareProductStmt != nil {
+		if cerr := q.createFareProductStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareProductStmt: %w", cerr)
+		}
+	}
+	if q.createFareRuleStmt != nil {
+		if cerr := q.createFareRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareRuleStmt: %w", cerr)
+		}
+	}
+	if q.createFareTransferRuleStmt != nil {
+		if cerr := q.createFareTransferRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFareTransferRuleStmt: %w", cerr)
+		}
+	}
+	if q.createFeedInfoStmt != nil {
+		if cerr := q.createFeedInfoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFeedInfoStmt: %w", cerr)
+		}
+	}
+	if q.createFrequencyStmt != nil {
+		if cerr := q.createFrequencyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createFrequencyStmt: %w", cerr)
+		}
+	}
+	if q.createLevelStmt != nil {
+		if cerr := q.createLevelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createLevelStmt: %w", cerr)
+		}
+	}
+	if q.createNetworkStmt != nil {
+		if cerr := q.createNetworkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createNetworkStmt: %w", cerr)
+		}
+	}
+	if q.createPathwayStmt != nil {
+		if cerr := q.createPathwayStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createPathwayStmt: %w", cerr)
+		}
+	}
+	if q.createProblemReportStmt != nil {
+		if cerr := q.createProblemReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createProblemReportStmt: %w", cerr)
+		}
+	}
 	if q.createRouteStmt != nil {
 		if cerr := q.createRouteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createRouteStmt: %w", cerr)
 		}
 	}
+	if q.createRouteNetworkStmt != nil {
+		if cerr := q.createRouteNetworkStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createRouteNetworkStmt: %w", cerr)
+		}
+	}
 	if q.createShapeStmt != nil {
 		if cerr := q.createShapeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createShapeStmt: %w", cerr)
@@ -274,16 +693,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createStopStmt: %w", cerr)
 		}
 	}
+	if q.createStopAreaStmt != nil {
+		if cerr := q.createStopAreaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createStopAreaStmt: %w", cerr)
+		}
+	}
 	if q.createStopTimeStmt != nil {
 		if cerr := q.createStopTimeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createStopTimeStmt: %w", cerr)
 		}
 	}
+	if q.createTimeframeStmt != nil {
+		if cerr := q.createTimeframeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTimeframeStmt: %w", cerr)
+		}
+	}
+	if q.createTransferStmt != nil {
+		if cerr := q.createTransferStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTransferStmt: %w", cerr)
+		}
+	}
+	if q.createTranslationStmt != nil {
+		if cerr := q.createTranslationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createTranslationStmt: %w", cerr)
+		}
+	}
 	if q.createTripStmt != nil {
 		if cerr := q.createTripStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createTripStmt: %w", cerr)
 		}
 	}
+	if q.createValidationFindingStmt != nil {
+		if cerr := q.createValidationFindingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createValidationFindingStmt: %w", cerr)
+		}
+	}
+	if q.deleteArrivalAlarmStmt != nil {
+		if cerr := q.deleteArrivalAlarmStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteArrivalAlarmStmt: %w", cerr)
+		}
+	}
+	if q.deleteImportMetadataStmt != nil {
+		if cerr := q.deleteImportMetadataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteImportMetadataStmt: %w", cerr)
+		}
+	}
 	if q.getActiveServiceIDsForDateStmt != nil {
 		if cerr := q.getActiveServiceIDsForDateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getActiveServiceIDsForDateStmt: %w", cerr)
@@ -304,6 +758,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAgencyForStopStmt: %w", cerr)
 		}
 	}
+	if q.getAgencyIDsForTripsStmt != nil {
+		if cerr := q.getAgencyIDsForTripsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAgencyIDsForTripsStmt: %w", cerr)
+		}
+	}
 	if q.getAllShapesStmt != nil {
 		if cerr := q.getAllShapesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAllShapesStmt: %w", cerr)
@@ -314,6 +773,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAllTripsForRouteStmt: %w", cerr)
 		}
 	}
+	if q.getAreasForStopsStmt != nil {
+		if cerr := q.getAreasForStopsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAreasForStopsStmt: %w", cerr)
+		}
+	}
+	if q.getArrivalAlarmStmt != nil {
+		if cerr := q.getArrivalAlarmStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getArrivalAlarmStmt: %w", cerr)
+		}
+	}
 	if q.getArrivalsAndDeparturesForStopStmt != nil {
 		if cerr := q.getArrivalsAndDeparturesForStopStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getArrivalsAndDeparturesForStopStmt: %w", cerr)
@@ -329,6 +798,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getBlockIDByTripIDStmt: %w", cerr)
 		}
 	}
+	if q.getBlockIDsForTripsStmt != nil {
+		if cerr := q.getBlockIDsForTripsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getBlockIDsForTripsStmt: %w", cerr)
+		}
+	}
 	if q.getCalendarByServiceIDStmt != nil {
 		if cerr := q.getCalendarByServiceIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCalendarByServiceIDStmt: %w", cerr)
@@ -339,11 +813,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCalendarDateExceptionsForServiceIDStmt: %w", cerr)
 		}
 	}
+	if q.getCalendarDateRangeStmt != nil {
+		if cerr := q.getCalendarDateRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCalendarDateRangeStmt: %w", cerr)
+		}
+	}
+	if q.getChildStopsStmt != nil {
+		if cerr := q.getChildStopsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getChildStopsStmt: %w", cerr)
+		}
+	}
+	if q.getFeedInfoStmt != nil {
+		if cerr := q.getFeedInfoStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFeedInfoStmt: %w", cerr)
+		}
+	}
+	if q.getFrequenciesForTripStmt != nil {
+		if cerr := q.getFrequenciesForTripStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFrequenciesForTripStmt: %w", cerr)
+		}
+	}
+	if q.getFrequenciesForTripsStmt != nil {
+		if cerr := q.getFrequenciesForTripsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFrequenciesForTripsStmt: %w", cerr)
+		}
+	}
 	if q.getImportMetadataStmt != nil {
 		if cerr := q.getImportMetadataStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getImportMetadataStmt: %w", cerr)
 		}
 	}
+	if q.getLevelsByIDsStmt != nil {
+		if cerr := q.getLevelsByIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLevelsByIDsStmt: %w", cerr)
+		}
+	}
+	if q.getNetworksForRoutesStmt != nil {
+		if cerr := q.getNetworksForRoutesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNetworksForRoutesStmt: %w", cerr)
+		}
+	}
 	if q.getNextStopInTripStmt != nil {
 		if cerr := q.getNextStopInTripStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getNextStopInTripStmt: %w", cerr)
@@ -354,6 +863,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getOrderedStopIDsForTripStmt: %w", cerr)
 		}
 	}
+	if q.getPathwaysForStopsStmt != nil {
+		if cerr := q.getPathwaysForStopsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPathwaysForStopsStmt: %w", cerr)
+		}
+	}
+	if q.getProblemReportStmt != nil {
+		if cerr := q.getProblemReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProblemReportStmt: %w", cerr)
+		}
+	}
 	if q.getRouteStmt != nil {
 		if cerr := q.getRouteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getRouteStmt: %w", cerr)
@@ -434,6 +953,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getStopIDsForTripStmt: %w", cerr)
 		}
 	}
+	if q.getStopIDsForTripsStmt != nil {
+		if cerr := q.getStopIDsForTripsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStopIDsForTripsStmt: %w", cerr)
+		}
+	}
 	if q.getStopTimesByStopIDsStmt != nil {
 		if cerr := q.getStopTimesByStopIDsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getStopTimesByStopIDsStmt: %w", cerr)
@@ -444,6 +968,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getStopTimesForTripStmt: %w", cerr)
 		}
 	}
+	if q.getStopZonesForTripStmt != nil {
+		if cerr := q.getStopZonesForTripStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStopZonesForTripStmt: %w", cerr)
+		}
+	}
 	if q.getStopsByIDsStmt != nil {
 		if cerr := q.getStopsByIDsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getStopsByIDsStmt: %w", cerr)
@@ -464,6 +993,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getStopsWithinBoundsStmt: %w", cerr)
 		}
 	}
+	if q.getTransfersForStopStmt != nil {
+		if cerr := q.getTransfersForStopStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransfersForStopStmt: %w", cerr)
+		}
+	}
+	if q.getTransfersFromStopsStmt != nil {
+		if cerr := q.getTransfersFromStopsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTransfersFromStopsStmt: %w", cerr)
+		}
+	}
 	if q.getTripStmt != nil {
 		if cerr := q.getTripStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTripStmt: %w", cerr)
@@ -494,16 +1033,101 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing listAgenciesStmt: %w", cerr)
 		}
 	}
+	if q.listArrivalAlarmsStmt != nil {
+		if cerr := q.listArrivalAlarmsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listArrivalAlarmsStmt: %w", cerr)
+		}
+	}
+	if q.listCalendarDatesStmt != nil {
+		if cerr := q.listCalendarDatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalendarDatesStmt: %w", cerr)
+		}
+	}
+	if q.listCalendarsStmt != nil {
+		if cerr := q.listCalendarsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCalendarsStmt: %w", cerr)
+		}
+	}
+	if q.listFareAttributesStmt != nil {
+		if cerr := q.listFareAttributesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFareAttributesStmt: %w", cerr)
+		}
+	}
+	if q.listFareLegRulesStmt != nil {
+		if cerr := q.listFareLegRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFareLegRulesStmt: %w", cerr)
+		}
+	}
+	if q.listFareProductsStmt != nil {
+		if cerr := q.listFareProductsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFareProductsStmt: %w", cerr)
+		}
+	}
+	if q.listFareRulesStmt != nil {
+		if cerr := q.listFareRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFareRulesStmt: %w", cerr)
+		}
+	}
+	if q.listFareTransferRulesStmt != nil {
+		if cerr := q.listFareTransferRulesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listFareTransferRulesStmt: %w", cerr)
+		}
+	}
+	if q.listImportMetadataStmt != nil {
+		if cerr := q.listImportMetadataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listImportMetadataStmt: %w", cerr)
+		}
+	}
+	if q.listProblemReportsStmt != nil {
+		if cerr := q.listProblemReportsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listProblemReportsStmt: %w", cerr)
+		}
+	}
 	if q.listRoutesStmt != nil {
 		if cerr := q.listRoutesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listRoutesStmt: %w", cerr)
 		}
 	}
+	if q.listTimeframesStmt != nil {
+		if cerr := q.listTimeframesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTimeframesStmt: %w", cerr)
+		}
+	}
+	if q.listTranslationsStmt != nil {
+		if cerr := q.listTranslationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTranslationsStmt: %w", cerr)
+		}
+	}
 	if q.listTripsStmt != nil {
 		if cerr := q.listTripsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listTripsStmt: %w", cerr)
 		}
 	}
+	if q.listValidationFindingsStmt != nil {
+		if cerr := q.listValidationFindingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listValidationFindingsStmt: %w", cerr)
+		}
+	}
+	if q.rebuildRoutesSearchIndexStmt != nil {
+		if cerr := q.rebuildRoutesSearchIndexStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rebuildRoutesSearchIndexStmt: %w", cerr)
+		}
+	}
+	if q.rebuildStopsSearchIndexStmt != nil {
+		if cerr := q.rebuildStopsSearchIndexStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rebuildStopsSearchIndexStmt: %w", cerr)
+		}
+	}
+	if q.resolveProblemReportStmt != nil {
+		if cerr := q.resolveProblemReportStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resolveProblemReportStmt: %w", cerr)
+		}
+	}
+	if q.updateImportMetadataValidatorsStmt != nil {
+		if cerr := q.updateImportMetadataValidatorsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateImportMetadataValidatorsStmt: %w", cerr)
+		}
+	}
 	if q.upsertImportMetadataStmt != nil {
 		if cerr := q.upsertImportMetadataStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertImportMetadataStmt: %w", cerr)
@@ -548,35 +1172,92 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 type Queries struct {
 	db                                        DBTX
 	tx                                        *sql.Tx
+	claimArrivalAlarmStmt                     *sql.Stmt
 	clearAgenciesStmt                         *sql.Stmt
+	clearAreasStmt                            *sql.Stmt
 	clearCalendarStmt                         *sql.Stmt
+	clearFareAttributesStmt                   *sql.Stmt
+	clearFareLegRulesStmt                     *sql.Stmt
+	clearFareMediaStmt                        *sql.Stmt
+	clearFareProductsStmt                     *sql.Stmt
+	clearFareRulesStmt                        *sql.Stmt
+	clearFareTransferRulesStmt                *sql.Stmt
+	clearFeedInfoStmt                         *sql.Stmt
+	clearFrequenciesStmt                      *sql.Stmt
+	clearLevelsStmt                           *sql.Stmt
+	clearNetworksStmt                         *sql.Stmt
+	clearPathwaysStmt                         *sql.Stmt
+	clearRouteNetworksStmt                    *sql.Stmt
 	clearRoutesStmt                           *sql.Stmt
 	clearShapesStmt                           *sql.Stmt
+	clearStopAreasStmt                        *sql.Stmt
 	clearStopTimesStmt                        *sql.Stmt
 	clearStopsStmt                            *sql.Stmt
+	clearTimeframesStmt                       *sql.Stmt
+	clearTransfersStmt                        *sql.Stmt
+	clearTranslationsStmt                     *sql.Stmt
 	clearTripsStmt                            *sql.Stmt
+	clearValidationFindingsStmt               *sql.Stmt
+	countArrivalAlarmsStmt                    *sql.Stmt
+	countArrivalAlarmsForClientStmt           *sql.Stmt
+	countValidationFindingsStmt               *sql.Stmt
 	createAgencyStmt                          *sql.Stmt
+	createAreaStmt                            *sql.Stmt
+	createArrivalAlarmStmt                    *sql.Stmt
 	createCalendarStmt                        *sql.Stmt
 	createCalendarDateStmt                    *sql.Stmt
+	createFareAttributeStmt                   *sql.Stmt
+	createFareLegRuleStmt                     *sql.Stmt
+	createFareMediaStmt                       *sql.Stmt
+	createFareProductStmt                     *sql.Stmt
+	createFareRuleStmt                        *sql.Stmt
+	createFareTransferRuleStmt                *sql.Stmt
+	createFeedInfoStmt                        *sql.Stmt
+	createFrequencyStmt                       *sql.Stmt
+	createLevelStmt                           *sql.Stmt
+	createNetworkStmt                         *sql.Stmt
+	createPathwayStmt                         *sql.Stmt
+	createProblemReportStmt                   *sql.Stmt
 	createRouteStmt                           *sql.Stmt
+	createRouteNetworkStmt                    *sql.Stmt
 	createShapeStmt                           *sql.Stmt
 	createStopStmt                            *sql.Stmt
+	createStopAreaStmt                        *sql.Stmt
 	createStopTimeStmt                        *sql.Stmt
+	createTimeframeStmt                       *sql.Stmt
+	createTransferStmt                        *sql.Stmt
+	createTranslationStmt                     *sql.Stmt
 	createTripStmt                            *sql.Stmt
+	createValidationFindingStmt               *sql.Stmt
+	deleteArrivalAlarmStmt                    *sql.Stmt
+	deleteImportMetadataStmt                  *sql.Stmt
 	getActiveServiceIDsForDateStmt            *sql.Stmt
 	getAgenciesForStopsStmt                   *sql.Stmt
 	getAgencyStmt                             *sql.Stmt
 	getAgencyForStopStmt                      *sql.Stmt
+	getAgencyIDsForTripsStmt                  *sql.Stmt
 	getAllShapesStmt                          *sql.Stmt
 	getAllTripsForRouteStmt                   *sql.Stmt
+	getAreasForStopsStmt                      *sql.Stmt
+	getArrivalAlarmStmt                       *sql.Stmt
 	getArrivalsAndDeparturesForStopStmt       *sql.Stmt
 	getBlockDetailsStmt                       *sql.Stmt
 	getBlockIDByTripIDStmt                    *sql.Stmt
+	getBlockIDsForTripsStmt                   *sql.Stmt
 	getCalendarByServiceIDStmt                *sql.Stmt
 	getCalendarDateExceptionsForServiceIDStmt *sql.Stmt
+	getCalendarDateRangeStmt                  *sql.Stmt
+	getChildStopsStmt                         *sql.Stmt
+	getFeedInfoStmt                           *sql.Stmt
+	getFrequenciesForTripStmt                 *sql.Stmt
+	getFrequenciesForTripsStmt                *sql.Stmt
 	getImportMetadataStmt                     *sql.Stmt
+	getLevelsByIDsStmt                        *sql.Stmt
+	getNetworksForRoutesStmt                  *sql.Stmt
 	getNextStopInTripStmt                     *sql.Stmt
 	getOrderedStopIDsForTripStmt              *sql.Stmt
+	getPathwaysForStopsStmt                   *sql.Stmt
+	getProblemReportStmt                      *sql.Stmt
 	getRouteStmt                              *sql.Stmt
 	getRouteIDsForAgencyStmt                  *sql.Stmt
 	getRouteIDsForStopStmt                    *sql.Stmt
@@ -593,56 +1274,134 @@ type Queries struct {
 	getStopIDsForAgencyStmt                   *sql.Stmt
 	getStopIDsForRouteStmt                    *sql.Stmt
 	getStopIDsForTripStmt                     *sql.Stmt
+	getStopIDsForTripsStmt                    *sql.Stmt
 	getStopTimesByStopIDsStmt                 *sql.Stmt
 	getStopTimesForTripStmt                   *sql.Stmt
+	getStopZonesForTripStmt                   *sql.Stmt
 	getStopsByIDsStmt                         *sql.Stmt
 	getStopsForRouteStmt                      *sql.Stmt
 	getStopsWithTripContextStmt               *sql.Stmt
 	getStopsWithinBoundsStmt                  *sql.Stmt
+	getTransfersForStopStmt                   *sql.Stmt
+	getTransfersFromStopsStmt                 *sql.Stmt
 	getTripStmt                               *sql.Stmt
 	getTripsByBlockIDStmt                     *sql.Stmt
 	getTripsByBlockIDOrderedStmt              *sql.Stmt
 	getTripsByServiceIDStmt                   *sql.Stmt
 	getTripsForRouteInActiveServiceIDsStmt    *sql.Stmt
 	listAgenciesStmt                          *sql.Stmt
+	listArrivalAlarmsStmt                     *sql.Stmt
+	listCalendarDatesStmt                     *sql.Stmt
+	listCalendarsStmt                         *sql.Stmt
+	listFareAttributesStmt                    *sql.Stmt
+	listFareLegRulesStmt                      *sql.Stmt
+	listFareProductsStmt                      *sql.Stmt
+	listFareRulesStmt                         *sql.Stmt
+	listFareTransferRulesStmt                 *sql.Stmt
+	listImportMetadataStmt                    *sql.Stmt
+	listProblemReportsStmt                    *sql.Stmt
 	listRoutesStmt                            *sql.Stmt
+	listTimeframesStmt                        *sql.Stmt
+	listTranslationsStmt                      *sql.Stmt
 	listTripsStmt                             *sql.Stmt
+	listValidationFindingsStmt                *sql.Stmt
+	rebuildRoutesSearchIndexStmt              *sql.Stmt
+	rebuildStopsSearchIndexStmt               *sql.Stmt
+	resolveProblemReportStmt                  *sql.Stmt
+	updateImportMetadataValidatorsStmt        *sql.Stmt
 	upsertImportMetadataStmt                  *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                  tx,
-		tx:                                  tx,
-		clearAgenciesStmt:                   q.clearAgenciesStmt,
-		clearCalendarStmt:                   q.clearCalendarStmt,
-		clearRoutesStmt:                     q.clearRoutesStmt,
-		clearShapesStmt:                     q.clearShapesStmt,
-		clearStopTimesStmt:                  q.clearStopTimesStmt,
-		clearStopsStmt:                      q.clearStopsStmt,
-		clearTripsStmt:                      q.clearTripsStmt,
-		createAgencyStmt:                    q.createAgencyStmt,
-		createCalendarStmt:                  q.createCalendarStmt,
-		createCalendarDateStmt:              q.createCalendarDateStmt,
-		createRouteStmt:                     q.createRouteStmt,
-		createShapeStmt:                     q.createShapeStmt,
-		createStopStmt:                      q.createStopStmt,
-		createStopTimeStmt:                  q.createStopTimeStmt,
-		createTripStmt:                      q.createTripStmt,
-		getActiveServiceIDsForDateStmt:      q.getActiveServiceIDsForDateStmt,
-		getAgenciesForStopsStmt:             q.getAgenciesForStopsStmt,
-		getAgencyStmt:                       q.getAgencyStmt,
-		getAgencyForStopStmt:                q.getAgencyForStopStmt,
-		getAllShapesStmt:                    q.getAllShapesStmt,
-		getAllTripsForRouteStmt:             q.getAllTripsForRouteStmt,
-		getArrivalsAndDeparturesForStopStmt: q.getArrivalsAndDeparturesForStopStmt,
-		getBlockDetailsStmt:                 q.getBlockDetailsStmt,
-		getBlockIDByTripIDStmt:              q.getBlockIDByTripIDStmt,
-		getCalendarByServiceIDStmt:          q.getCalendarByServiceIDStmt,
+		db:                                        tx,
+		tx:                                        tx,
+		claimArrivalAlarmStmt:                     q.claimArrivalAlarmStmt,
+		clearAgenciesStmt:                         q.clearAgenciesStmt,
+		clearAreasStmt:                            q.clearAreasStmt,
+		clearCalendarStmt:                         q.clearCalendarStmt,
+		clearFareAttributesStmt:                   q.clearFareAttributesStmt,
+		clearFareLegRulesStmt:                     q.clearFareLegRulesStmt,
+		clearFareMediaStmt:                        q.clearFareMediaStmt,
+		clearFareProductsStmt:                     q.clearFareProductsStmt,
+		clearFareRulesStmt:                        q.clearFareRulesStmt,
+		clearFareTransferRulesStmt:                q.clearFareTransferRulesStmt,
+		clearFeedInfoStmt:                         q.clearFeedInfoStmt,
+		clearFrequenciesStmt:                      q.clearFrequenciesStmt,
+		clearLevelsStmt:                           q.clearLevelsStmt,
+		clearNetworksStmt:                         q.clearNetworksStmt,
+		clearPathwaysStmt:                         q.clearPathwaysStmt,
+		clearRouteNetworksStmt:                    q.clearRouteNetworksStmt,
+		clearRoutesStmt:                           q.clearRoutesStmt,
+		clearShapesStmt:                           q.clearShapesStmt,
+		clearStopAreasStmt:                        q.clearStopAreasStmt,
+		clearStopTimesStmt:                        q.clearStopTimesStmt,
+		clearStopsStmt:                            q.clearStopsStmt,
+		clearTimeframesStmt:                       q.clearTimeframesStmt,
+		clearTransfersStmt:                        q.clearTransfersStmt,
+		clearTranslationsStmt:                     q.clearTranslationsStmt,
+		clearTripsStmt:                            q.clearTripsStmt,
+		clearValidationFindingsStmt:               q.clearValidationFindingsStmt,
+		countArrivalAlarmsStmt:                    q.countArrivalAlarmsStmt,
+		countArrivalAlarmsForClientStmt:           q.countArrivalAlarmsForClientStmt,
+		countValidationFindingsStmt:               q.countValidationFindingsStmt,
+		createAgencyStmt:                          q.createAgencyStmt,
+		createAreaStmt:                            q.createAreaStmt,
+		createArrivalAlarmStmt:                    q.createArrivalAlarmStmt,
+		createCalendarStmt:                        q.createCalendarStmt,
+		createCalendarDateStmt:                    q.createCalendarDateStmt,
+		createFareAttributeStmt:                   q.createFareAttributeStmt,
+		createFareLegRuleStmt:                     q.createFareLegRuleStmt,
+		createFareMediaStmt:                       q.createFareMediaStmt,
+		createFareProductStmt:                     q.createFareProductStmt,
+		createFareRuleStmt:                        q.createFareRuleStmt,
+		createFareTransferRuleStmt:                q.createFareTransferRuleStmt,
+		createFeedInfoStmt:                        q.createFeedInfoStmt,
+		createFrequencyStmt:                       q.createFrequencyStmt,
+		createLevelStmt:                           q.createLevelStmt,
+		createNetworkStmt:                         q.createNetworkStmt,
+		createPathwayStmt:                         q.createPathwayStmt,
+		createProblemReportStmt:                   q.createProblemReportStmt,
+		createRouteStmt:                           q.createRouteStmt,
+		createRouteNetworkStmt:                    q.createRouteNetworkStmt,
+		createShapeStmt:                           q.createShapeStmt,
+		createStopStmt:                            q.createStopStmt,
+		createStopAreaStmt:                        q.createStopAreaStmt,
+		createStopTimeStmt:                        q.createStopTimeStmt,
+		createTimeframeStmt:                       q.createTimeframeStmt,
+		createTransferStmt:                        q.createTransferStmt,
+		createTranslationStmt:                     q.createTranslationStmt,
+		createTripStmt:                            q.createTripStmt,
+		createValidationFindingStmt:               q.createValidationFindingStmt,
+		deleteArrivalAlarmStmt:                    q.deleteArrivalAlarmStmt,
+		deleteImportMetadataStmt:                  q.deleteImportMetadataStmt,
+		getActiveServiceIDsForDateStmt:            q.getActiveServiceIDsForDateStmt,
+		getAgenciesForStopsStmt:                   q.getAgenciesForStopsStmt,
+		getAgencyStmt:                             q.getAgencyStmt,
+		getAgencyForStopStmt:                      q.getAgencyForStopStmt,
+		getAgencyIDsForTripsStmt:                  q.getAgencyIDsForTripsStmt,
+		getAllShapesStmt:                          q.getAllShapesStmt,
+		getAllTripsForRouteStmt:                   q.getAllTripsForRouteStmt,
+		getAreasForStopsStmt:                      q.getAreasForStopsStmt,
+		getArrivalAlarmStmt:                       q.getArrivalAlarmStmt,
+		getArrivalsAndDeparturesForStopStmt:       q.getArrivalsAndDeparturesForStopStmt,
+		getBlockDetailsStmt:                       q.getBlockDetailsStmt,
+		getBlockIDByTripIDStmt:                    q.getBlockIDByTripIDStmt,
+		getBlockIDsForTripsStmt:                   q.getBlockIDsForTripsStmt,
+		getCalendarByServiceIDStmt:                q.getCalendarByServiceIDStmt,
 		getCalendarDateExceptionsForServiceIDStmt: q.getCalendarDateExceptionsForServiceIDStmt,
+		getCalendarDateRangeStmt:                  q.getCalendarDateRangeStmt,
+		getChildStopsStmt:                         q.getChildStopsStmt,
+		getFeedInfoStmt:                           q.getFeedInfoStmt,
+		getFrequenciesForTripStmt:                 q.getFrequenciesForTripStmt,
+		getFrequenciesForTripsStmt:                q.getFrequenciesForTripsStmt,
 		getImportMetadataStmt:                     q.getImportMetadataStmt,
+		getLevelsByIDsStmt:                        q.getLevelsByIDsStmt,
+		getNetworksForRoutesStmt:                  q.getNetworksForRoutesStmt,
 		getNextStopInTripStmt:                     q.getNextStopInTripStmt,
 		getOrderedStopIDsForTripStmt:              q.getOrderedStopIDsForTripStmt,
+		getPathwaysForStopsStmt:                   q.getPathwaysForStopsStmt,
+		getProblemReportStmt:                      q.getProblemReportStmt,
 		getRouteStmt:                              q.getRouteStmt,
 		getRouteIDsForAgencyStmt:                  q.getRouteIDsForAgencyStmt,
 		getRouteIDsForStopStmt:                    q.getRouteIDsForStopStmt,
@@ -659,20 +1418,41 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		getStopIDsForAgencyStmt:                   q.getStopIDsForAgencyStmt,
 		getStopIDsForRouteStmt:                    q.getStopIDsForRouteStmt,
 		getStopIDsForTripStmt:                     q.getStopIDsForTripStmt,
+		getStopIDsForTripsStmt:                    q.getStopIDsForTripsStmt,
 		getStopTimesByStopIDsStmt:                 q.getStopTimesByStopIDsStmt,
 		getStopTimesForTripStmt:                   q.getStopTimesForTripStmt,
+		getStopZonesForTripStmt:                   q.getStopZonesForTripStmt,
 		getStopsByIDsStmt:                         q.getStopsByIDsStmt,
 		getStopsForRouteStmt:                      q.getStopsForRouteStmt,
 		getStopsWithTripContextStmt:               q.getStopsWithTripContextStmt,
 		getStopsWithinBoundsStmt:                  q.getStopsWithinBoundsStmt,
+		getTransfersForStopStmt:                   q.getTransfersForStopStmt,
+		getTransfersFromStopsStmt:                 q.getTransfersFromStopsStmt,
 		getTripStmt:                               q.getTripStmt,
 		getTripsByBlockIDStmt:                     q.getTripsByBlockIDStmt,
 		getTripsByBlockIDOrderedStmt:              q.getTripsByBlockIDOrderedStmt,
 		getTripsByServiceIDStmt:                   q.getTripsByServiceIDStmt,
 		getTripsForRouteInActiveServiceIDsStmt:    q.getTripsForRouteInActiveServiceIDsStmt,
 		listAgenciesStmt:                          q.listAgenciesStmt,
+		listArrivalAlarmsStmt:                     q.listArrivalAlarmsStmt,
+		listCalendarDatesStmt:                     q.listCalendarDatesStmt,
+		listCalendarsStmt:                         q.listCalendarsStmt,
+		listFareAttributesStmt:                    q.listFareAttributesStmt,
+		listFareLegRulesStmt:                      q.listFareLegRulesStmt,
+		listFareProductsStmt:                      q.listFareProductsStmt,
+		listFareRulesStmt:                         q.listFareRulesStmt,
+		listFareTransferRulesStmt:                 q.listFareTransferRulesStmt,
+		listImportMetadataStmt:                    q.listImportMetadataStmt,
+		listProblemReportsStmt:                    q.listProblemReportsStmt,
 		listRoutesStmt:                            q.listRoutesStmt,
+		listTimeframesStmt:                        q.listTimeframesStmt,
+		listTranslationsStmt:                      q.listTranslationsStmt,
 		listTripsStmt:                             q.listTripsStmt,
+		listValidationFindingsStmt:                q.listValidationFindingsStmt,
+		rebuildRoutesSearchIndexStmt:              q.rebuildRoutesSearchIndexStmt,
+		rebuildStopsSearchIndexStmt:               q.rebuildStopsSearchIndexStmt,
+		resolveProblemReportStmt:                  q.resolveProblemReportStmt,
+		updateImportMetadataValidatorsStmt:        q.updateImportMetadataValidatorsStmt,
 		upsertImportMetadataStmt:                  q.upsertImportMetadataStmt,
 	}
 }
@@ -0,0 +1,182 @@
+package gtfsdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// Default limits of feed downloads
+const (
+	DefaultDownloadTimeout     = 5 * time.Minute
+	DefaultMaxDownloadBytes    = 512 << 20
+	DefaultDownloadAttempts    = 4
+	DefaultDownloadBackoff     = 2 * time.Second
+	maxDownloadBackoffInterval = time.Minute
+)
+
+// ErrFeedTooLarge is returned when a feed is larger than the download size limit.
+var ErrFeedTooLarge = errors.New("feed exceeds the maximum download size")
+
+// DownloadConfig limits static feed downloads. Zero fields take the defaults.
+type DownloadConfig struct {
+	Timeout        time.Duration // Limit on each attempt, including reading the body
+	MaxBytes       int64         // Largest feed accepted
+	MaxAttempts    int           // Attempts made before giving up
+	InitialBackoff time.Duration // Wait before the second attempt, doubled for each one after
+}
+
+func (config DownloadConfig) withDefaults() DownloadConfig {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultDownloadTimeout
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = DefaultMaxDownloadBytes
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultDownloadAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = DefaultDownloadBackoff
+	}
+	return config
+}
+
+// FeedValidators are the HTTP cache validators a server sent with a feed, used to ask for the
+// feed again only if it has changed.
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// Validators returns the validators recorded for an imported feed.
+func (m ImportMetadatum) Validators() FeedValidators {
+	return FeedValidators{ETag: m.Etag.String, LastModified: m.LastModified.String}
+}
+
+// FeedDownload is a static feed fetched from its source.
+type FeedDownload struct {
+	FeedValidators
+	Data []byte
+	// NotModified is set when the server reported the feed unchanged since the validators sent
+	// with the request, in which case Data is empty
+	NotModified bool
+}
+
+// httpStatusError is an unexpected response status from a feed server.
+type httpStatusError struct {
+	statusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected response status %d", e.statusCode)
+}
+
+// DownloadFeed downloads the feed at url, retrying failures that may be temporary with an
+// exponential backoff. When validators are given the request is conditional, and a feed the
+// server reports unchanged comes back NotModified.
+func DownloadFeed(ctx context.Context, url string, validators FeedValidators, config DownloadConfig) (*FeedDownload, error) {
+	config = config.withDefaults()
+	logger := slog.Default().With(slog.String("component", "gtfs_downloader"), slog.String("url", url))
+
+	backoff := config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		download, err := downloadFeedOnce(ctx, url, validators, config)
+		if err == nil {
+			return download, nil
+		}
+		if attempt >= config.MaxAttempts || !isRetryableDownloadError(err) {
+			return nil, fmt.Errorf("error downloading GTFS data: %w", err)
+		}
+
+		logging.LogError(logger, "Error downloading GTFS data, retrying", err,
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("error downloading GTFS data: %w", ctx.Err())
+		}
+		backoff = min(backoff*2, maxDownloadBackoffInterval)
+	}
+}
+
+func downloadFeedOnce(ctx context.Context, url string, validators FeedValidators, config DownloadConfig) (*FeedDownload, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer logging.SafeCloseWithLogging(resp.Body,
+		slog.Default().With(slog.String("component", "gtfs_downloader")),
+		"http_response_body")
+
+	if resp.StatusCode == http.StatusNotModified {
+		return &FeedDownload{FeedValidators: validators, NotModified: true}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{statusCode: resp.StatusCode}
+	}
+	if resp.ContentLength > config.MaxBytes {
+		return nil, fmt.Errorf("%w: %d bytes", ErrFeedTooLarge, resp.ContentLength)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, config.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > config.MaxBytes {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrFeedTooLarge, config.MaxBytes)
+	}
+
+	return &FeedDownload{
+		FeedValidators: FeedValidators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		},
+		Data: data,
+	}, nil
+}
+
+// isRetryableDownloadError reports whether a download failed in a way that may not happen
+// again: a timeout, a dropped connection or a server error.
+func isRetryableDownloadError(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= 500 ||
+			statusErr.statusCode == http.StatusTooManyRequests ||
+			statusErr.statusCode == http.StatusRequestTimeout
+	}
+	if errors.Is(err, ErrFeedTooLarge) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
@@ -0,0 +1,163 @@
+package gtfsdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+)
+
+// fastRetries keeps retrying tests quick
+var fastRetries = DownloadConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+func TestDownloadFeedConditional(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Wed, 01 Jan 2025 00:00:00 GMT"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", lastModified)
+		_, _ = w.Write([]byte("feed"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	download, err := DownloadFeed(ctx, server.URL, FeedValidators{}, fastRetries)
+	require.NoError(t, err)
+	assert.False(t, download.NotModified)
+	assert.Equal(t, []byte("feed"), download.Data)
+	assert.Equal(t, FeedValidators{ETag: etag, LastModified: lastModified}, download.FeedValidators)
+
+	download, err = DownloadFeed(ctx, server.URL, download.FeedValidators, fastRetries)
+	require.NoError(t, err)
+	assert.True(t, download.NotModified)
+	assert.Empty(t, download.Data)
+}
+
+func TestDownloadFeedRetries(t *testing.T) {
+	testCases := []struct {
+		name          string
+		failures      int
+		status        int
+		expectSuccess bool
+		expectCalls   int64
+	}{
+		{name: "RecoversFromServerErrors", failures: 2, status: http.StatusServiceUnavailable, expectSuccess: true, expectCalls: 3},
+		{name: "GivesUpAfterMaxAttempts", failures: 5, status: http.StatusBadGateway, expectCalls: 3},
+		{name: "RetriesRateLimiting", failures: 1, status: http.StatusTooManyRequests, expectSuccess: true, expectCalls: 2},
+		{name: "DoesNotRetryClientErrors", failures: 5, status: http.StatusNotFound, expectCalls: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= int64(tc.failures) {
+					w.WriteHeader(tc.status)
+					return
+				}
+				_, _ = w.Write([]byte("feed"))
+			}))
+			defer server.Close()
+
+			_, err := DownloadFeed(context.Background(), server.URL, FeedValidators{}, fastRetries)
+			if tc.expectSuccess {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tc.expectCalls, calls.Load())
+		})
+	}
+}
+
+func TestDownloadFeedLimits(t *testing.T) {
+	body := strings.Repeat("x", 100)
+
+	t.Run("DeclaredSizeTooLarge", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		_, err := DownloadFeed(context.Background(), server.URL, FeedValidators{}, DownloadConfig{MaxBytes: 50})
+		assert.ErrorIs(t, err, ErrFeedTooLarge)
+	})
+
+	t.Run("StreamedSizeTooLarge", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Flushing before the body is written leaves out the Content-Length
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		_, err := DownloadFeed(context.Background(), server.URL, FeedValidators{}, DownloadConfig{MaxBytes: 50})
+		assert.ErrorIs(t, err, ErrFeedTooLarge)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+		defer close(release)
+
+		start := time.Now()
+		_, err := DownloadFeed(context.Background(), server.URL, FeedValidators{},
+			DownloadConfig{Timeout: 50 * time.Millisecond, MaxAttempts: 2, InitialBackoff: time.Millisecond})
+		assert.Error(t, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
+
+func TestDownloadAndStoreFeedIsConditional(t *testing.T) {
+	data, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+
+	const etag = `"raba-1"`
+	var fullResponses atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fullResponses.Add(1)
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	require.NoError(t, client.DownloadAndStoreFeed(ctx, "raba", server.URL))
+	metadata, err := client.Queries.GetImportMetadata(ctx, "raba")
+	require.NoError(t, err)
+	assert.Equal(t, etag, metadata.Etag.String)
+	assert.False(t, metadata.LastModified.Valid)
+
+	require.NoError(t, client.DownloadAndStoreFeed(ctx, "raba", server.URL))
+	assert.Equal(t, int64(1), fullResponses.Load(), "an unchanged feed is not downloaded again")
+
+	unchanged, err := client.Queries.GetImportMetadata(ctx, "raba")
+	require.NoError(t, err)
+	assert.Equal(t, metadata, unchanged)
+}
@@ -0,0 +1,65 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportFares(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "fares"))
+
+	attributes, err := client.Queries.ListFareAttributes(ctx)
+	require.NoError(t, err)
+	require.Len(t, attributes, 3)
+	assert.Equal(t, "63", attributes[0].FareID, "fares are ordered by price")
+	assert.Equal(t, 2.0, attributes[0].Price)
+	assert.Equal(t, "USD", attributes[0].CurrencyType)
+	assert.Equal(t, "25", attributes[0].AgencyID.String)
+	assert.False(t, attributes[0].Transfers.Valid, "blank transfers means unlimited")
+
+	rules, err := client.Queries.ListFareRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 12)
+
+	t.Run("zones and transfer limits", func(t *testing.T) {
+		feedPath := models.BuildFeedWithFiles(t, getTestFixturePath(t, "raba.zip"), map[string]string{
+			"fare_attributes.txt": "fare_id,price,currency_type,payment_method,transfers,transfer_duration\n" +
+				"zonal,3.25,USD,1,0,5400\n" +
+				"broken,free,USD,0,,\n",
+			"fare_rules.txt": "fare_id,route_id,origin_id,destination_id,contains_id\n" +
+				"zonal,,25,26,\n" +
+				"broken,151,,,\n",
+		})
+		feed, err := os.ReadFile(feedPath)
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "fares"))
+
+		attributes, err := client.Queries.ListFareAttributes(ctx)
+		require.NoError(t, err)
+		require.Len(t, attributes, 1, "rows with an invalid price are skipped")
+		assert.Equal(t, int64(1), attributes[0].PaymentMethod)
+		assert.Equal(t, int64(0), attributes[0].Transfers.Int64)
+		assert.True(t, attributes[0].Transfers.Valid)
+		assert.Equal(t, int64(5400), attributes[0].TransferDuration.Int64)
+		assert.False(t, attributes[0].AgencyID.Valid)
+
+		rules, err := client.Queries.ListFareRules(ctx)
+		require.NoError(t, err)
+		require.Len(t, rules, 1, "rules for skipped fares are dropped")
+		assert.Equal(t, "25", rules[0].OriginID.String)
+		assert.Equal(t, "26", rules[0].DestinationID.String)
+	})
+}
@@ -0,0 +1,99 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportFaresV2(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	feedPath := models.BuildFeedWithFiles(t, getTestFixturePath(t, "raba.zip"), map[string]string{
+		"fare_media.txt": "fare_media_id,fare_media_name,fare_media_type\n" +
+			"cash,Cash,0\n" +
+			"card,Smart card,2\n",
+		"fare_products.txt": "fare_product_id,fare_product_name,fare_media_id,amount,currency\n" +
+			"local,Local fare,cash,2.50,USD\n" +
+			"local,Local fare,card,2.00,USD\n" +
+			"broken,Broken,,free,USD\n",
+		"areas.txt":      "area_id,area_name\ndowntown,Downtown\n",
+		"stop_areas.txt": "area_id,stop_id\ndowntown,1000\n",
+		"networks.txt":   "network_id,network_name\nbus,Local bus\n",
+		"route_networks.txt": "network_id,route_id\n" +
+			"bus,151\n",
+		"timeframes.txt": "timeframe_group_id,start_time,end_time,service_id\n" +
+			"peak,07:00:00,09:30:00,c_1658_b_18260_d_31\n" +
+			"all_day,,,c_1658_b_18260_d_31\n",
+		"fare_leg_rules.txt": "leg_group_id,network_id,from_area_id,to_area_id,from_timeframe_group_id,to_timeframe_group_id,fare_product_id,rule_priority\n" +
+			"local,bus,,,,,local,\n" +
+			"peak,bus,downtown,,peak,,local,2\n",
+		"fare_transfer_rules.txt": "from_leg_group_id,to_leg_group_id,transfer_count,duration_limit,duration_limit_type,fare_transfer_type,fare_product_id\n" +
+			"local,local,1,5400,0,0,\n",
+	})
+	feed, err := os.ReadFile(feedPath)
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "fares_v2"))
+
+	products, err := client.Queries.ListFareProducts(ctx)
+	require.NoError(t, err)
+	require.Len(t, products, 2, "rows with an invalid amount are skipped")
+	assert.Equal(t, "local", products[0].FareProductID)
+
+	legRules, err := client.Queries.ListFareLegRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, legRules, 2)
+	assert.Equal(t, "peak", legRules[0].LegGroupID.String, "rules are ordered by descending priority")
+	assert.Equal(t, int64(2), legRules[0].RulePriority)
+
+	transferRules, err := client.Queries.ListFareTransferRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, transferRules, 1)
+	assert.Equal(t, int64(1), transferRules[0].TransferCount.Int64)
+	assert.Equal(t, int64(5400), transferRules[0].DurationLimit.Int64)
+	assert.False(t, transferRules[0].FareProductID.Valid)
+
+	timeframes, err := client.Queries.ListTimeframes(ctx)
+	require.NoError(t, err)
+	require.Len(t, timeframes, 2)
+	byGroup := make(map[string]Timeframe)
+	for _, timeframe := range timeframes {
+		byGroup[timeframe.TimeframeGroupID] = timeframe
+	}
+	assert.Equal(t, int64(7*time.Hour), byGroup["peak"].StartTime)
+	assert.Equal(t, int64(9*time.Hour+30*time.Minute), byGroup["peak"].EndTime)
+	assert.Equal(t, int64(0), byGroup["all_day"].StartTime)
+	assert.Equal(t, int64(24*time.Hour), byGroup["all_day"].EndTime, "a blank end_time covers the whole day")
+
+	areas, err := client.Queries.GetAreasForStops(ctx, []string{"1000"})
+	require.NoError(t, err)
+	require.Len(t, areas, 1)
+	assert.Equal(t, "downtown", areas[0].AreaID)
+
+	networks, err := client.Queries.GetNetworksForRoutes(ctx, []string{"151"})
+	require.NoError(t, err)
+	require.Len(t, networks, 1)
+	assert.Equal(t, "bus", networks[0].NetworkID)
+
+	t.Run("reimport clears fares v2 data", func(t *testing.T) {
+		original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "fares_v2"))
+
+		legRules, err := client.Queries.ListFareLegRules(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, legRules)
+		products, err := client.Queries.ListFareProducts(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, products)
+	})
+}
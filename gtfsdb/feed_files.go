@@ -0,0 +1,538 @@
+package gtfsdb
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// readFeedFile returns the rows of a CSV file in a zipped GTFS feed, keyed by column name.
+// go-gtfs drops some files and columns entirely, so those are read from the archive here.
+// A feed without the file yields no rows.
+func readFeedFile(feed []byte, name string) ([]map[string]string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(feed), int64(len(feed)))
+	if err != nil {
+		return nil, fmt.Errorf("error opening GTFS archive: %w", err)
+	}
+
+	var file *zip.File
+	for _, f := range archive.File {
+		// Some producers nest the feed inside a directory
+		if path.Base(f.Name) == name {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return nil, nil
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", name, err)
+	}
+	defer logging.SafeCloseWithLogging(rc,
+		slog.Default().With(slog.String("component", "gtfs_importer")),
+		name)
+
+	reader := csv.NewReader(rc)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading %s header: %w", name, err)
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", name, err)
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseOptionalInt parses an optional integer column, returning an invalid NullInt64 when
+// the value is empty. Unlike toNullInt64, zero is a valid value.
+func parseOptionalInt(value string) (sql.NullInt64, error) {
+	if value == "" {
+		return sql.NullInt64{}, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return sql.NullInt64{}, err
+	}
+	return sql.NullInt64{Int64: parsed, Valid: true}, nil
+}
+
+// parseOptionalFloat is parseOptionalInt for decimal columns.
+func parseOptionalFloat(value string) (sql.NullFloat64, error) {
+	if value == "" {
+		return sql.NullFloat64{}, nil
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return sql.NullFloat64{}, err
+	}
+	return sql.NullFloat64{Float64: parsed, Valid: true}, nil
+}
+
+// transferParamsFromFeed reads transfers.txt. go-gtfs drops the route and trip columns and
+// same-stop transfers, both of which riders rely on for timed connections.
+func transferParamsFromFeed(feed []byte) ([]CreateTransferParams, error) {
+	rows, err := readFeedFile(feed, "transfers.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	var params []CreateTransferParams
+	for i, row := range rows {
+		transferType, err := parseOptionalInt(row["transfer_type"])
+		if err != nil || transferType.Int64 < 0 || transferType.Int64 > 5 {
+			logging.LogOperation(logger, "skipping_invalid_transfer",
+				slog.Int("row", i+2),
+				slog.String("transfer_type", row["transfer_type"]))
+			continue
+		}
+		minTransferTime, err := parseOptionalInt(row["min_transfer_time"])
+		if err != nil {
+			logging.LogOperation(logger, "ignoring_invalid_min_transfer_time",
+				slog.Int("row", i+2),
+				slog.String("min_transfer_time", row["min_transfer_time"]))
+		}
+
+		params = append(params, CreateTransferParams{
+			FromStopID:      toNullString(row["from_stop_id"]),
+			ToStopID:        toNullString(row["to_stop_id"]),
+			FromRouteID:     toNullString(row["from_route_id"]),
+			ToRouteID:       toNullString(row["to_route_id"]),
+			FromTripID:      toNullString(row["from_trip_id"]),
+			ToTripID:        toNullString(row["to_trip_id"]),
+			TransferType:    transferType.Int64,
+			MinTransferTime: minTransferTime,
+		})
+	}
+	return params, nil
+}
+
+// feedInfoParamsFromFeed reads feed_info.txt, which go-gtfs does not parse.
+func feedInfoParamsFromFeed(feed []byte) ([]CreateFeedInfoParams, error) {
+	rows, err := readFeedFile(feed, "feed_info.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]CreateFeedInfoParams, 0, len(rows))
+	for _, row := range rows {
+		params = append(params, CreateFeedInfoParams{
+			FeedID:            toNullString(row["feed_id"]),
+			FeedPublisherName: row["feed_publisher_name"],
+			FeedPublisherUrl:  row["feed_publisher_url"],
+			FeedLang:          row["feed_lang"],
+			DefaultLang:       toNullString(row["default_lang"]),
+			FeedStartDate:     toNullString(row["feed_start_date"]),
+			FeedEndDate:       toNullString(row["feed_end_date"]),
+			FeedVersion:       toNullString(row["feed_version"]),
+			FeedContactEmail:  toNullString(row["feed_contact_email"]),
+			FeedContactUrl:    toNullString(row["feed_contact_url"]),
+		})
+	}
+	return params, nil
+}
+
+// translationParamsFromFeed reads translations.txt, which go-gtfs does not parse. Rows must
+// identify what they translate either by record_id or by field_value; feed_info rows need
+// neither.
+func translationParamsFromFeed(feed []byte) ([]CreateTranslationParams, error) {
+	rows, err := readFeedFile(feed, "translations.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	params := make([]CreateTranslationParams, 0, len(rows))
+	for i, row := range rows {
+		identified := row["record_id"] != "" || row["field_value"] != "" || row["table_name"] == "feed_info"
+		if row["table_name"] == "" || row["field_name"] == "" || row["language"] == "" || !identified {
+			logging.LogOperation(logger, "skipping_invalid_translation",
+				slog.Int("row", i+2),
+				slog.String("table_name", row["table_name"]),
+				slog.String("field_name", row["field_name"]))
+			continue
+		}
+		params = append(params, CreateTranslationParams{
+			TableName:   row["table_name"],
+			FieldName:   row["field_name"],
+			Language:    row["language"],
+			Translation: row["translation"],
+			RecordID:    toNullString(row["record_id"]),
+			RecordSubID: toNullString(row["record_sub_id"]),
+			FieldValue:  toNullString(row["field_value"]),
+		})
+	}
+	return params, nil
+}
+
+// fareParamsFromFeed reads fare_attributes.txt and fare_rules.txt (GTFS Fares v1), which
+// go-gtfs does not parse. Rows with an unparseable price are skipped along with their rules.
+func fareParamsFromFeed(feed []byte) ([]CreateFareAttributeParams, []CreateFareRuleParams, error) {
+	attributeRows, err := readFeedFile(feed, "fare_attributes.txt")
+	if err != nil {
+		return nil, nil, err
+	}
+	ruleRows, err := readFeedFile(feed, "fare_rules.txt")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	fareIDs := make(map[string]bool, len(attributeRows))
+	var attributes []CreateFareAttributeParams
+	for i, row := range attributeRows {
+		price, err := strconv.ParseFloat(row["price"], 64)
+		if err != nil || row["fare_id"] == "" {
+			logging.LogOperation(logger, "skipping_invalid_fare_attribute",
+				slog.Int("row", i+2),
+				slog.String("fare_id", row["fare_id"]))
+			continue
+		}
+		paymentMethod, _ := parseOptionalInt(row["payment_method"])
+		transfers, _ := parseOptionalInt(row["transfers"])
+		transferDuration, _ := parseOptionalInt(row["transfer_duration"])
+
+		fareIDs[row["fare_id"]] = true
+		attributes = append(attributes, CreateFareAttributeParams{
+			FareID:           row["fare_id"],
+			Price:            price,
+			CurrencyType:     row["currency_type"],
+			PaymentMethod:    paymentMethod.Int64,
+			Transfers:        transfers,
+			AgencyID:         toNullString(row["agency_id"]),
+			TransferDuration: transferDuration,
+		})
+	}
+
+	var rules []CreateFareRuleParams
+	for _, row := range ruleRows {
+		if !fareIDs[row["fare_id"]] {
+			continue
+		}
+		rules = append(rules, CreateFareRuleParams{
+			FareID:        row["fare_id"],
+			RouteID:       toNullString(row["route_id"]),
+			OriginID:      toNullString(row["origin_id"]),
+			DestinationID: toNullString(row["destination_id"]),
+			ContainsID:    toNullString(row["contains_id"]),
+		})
+	}
+	return attributes, rules, nil
+}
+
+// faresV2Params holds the GTFS Fares v2 rows of a feed, ready for insertion.
+type faresV2Params struct {
+	Media         []CreateFareMediaParams
+	Products      []CreateFareProductParams
+	Areas         []CreateAreaParams
+	StopAreas     []CreateStopAreaParams
+	Networks      []CreateNetworkParams
+	RouteNetworks []CreateRouteNetworkParams
+	Timeframes    []CreateTimeframeParams
+	LegRules      []CreateFareLegRuleParams
+	TransferRules []CreateFareTransferRuleParams
+}
+
+// faresV2ParamsFromFeed reads the GTFS Fares v2 files, none of which go-gtfs parses.
+// Route networks come from both route_networks.txt and the network_id column of routes.txt.
+// Rows missing required values are skipped.
+func faresV2ParamsFromFeed(feed []byte) (faresV2Params, error) {
+	var params faresV2Params
+	files := make(map[string][]map[string]string)
+	for _, name := range []string{
+		"fare_media.txt", "fare_products.txt", "areas.txt", "stop_areas.txt", "networks.txt",
+		"route_networks.txt", "routes.txt", "timeframes.txt", "fare_leg_rules.txt",
+		"fare_transfer_rules.txt",
+	} {
+		rows, err := readFeedFile(feed, name)
+		if err != nil {
+			return params, err
+		}
+		files[name] = rows
+	}
+
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	skip := func(file string, row int) {
+		logging.LogOperation(logger, "skipping_invalid_fare_row",
+			slog.String("file", file),
+			slog.Int("row", row+2))
+	}
+
+	for i, row := range files["fare_media.txt"] {
+		mediaType, err := parseOptionalInt(row["fare_media_type"])
+		if err != nil || row["fare_media_id"] == "" {
+			skip("fare_media.txt", i)
+			continue
+		}
+		params.Media = append(params.Media, CreateFareMediaParams{
+			FareMediaID:   row["fare_media_id"],
+			FareMediaName: toNullString(row["fare_media_name"]),
+			FareMediaType: mediaType.Int64,
+		})
+	}
+
+	for i, row := range files["fare_products.txt"] {
+		amount, err := strconv.ParseFloat(row["amount"], 64)
+		if err != nil || row["fare_product_id"] == "" || row["currency"] == "" {
+			skip("fare_products.txt", i)
+			continue
+		}
+		params.Products = append(params.Products, CreateFareProductParams{
+			FareProductID:   row["fare_product_id"],
+			FareProductName: toNullString(row["fare_product_name"]),
+			FareMediaID:     row["fare_media_id"],
+			Amount:          amount,
+			Currency:        row["currency"],
+		})
+	}
+
+	for i, row := range files["areas.txt"] {
+		if row["area_id"] == "" {
+			skip("areas.txt", i)
+			continue
+		}
+		params.Areas = append(params.Areas, CreateAreaParams{
+			AreaID:   row["area_id"],
+			AreaName: toNullString(row["area_name"]),
+		})
+	}
+
+	for i, row := range files["stop_areas.txt"] {
+		if row["area_id"] == "" || row["stop_id"] == "" {
+			skip("stop_areas.txt", i)
+			continue
+		}
+		params.StopAreas = append(params.StopAreas, CreateStopAreaParams{
+			AreaID: row["area_id"],
+			StopID: row["stop_id"],
+		})
+	}
+
+	for i, row := range files["networks.txt"] {
+		if row["network_id"] == "" {
+			skip("networks.txt", i)
+			continue
+		}
+		params.Networks = append(params.Networks, CreateNetworkParams{
+			NetworkID:   row["network_id"],
+			NetworkName: toNullString(row["network_name"]),
+		})
+	}
+
+	for _, name := range []string{"routes.txt", "route_networks.txt"} {
+		for _, row := range files[name] {
+			if row["network_id"] == "" || row["route_id"] == "" {
+				continue
+			}
+			params.RouteNetworks = append(params.RouteNetworks, CreateRouteNetworkParams{
+				RouteID:   row["route_id"],
+				NetworkID: row["network_id"],
+			})
+		}
+	}
+
+	for i, row := range files["timeframes.txt"] {
+		startTime, err := parseFeedTime(row["start_time"], 0)
+		if err != nil {
+			skip("timeframes.txt", i)
+			continue
+		}
+		endTime, err := parseFeedTime(row["end_time"], 24*time.Hour)
+		if err != nil || row["timeframe_group_id"] == "" || row["service_id"] == "" {
+			skip("timeframes.txt", i)
+			continue
+		}
+		params.Timeframes = append(params.Timeframes, CreateTimeframeParams{
+			TimeframeGroupID: row["timeframe_group_id"],
+			StartTime:        int64(startTime),
+			EndTime:          int64(endTime),
+			ServiceID:        row["service_id"],
+		})
+	}
+
+	for i, row := range files["fare_leg_rules.txt"] {
+		priority, err := parseOptionalInt(row["rule_priority"])
+		if err != nil || row["fare_product_id"] == "" {
+			skip("fare_leg_rules.txt", i)
+			continue
+		}
+		params.LegRules = append(params.LegRules, CreateFareLegRuleParams{
+			LegGroupID:           toNullString(row["leg_group_id"]),
+			NetworkID:            toNullString(row["network_id"]),
+			FromAreaID:           toNullString(row["from_area_id"]),
+			ToAreaID:             toNullString(row["to_area_id"]),
+			FromTimeframeGroupID: toNullString(row["from_timeframe_group_id"]),
+			ToTimeframeGroupID:   toNullString(row["to_timeframe_group_id"]),
+			FareProductID:        row["fare_product_id"],
+			RulePriority:         priority.Int64,
+		})
+	}
+
+	for i, row := range files["fare_transfer_rules.txt"] {
+		transferType, err := parseOptionalInt(row["fare_transfer_type"])
+		if err != nil || !transferType.Valid {
+			skip("fare_transfer_rules.txt", i)
+			continue
+		}
+		transferCount, err1 := parseOptionalInt(row["transfer_count"])
+		durationLimit, err2 := parseOptionalInt(row["duration_limit"])
+		durationLimitType, err3 := parseOptionalInt(row["duration_limit_type"])
+		if err1 != nil || err2 != nil || err3 != nil {
+			skip("fare_transfer_rules.txt", i)
+			continue
+		}
+		params.TransferRules = append(params.TransferRules, CreateFareTransferRuleParams{
+			FromLegGroupID:    toNullString(row["from_leg_group_id"]),
+			ToLegGroupID:      toNullString(row["to_leg_group_id"]),
+			TransferCount:     transferCount,
+			DurationLimit:     durationLimit,
+			DurationLimitType: durationLimitType,
+			FareTransferType:  transferType.Int64,
+			FareProductID:     toNullString(row["fare_product_id"]),
+		})
+	}
+
+	return params, nil
+}
+
+// stopLevelsFromFeed reads the level_id column of stops.txt, which go-gtfs does not parse,
+// keyed by stop ID.
+func stopLevelsFromFeed(feed []byte) (map[string]string, error) {
+	rows, err := readFeedFile(feed, "stops.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string]string)
+	for _, row := range rows {
+		if row["level_id"] != "" {
+			levels[row["stop_id"]] = row["level_id"]
+		}
+	}
+	return levels, nil
+}
+
+// stationParamsFromFeed reads levels.txt and pathways.txt, which go-gtfs does not parse.
+// Pathways between stops outside knownStops are skipped, along with rows missing required
+// values.
+func stationParamsFromFeed(feed []byte, knownStops map[string]bool) ([]CreateLevelParams, []CreatePathwayParams, error) {
+	levelRows, err := readFeedFile(feed, "levels.txt")
+	if err != nil {
+		return nil, nil, err
+	}
+	pathwayRows, err := readFeedFile(feed, "pathways.txt")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	skip := func(file string, row int) {
+		logging.LogOperation(logger, "skipping_invalid_station_row",
+			slog.String("file", file),
+			slog.Int("row", row+2))
+	}
+
+	var levels []CreateLevelParams
+	for i, row := range levelRows {
+		index, err := strconv.ParseFloat(row["level_index"], 64)
+		if err != nil || row["level_id"] == "" {
+			skip("levels.txt", i)
+			continue
+		}
+		levels = append(levels, CreateLevelParams{
+			LevelID:    row["level_id"],
+			LevelIndex: index,
+			LevelName:  toNullString(row["level_name"]),
+		})
+	}
+
+	var pathways []CreatePathwayParams
+	for i, row := range pathwayRows {
+		mode, err1 := parseOptionalInt(row["pathway_mode"])
+		bidirectional, err2 := parseOptionalInt(row["is_bidirectional"])
+		traversalTime, err3 := parseOptionalInt(row["traversal_time"])
+		stairCount, err4 := parseOptionalInt(row["stair_count"])
+		length, err5 := parseOptionalFloat(row["length"])
+		maxSlope, err6 := parseOptionalFloat(row["max_slope"])
+		minWidth, err7 := parseOptionalFloat(row["min_width"])
+		if err := errors.Join(err1, err2, err3, err4, err5, err6, err7); err != nil ||
+			row["pathway_id"] == "" || !mode.Valid || mode.Int64 < 1 || mode.Int64 > 7 || !bidirectional.Valid ||
+			!knownStops[row["from_stop_id"]] || !knownStops[row["to_stop_id"]] {
+			skip("pathways.txt", i)
+			continue
+		}
+		pathways = append(pathways, CreatePathwayParams{
+			PathwayID:            row["pathway_id"],
+			FromStopID:           row["from_stop_id"],
+			ToStopID:             row["to_stop_id"],
+			PathwayMode:          mode.Int64,
+			IsBidirectional:      bidirectional.Int64,
+			Length:               length,
+			TraversalTime:        traversalTime,
+			StairCount:           stairCount,
+			MaxSlope:             maxSlope,
+			MinWidth:             minWidth,
+			SignpostedAs:         toNullString(row["signposted_as"]),
+			ReversedSignpostedAs: toNullString(row["reversed_signposted_as"]),
+		})
+	}
+	return levels, pathways, nil
+}
+
+// parseFeedTime parses a GTFS HH:MM:SS time, which may exceed 24:00:00, into an offset from
+// midnight. An empty value yields fallback.
+func parseFeedTime(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	var fields [3]int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time %q", value)
+		}
+		fields[i] = n
+	}
+	return time.Duration(fields[0])*time.Hour + time.Duration(fields[1])*time.Minute + time.Duration(fields[2])*time.Second, nil
+}
@@ -0,0 +1,115 @@
+package gtfsdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// feedIDSeparator separates the namespace of a feed from an ID as it is written in the feed. It
+// is a control character, which GTFS IDs don't contain.
+const feedIDSeparator = "\x1f"
+
+// FeedNamespace returns the namespace the IDs of a feed are imported into, so that feeds served
+// together may reuse each other's stop, route, trip and other IDs. The default feed's IDs are
+// imported as they are. Agency IDs are never namespaced, since combined IDs are resolved to a
+// feed through their agency.
+func FeedNamespace(feedID string) string {
+	if feedID == DefaultFeedID {
+		return ""
+	}
+	return feedID
+}
+
+// ScopedID returns the ID stored for id, as written in a feed, once imported into namespace.
+func ScopedID(namespace, id string) string {
+	if namespace == "" || id == "" {
+		return id
+	}
+	return namespace + feedIDSeparator + id
+}
+
+// UnscopedID returns a stored ID as it is written in its feed.
+func UnscopedID(id string) string {
+	if _, feedID, ok := strings.Cut(id, feedIDSeparator); ok {
+		return feedID
+	}
+	return id
+}
+
+// feedIDColumns are the columns of each table that hold IDs of a feed's own entities. Fare
+// attribute agency IDs are left out with the agencies they refer to, and validation findings
+// keep the IDs they report as written in the feed.
+var feedIDColumns = map[string][]string{
+	"stop_times":          {"trip_id", "stop_id"},
+	"frequencies":         {"trip_id"},
+	"transfers":           {"from_stop_id", "to_stop_id", "from_route_id", "to_route_id", "from_trip_id", "to_trip_id"},
+	"fare_rules":          {"fare_id", "route_id", "origin_id", "destination_id", "contains_id"},
+	"fare_attributes":     {"fare_id"},
+	"fare_transfer_rules": {"from_leg_group_id", "to_leg_group_id", "fare_product_id"},
+	"fare_leg_rules":      {"leg_group_id", "network_id", "from_area_id", "to_area_id", "from_timeframe_group_id", "to_timeframe_group_id", "fare_product_id"},
+	"timeframes":          {"timeframe_group_id", "service_id"},
+	"route_networks":      {"route_id", "network_id"},
+	"networks":            {"network_id"},
+	"stop_areas":          {"area_id", "stop_id"},
+	"areas":               {"area_id"},
+	"fare_products":       {"fare_product_id", "fare_media_id"},
+	"fare_media":          {"fare_media_id"},
+	"pathways":            {"pathway_id", "from_stop_id", "to_stop_id"},
+	"levels":              {"level_id"},
+	"shapes":              {"shape_id"},
+	"trips":               {"id", "route_id", "service_id", "block_id", "shape_id"},
+	"calendar_dates":      {"service_id"},
+	"calendar":            {"id"},
+	"stops":               {"id", "zone_id", "parent_station", "level_id"},
+	"routes":              {"id"},
+}
+
+// scopeFeedRowIDs moves the IDs of the rows written by an import, which are not yet assigned to
+// a feed, into namespace.
+func (c *Client) scopeFeedRowIDs(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	prefix := namespace + feedIDSeparator
+
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Rows refer to each other by the IDs being changed, so references are only checked once
+	// every table has been updated
+	if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+		return err
+	}
+
+	for _, table := range feedTables {
+		columns := feedIDColumns[table]
+		if len(columns) == 0 {
+			continue
+		}
+		assignments := make([]string, len(columns))
+		args := make([]interface{}, len(columns))
+		for i, column := range columns {
+			// Empty IDs mean no entity, such as a fare product without fare media
+			assignments[i] = fmt.Sprintf("%[1]s = CASE WHEN %[1]s IS NULL OR %[1]s = '' THEN %[1]s ELSE ? || %[1]s END", column)
+			args[i] = prefix
+		}
+		query := fmt.Sprintf("UPDATE %s SET %s WHERE source_feed_id IS NULL", table, strings.Join(assignments, ", "))
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("error scoping IDs of %s: %w", table, err)
+		}
+	}
+
+	// Translations name the record they translate in the table they translate
+	_, err = tx.ExecContext(ctx,
+		"UPDATE translations SET record_id = ? || record_id WHERE source_feed_id IS NULL AND record_id IS NOT NULL AND record_id != '' AND table_name != 'agency'",
+		prefix)
+	if err != nil {
+		return fmt.Errorf("error scoping IDs of translations: %w", err)
+	}
+
+	return tx.Commit()
+}
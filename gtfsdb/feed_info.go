@@ -0,0 +1,32 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// FeedValidityWindow returns the first and last service dates, as YYYYMMDD, covered by the
+// imported feed. The dates come from feed_info.txt when it provides them and otherwise from
+// the range of calendar.txt. Either value is empty when it cannot be determined.
+func (c *Client) FeedValidityWindow(ctx context.Context) (startDate, endDate string, err error) {
+	feedInfo, err := c.Queries.GetFeedInfo(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", "", err
+	}
+	startDate, endDate = feedInfo.FeedStartDate.String, feedInfo.FeedEndDate.String
+
+	if startDate == "" || endDate == "" {
+		calendarRange, err := c.Queries.GetCalendarDateRange(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		if startDate == "" {
+			startDate = calendarRange.StartDate
+		}
+		if endDate == "" {
+			endDate = calendarRange.EndDate
+		}
+	}
+	return startDate, endDate, nil
+}
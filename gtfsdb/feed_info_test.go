@@ -0,0 +1,61 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportFeedInfo(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "feed_info"))
+
+	feedInfo, err := client.Queries.GetFeedInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Arcadis, Inc.", feedInfo.FeedPublisherName)
+	assert.Equal(t, "http://arcadis.com/", feedInfo.FeedPublisherUrl)
+	assert.Equal(t, "en", feedInfo.FeedLang)
+	assert.Equal(t, "redding-ca-us", feedInfo.FeedID.String)
+	assert.Equal(t, "20250421", feedInfo.FeedVersion.String)
+	assert.Equal(t, "otpdtsupport@arcadis.com", feedInfo.FeedContactEmail.String)
+	assert.False(t, feedInfo.FeedContactUrl.Valid)
+
+	startDate, endDate, err := client.FeedValidityWindow(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "20250101", startDate)
+	assert.Equal(t, "20251231", endDate)
+
+	t.Run("falls back to the calendar without feed dates", func(t *testing.T) {
+		feedPath := models.BuildFeedWithFiles(t, getTestFixturePath(t, "raba.zip"), map[string]string{
+			"feed_info.txt": "feed_publisher_name,feed_publisher_url,feed_lang,feed_version\n" +
+				"Example,https://example.com,en,v2\n",
+		})
+		feed, err := os.ReadFile(feedPath)
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "feed_info"))
+
+		feedInfo, err := client.Queries.GetFeedInfo(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", feedInfo.FeedVersion.String, "the previous feed_info row is replaced")
+
+		calendarRange, err := client.Queries.GetCalendarDateRange(ctx)
+		require.NoError(t, err)
+
+		startDate, endDate, err := client.FeedValidityWindow(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, calendarRange.StartDate, startDate)
+		assert.Equal(t, calendarRange.EndDate, endDate)
+		assert.NotEmpty(t, startDate)
+	})
+}
@@ -0,0 +1,54 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportFrequencies(t *testing.T) {
+	const tripID = "84f4520e-88b6-4ee6-8975-856799bc1359"
+
+	feedPath := models.BuildFeedWithFiles(t, getTestFixturePath(t, "raba.zip"), map[string]string{
+		"frequencies.txt": "trip_id,start_time,end_time,headway_secs,exact_times\n" +
+			tripID + ",06:00:00,09:00:00,600,0\n" +
+			tripID + ",16:00:00,25:30:00,900,1\n",
+	})
+	feed, err := os.ReadFile(feedPath)
+	require.NoError(t, err)
+
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "frequencies"))
+
+	frequencies, err := client.Queries.GetFrequenciesForTrip(ctx, tripID)
+	require.NoError(t, err)
+	require.Len(t, frequencies, 2)
+
+	assert.Equal(t, int64(6*time.Hour), frequencies[0].StartTime)
+	assert.Equal(t, int64(9*time.Hour), frequencies[0].EndTime)
+	assert.Equal(t, int64(600), frequencies[0].HeadwaySecs)
+	assert.Equal(t, int64(0), frequencies[0].ExactTimes)
+
+	assert.Equal(t, int64(16*time.Hour), frequencies[1].StartTime)
+	assert.Equal(t, int64(25*time.Hour+30*time.Minute), frequencies[1].EndTime, "times past midnight are kept")
+	assert.Equal(t, int64(1), frequencies[1].ExactTimes)
+
+	// Reimporting a feed without frequencies.txt removes the old entries
+	original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "frequencies"))
+
+	frequencies, err = client.Queries.GetFrequenciesForTrip(ctx, tripID)
+	require.NoError(t, err)
+	assert.Empty(t, frequencies)
+}
@@ -8,7 +8,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/OneBusAway/go-gtfs"
@@ -19,30 +21,127 @@ import (
 //go:embed schema.sql
 var ddl string
 
+//go:embed user_schema.sql
+var userDDL string
+
+// memoryDBCount numbers in-memory databases, keeping those of different clients apart
+var memoryDBCount atomic.Int64
+
+// databaseDSN returns the data source name of the SQLite database at path.
+func databaseDSN(path string) string {
+	if path == ":memory:" {
+		// Each connection to ":memory:" opens a database of its own, so every connection in the
+		// pool shares one named in-memory database instead
+		return fmt.Sprintf("file:gtfsdb-memory-%d?mode=memory&cache=shared", memoryDBCount.Add(1))
+	}
+	// Writers wait for one another rather than failing, such as while a rebuilt database is
+	// moved into place
+	return path + "?_pragma=busy_timeout(10000)"
+}
+
+// userDBPath returns the path of the database holding the user tables of the GTFS database at
+// dbPath. Beside an in-memory database it is in memory too.
+func userDBPath(dbPath string) string {
+	if dbPath == ":memory:" {
+		return dbPath
+	}
+	ext := filepath.Ext(dbPath)
+	return strings.TrimSuffix(dbPath, ext) + "-user" + ext
+}
+
+// openDB opens the SQLite database with the given data source name.
+func openDB(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Configure connection pool settings
+	configureConnectionPool(db)
+	if strings.Contains(dsn, "mode=memory") {
+		// An in-memory database is gone once its last connection closes
+		db.SetConnMaxLifetime(0)
+	}
+	return db, nil
+}
+
 // createDB creates a new SQLite database with tables for static GTFS data
 func createDB(config Config) (*sql.DB, error) {
 	if config.Env == appconf.Test && config.DBPath != ":memory:" {
 		return nil, fmt.Errorf("test database must use in-memory storage, got path: %s", config.DBPath)
 	}
 
-	db, err := sql.Open("sqlite", config.DBPath)
+	db, err := openDB(databaseDSN(config.DBPath))
 	if err != nil {
 		return nil, err
 	}
 
 	ctx := context.Background()
+	err = dropSingleFeedImportMetadata(ctx, db)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error migrating import metadata: %w", err)
+	}
 	err = performDatabaseMigration(ctx, db)
 	if err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("error performing database migration: %w", err)
 	}
 
-	// Configure connection pool settings
-	configureConnectionPool(db)
+	return db, nil
+}
 
+// createUserDB opens the database of user tables with the given data source name, creating
+// its tables if need be.
+func createUserDB(dsn string) (*sql.DB, error) {
+	db, err := openDB(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(context.Background(), db, userDDL); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("error performing user database migration: %w", err)
+	}
 	return db, nil
 }
 
+// moveUserTables moves the user tables of a GTFS database created by an older version, which
+// kept them alongside the feed data, into the user database.
+func moveUserTables(ctx context.Context, db, userDB *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer logging.SafeCloseWithLogging(conn,
+		slog.Default().With(slog.String("component", "gtfs_importer")),
+		"database_connection")
+
+	for _, table := range userTables {
+		var exists bool
+		err := conn.QueryRowContext(ctx,
+			"SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err := copyTable(ctx, conn, userDB, table); err != nil {
+			return fmt.Errorf("error moving %s: %w", table, err)
+		}
+		if _, err := conn.ExecContext(ctx, fmt.Sprintf("DROP TABLE %s", table)); err != nil {
+			return fmt.Errorf("error dropping %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
 func performDatabaseMigration(ctx context.Context, db *sql.DB) error {
+	return migrate(ctx, db, ddl)
+}
+
+// migrate executes the statements of a schema, each following a "-- migrate" line.
+func migrate(ctx context.Context, db *sql.DB, ddl string) error {
 	statements := strings.Split(ddl, "-- migrate") // Split DDL into individual statements
 	for _, stmt := range statements {
 		trimmedStmt := strings.TrimSpace(stmt)
@@ -50,14 +149,82 @@ func performDatabaseMigration(ctx context.Context, db *sql.DB) error {
 			continue // Skip empty statements
 		}
 		if _, err := db.ExecContext(ctx, trimmedStmt); err != nil {
+			// Columns added to existing tables are only missing from databases created by
+			// older versions, so re-running the ALTER TABLE is expected to fail.
+			if strings.HasPrefix(trimmedStmt, "ALTER TABLE") && strings.Contains(err.Error(), "duplicate column name") {
+				continue
+			}
 			return fmt.Errorf("error executing DDL statement [%s]: %w", trimmedStmt, err)
 		}
 	}
 	return nil
 }
 
+// dropSingleFeedImportMetadata drops the import_metadata table of databases created before
+// import state was tracked per feed, which held a single row. The table is recreated by the
+// migration, so the next start reimports every feed.
+func dropSingleFeedImportMetadata(ctx context.Context, db *sql.DB) error {
+	var hasIDColumn bool
+	err := db.QueryRowContext(ctx,
+		"SELECT COUNT(*) > 0 FROM pragma_table_info('import_metadata') WHERE name = 'id'").Scan(&hasIDColumn)
+	if err != nil || !hasIDColumn {
+		return err
+	}
+	_, err = db.ExecContext(ctx, "DROP TABLE import_metadata")
+	return err
+}
+
+// feedTables are the tables holding static GTFS data, in an order that deletes rows before the
+// rows they refer to.
+var feedTables = []string{
+	"validation_findings",
+	"stop_times",
+	"frequencies",
+	"transfers",
+	"fare_rules",
+	"fare_attributes",
+	"fare_transfer_rules",
+	"fare_leg_rules",
+	"timeframes",
+	"route_networks",
+	"networks",
+	"stop_areas",
+	"areas",
+	"fare_products",
+	"fare_media",
+	"pathways",
+	"levels",
+	"translations",
+	"feed_info",
+	"shapes",
+	"trips",
+	"calendar_dates",
+	"calendar",
+	"stops",
+	"routes",
+	"agencies",
+}
+
+// HashFeedData returns the hash recorded in the import metadata of a feed imported from b.
+func HashFeedData(b []byte) string {
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:])
+}
+
 func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) error {
-	logger := slog.Default().With(slog.String("component", "gtfs_importer"))
+	return c.processAndStoreFeedData(DefaultFeedID, b, source)
+}
+
+// processAndStoreFeedData imports the zipped GTFS feed in b as the feed with the given ID,
+// replacing only the rows previously imported for that feed. The import is skipped when the
+// feed was last imported from the same source with the same contents.
+func (c *Client) processAndStoreFeedData(feedID string, b []byte, source string) error {
+	logger := slog.Default().With(slog.String("component", "gtfs_importer"), slog.String("feed_id", feedID))
+
+	// Rows are written without a feed and assigned to this one once the import completes, so
+	// imports must not overlap
+	c.importMutex.Lock()
+	defer c.importMutex.Unlock()
 
 	startTime := time.Now()
 	defer func() {
@@ -72,17 +239,16 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		}
 	}()
 
-	// Calculate hash of the GTFS data
-	hash := sha256.Sum256(b)
-	hashStr := hex.EncodeToString(hash[:])
+	hashStr := HashFeedData(b)
+	namespace := FeedNamespace(feedID)
 
 	ctx := context.Background()
 
 	// Check if we already have this data imported
-	existingMetadata, err := c.Queries.GetImportMetadata(ctx)
+	existingMetadata, err := c.Queries.GetImportMetadata(ctx, feedID)
 	if err == nil {
 		// We have existing metadata, check if hash matches
-		if existingMetadata.FileHash == hashStr && existingMetadata.FileSource == source {
+		if existingMetadata.FileHash == hashStr && existingMetadata.FileSource == source && existingMetadata.IDNamespace == namespace {
 			if c.config.verbose {
 				logging.LogOperation(logger, "gtfs_data_unchanged_skipping_import",
 					slog.String("hash", hashStr[:8]))
@@ -95,23 +261,47 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 				slog.String("old_hash", existingMetadata.FileHash[:8]),
 				slog.String("new_hash", hashStr[:8]))
 		}
-		err = c.clearAllGTFSData(ctx)
-		if err != nil {
-			return fmt.Errorf("error clearing existing GTFS data: %w", err)
-		}
-	} else if err != nil && err != sql.ErrNoRows {
+	} else if err != sql.ErrNoRows {
 		// Some other error occurred
 		return fmt.Errorf("error checking import metadata: %w", err)
 	}
-	// If err == sql.ErrNoRows, this is the first import, continue normally
-
-	var staticCounts map[string]int
 
 	staticData, err := gtfs.ParseStatic(b, gtfs.ParseStaticOptions{})
 	if err != nil {
 		return err
 	}
 
+	// Combined IDs are resolved to a feed through their agency, so agency IDs are shared by every
+	// feed
+	err = c.checkFeedAgencyConflicts(ctx, feedID, staticData)
+	if err != nil {
+		return err
+	}
+
+	// Clear this feed's rows. Its metadata goes too, so that a failure below is retried rather
+	// than skipped next time.
+	err = c.clearFeedGTFSData(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("error clearing existing GTFS data: %w", err)
+	}
+	err = c.Queries.DeleteImportMetadata(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("error clearing import metadata: %w", err)
+	}
+
+	// Rows written below only join this feed once the import completes, so an import that fails
+	// removes them rather than leave them for the next import to take
+	assigned := false
+	defer func() {
+		if !assigned {
+			if _, err := c.clearUnassignedGTFSData(ctx); err != nil {
+				logging.LogError(logger, "Error clearing rows of a failed import", err)
+			}
+		}
+	}()
+
+	var staticCounts map[string]int
+
 	if c.config.verbose {
 		fmt.Printf("retrieved static data (warnings: %d)\n", len(staticData.Warnings))
 		fmt.Print("========\n\n")
@@ -169,23 +359,40 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		}
 	}
 
+	stopLevels, err := stopLevelsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read stop levels: %w", err)
+	}
+
 	var allStopParams []CreateStopParams
+	knownStops := make(map[string]bool, len(staticData.Stops))
 	for _, s := range staticData.Stops {
+		lat, lon, ok := stopCoordinates(&s)
+		if !ok {
+			logging.LogOperation(logger, "skipping_stop_without_coordinates",
+				slog.String("stop_id", s.Id))
+			continue
+		}
 		params := CreateStopParams{
 			ID:                 s.Id,
 			Code:               toNullString(s.Code),
 			Name:               toNullString(s.Name),
 			Desc:               toNullString(s.Description),
-			Lat:                *s.Latitude,
-			Lon:                *s.Longitude,
+			Lat:                lat,
+			Lon:                lon,
 			ZoneID:             toNullString(s.ZoneId),
 			Url:                toNullString(s.Url),
-			LocationType:       toNullInt64(int64(s.Type)),
+			LocationType:       toNullInt64(locationType(s.Type)),
 			Timezone:           toNullString(s.Timezone),
 			WheelchairBoarding: toNullInt64(int64(s.WheelchairBoarding)),
 			PlatformCode:       toNullString(s.PlatformCode),
+			LevelID:            toNullString(stopLevels[s.Id]),
+		}
+		if s.Parent != nil {
+			params.ParentStation = toNullString(s.Parent.Id)
 		}
 
+		knownStops[s.Id] = true
 		allStopParams = append(allStopParams, params)
 	}
 	err = c.bulkInsertStops(ctx, allStopParams)
@@ -193,6 +400,15 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		return fmt.Errorf("unable to create stops: %w", err)
 	}
 
+	allLevelParams, allPathwayParams, err := stationParamsFromFeed(b, knownStops)
+	if err != nil {
+		return fmt.Errorf("unable to read levels and pathways: %w", err)
+	}
+	err = c.bulkInsertStationData(ctx, allLevelParams, allPathwayParams)
+	if err != nil {
+		return fmt.Errorf("unable to create levels and pathways: %w", err)
+	}
+
 	for _, s := range staticData.Services {
 		params := CreateCalendarParams{
 			ID:        s.Id,
@@ -215,6 +431,10 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 
 	var allTripParams []CreateTripParams
 	for _, t := range staticData.Trips {
+		var shapeID string
+		if t.Shape != nil {
+			shapeID = t.Shape.ID
+		}
 		params := CreateTripParams{
 			ID:                   t.ID,
 			RouteID:              t.Route.Id,
@@ -223,7 +443,7 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 			TripShortName:        toNullString(t.ShortName),
 			DirectionID:          toNullInt64(int64(t.DirectionId)),
 			BlockID:              toNullString(t.BlockID),
-			ShapeID:              toNullString(t.Shape.ID),
+			ShapeID:              toNullString(shapeID),
 			WheelchairAccessible: toNullInt64(int64(t.WheelchairAccessible)),
 			BikesAllowed:         toNullInt64(int64(t.BikesAllowed)),
 		}
@@ -257,6 +477,24 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		return fmt.Errorf("unable to create stop times: %w", err)
 	}
 
+	var allFrequencyParams []CreateFrequencyParams
+	for _, t := range staticData.Trips {
+		for _, f := range t.Frequencies {
+			params := CreateFrequencyParams{
+				TripID:      t.ID,
+				StartTime:   int64(f.StartTime),
+				EndTime:     int64(f.EndTime),
+				HeadwaySecs: int64(f.Headway / time.Second),
+				ExactTimes:  int64(f.ExactTimes),
+			}
+			allFrequencyParams = append(allFrequencyParams, params)
+		}
+	}
+	err = c.bulkInsertFrequencies(ctx, allFrequencyParams)
+	if err != nil {
+		return fmt.Errorf("unable to create frequencies: %w", err)
+	}
+
 	var allShapeParams []CreateShapeParams
 	for _, s := range staticData.Shapes {
 		for idx, pt := range s.Points {
@@ -266,6 +504,9 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 				Lon:             pt.Longitude,
 				ShapePtSequence: int64(idx),
 			}
+			if pt.Distance != nil {
+				params.ShapeDistTraveled = sql.NullFloat64{Float64: *pt.Distance, Valid: true}
+			}
 			allShapeParams = append(allShapeParams, params)
 		}
 	}
@@ -274,6 +515,58 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		return fmt.Errorf("unable to create shapes: %w", err)
 	}
 
+	allTransferParams, err := transferParamsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read transfers: %w", err)
+	}
+	err = c.bulkInsertTransfers(ctx, allTransferParams)
+	if err != nil {
+		return fmt.Errorf("unable to create transfers: %w", err)
+	}
+
+	allFareAttributeParams, allFareRuleParams, err := fareParamsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read fares: %w", err)
+	}
+	err = c.bulkInsertFares(ctx, allFareAttributeParams, allFareRuleParams)
+	if err != nil {
+		return fmt.Errorf("unable to create fares: %w", err)
+	}
+
+	faresV2, err := faresV2ParamsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read Fares v2 data: %w", err)
+	}
+	err = c.bulkInsertFaresV2(ctx, faresV2)
+	if err != nil {
+		return fmt.Errorf("unable to create Fares v2 data: %w", err)
+	}
+
+	allTranslationParams, err := translationParamsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read translations: %w", err)
+	}
+	err = c.bulkInsertTranslations(ctx, allTranslationParams)
+	if err != nil {
+		return fmt.Errorf("unable to create translations: %w", err)
+	}
+
+	allFeedInfoParams, err := feedInfoParamsFromFeed(b)
+	if err != nil {
+		return fmt.Errorf("unable to read feed info: %w", err)
+	}
+	for _, params := range allFeedInfoParams {
+		_, err := c.Queries.CreateFeedInfo(ctx, params)
+		if err != nil {
+			return fmt.Errorf("unable to create feed info: %w", err)
+		}
+	}
+
+	err = c.rebuildSearchIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
 	if c.config.verbose {
 		counts, err := c.TableCounts()
 		if err != nil {
@@ -285,25 +578,6 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		}
 	}
 
-	// Update import metadata to record successful import
-	if c.config.verbose {
-		logging.LogOperation(logger, "updating_import_metadata",
-			slog.String("hash", hashStr[:8]),
-			slog.String("source", source))
-	}
-	_, err = c.Queries.UpsertImportMetadata(ctx, UpsertImportMetadataParams{
-		FileHash:   hashStr,
-		ImportTime: time.Now().Unix(),
-		FileSource: source,
-	})
-	if err != nil {
-		logging.LogError(logger, "Error updating import metadata", err)
-		return fmt.Errorf("error updating import metadata: %w", err)
-	}
-	if c.config.verbose {
-		logging.LogOperation(logger, "import_metadata_updated_successfully")
-	}
-
 	var allCalendarDateParams []CreateCalendarDateParams
 
 	for _, service := range staticData.Services {
@@ -337,32 +611,147 @@ func (c *Client) processAndStoreGTFSDataWithSource(b []byte, source string) erro
 		}
 	}
 
+	findings, err := validateFeed(b, staticData.Warnings)
+	if err != nil {
+		return fmt.Errorf("error validating feed: %w", err)
+	}
+	if len(findings) > 0 {
+		err = c.bulkInsertValidationFindings(ctx, findings)
+		if err != nil {
+			logging.LogError(logger, "Unable to store validation findings", err)
+			return fmt.Errorf("unable to store validation findings: %w", err)
+		}
+	}
+	if c.config.verbose {
+		logging.LogOperation(logger, "gtfs_data_validated",
+			slog.Int("findings", len(findings)))
+	}
+
+	err = c.scopeFeedRowIDs(ctx, namespace)
+	if err != nil {
+		return fmt.Errorf("error scoping IDs of feed %s: %w", feedID, err)
+	}
+	err = c.assignFeedRows(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("error assigning imported rows to feed %s: %w", feedID, err)
+	}
+	assigned = true
+
+	// Update import metadata to record successful import
+	if c.config.verbose {
+		logging.LogOperation(logger, "updating_import_metadata",
+			slog.String("hash", hashStr[:8]),
+			slog.String("source", source))
+	}
+	_, err = c.Queries.UpsertImportMetadata(ctx, UpsertImportMetadataParams{
+		FeedID:      feedID,
+		FileHash:    hashStr,
+		ImportTime:  time.Now().Unix(),
+		FileSource:  source,
+		IDNamespace: namespace,
+	})
+	if err != nil {
+		logging.LogError(logger, "Error updating import metadata", err)
+		return fmt.Errorf("error updating import metadata: %w", err)
+	}
+	if c.config.verbose {
+		logging.LogOperation(logger, "import_metadata_updated_successfully")
+	}
+
 	return nil
 }
 
-// clearAllGTFSData clears all GTFS data from the database in the correct order to respect foreign key constraints
-func (c *Client) clearAllGTFSData(ctx context.Context) error {
-	// Delete in reverse order of dependencies to avoid foreign key constraint violations
-	if err := c.Queries.ClearStopTimes(ctx); err != nil {
-		return fmt.Errorf("error clearing stop_times: %w", err)
+// clearFeedGTFSData deletes the rows imported for one feed, leaving other feeds' data in place.
+func (c *Client) clearFeedGTFSData(ctx context.Context, feedID string) error {
+	for _, table := range feedTables {
+		query := fmt.Sprintf("DELETE FROM %s WHERE source_feed_id = ?", table)
+		if _, err := c.DB.ExecContext(ctx, query, feedID); err != nil {
+			return fmt.Errorf("error clearing %s: %w", table, err)
+		}
 	}
-	if err := c.Queries.ClearShapes(ctx); err != nil {
-		return fmt.Errorf("error clearing shapes: %w", err)
+	return nil
+}
+
+// clearUnassignedGTFSData deletes the rows not assigned to any feed, written by an import that
+// failed, and reports whether there were any.
+func (c *Client) clearUnassignedGTFSData(ctx context.Context) (bool, error) {
+	cleared := false
+	for _, table := range feedTables {
+		query := fmt.Sprintf("DELETE FROM %s WHERE source_feed_id IS NULL", table)
+		result, err := c.DB.ExecContext(ctx, query)
+		if err != nil {
+			return false, fmt.Errorf("error clearing unassigned rows of %s: %w", table, err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n > 0 {
+			cleared = true
+		}
 	}
-	if err := c.Queries.ClearTrips(ctx); err != nil {
-		return fmt.Errorf("error clearing trips: %w", err)
+	return cleared, nil
+}
+
+// checkFeedAgencyConflicts returns an error naming the first agency of staticData whose ID
+// another feed has already imported.
+func (c *Client) checkFeedAgencyConflicts(ctx context.Context, feedID string, staticData *gtfs.Static) error {
+	for _, agency := range staticData.Agencies {
+		var otherFeedID string
+		err := c.DB.QueryRowContext(ctx,
+			"SELECT source_feed_id FROM agencies WHERE id = ? AND source_feed_id IS NOT NULL AND source_feed_id != ?",
+			agency.Id, feedID).Scan(&otherFeedID)
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error checking agencies of feed %s: %w", feedID, err)
+		}
+		return fmt.Errorf("feed %s reuses agency ID %q already imported by feed %s", feedID, agency.Id, otherFeedID)
 	}
-	if err := c.Queries.ClearCalendar(ctx); err != nil {
-		return fmt.Errorf("error clearing calendar: %w", err)
+	return nil
+}
+
+// assignFeedRows assigns the rows written by an import to the feed being imported.
+func (c *Client) assignFeedRows(ctx context.Context, feedID string) error {
+	for _, table := range feedTables {
+		query := fmt.Sprintf("UPDATE %s SET source_feed_id = ? WHERE source_feed_id IS NULL", table)
+		if _, err := c.DB.ExecContext(ctx, query, feedID); err != nil {
+			return fmt.Errorf("error assigning %s: %w", table, err)
+		}
 	}
-	if err := c.Queries.ClearStops(ctx); err != nil {
-		return fmt.Errorf("error clearing stops: %w", err)
+	return nil
+}
+
+// RemoveFeedsExcept deletes the data and import state of every imported feed not listed in
+// feedIDs, such as feeds since removed from the configuration, along with rows left unassigned
+// by an import that was interrupted.
+func (c *Client) RemoveFeedsExcept(ctx context.Context, feedIDs []string) error {
+	c.importMutex.Lock()
+	defer c.importMutex.Unlock()
+
+	keep := make(map[string]bool, len(feedIDs))
+	for _, feedID := range feedIDs {
+		keep[feedID] = true
 	}
-	if err := c.Queries.ClearRoutes(ctx); err != nil {
-		return fmt.Errorf("error clearing routes: %w", err)
+
+	imported, err := c.Queries.ListImportMetadata(ctx)
+	if err != nil {
+		return err
 	}
-	if err := c.Queries.ClearAgencies(ctx); err != nil {
-		return fmt.Errorf("error clearing agencies: %w", err)
+	removed, err := c.clearUnassignedGTFSData(ctx)
+	if err != nil {
+		return err
+	}
+	for _, metadata := range imported {
+		if keep[metadata.FeedID] {
+			continue
+		}
+		if err := c.clearFeedGTFSData(ctx, metadata.FeedID); err != nil {
+			return err
+		}
+		if err := c.Queries.DeleteImportMetadata(ctx, metadata.FeedID); err != nil {
+			return err
+		}
+		removed = true
+	}
+	if removed {
+		return c.rebuildSearchIndexes(ctx)
 	}
 	return nil
 }
@@ -392,6 +781,26 @@ func toNullString(s string) sql.NullString {
 	}
 }
 
+// locationType converts a go-gtfs stop type back to the GTFS location_type. go-gtfs reports
+// stops with a parent station as platforms, which GTFS encodes as 0.
+func locationType(t gtfs.StopType) int64 {
+	if t == gtfs.StopType_Platform {
+		return int64(gtfs.StopType_Stop)
+	}
+	return int64(t)
+}
+
+// stopCoordinates returns a stop's position, falling back to its nearest ancestor's since
+// GTFS allows generic nodes and boarding areas to omit stop_lat and stop_lon.
+func stopCoordinates(stop *gtfs.Stop) (lat, lon float64, ok bool) {
+	for s := stop; s != nil; s = s.Parent {
+		if s.Latitude != nil && s.Longitude != nil {
+			return *s.Latitude, *s.Longitude, true
+		}
+	}
+	return 0, 0, false
+}
+
 func pickFirstAvailable(a, b string) string {
 	if a != "" {
 		return a
@@ -420,6 +829,51 @@ func (c *Client) bulkInsertStops(ctx context.Context, stops []CreateStopParams)
 	return tx.Commit()
 }
 
+func (c *Client) bulkInsertStationData(ctx context.Context, levels []CreateLevelParams, pathways []CreatePathwayParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_station_data")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range levels {
+		if _, err := qtx.CreateLevel(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range pathways {
+		if _, err := qtx.CreatePathway(ctx, params); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *Client) bulkInsertTranslations(ctx context.Context, translations []CreateTranslationParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_translations")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range translations {
+		if _, err := qtx.CreateTranslation(ctx, params); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 func (c *Client) bulkInsertTrips(ctx context.Context, trips []CreateTripParams) error {
 	db := c.DB
 	queries := c.Queries
@@ -462,6 +916,135 @@ func (c *Client) bulkInsertStopTimes(ctx context.Context, stopTimes []CreateStop
 	return tx.Commit()
 }
 
+func (c *Client) bulkInsertFrequencies(ctx context.Context, frequencies []CreateFrequencyParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_frequencies")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range frequencies {
+		_, err := qtx.CreateFrequency(ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *Client) bulkInsertTransfers(ctx context.Context, transfers []CreateTransferParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_transfers")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range transfers {
+		_, err := qtx.CreateTransfer(ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *Client) bulkInsertFares(ctx context.Context, attributes []CreateFareAttributeParams, rules []CreateFareRuleParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_fares")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range attributes {
+		_, err := qtx.CreateFareAttribute(ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+	for _, params := range rules {
+		_, err := qtx.CreateFareRule(ctx, params)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *Client) bulkInsertFaresV2(ctx context.Context, fares faresV2Params) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_fares_v2")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range fares.Media {
+		if _, err := qtx.CreateFareMedia(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.Products {
+		if _, err := qtx.CreateFareProduct(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.Areas {
+		if _, err := qtx.CreateArea(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.StopAreas {
+		if _, err := qtx.CreateStopArea(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.Networks {
+		if _, err := qtx.CreateNetwork(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.RouteNetworks {
+		if _, err := qtx.CreateRouteNetwork(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.Timeframes {
+		if _, err := qtx.CreateTimeframe(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.LegRules {
+		if _, err := qtx.CreateFareLegRule(ctx, params); err != nil {
+			return err
+		}
+	}
+	for _, params := range fares.TransferRules {
+		if _, err := qtx.CreateFareTransferRule(ctx, params); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
 func (c *Client) bulkInsertShapes(ctx context.Context, shapes []CreateShapeParams) error {
 	db := c.DB
 	queries := c.Queries
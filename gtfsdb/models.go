@@ -9,40 +9,202 @@ import (
 )
 
 type Agency struct {
-	ID       string
-	Name     string
-	Url      string
-	Timezone string
-	Lang     sql.NullString
-	Phone    sql.NullString
-	FareUrl  sql.NullString
-	Email    sql.NullString
+	ID           string
+	Name         string
+	Url          string
+	Timezone     string
+	Lang         sql.NullString
+	Phone        sql.NullString
+	FareUrl      sql.NullString
+	Email        sql.NullString
+	SourceFeedID sql.NullString
+}
+
+type Area struct {
+	AreaID       string
+	AreaName     sql.NullString
+	SourceFeedID sql.NullString
+}
+
+type ArrivalAlarm struct {
+	ID              string
+	AgencyID        string
+	StopID          string
+	TripID          string
+	ServiceDate     int64
+	StopSequence    int64
+	VehicleID       sql.NullString
+	AlarmTimeOffset int64
+	OnArrival       int64
+	CallbackUrl     string
+	CreatedAt       int64
+	ClientID        string
 }
 
 type Calendar struct {
-	ID        string
-	Monday    int64
-	Tuesday   int64
-	Wednesday int64
-	Thursday  int64
-	Friday    int64
-	Saturday  int64
-	Sunday    int64
-	StartDate string
-	EndDate   string
+	ID           string
+	Monday       int64
+	Tuesday      int64
+	Wednesday    int64
+	Thursday     int64
+	Friday       int64
+	Saturday     int64
+	Sunday       int64
+	StartDate    string
+	EndDate      string
+	SourceFeedID sql.NullString
 }
 
 type CalendarDate struct {
 	ServiceID     string
 	Date          string
 	ExceptionType int64
+	SourceFeedID  sql.NullString
+}
+
+type FareAttribute struct {
+	FareID           string
+	Price            float64
+	CurrencyType     string
+	PaymentMethod    int64
+	Transfers        sql.NullInt64
+	AgencyID         sql.NullString
+	TransferDuration sql.NullInt64
+	SourceFeedID     sql.NullString
+}
+
+type FareLegRule struct {
+	ID                   int64
+	LegGroupID           sql.NullString
+	NetworkID            sql.NullString
+	FromAreaID           sql.NullString
+	ToAreaID             sql.NullString
+	FromTimeframeGroupID sql.NullString
+	ToTimeframeGroupID   sql.NullString
+	FareProductID        string
+	RulePriority         int64
+	SourceFeedID         sql.NullString
+}
+
+type FareMedium struct {
+	FareMediaID   string
+	FareMediaName sql.NullString
+	FareMediaType int64
+	SourceFeedID  sql.NullString
+}
+
+type FareProduct struct {
+	FareProductID   string
+	FareProductName sql.NullString
+	FareMediaID     string
+	Amount          float64
+	Currency        string
+	SourceFeedID    sql.NullString
+}
+
+type FareRule struct {
+	ID            int64
+	FareID        string
+	RouteID       sql.NullString
+	OriginID      sql.NullString
+	DestinationID sql.NullString
+	ContainsID    sql.NullString
+	SourceFeedID  sql.NullString
+}
+
+type FareTransferRule struct {
+	ID                int64
+	FromLegGroupID    sql.NullString
+	ToLegGroupID      sql.NullString
+	TransferCount     sql.NullInt64
+	DurationLimit     sql.NullInt64
+	DurationLimitType sql.NullInt64
+	FareTransferType  int64
+	FareProductID     sql.NullString
+	SourceFeedID      sql.NullString
+}
+
+type FeedInfo struct {
+	ID                int64
+	FeedID            sql.NullString
+	FeedPublisherName string
+	FeedPublisherUrl  string
+	FeedLang          string
+	DefaultLang       sql.NullString
+	FeedStartDate     sql.NullString
+	FeedEndDate       sql.NullString
+	FeedVersion       sql.NullString
+	FeedContactEmail  sql.NullString
+	FeedContactUrl    sql.NullString
+	SourceFeedID      sql.NullString
+}
+
+type Frequency struct {
+	TripID       string
+	StartTime    int64
+	EndTime      int64
+	HeadwaySecs  int64
+	ExactTimes   int64
+	SourceFeedID sql.NullString
 }
 
 type ImportMetadatum struct {
-	ID         int64
-	FileHash   string
-	ImportTime int64
-	FileSource string
+	FeedID       string
+	FileHash     string
+	ImportTime   int64
+	FileSource   string
+	Etag         sql.NullString
+	LastModified sql.NullString
+	IDNamespace  string
+}
+
+type Level struct {
+	LevelID      string
+	LevelIndex   float64
+	LevelName    sql.NullString
+	SourceFeedID sql.NullString
+}
+
+type Network struct {
+	NetworkID    string
+	NetworkName  sql.NullString
+	SourceFeedID sql.NullString
+}
+
+type Pathway struct {
+	PathwayID            string
+	FromStopID           string
+	ToStopID             string
+	PathwayMode          int64
+	IsBidirectional      int64
+	Length               sql.NullFloat64
+	TraversalTime        sql.NullInt64
+	StairCount           sql.NullInt64
+	MaxSlope             sql.NullFloat64
+	MinWidth             sql.NullFloat64
+	SignpostedAs         sql.NullString
+	ReversedSignpostedAs sql.NullString
+	SourceFeedID         sql.NullString
+}
+
+type ProblemReport struct {
+	ID                   int64
+	ReportType           string
+	TripID               sql.NullString
+	StopID               sql.NullString
+	Code                 string
+	ServiceDate          sql.NullInt64
+	VehicleID            sql.NullString
+	UserComment          sql.NullString
+	UserOnVehicle        sql.NullInt64
+	UserVehicleNumber    sql.NullString
+	UserLat              sql.NullFloat64
+	UserLon              sql.NullFloat64
+	UserLocationAccuracy sql.NullFloat64
+	Status               string
+	CreatedAt            int64
+	ResolvedAt           sql.NullInt64
+	ResolutionNote       sql.NullString
 }
 
 type Route struct {
@@ -57,14 +219,30 @@ type Route struct {
 	TextColor         sql.NullString
 	ContinuousPickup  sql.NullInt64
 	ContinuousDropOff sql.NullInt64
+	SourceFeedID      sql.NullString
+}
+
+type RouteNetwork struct {
+	RouteID      string
+	NetworkID    string
+	SourceFeedID sql.NullString
+}
+
+type RoutesFt struct {
+	ID        string
+	ShortName string
+	LongName  string
+	Desc      string
 }
 
 type Shape struct {
-	ID              int64
-	ShapeID         string
-	Lat             float64
-	Lon             float64
-	ShapePtSequence int64
+	ID                int64
+	ShapeID           string
+	Lat               float64
+	Lon               float64
+	ShapePtSequence   int64
+	SourceFeedID      sql.NullString
+	ShapeDistTraveled sql.NullFloat64
 }
 
 type Stop struct {
@@ -80,6 +258,15 @@ type Stop struct {
 	Timezone           sql.NullString
 	WheelchairBoarding sql.NullInt64
 	PlatformCode       sql.NullString
+	ParentStation      sql.NullString
+	LevelID            sql.NullString
+	SourceFeedID       sql.NullString
+}
+
+type StopArea struct {
+	AreaID       string
+	StopID       string
+	SourceFeedID sql.NullString
 }
 
 type StopTime struct {
@@ -93,6 +280,13 @@ type StopTime struct {
 	DropOffType       sql.NullInt64
 	ShapeDistTraveled sql.NullFloat64
 	Timepoint         sql.NullInt64
+	SourceFeedID      sql.NullString
+}
+
+type StopsFt struct {
+	ID   string
+	Code string
+	Name string
 }
 
 type StopsRtreeNode struct {
@@ -110,6 +304,40 @@ type StopsRtreeRowid struct {
 	Nodeno interface{}
 }
 
+type Timeframe struct {
+	ID               int64
+	TimeframeGroupID string
+	StartTime        int64
+	EndTime          int64
+	ServiceID        string
+	SourceFeedID     sql.NullString
+}
+
+type Transfer struct {
+	ID              int64
+	FromStopID      sql.NullString
+	ToStopID        sql.NullString
+	FromRouteID     sql.NullString
+	ToRouteID       sql.NullString
+	FromTripID      sql.NullString
+	ToTripID        sql.NullString
+	TransferType    int64
+	MinTransferTime sql.NullInt64
+	SourceFeedID    sql.NullString
+}
+
+type Translation struct {
+	ID           int64
+	TableName    string
+	FieldName    string
+	Language     string
+	Translation  string
+	RecordID     sql.NullString
+	RecordSubID  sql.NullString
+	FieldValue   sql.NullString
+	SourceFeedID sql.NullString
+}
+
 type Trip struct {
 	ID                   string
 	RouteID              string
@@ -121,4 +349,15 @@ type Trip struct {
 	ShapeID              sql.NullString
 	WheelchairAccessible sql.NullInt64
 	BikesAllowed         sql.NullInt64
+	SourceFeedID         sql.NullString
+}
+
+type ValidationFinding struct {
+	ID           int64
+	Severity     string
+	Code         string
+	FileName     sql.NullString
+	EntityID     sql.NullString
+	Message      string
+	SourceFeedID sql.NullString
 }
@@ -0,0 +1,183 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportMultipleFeeds(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	rabaPath := getTestFixturePath(t, "raba.zip")
+	shuttlePath := models.BuildShuttleFeed(t)
+	require.NoError(t, client.ImportFeedFromFile(ctx, "raba", rabaPath))
+	require.NoError(t, client.ImportFeedFromFile(ctx, "shuttle", shuttlePath))
+
+	agencyIDs := func() []string {
+		agencies, err := client.Queries.ListAgencies(ctx)
+		require.NoError(t, err)
+		var ids []string
+		for _, agency := range agencies {
+			ids = append(ids, agency.ID)
+		}
+		return ids
+	}
+	assert.ElementsMatch(t, []string{"25", "SHUTTLE"}, agencyIDs())
+
+	rabaStop, err := client.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+	require.NoError(t, err)
+	assert.Equal(t, "raba", rabaStop.SourceFeedID.String)
+	shuttleStop, err := client.Queries.GetStop(ctx, ScopedID("shuttle", "SH_A"))
+	require.NoError(t, err)
+	assert.Equal(t, "shuttle", shuttleStop.SourceFeedID.String)
+
+	imports, err := client.Queries.ListImportMetadata(ctx)
+	require.NoError(t, err)
+	require.Len(t, imports, 2)
+	assert.Equal(t, "raba", imports[0].FeedID)
+	assert.Equal(t, rabaPath, imports[0].FileSource)
+	assert.Equal(t, "shuttle", imports[1].FeedID)
+	assert.NotEqual(t, imports[0].FileHash, imports[1].FileHash)
+
+	t.Run("reimporting one feed keeps the others", func(t *testing.T) {
+		changed := models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+			"stops.txt": "stop_id,stop_code,stop_name,stop_lat,stop_lon\n" +
+				"SH_A,A,Shuttle Stop A,40.5870,-122.3920\n" +
+				"SH_B,B,Shuttle Stop B (moved),40.5885,-122.3935\n",
+		})
+		require.NoError(t, client.ImportFeedFromFile(ctx, "shuttle", changed))
+
+		moved, err := client.Queries.GetStop(ctx, ScopedID("shuttle", "SH_B"))
+		require.NoError(t, err)
+		assert.Equal(t, "Shuttle Stop B (moved)", moved.Name.String)
+
+		_, err = client.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+		assert.NoError(t, err, "the other feed's stops are kept")
+		trip, err := client.Queries.GetTrip(ctx, ScopedID("raba", "84f4520e-88b6-4ee6-8975-856799bc1359"))
+		require.NoError(t, err)
+		assert.Equal(t, "raba", trip.SourceFeedID.String)
+		assert.ElementsMatch(t, []string{"25", "SHUTTLE"}, agencyIDs())
+
+		exceptions, err := client.Queries.GetCalendarDateExceptionsForServiceID(ctx, ScopedID("shuttle", "SH_WEEKDAY"))
+		require.NoError(t, err)
+		assert.Len(t, exceptions, 1, "calendar dates are replaced rather than duplicated")
+	})
+
+	t.Run("feeds no longer configured are removed", func(t *testing.T) {
+		require.NoError(t, client.RemoveFeedsExcept(ctx, []string{"shuttle"}))
+
+		assert.Equal(t, []string{"SHUTTLE"}, agencyIDs())
+		_, err := client.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = client.Queries.GetImportMetadata(ctx, "raba")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		stops, err := client.SearchStops(ctx, "Northpoint", 10)
+		require.NoError(t, err)
+		assert.Empty(t, stops, "the search index is rebuilt")
+	})
+	t.Run("rows left unassigned by an interrupted import are removed", func(t *testing.T) {
+		_, err := client.DB.ExecContext(ctx,
+			"INSERT INTO agencies (id, name, url, timezone) VALUES ('LEFTOVER', 'Leftover', 'http://example.com', 'UTC')")
+		require.NoError(t, err)
+
+		require.NoError(t, client.RemoveFeedsExcept(ctx, []string{"shuttle"}))
+		assert.Equal(t, []string{"SHUTTLE"}, agencyIDs())
+	})
+}
+
+func TestImportFeedsSharingIDs(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	require.NoError(t, client.ImportFeedFromFile(ctx, "raba", getTestFixturePath(t, "raba.zip")))
+	before, err := client.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+	require.NoError(t, err)
+
+	sharing := models.BuildFeedWithFiles(t, models.BuildShuttleFeed(t), map[string]string{
+		"stops.txt": "stop_id,stop_code,stop_name,stop_lat,stop_lon\n" +
+			"SH_A,A,Shuttle Stop A,40.5870,-122.3920\n" +
+			"1001,1001,Shuttle Stop 1001,40.5890,-122.3940\n",
+		"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+			"LOOP_1,08:00:00,08:00:00,SH_A,1\n" +
+			"LOOP_1,08:10:00,08:10:00,1001,2\n",
+	})
+	require.NoError(t, client.ImportFeedFromFile(ctx, "shuttle", sharing))
+
+	after, err := client.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+	require.NoError(t, err)
+	assert.Equal(t, "raba", after.SourceFeedID.String, "the stop stays with its feed")
+	assert.Equal(t, before.Name, after.Name)
+	shared, err := client.Queries.GetStop(ctx, ScopedID("shuttle", "1001"))
+	require.NoError(t, err)
+	assert.Equal(t, "Shuttle Stop 1001", shared.Name.String)
+	assert.Equal(t, "1001", UnscopedID(shared.ID))
+
+	stopTimes, err := client.Queries.GetStopTimesForTrip(ctx, ScopedID("shuttle", "LOOP_1"))
+	require.NoError(t, err)
+	require.Len(t, stopTimes, 2)
+	assert.Equal(t, shared.ID, stopTimes[1].StopID, "references stay within the feed")
+
+	metadata, err := client.Queries.GetImportMetadata(ctx, "shuttle")
+	require.NoError(t, err)
+	assert.Equal(t, "shuttle", metadata.IDNamespace)
+
+	t.Run("a feed reusing another's agency ID is refused", func(t *testing.T) {
+		err := client.ImportFeedFromFile(ctx, "raba-copy", getTestFixturePath(t, "raba.zip"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"25"`)
+		assert.Contains(t, err.Error(), "raba")
+
+		_, err = client.Queries.GetStop(ctx, ScopedID("raba-copy", "1001"))
+		assert.ErrorIs(t, err, sql.ErrNoRows, "nothing of the refused feed is imported")
+		_, err = client.Queries.GetImportMetadata(ctx, "raba-copy")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("the default feed's IDs are kept as they are", func(t *testing.T) {
+		assert.Equal(t, "1001", ScopedID(FeedNamespace(DefaultFeedID), "1001"))
+	})
+}
+
+func TestMigrationFromSingleFeedImportMetadata(t *testing.T) {
+	dbPath := t.TempDir() + "/legacy.db"
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE import_metadata (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		file_hash TEXT NOT NULL,
+		import_time INTEGER NOT NULL,
+		file_source TEXT NOT NULL
+	)`)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO import_metadata VALUES (1, 'abc', 1, 'old.zip')")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	client, err := NewClient(Config{DBPath: dbPath, Env: appconf.Development})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	imports, err := client.Queries.ListImportMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, imports, "the single-feed import state is dropped so that feeds are reimported")
+
+	feed, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "raba.zip"))
+	metadata, err := client.Queries.GetImportMetadata(context.Background(), DefaultFeedID)
+	require.NoError(t, err)
+	assert.Equal(t, "raba.zip", metadata.FileSource)
+}
@@ -63,10 +63,12 @@ OR REPLACE INTO stops (
     location_type,
     timezone,
     wheelchair_boarding,
-    platform_code
+    platform_code,
+    parent_station,
+    level_id
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
 -- name: CreateCalendar :one
 INSERT
@@ -87,9 +89,9 @@ VALUES
 
 -- name: CreateShape :one
 INSERT
-OR REPLACE INTO shapes (shape_id, lat, lon, shape_pt_sequence)
+OR REPLACE INTO shapes (shape_id, lat, lon, shape_pt_sequence, shape_dist_traveled)
 VALUES
-    (?, ?, ?, ?) RETURNING *;
+    (?, ?, ?, ?, ?) RETURNING *;
 
 -- name: CreateStopTime :one
 INSERT
@@ -107,6 +109,197 @@ OR REPLACE INTO stop_times (
 VALUES
     (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
 
+-- name: CreateFrequency :one
+INSERT
+OR REPLACE INTO frequencies (
+    trip_id,
+    start_time,
+    end_time,
+    headway_secs,
+    exact_times
+)
+VALUES
+    (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateTransfer :one
+INSERT INTO
+    transfers (
+        from_stop_id,
+        to_stop_id,
+        from_route_id,
+        to_route_id,
+        from_trip_id,
+        to_trip_id,
+        transfer_type,
+        min_transfer_time
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFareAttribute :one
+INSERT
+OR REPLACE INTO fare_attributes (
+    fare_id,
+    price,
+    currency_type,
+    payment_method,
+    transfers,
+    agency_id,
+    transfer_duration
+)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFareRule :one
+INSERT INTO
+    fare_rules (
+        fare_id,
+        route_id,
+        origin_id,
+        destination_id,
+        contains_id
+    )
+VALUES
+    (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFareMedia :one
+INSERT
+OR REPLACE INTO fare_media (fare_media_id, fare_media_name, fare_media_type)
+VALUES
+    (?, ?, ?) RETURNING *;
+
+-- name: CreateFareProduct :one
+INSERT
+OR REPLACE INTO fare_products (
+    fare_product_id,
+    fare_product_name,
+    fare_media_id,
+    amount,
+    currency
+)
+VALUES
+    (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateArea :one
+INSERT
+OR REPLACE INTO areas (area_id, area_name)
+VALUES
+    (?, ?) RETURNING *;
+
+-- name: CreateStopArea :one
+INSERT
+OR REPLACE INTO stop_areas (area_id, stop_id)
+VALUES
+    (?, ?) RETURNING *;
+
+-- name: CreateNetwork :one
+INSERT
+OR REPLACE INTO networks (network_id, network_name)
+VALUES
+    (?, ?) RETURNING *;
+
+-- name: CreateRouteNetwork :one
+INSERT
+OR REPLACE INTO route_networks (route_id, network_id)
+VALUES
+    (?, ?) RETURNING *;
+
+-- name: CreateTimeframe :one
+INSERT INTO
+    timeframes (
+        timeframe_group_id,
+        start_time,
+        end_time,
+        service_id
+    )
+VALUES
+    (?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFareLegRule :one
+INSERT INTO
+    fare_leg_rules (
+        leg_group_id,
+        network_id,
+        from_area_id,
+        to_area_id,
+        from_timeframe_group_id,
+        to_timeframe_group_id,
+        fare_product_id,
+        rule_priority
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFareTransferRule :one
+INSERT INTO
+    fare_transfer_rules (
+        from_leg_group_id,
+        to_leg_group_id,
+        transfer_count,
+        duration_limit,
+        duration_limit_type,
+        fare_transfer_type,
+        fare_product_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateLevel :one
+INSERT
+OR REPLACE INTO levels (level_id, level_index, level_name)
+VALUES
+    (?, ?, ?) RETURNING *;
+
+-- name: CreatePathway :one
+INSERT
+OR REPLACE INTO pathways (
+    pathway_id,
+    from_stop_id,
+    to_stop_id,
+    pathway_mode,
+    is_bidirectional,
+    length,
+    traversal_time,
+    stair_count,
+    max_slope,
+    min_width,
+    signposted_as,
+    reversed_signposted_as
+)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateTranslation :one
+INSERT INTO
+    translations (
+        table_name,
+        field_name,
+        language,
+        translation,
+        record_id,
+        record_sub_id,
+        field_value
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: CreateFeedInfo :one
+INSERT INTO
+    feed_info (
+        feed_id,
+        feed_publisher_name,
+        feed_publisher_url,
+        feed_lang,
+        default_lang,
+        feed_start_date,
+        feed_end_date,
+        feed_version,
+        feed_contact_email,
+        feed_contact_url
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
 -- name: CreateTrip :one
 INSERT
 OR REPLACE INTO trips (
@@ -143,7 +336,8 @@ SELECT
     color,
     text_color,
     continuous_pickup,
-    continuous_drop_off
+    continuous_drop_off,
+    source_feed_id
 FROM
     routes
 ORDER BY
@@ -160,8 +354,9 @@ WHERE
     a.id = ?;
 
 -- name: GetRouteIDsForStop :many
+-- Route IDs are combined as written in their feed, without the namespace of FeedNamespace
 SELECT DISTINCT
-    (routes.agency_id || '_' || routes.id) AS route_id
+    (routes.agency_id || '_' || substr(routes.id, instr(routes.id, char(31)) + 1)) AS route_id
 FROM
     stop_times
     JOIN trips ON stop_times.trip_id = trips.id
@@ -178,7 +373,8 @@ SELECT DISTINCT
     a.lang,
     a.phone,
     a.fare_url,
-    a.email
+    a.email,
+    a.source_feed_id
 FROM
     agencies a
     JOIN routes r ON a.id = r.agency_id
@@ -307,6 +503,12 @@ WHERE c.start_date <= @target_date
     (fd.weekday = '5' AND c.friday = 1) OR
     (fd.weekday = '6' AND c.saturday = 1)
     )
+  AND c.id NOT IN (
+    SELECT service_id
+    FROM calendar_dates
+    WHERE date = @target_date
+      AND exception_type = 2
+    )
 UNION
 SELECT DISTINCT service_id
 FROM calendar_dates
@@ -332,19 +534,26 @@ SELECT
     st.arrival_time,
     st.departure_time,
     st.stop_headsign,
+    st.stop_sequence,
     t.service_id,
     t.route_id,
     t.trip_headsign,
     r.id as route_id,
-    r.agency_id
+    r.agency_id,
+    f.start_time AS frequency_start_time,
+    f.end_time AS frequency_end_time,
+    f.headway_secs AS frequency_headway_secs,
+    f.exact_times AS frequency_exact_times,
+    CAST(COALESCE((SELECT MIN(st0.departure_time) FROM stop_times st0 WHERE f.trip_id IS NOT NULL AND st0.trip_id = st.trip_id), 0) AS INTEGER) AS trip_start_time
 FROM
     stop_times st
     JOIN trips t ON st.trip_id = t.id
     JOIN routes r ON t.route_id = r.id
+    LEFT JOIN frequencies f ON f.trip_id = st.trip_id
 WHERE
     st.stop_id = ?
 ORDER BY
-    r.id, st.arrival_time;
+    r.id, st.arrival_time, f.start_time;
 
 -- name: GetImportMetadata :one
 SELECT
@@ -352,22 +561,98 @@ SELECT
 FROM
     import_metadata
 WHERE
-    id = 1;
+    feed_id = ?;
+
+-- name: ListImportMetadata :many
+SELECT
+    *
+FROM
+    import_metadata
+ORDER BY
+    feed_id;
 
 -- name: UpsertImportMetadata :one
 INSERT
 OR REPLACE INTO import_metadata (
-    id,
+    feed_id,
     file_hash,
     import_time,
-    file_source
+    file_source,
+    id_namespace
 )
 VALUES
-    (1, ?, ?, ?) RETURNING *;
+    (?, ?, ?, ?, ?) RETURNING *;
+
+-- name: UpdateImportMetadataValidators :exec
+UPDATE import_metadata
+SET
+    etag = ?,
+    last_modified = ?
+WHERE
+    feed_id = ?;
+
+-- name: DeleteImportMetadata :exec
+DELETE FROM import_metadata
+WHERE
+    feed_id = ?;
 
 -- name: ClearStopTimes :exec
 DELETE FROM stop_times;
 
+-- name: ClearFrequencies :exec
+DELETE FROM frequencies;
+
+-- name: ClearTransfers :exec
+DELETE FROM transfers;
+
+-- name: ClearFareRules :exec
+DELETE FROM fare_rules;
+
+-- name: ClearFareAttributes :exec
+DELETE FROM fare_attributes;
+
+-- name: ClearFareTransferRules :exec
+DELETE FROM fare_transfer_rules;
+
+-- name: ClearFareLegRules :exec
+DELETE FROM fare_leg_rules;
+
+-- name: ClearTimeframes :exec
+DELETE FROM timeframes;
+
+-- name: ClearRouteNetworks :exec
+DELETE FROM route_networks;
+
+-- name: ClearNetworks :exec
+DELETE FROM networks;
+
+-- name: ClearStopAreas :exec
+DELETE FROM stop_areas;
+
+-- name: ClearAreas :exec
+DELETE FROM areas;
+
+-- name: ClearFareProducts :exec
+DELETE FROM fare_products;
+
+-- name: ClearFareMedia :exec
+DELETE FROM fare_media;
+
+-- name: ClearPathways :exec
+DELETE FROM pathways;
+
+-- name: ClearLevels :exec
+DELETE FROM levels;
+
+-- name: ClearTranslations :exec
+DELETE FROM translations;
+
+-- name: ClearValidationFindings :exec
+DELETE FROM validation_findings;
+
+-- name: ClearFeedInfo :exec
+DELETE FROM feed_info;
+
 -- name: ClearShapes :exec
 DELETE FROM shapes;
 
@@ -400,8 +685,9 @@ WHERE
     stop_times.stop_id IN (sqlc.slice('stop_ids'));
 
 -- name: GetRouteIDsForStops :many
+-- Route IDs are combined as written in their feed, without the namespace of FeedNamespace
 SELECT DISTINCT
-    routes.agency_id || '_' || routes.id AS route_id,
+    routes.agency_id || '_' || substr(routes.id, instr(routes.id, char(31)) + 1) AS route_id,
     stop_times.stop_id
 FROM
     stop_times
@@ -439,6 +725,181 @@ WHERE
 ORDER BY
     stop_sequence;
 
+-- name: GetFrequenciesForTrip :many
+SELECT
+    *
+FROM
+    frequencies
+WHERE
+    trip_id = ?
+ORDER BY
+    start_time;
+
+-- name: GetFrequenciesForTrips :many
+SELECT
+    *
+FROM
+    frequencies
+WHERE
+    trip_id IN (sqlc.slice('trip_ids'))
+ORDER BY
+    trip_id, start_time;
+
+-- name: GetTransfersForStop :many
+SELECT
+    *
+FROM
+    transfers
+WHERE
+    from_stop_id = sqlc.arg('stop_id')
+    OR to_stop_id = sqlc.arg('stop_id')
+ORDER BY
+    from_stop_id, to_stop_id, id;
+
+-- name: GetTransfersFromStops :many
+SELECT
+    *
+FROM
+    transfers
+WHERE
+    from_stop_id IN (sqlc.slice('stop_ids'))
+ORDER BY
+    from_stop_id, to_stop_id, id;
+
+-- name: ListFareAttributes :many
+SELECT
+    *
+FROM
+    fare_attributes
+ORDER BY
+    price, fare_id;
+
+-- name: ListFareRules :many
+SELECT
+    *
+FROM
+    fare_rules
+ORDER BY
+    fare_id, id;
+
+-- name: GetStopZonesForTrip :many
+SELECT
+    st.stop_sequence,
+    st.stop_id,
+    s.zone_id
+FROM
+    stop_times st
+    JOIN stops s ON st.stop_id = s.id
+WHERE
+    st.trip_id = ?
+ORDER BY
+    st.stop_sequence;
+
+-- name: ListFareProducts :many
+SELECT
+    *
+FROM
+    fare_products
+ORDER BY
+    fare_product_id, amount;
+
+-- name: ListFareLegRules :many
+SELECT
+    *
+FROM
+    fare_leg_rules
+ORDER BY
+    rule_priority DESC, id;
+
+-- name: ListFareTransferRules :many
+SELECT
+    *
+FROM
+    fare_transfer_rules
+ORDER BY
+    id;
+
+-- name: ListTimeframes :many
+SELECT
+    *
+FROM
+    timeframes
+ORDER BY
+    timeframe_group_id, start_time;
+
+-- name: GetAreasForStops :many
+SELECT
+    *
+FROM
+    stop_areas
+WHERE
+    stop_id IN (sqlc.slice('stop_ids'));
+
+-- name: GetNetworksForRoutes :many
+SELECT
+    *
+FROM
+    route_networks
+WHERE
+    route_id IN (sqlc.slice('route_ids'));
+
+-- name: GetChildStops :many
+SELECT
+    *
+FROM
+    stops
+WHERE
+    parent_station IN (sqlc.slice('parent_ids'))
+ORDER BY
+    id;
+
+-- name: GetLevelsByIDs :many
+SELECT
+    *
+FROM
+    levels
+WHERE
+    level_id IN (sqlc.slice('level_ids'))
+ORDER BY
+    level_index,
+    level_id;
+
+-- name: GetPathwaysForStops :many
+SELECT
+    *
+FROM
+    pathways
+WHERE
+    from_stop_id IN (sqlc.slice('from_stop_ids'))
+    OR to_stop_id IN (sqlc.slice('to_stop_ids'))
+ORDER BY
+    pathway_id;
+
+-- name: ListTranslations :many
+SELECT
+    *
+FROM
+    translations
+ORDER BY
+    id;
+
+-- name: GetFeedInfo :one
+SELECT
+    *
+FROM
+    feed_info
+ORDER BY
+    id
+LIMIT
+    1;
+
+-- name: GetCalendarDateRange :one
+SELECT
+    CAST(COALESCE(MIN(start_date), '') AS TEXT) AS start_date,
+    CAST(COALESCE(MAX(end_date), '') AS TEXT) AS end_date
+FROM
+    calendar;
+
 -- name: GetTripsByBlockID :many
 SELECT
     id,
@@ -454,6 +915,25 @@ FROM
 WHERE
     block_id = ?;
 
+-- name: GetStopIDsForTrips :many
+SELECT DISTINCT
+    trip_id,
+    stop_id
+FROM
+    stop_times
+WHERE
+    trip_id IN (sqlc.slice('trip_ids'));
+
+-- name: GetBlockIDsForTrips :many
+SELECT
+    id,
+    block_id
+FROM
+    trips
+WHERE
+    id IN (sqlc.slice('trip_ids'))
+    AND block_id IS NOT NULL;
+
 -- name: GetCalendarByServiceID :one
 SELECT
     *
@@ -470,6 +950,23 @@ FROM
 WHERE
     service_id = ?;
 
+-- name: ListCalendars :many
+SELECT
+    *
+FROM
+    calendar
+ORDER BY
+    id;
+
+-- name: ListCalendarDates :many
+SELECT
+    *
+FROM
+    calendar_dates
+ORDER BY
+    service_id,
+    date;
+
 -- name: GetStopsForRoute :many
 SELECT DISTINCT
     stops.*
@@ -487,7 +984,9 @@ SELECT
     s.shape_id,
     s.lat,
     s.lon,
-    s.shape_pt_sequence
+    s.shape_pt_sequence,
+    s.source_feed_id,
+    s.shape_dist_traveled
 FROM
     shapes s
     JOIN trips t ON t.shape_id = s.shape_id
@@ -540,6 +1039,16 @@ WHERE
 ORDER BY
     id;
 
+-- name: GetAgencyIDsForTrips :many
+SELECT
+    t.id,
+    r.agency_id
+FROM
+    trips t
+    JOIN routes r ON t.route_id = r.id
+WHERE
+    t.id IN (sqlc.slice('trip_ids'));
+
 -- name: GetBlockDetails :many
 SELECT
     t.service_id,
@@ -588,19 +1097,35 @@ SELECT
     t.service_id,
     t.route_id,
     t.trip_headsign,
+    t.trip_short_name,
+    t.direction_id,
     t.block_id,
-    r.id as route_id,
+    t.shape_id,
     r.agency_id,
-    r.short_name as route_short_name,
-    r.long_name as route_long_name
+    r.short_name AS route_short_name,
+    r.long_name AS route_long_name,
+    (SELECT COUNT(*) FROM stop_times st2 WHERE st2.trip_id = st.trip_id) AS total_stops_in_trip,
+    f.start_time AS frequency_start_time,
+    f.end_time AS frequency_end_time,
+    f.headway_secs AS frequency_headway_secs,
+    f.exact_times AS frequency_exact_times,
+    CAST(COALESCE((SELECT MIN(st0.departure_time) FROM stop_times st0 WHERE f.trip_id IS NOT NULL AND st0.trip_id = st.trip_id), 0) AS INTEGER) AS trip_start_time
 FROM
     stop_times st
         JOIN trips t ON st.trip_id = t.id
         JOIN routes r ON t.route_id = r.id
+        LEFT JOIN frequencies f ON f.trip_id = st.trip_id
 WHERE
-    st.stop_id = ?
+    st.stop_id = @stop_id
+    -- Frequency-based trips repeat their stop times, so their window is checked after expansion
+    AND (
+        f.trip_id IS NOT NULL
+        OR (st.departure_time >= @window_start AND st.arrival_time <= @window_end)
+    )
+    AND t.service_id IN (sqlc.slice('service_ids'))
 ORDER BY
-    st.arrival_time LIMIT 50;
+    st.arrival_time, st.trip_id, f.start_time;
+
 -- name: GetTripsByServiceID :many
 SELECT *
 FROM trips
@@ -631,3 +1156,164 @@ FROM stops s
 JOIN stop_times st ON s.id = st.stop_id
 JOIN trips t ON st.trip_id = t.id
 WHERE s.id = ?;
+
+-- name: RebuildStopsSearchIndex :exec
+INSERT INTO stops_fts (stops_fts) VALUES ('rebuild');
+
+-- name: RebuildRoutesSearchIndex :exec
+INSERT INTO routes_fts (routes_fts) VALUES ('rebuild');
+
+
+-- name: CreateArrivalAlarm :one
+INSERT INTO
+    arrival_alarms (
+        id,
+        agency_id,
+        stop_id,
+        trip_id,
+        service_date,
+        stop_sequence,
+        vehicle_id,
+        alarm_time_offset,
+        on_arrival,
+        callback_url,
+        created_at,
+        client_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetArrivalAlarm :one
+SELECT
+    *
+FROM
+    arrival_alarms
+WHERE
+    id = ?;
+
+-- name: ListArrivalAlarms :many
+SELECT
+    *
+FROM
+    arrival_alarms
+ORDER BY
+    created_at, id;
+
+-- name: CountArrivalAlarms :one
+SELECT
+    COUNT(*)
+FROM
+    arrival_alarms;
+
+-- name: CountArrivalAlarmsForClient :one
+SELECT
+    COUNT(*)
+FROM
+    arrival_alarms
+WHERE
+    client_id = ?;
+
+-- name: DeleteArrivalAlarm :execrows
+DELETE FROM arrival_alarms
+WHERE
+    id = ?;
+
+-- name: ClaimArrivalAlarm :one
+DELETE FROM arrival_alarms
+WHERE
+    id = ? RETURNING *;
+
+-- name: CreateProblemReport :one
+INSERT INTO
+    problem_reports (
+        report_type,
+        trip_id,
+        stop_id,
+        code,
+        service_date,
+        vehicle_id,
+        user_comment,
+        user_on_vehicle,
+        user_vehicle_number,
+        user_lat,
+        user_lon,
+        user_location_accuracy,
+        created_at
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING *;
+
+-- name: GetProblemReport :one
+SELECT
+    *
+FROM
+    problem_reports
+WHERE
+    id = ?;
+
+-- name: ListProblemReports :many
+SELECT
+    *
+FROM
+    problem_reports
+WHERE
+    (CAST(sqlc.narg('report_type') AS TEXT) IS NULL OR report_type = sqlc.narg('report_type'))
+    AND (CAST(sqlc.narg('status') AS TEXT) IS NULL OR status = sqlc.narg('status'))
+    AND (CAST(sqlc.narg('code') AS TEXT) IS NULL OR code = sqlc.narg('code'))
+    AND (CAST(sqlc.narg('trip_id') AS TEXT) IS NULL OR trip_id = sqlc.narg('trip_id'))
+    AND (CAST(sqlc.narg('stop_id') AS TEXT) IS NULL OR stop_id = sqlc.narg('stop_id'))
+    AND (CAST(sqlc.narg('created_after') AS INTEGER) IS NULL OR created_at >= sqlc.narg('created_after'))
+    AND (CAST(sqlc.narg('created_before') AS INTEGER) IS NULL OR created_at < sqlc.narg('created_before'))
+ORDER BY
+    created_at DESC,
+    id DESC
+LIMIT
+    @max_results
+OFFSET
+    @skip;
+
+-- name: ResolveProblemReport :execrows
+UPDATE problem_reports
+SET
+    status = 'resolved',
+    resolved_at = ?,
+    resolution_note = ?
+WHERE
+    id = ?;
+
+-- name: CreateValidationFinding :exec
+INSERT INTO
+    validation_findings (severity, code, file_name, entity_id, message)
+VALUES
+    (?, ?, ?, ?, ?);
+
+-- name: ListValidationFindings :many
+SELECT
+    *
+FROM
+    validation_findings
+WHERE
+    (CAST(sqlc.narg('feed_id') AS TEXT) IS NULL OR source_feed_id = sqlc.narg('feed_id'))
+    AND (CAST(sqlc.narg('severity') AS TEXT) IS NULL OR severity = sqlc.narg('severity'))
+ORDER BY
+    source_feed_id,
+    CASE severity
+        WHEN 'error' THEN 0
+        WHEN 'warning' THEN 1
+        ELSE 2
+    END,
+    id;
+
+-- name: CountValidationFindings :many
+SELECT
+    source_feed_id,
+    severity,
+    COUNT(*) AS finding_count
+FROM
+    validation_findings
+GROUP BY
+    source_feed_id,
+    severity
+ORDER BY
+    source_feed_id,
+    severity;
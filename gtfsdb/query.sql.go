@@ -11,6 +11,32 @@ import (
 	"strings"
 )
 
+const claimArrivalAlarm = `-- name: ClaimArrivalAlarm :one
+DELETE FROM arrival_alarms
+WHERE
+    id = ? RETURNING id, agency_id, stop_id, trip_id, service_date, stop_sequence, vehicle_id, alarm_time_offset, on_arrival, callback_url, created_at, client_id
+`
+
+func (q *Queries) ClaimArrivalAlarm(ctx context.Context, id string) (ArrivalAlarm, error) {
+	row := q.queryRow(ctx, q.claimArrivalAlarmStmt, claimArrivalAlarm, id)
+	var i ArrivalAlarm
+	err := row.Scan(
+		&i.ID,
+		&i.AgencyID,
+		&i.StopID,
+		&i.TripID,
+		&i.ServiceDate,
+		&i.StopSequence,
+		&i.VehicleID,
+		&i.AlarmTimeOffset,
+		&i.OnArrival,
+		&i.CallbackUrl,
+		&i.CreatedAt,
+		&i.ClientID,
+	)
+	return i, err
+}
+
 const clearAgencies = `-- name: ClearAgencies :exec
 DELETE FROM agencies
 `
@@ -20,6 +46,15 @@ func (q *Queries) ClearAgencies(ctx context.Context) error {
 	return err
 }
 
+const clearAreas = `-- name: ClearAreas :exec
+DELETE FROM areas
+`
+
+func (q *Queries) ClearAreas(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearAreasStmt, clearAreas)
+	return err
+}
+
 const clearCalendar = `-- name: ClearCalendar :exec
 DELETE FROM calendar
 `
@@ -29,6 +64,114 @@ func (q *Queries) ClearCalendar(ctx context.Context) error {
 	return err
 }
 
+const clearFareAttributes = `-- name: ClearFareAttributes :exec
+DELETE FROM fare_attributes
+`
+
+func (q *Queries) ClearFareAttributes(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareAttributesStmt, clearFareAttributes)
+	return err
+}
+
+const clearFareLegRules = `-- name: ClearFareLegRules :exec
+DELETE FROM fare_leg_rules
+`
+
+func (q *Queries) ClearFareLegRules(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareLegRulesStmt, clearFareLegRules)
+	return err
+}
+
+const clearFareMedia = `-- name: ClearFareMedia :exec
+DELETE FROM fare_media
+`
+
+func (q *Queries) ClearFareMedia(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareMediaStmt, clearFareMedia)
+	return err
+}
+
+const clearFareProducts = `-- name: ClearFareProducts :exec
+DELETE FROM fare_products
+`
+
+func (q *Queries) ClearFareProducts(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareProductsStmt, clearFareProducts)
+	return err
+}
+
+const clearFareRules = `-- name: ClearFareRules :exec
+DELETE FROM fare_rules
+`
+
+func (q *Queries) ClearFareRules(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareRulesStmt, clearFareRules)
+	return err
+}
+
+const clearFareTransferRules = `-- name: ClearFareTransferRules :exec
+DELETE FROM fare_transfer_rules
+`
+
+func (q *Queries) ClearFareTransferRules(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFareTransferRulesStmt, clearFareTransferRules)
+	return err
+}
+
+const clearFeedInfo = `-- name: ClearFeedInfo :exec
+DELETE FROM feed_info
+`
+
+func (q *Queries) ClearFeedInfo(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFeedInfoStmt, clearFeedInfo)
+	return err
+}
+
+const clearFrequencies = `-- name: ClearFrequencies :exec
+DELETE FROM frequencies
+`
+
+func (q *Queries) ClearFrequencies(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearFrequenciesStmt, clearFrequencies)
+	return err
+}
+
+const clearLevels = `-- name: ClearLevels :exec
+DELETE FROM levels
+`
+
+func (q *Queries) ClearLevels(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearLevelsStmt, clearLevels)
+	return err
+}
+
+const clearNetworks = `-- name: ClearNetworks :exec
+DELETE FROM networks
+`
+
+func (q *Queries) ClearNetworks(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearNetworksStmt, clearNetworks)
+	return err
+}
+
+const clearPathways = `-- name: ClearPathways :exec
+DELETE FROM pathways
+`
+
+func (q *Queries) ClearPathways(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearPathwaysStmt, clearPathways)
+	return err
+}
+
+const clearRouteNetworks = `-- name: ClearRouteNetworks :exec
+DELETE FROM route_networks
+`
+
+func (q *Queries) ClearRouteNetworks(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearRouteNetworksStmt, clearRouteNetworks)
+	return err
+}
+
 const clearRoutes = `-- name: ClearRoutes :exec
 DELETE FROM routes
 `
@@ -47,6 +190,15 @@ func (q *Queries) ClearShapes(ctx context.Context) error {
 	return err
 }
 
+const clearStopAreas = `-- name: ClearStopAreas :exec
+DELETE FROM stop_areas
+`
+
+func (q *Queries) ClearStopAreas(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearStopAreasStmt, clearStopAreas)
+	return err
+}
+
 const clearStopTimes = `-- name: ClearStopTimes :exec
 DELETE FROM stop_times
 `
@@ -65,6 +217,33 @@ func (q *Queries) ClearStops(ctx context.Context) error {
 	return err
 }
 
+const clearTimeframes = `-- name: ClearTimeframes :exec
+DELETE FROM timeframes
+`
+
+func (q *Queries) ClearTimeframes(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearTimeframesStmt, clearTimeframes)
+	return err
+}
+
+const clearTransfers = `-- name: ClearTransfers :exec
+DELETE FROM transfers
+`
+
+func (q *Queries) ClearTransfers(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearTransfersStmt, clearTransfers)
+	return err
+}
+
+const clearTranslations = `-- name: ClearTranslations :exec
+DELETE FROM translations
+`
+
+func (q *Queries) ClearTranslations(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearTranslationsStmt, clearTranslations)
+	return err
+}
+
 const clearTrips = `-- name: ClearTrips :exec
 DELETE FROM trips
 `
@@ -74,6 +253,89 @@ func (q *Queries) ClearTrips(ctx context.Context) error {
 	return err
 }
 
+const clearValidationFindings = `-- name: ClearValidationFindings :exec
+DELETE FROM validation_findings
+`
+
+func (q *Queries) ClearValidationFindings(ctx context.Context) error {
+	_, err := q.exec(ctx, q.clearValidationFindingsStmt, clearValidationFindings)
+	return err
+}
+
+const countArrivalAlarms = `-- name: CountArrivalAlarms :one
+SELECT
+    COUNT(*)
+FROM
+    arrival_alarms
+`
+
+func (q *Queries) CountArrivalAlarms(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countArrivalAlarmsStmt, countArrivalAlarms)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countArrivalAlarmsForClient = `-- name: CountArrivalAlarmsForClient :one
+SELECT
+    COUNT(*)
+FROM
+    arrival_alarms
+WHERE
+    client_id = ?
+`
+
+func (q *Queries) CountArrivalAlarmsForClient(ctx context.Context, clientID string) (int64, error) {
+	row := q.queryRow(ctx, q.countArrivalAlarmsForClientStmt, countArrivalAlarmsForClient, clientID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countValidationFindings = `-- name: CountValidationFindings :many
+SELECT
+    source_feed_id,
+    severity,
+    COUNT(*) AS finding_count
+FROM
+    validation_findings
+GROUP BY
+    source_feed_id,
+    severity
+ORDER BY
+    source_feed_id,
+    severity
+`
+
+type CountValidationFindingsRow struct {
+	SourceFeedID sql.NullString
+	Severity     string
+	FindingCount int64
+}
+
+func (q *Queries) CountValidationFindings(ctx context.Context) ([]CountValidationFindingsRow, error) {
+	rows, err := q.query(ctx, q.countValidationFindingsStmt, countValidationFindings)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountValidationFindingsRow
+	for rows.Next() {
+		var i CountValidationFindingsRow
+		if err := rows.Scan(&i.SourceFeedID, &i.Severity, &i.FindingCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createAgency = `-- name: CreateAgency :one
 INSERT
 OR REPLACE INTO agencies (
@@ -87,7 +349,7 @@ OR REPLACE INTO agencies (
     email
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, name, url, timezone, lang, phone, fare_url, email
+    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, name, url, timezone, lang, phone, fare_url, email, source_feed_id
 `
 
 type CreateAgencyParams struct {
@@ -122,6 +384,94 @@ func (q *Queries) CreateAgency(ctx context.Context, arg CreateAgencyParams) (Age
 		&i.Phone,
 		&i.FareUrl,
 		&i.Email,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createArea = `-- name: CreateArea :one
+INSERT
+OR REPLACE INTO areas (area_id, area_name)
+VALUES
+    (?, ?) RETURNING area_id, area_name, source_feed_id
+`
+
+type CreateAreaParams struct {
+	AreaID   string
+	AreaName sql.NullString
+}
+
+func (q *Queries) CreateArea(ctx context.Context, arg CreateAreaParams) (Area, error) {
+	row := q.queryRow(ctx, q.createAreaStmt, createArea, arg.AreaID, arg.AreaName)
+	var i Area
+	err := row.Scan(&i.AreaID, &i.AreaName, &i.SourceFeedID)
+	return i, err
+}
+
+const createArrivalAlarm = `-- name: CreateArrivalAlarm :one
+INSERT INTO
+    arrival_alarms (
+        id,
+        agency_id,
+        stop_id,
+        trip_id,
+        service_date,
+        stop_sequence,
+        vehicle_id,
+        alarm_time_offset,
+        on_arrival,
+        callback_url,
+        created_at,
+        client_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, agency_id, stop_id, trip_id, service_date, stop_sequence, vehicle_id, alarm_time_offset, on_arrival, callback_url, created_at, client_id
+`
+
+type CreateArrivalAlarmParams struct {
+	ID              string
+	AgencyID        string
+	StopID          string
+	TripID          string
+	ServiceDate     int64
+	StopSequence    int64
+	VehicleID       sql.NullString
+	AlarmTimeOffset int64
+	OnArrival       int64
+	CallbackUrl     string
+	CreatedAt       int64
+	ClientID        string
+}
+
+func (q *Queries) CreateArrivalAlarm(ctx context.Context, arg CreateArrivalAlarmParams) (ArrivalAlarm, error) {
+	row := q.queryRow(ctx, q.createArrivalAlarmStmt, createArrivalAlarm,
+		arg.ID,
+		arg.AgencyID,
+		arg.StopID,
+		arg.TripID,
+		arg.ServiceDate,
+		arg.StopSequence,
+		arg.VehicleID,
+		arg.AlarmTimeOffset,
+		arg.OnArrival,
+		arg.CallbackUrl,
+		arg.CreatedAt,
+		arg.ClientID,
+	)
+	var i ArrivalAlarm
+	err := row.Scan(
+		&i.ID,
+		&i.AgencyID,
+		&i.StopID,
+		&i.TripID,
+		&i.ServiceDate,
+		&i.StopSequence,
+		&i.VehicleID,
+		&i.AlarmTimeOffset,
+		&i.OnArrival,
+		&i.CallbackUrl,
+		&i.CreatedAt,
+		&i.ClientID,
 	)
 	return i, err
 }
@@ -141,7 +491,7 @@ OR REPLACE INTO calendar (
     end_date
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, monday, tuesday, wednesday, thursday, friday, saturday, sunday, start_date, end_date
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, monday, tuesday, wednesday, thursday, friday, saturday, sunday, start_date, end_date, source_feed_id
 `
 
 type CreateCalendarParams struct {
@@ -182,6 +532,7 @@ func (q *Queries) CreateCalendar(ctx context.Context, arg CreateCalendarParams)
 		&i.Sunday,
 		&i.StartDate,
 		&i.EndDate,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
@@ -190,7 +541,7 @@ const createCalendarDate = `-- name: CreateCalendarDate :one
 INSERT
 OR REPLACE INTO calendar_dates (service_id, date, exception_type)
 VALUES
-    (?, ?, ?) RETURNING service_id, date, exception_type
+    (?, ?, ?) RETURNING service_id, date, exception_type, source_feed_id
 `
 
 type CreateCalendarDateParams struct {
@@ -202,127 +553,711 @@ type CreateCalendarDateParams struct {
 func (q *Queries) CreateCalendarDate(ctx context.Context, arg CreateCalendarDateParams) (CalendarDate, error) {
 	row := q.queryRow(ctx, q.createCalendarDateStmt, createCalendarDate, arg.ServiceID, arg.Date, arg.ExceptionType)
 	var i CalendarDate
-	err := row.Scan(&i.ServiceID, &i.Date, &i.ExceptionType)
+	err := row.Scan(
+		&i.ServiceID,
+		&i.Date,
+		&i.ExceptionType,
+		&i.SourceFeedID,
+	)
 	return i, err
 }
 
-const createRoute = `-- name: CreateRoute :one
+const createFareAttribute = `-- name: CreateFareAttribute :one
 INSERT
-OR REPLACE INTO routes (
-    id,
+OR REPLACE INTO fare_attributes (
+    fare_id,
+    price,
+    currency_type,
+    payment_method,
+    transfers,
     agency_id,
-    short_name,
-    long_name,
-    desc,
-    type,
-    url,
-    color,
-    text_color,
-    continuous_pickup,
-    continuous_drop_off
+    transfer_duration
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off
+    (?, ?, ?, ?, ?, ?, ?) RETURNING fare_id, price, currency_type, payment_method, transfers, agency_id, transfer_duration, source_feed_id
 `
 
-type CreateRouteParams struct {
-	ID                string
-	AgencyID          string
-	ShortName         sql.NullString
-	LongName          sql.NullString
-	Desc              sql.NullString
-	Type              int64
-	Url               sql.NullString
-	Color             sql.NullString
-	TextColor         sql.NullString
-	ContinuousPickup  sql.NullInt64
-	ContinuousDropOff sql.NullInt64
+type CreateFareAttributeParams struct {
+	FareID           string
+	Price            float64
+	CurrencyType     string
+	PaymentMethod    int64
+	Transfers        sql.NullInt64
+	AgencyID         sql.NullString
+	TransferDuration sql.NullInt64
 }
 
-func (q *Queries) CreateRoute(ctx context.Context, arg CreateRouteParams) (Route, error) {
-	row := q.queryRow(ctx, q.createRouteStmt, createRoute,
-		arg.ID,
+func (q *Queries) CreateFareAttribute(ctx context.Context, arg CreateFareAttributeParams) (FareAttribute, error) {
+	row := q.queryRow(ctx, q.createFareAttributeStmt, createFareAttribute,
+		arg.FareID,
+		arg.Price,
+		arg.CurrencyType,
+		arg.PaymentMethod,
+		arg.Transfers,
 		arg.AgencyID,
-		arg.ShortName,
-		arg.LongName,
-		arg.Desc,
-		arg.Type,
-		arg.Url,
-		arg.Color,
-		arg.TextColor,
-		arg.ContinuousPickup,
-		arg.ContinuousDropOff,
+		arg.TransferDuration,
 	)
-	var i Route
+	var i FareAttribute
 	err := row.Scan(
-		&i.ID,
+		&i.FareID,
+		&i.Price,
+		&i.CurrencyType,
+		&i.PaymentMethod,
+		&i.Transfers,
 		&i.AgencyID,
-		&i.ShortName,
-		&i.LongName,
-		&i.Desc,
-		&i.Type,
-		&i.Url,
-		&i.Color,
-		&i.TextColor,
-		&i.ContinuousPickup,
-		&i.ContinuousDropOff,
+		&i.TransferDuration,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
-const createShape = `-- name: CreateShape :one
-INSERT
-OR REPLACE INTO shapes (shape_id, lat, lon, shape_pt_sequence)
+const createFareLegRule = `-- name: CreateFareLegRule :one
+INSERT INTO
+    fare_leg_rules (
+        leg_group_id,
+        network_id,
+        from_area_id,
+        to_area_id,
+        from_timeframe_group_id,
+        to_timeframe_group_id,
+        fare_product_id,
+        rule_priority
+    )
 VALUES
-    (?, ?, ?, ?) RETURNING id, shape_id, lat, lon, shape_pt_sequence
+    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, leg_group_id, network_id, from_area_id, to_area_id, from_timeframe_group_id, to_timeframe_group_id, fare_product_id, rule_priority, source_feed_id
 `
 
-type CreateShapeParams struct {
-	ShapeID         string
-	Lat             float64
-	Lon             float64
-	ShapePtSequence int64
+type CreateFareLegRuleParams struct {
+	LegGroupID           sql.NullString
+	NetworkID            sql.NullString
+	FromAreaID           sql.NullString
+	ToAreaID             sql.NullString
+	FromTimeframeGroupID sql.NullString
+	ToTimeframeGroupID   sql.NullString
+	FareProductID        string
+	RulePriority         int64
 }
 
-func (q *Queries) CreateShape(ctx context.Context, arg CreateShapeParams) (Shape, error) {
-	row := q.queryRow(ctx, q.createShapeStmt, createShape,
-		arg.ShapeID,
-		arg.Lat,
-		arg.Lon,
-		arg.ShapePtSequence,
+func (q *Queries) CreateFareLegRule(ctx context.Context, arg CreateFareLegRuleParams) (FareLegRule, error) {
+	row := q.queryRow(ctx, q.createFareLegRuleStmt, createFareLegRule,
+		arg.LegGroupID,
+		arg.NetworkID,
+		arg.FromAreaID,
+		arg.ToAreaID,
+		arg.FromTimeframeGroupID,
+		arg.ToTimeframeGroupID,
+		arg.FareProductID,
+		arg.RulePriority,
 	)
-	var i Shape
+	var i FareLegRule
 	err := row.Scan(
 		&i.ID,
-		&i.ShapeID,
-		&i.Lat,
-		&i.Lon,
-		&i.ShapePtSequence,
+		&i.LegGroupID,
+		&i.NetworkID,
+		&i.FromAreaID,
+		&i.ToAreaID,
+		&i.FromTimeframeGroupID,
+		&i.ToTimeframeGroupID,
+		&i.FareProductID,
+		&i.RulePriority,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
-const createStop = `-- name: CreateStop :one
+const createFareMedia = `-- name: CreateFareMedia :one
 INSERT
-OR REPLACE INTO stops (
-    id,
-    code,
-    name,
-    desc,
-    lat,
-    lon,
-    zone_id,
-    url,
-    location_type,
-    timezone,
-    wheelchair_boarding,
-    platform_code
-)
+OR REPLACE INTO fare_media (fare_media_id, fare_media_name, fare_media_type)
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code
+    (?, ?, ?) RETURNING fare_media_id, fare_media_name, fare_media_type, source_feed_id
 `
 
-type CreateStopParams struct {
+type CreateFareMediaParams struct {
+	FareMediaID   string
+	FareMediaName sql.NullString
+	FareMediaType int64
+}
+
+func (q *Queries) CreateFareMedia(ctx context.Context, arg CreateFareMediaParams) (FareMedium, error) {
+	row := q.queryRow(ctx, q.createFareMediaStmt, createFareMedia, arg.FareMediaID, arg.FareMediaName, arg.FareMediaType)
+	var i FareMedium
+	err := row.Scan(
+		&i.FareMediaID,
+		&i.FareMediaName,
+		&i.FareMediaType,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createFareProduct = `-- name: CreateFareProduct :one
+INSERT
+OR REPLACE INTO fare_products (
+    fare_product_id,
+    fare_product_name,
+    fare_media_id,
+    amount,
+    currency
+)
+VALUES
+    (?, ?, ?, ?, ?) RETURNING fare_product_id, fare_product_name, fare_media_id, amount, currency, source_feed_id
+`
+
+type CreateFareProductParams struct {
+	FareProductID   string
+	FareProductName sql.NullString
+	FareMediaID     string
+	Amount          float64
+	Currency        string
+}
+
+func (q *Queries) CreateFareProduct(ctx context.Context, arg CreateFareProductParams) (FareProduct, error) {
+	row := q.queryRow(ctx, q.createFareProductStmt, createFareProduct,
+		arg.FareProductID,
+		arg.FareProductName,
+		arg.FareMediaID,
+		arg.Amount,
+		arg.Currency,
+	)
+	var i FareProduct
+	err := row.Scan(
+		&i.FareProductID,
+		&i.FareProductName,
+		&i.FareMediaID,
+		&i.Amount,
+		&i.Currency,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createFareRule = `-- name: CreateFareRule :one
+INSERT INTO
+    fare_rules (
+        fare_id,
+        route_id,
+        origin_id,
+        destination_id,
+        contains_id
+    )
+VALUES
+    (?, ?, ?, ?, ?) RETURNING id, fare_id, route_id, origin_id, destination_id, contains_id, source_feed_id
+`
+
+type CreateFareRuleParams struct {
+	FareID        string
+	RouteID       sql.NullString
+	OriginID      sql.NullString
+	DestinationID sql.NullString
+	ContainsID    sql.NullString
+}
+
+func (q *Queries) CreateFareRule(ctx context.Context, arg CreateFareRuleParams) (FareRule, error) {
+	row := q.queryRow(ctx, q.createFareRuleStmt, createFareRule,
+		arg.FareID,
+		arg.RouteID,
+		arg.OriginID,
+		arg.DestinationID,
+		arg.ContainsID,
+	)
+	var i FareRule
+	err := row.Scan(
+		&i.ID,
+		&i.FareID,
+		&i.RouteID,
+		&i.OriginID,
+		&i.DestinationID,
+		&i.ContainsID,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createFareTransferRule = `-- name: CreateFareTransferRule :one
+INSERT INTO
+    fare_transfer_rules (
+        from_leg_group_id,
+        to_leg_group_id,
+        transfer_count,
+        duration_limit,
+        duration_limit_type,
+        fare_transfer_type,
+        fare_product_id
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?) RETURNING id, from_leg_group_id, to_leg_group_id, transfer_count, duration_limit, duration_limit_type, fare_transfer_type, fare_product_id, source_feed_id
+`
+
+type CreateFareTransferRuleParams struct {
+	FromLegGroupID    sql.NullString
+	ToLegGroupID      sql.NullString
+	TransferCount     sql.NullInt64
+	DurationLimit     sql.NullInt64
+	DurationLimitType sql.NullInt64
+	FareTransferType  int64
+	FareProductID     sql.NullString
+}
+
+func (q *Queries) CreateFareTransferRule(ctx context.Context, arg CreateFareTransferRuleParams) (FareTransferRule, error) {
+	row := q.queryRow(ctx, q.createFareTransferRuleStmt, createFareTransferRule,
+		arg.FromLegGroupID,
+		arg.ToLegGroupID,
+		arg.TransferCount,
+		arg.DurationLimit,
+		arg.DurationLimitType,
+		arg.FareTransferType,
+		arg.FareProductID,
+	)
+	var i FareTransferRule
+	err := row.Scan(
+		&i.ID,
+		&i.FromLegGroupID,
+		&i.ToLegGroupID,
+		&i.TransferCount,
+		&i.DurationLimit,
+		&i.DurationLimitType,
+		&i.FareTransferType,
+		&i.FareProductID,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createFeedInfo = `-- name: CreateFeedInfo :one
+INSERT INTO
+    feed_info (
+        feed_id,
+        feed_publisher_name,
+        feed_publisher_url,
+        feed_lang,
+        default_lang,
+        feed_start_date,
+        feed_end_date,
+        feed_version,
+        feed_contact_email,
+        feed_contact_url
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, feed_id, feed_publisher_name, feed_publisher_url, feed_lang, default_lang, feed_start_date, feed_end_date, feed_version, feed_contact_email, feed_contact_url, source_feed_id
+`
+
+type CreateFeedInfoParams struct {
+	FeedID            sql.NullString
+	FeedPublisherName string
+	FeedPublisherUrl  string
+	FeedLang          string
+	DefaultLang       sql.NullString
+	FeedStartDate     sql.NullString
+	FeedEndDate       sql.NullString
+	FeedVersion       sql.NullString
+	FeedContactEmail  sql.NullString
+	FeedContactUrl    sql.NullString
+}
+
+func (q *Queries) CreateFeedInfo(ctx context.Context, arg CreateFeedInfoParams) (FeedInfo, error) {
+	row := q.queryRow(ctx, q.createFeedInfoStmt, createFeedInfo,
+		arg.FeedID,
+		arg.FeedPublisherName,
+		arg.FeedPublisherUrl,
+		arg.FeedLang,
+		arg.DefaultLang,
+		arg.FeedStartDate,
+		arg.FeedEndDate,
+		arg.FeedVersion,
+		arg.FeedContactEmail,
+		arg.FeedContactUrl,
+	)
+	var i FeedInfo
+	err := row.Scan(
+		&i.ID,
+		&i.FeedID,
+		&i.FeedPublisherName,
+		&i.FeedPublisherUrl,
+		&i.FeedLang,
+		&i.DefaultLang,
+		&i.FeedStartDate,
+		&i.FeedEndDate,
+		&i.FeedVersion,
+		&i.FeedContactEmail,
+		&i.FeedContactUrl,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createFrequency = `-- name: CreateFrequency :one
+INSERT
+OR REPLACE INTO frequencies (
+    trip_id,
+    start_time,
+    end_time,
+    headway_secs,
+    exact_times
+)
+VALUES
+    (?, ?, ?, ?, ?) RETURNING trip_id, start_time, end_time, headway_secs, exact_times, source_feed_id
+`
+
+type CreateFrequencyParams struct {
+	TripID      string
+	StartTime   int64
+	EndTime     int64
+	HeadwaySecs int64
+	ExactTimes  int64
+}
+
+func (q *Queries) CreateFrequency(ctx context.Context, arg CreateFrequencyParams) (Frequency, error) {
+	row := q.queryRow(ctx, q.createFrequencyStmt, createFrequency,
+		arg.TripID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.HeadwaySecs,
+		arg.ExactTimes,
+	)
+	var i Frequency
+	err := row.Scan(
+		&i.TripID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.HeadwaySecs,
+		&i.ExactTimes,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createLevel = `-- name: CreateLevel :one
+INSERT
+OR REPLACE INTO levels (level_id, level_index, level_name)
+VALUES
+    (?, ?, ?) RETURNING level_id, level_index, level_name, source_feed_id
+`
+
+type CreateLevelParams struct {
+	LevelID    string
+	LevelIndex float64
+	LevelName  sql.NullString
+}
+
+func (q *Queries) CreateLevel(ctx context.Context, arg CreateLevelParams) (Level, error) {
+	row := q.queryRow(ctx, q.createLevelStmt, createLevel, arg.LevelID, arg.LevelIndex, arg.LevelName)
+	var i Level
+	err := row.Scan(
+		&i.LevelID,
+		&i.LevelIndex,
+		&i.LevelName,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createNetwork = `-- name: CreateNetwork :one
+INSERT
+OR REPLACE INTO networks (network_id, network_name)
+VALUES
+    (?, ?) RETURNING network_id, network_name, source_feed_id
+`
+
+type CreateNetworkParams struct {
+	NetworkID   string
+	NetworkName sql.NullString
+}
+
+func (q *Queries) CreateNetwork(ctx context.Context, arg CreateNetworkParams) (Network, error) {
+	row := q.queryRow(ctx, q.createNetworkStmt, createNetwork, arg.NetworkID, arg.NetworkName)
+	var i Network
+	err := row.Scan(&i.NetworkID, &i.NetworkName, &i.SourceFeedID)
+	return i, err
+}
+
+const createPathway = `-- name: CreatePathway :one
+INSERT
+OR REPLACE INTO pathways (
+    pathway_id,
+    from_stop_id,
+    to_stop_id,
+    pathway_mode,
+    is_bidirectional,
+    length,
+    traversal_time,
+    stair_count,
+    max_slope,
+    min_width,
+    signposted_as,
+    reversed_signposted_as
+)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING pathway_id, from_stop_id, to_stop_id, pathway_mode, is_bidirectional, length, traversal_time, stair_count, max_slope, min_width, signposted_as, reversed_signposted_as, source_feed_id
+`
+
+type CreatePathwayParams struct {
+	PathwayID            string
+	FromStopID           string
+	ToStopID             string
+	PathwayMode          int64
+	IsBidirectional      int64
+	Length               sql.NullFloat64
+	TraversalTime        sql.NullInt64
+	StairCount           sql.NullInt64
+	MaxSlope             sql.NullFloat64
+	MinWidth             sql.NullFloat64
+	SignpostedAs         sql.NullString
+	ReversedSignpostedAs sql.NullString
+}
+
+func (q *Queries) CreatePathway(ctx context.Context, arg CreatePathwayParams) (Pathway, error) {
+	row := q.queryRow(ctx, q.createPathwayStmt, createPathway,
+		arg.PathwayID,
+		arg.FromStopID,
+		arg.ToStopID,
+		arg.PathwayMode,
+		arg.IsBidirectional,
+		arg.Length,
+		arg.TraversalTime,
+		arg.StairCount,
+		arg.MaxSlope,
+		arg.MinWidth,
+		arg.SignpostedAs,
+		arg.ReversedSignpostedAs,
+	)
+	var i Pathway
+	err := row.Scan(
+		&i.PathwayID,
+		&i.FromStopID,
+		&i.ToStopID,
+		&i.PathwayMode,
+		&i.IsBidirectional,
+		&i.Length,
+		&i.TraversalTime,
+		&i.StairCount,
+		&i.MaxSlope,
+		&i.MinWidth,
+		&i.SignpostedAs,
+		&i.ReversedSignpostedAs,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createProblemReport = `-- name: CreateProblemReport :one
+INSERT INTO
+    problem_reports (
+        report_type,
+        trip_id,
+        stop_id,
+        code,
+        service_date,
+        vehicle_id,
+        user_comment,
+        user_on_vehicle,
+        user_vehicle_number,
+        user_lat,
+        user_lon,
+        user_location_accuracy,
+        created_at
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, report_type, trip_id, stop_id, code, service_date, vehicle_id, user_comment, user_on_vehicle, user_vehicle_number, user_lat, user_lon, user_location_accuracy, status, created_at, resolved_at, resolution_note
+`
+
+type CreateProblemReportParams struct {
+	ReportType           string
+	TripID               sql.NullString
+	StopID               sql.NullString
+	Code                 string
+	ServiceDate          sql.NullInt64
+	VehicleID            sql.NullString
+	UserComment          sql.NullString
+	UserOnVehicle        sql.NullInt64
+	UserVehicleNumber    sql.NullString
+	UserLat              sql.NullFloat64
+	UserLon              sql.NullFloat64
+	UserLocationAccuracy sql.NullFloat64
+	CreatedAt            int64
+}
+
+func (q *Queries) CreateProblemReport(ctx context.Context, arg CreateProblemReportParams) (ProblemReport, error) {
+	row := q.queryRow(ctx, q.createProblemReportStmt, createProblemReport,
+		arg.ReportType,
+		arg.TripID,
+		arg.StopID,
+		arg.Code,
+		arg.ServiceDate,
+		arg.VehicleID,
+		arg.UserComment,
+		arg.UserOnVehicle,
+		arg.UserVehicleNumber,
+		arg.UserLat,
+		arg.UserLon,
+		arg.UserLocationAccuracy,
+		arg.CreatedAt,
+	)
+	var i ProblemReport
+	err := row.Scan(
+		&i.ID,
+		&i.ReportType,
+		&i.TripID,
+		&i.StopID,
+		&i.Code,
+		&i.ServiceDate,
+		&i.VehicleID,
+		&i.UserComment,
+		&i.UserOnVehicle,
+		&i.UserVehicleNumber,
+		&i.UserLat,
+		&i.UserLon,
+		&i.UserLocationAccuracy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+		&i.ResolutionNote,
+	)
+	return i, err
+}
+
+const createRoute = `-- name: CreateRoute :one
+INSERT
+OR REPLACE INTO routes (
+    id,
+    agency_id,
+    short_name,
+    long_name,
+    desc,
+    type,
+    url,
+    color,
+    text_color,
+    continuous_pickup,
+    continuous_drop_off
+)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off, source_feed_id
+`
+
+type CreateRouteParams struct {
+	ID                string
+	AgencyID          string
+	ShortName         sql.NullString
+	LongName          sql.NullString
+	Desc              sql.NullString
+	Type              int64
+	Url               sql.NullString
+	Color             sql.NullString
+	TextColor         sql.NullString
+	ContinuousPickup  sql.NullInt64
+	ContinuousDropOff sql.NullInt64
+}
+
+func (q *Queries) CreateRoute(ctx context.Context, arg CreateRouteParams) (Route, error) {
+	row := q.queryRow(ctx, q.createRouteStmt, createRoute,
+		arg.ID,
+		arg.AgencyID,
+		arg.ShortName,
+		arg.LongName,
+		arg.Desc,
+		arg.Type,
+		arg.Url,
+		arg.Color,
+		arg.TextColor,
+		arg.ContinuousPickup,
+		arg.ContinuousDropOff,
+	)
+	var i Route
+	err := row.Scan(
+		&i.ID,
+		&i.AgencyID,
+		&i.ShortName,
+		&i.LongName,
+		&i.Desc,
+		&i.Type,
+		&i.Url,
+		&i.Color,
+		&i.TextColor,
+		&i.ContinuousPickup,
+		&i.ContinuousDropOff,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createRouteNetwork = `-- name: CreateRouteNetwork :one
+INSERT
+OR REPLACE INTO route_networks (route_id, network_id)
+VALUES
+    (?, ?) RETURNING route_id, network_id, source_feed_id
+`
+
+type CreateRouteNetworkParams struct {
+	RouteID   string
+	NetworkID string
+}
+
+func (q *Queries) CreateRouteNetwork(ctx context.Context, arg CreateRouteNetworkParams) (RouteNetwork, error) {
+	row := q.queryRow(ctx, q.createRouteNetworkStmt, createRouteNetwork, arg.RouteID, arg.NetworkID)
+	var i RouteNetwork
+	err := row.Scan(&i.RouteID, &i.NetworkID, &i.SourceFeedID)
+	return i, err
+}
+
+const createShape = `-- name: CreateShape :one
+INSERT
+OR REPLACE INTO shapes (shape_id, lat, lon, shape_pt_sequence, shape_dist_traveled)
+VALUES
+    (?, ?, ?, ?, ?) RETURNING id, shape_id, lat, lon, shape_pt_sequence, source_feed_id, shape_dist_traveled
+`
+
+type CreateShapeParams struct {
+	ShapeID           string
+	Lat               float64
+	Lon               float64
+	ShapePtSequence   int64
+	ShapeDistTraveled sql.NullFloat64
+}
+
+func (q *Queries) CreateShape(ctx context.Context, arg CreateShapeParams) (Shape, error) {
+	row := q.queryRow(ctx, q.createShapeStmt, createShape,
+		arg.ShapeID,
+		arg.Lat,
+		arg.Lon,
+		arg.ShapePtSequence,
+		arg.ShapeDistTraveled,
+	)
+	var i Shape
+	err := row.Scan(
+		&i.ID,
+		&i.ShapeID,
+		&i.Lat,
+		&i.Lon,
+		&i.ShapePtSequence,
+		&i.SourceFeedID,
+		&i.ShapeDistTraveled,
+	)
+	return i, err
+}
+
+const createStop = `-- name: CreateStop :one
+INSERT
+OR REPLACE INTO stops (
+    id,
+    code,
+    name,
+    desc,
+    lat,
+    lon,
+    zone_id,
+    url,
+    location_type,
+    timezone,
+    wheelchair_boarding,
+    platform_code,
+    parent_station,
+    level_id
+)
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code, parent_station, level_id, source_feed_id
+`
+
+type CreateStopParams struct {
 	ID                 string
 	Code               sql.NullString
 	Name               sql.NullString
@@ -335,6 +1270,8 @@ type CreateStopParams struct {
 	Timezone           sql.NullString
 	WheelchairBoarding sql.NullInt64
 	PlatformCode       sql.NullString
+	ParentStation      sql.NullString
+	LevelID            sql.NullString
 }
 
 func (q *Queries) CreateStop(ctx context.Context, arg CreateStopParams) (Stop, error) {
@@ -351,6 +1288,8 @@ func (q *Queries) CreateStop(ctx context.Context, arg CreateStopParams) (Stop, e
 		arg.Timezone,
 		arg.WheelchairBoarding,
 		arg.PlatformCode,
+		arg.ParentStation,
+		arg.LevelID,
 	)
 	var i Stop
 	err := row.Scan(
@@ -366,10 +1305,32 @@ func (q *Queries) CreateStop(ctx context.Context, arg CreateStopParams) (Stop, e
 		&i.Timezone,
 		&i.WheelchairBoarding,
 		&i.PlatformCode,
+		&i.ParentStation,
+		&i.LevelID,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
+const createStopArea = `-- name: CreateStopArea :one
+INSERT
+OR REPLACE INTO stop_areas (area_id, stop_id)
+VALUES
+    (?, ?) RETURNING area_id, stop_id, source_feed_id
+`
+
+type CreateStopAreaParams struct {
+	AreaID string
+	StopID string
+}
+
+func (q *Queries) CreateStopArea(ctx context.Context, arg CreateStopAreaParams) (StopArea, error) {
+	row := q.queryRow(ctx, q.createStopAreaStmt, createStopArea, arg.AreaID, arg.StopID)
+	var i StopArea
+	err := row.Scan(&i.AreaID, &i.StopID, &i.SourceFeedID)
+	return i, err
+}
+
 const createStopTime = `-- name: CreateStopTime :one
 INSERT
 OR REPLACE INTO stop_times (
@@ -384,7 +1345,7 @@ OR REPLACE INTO stop_times (
     timepoint
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint
+    (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint, source_feed_id
 `
 
 type CreateStopTimeParams struct {
@@ -423,6 +1384,149 @@ func (q *Queries) CreateStopTime(ctx context.Context, arg CreateStopTimeParams)
 		&i.DropOffType,
 		&i.ShapeDistTraveled,
 		&i.Timepoint,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createTimeframe = `-- name: CreateTimeframe :one
+INSERT INTO
+    timeframes (
+        timeframe_group_id,
+        start_time,
+        end_time,
+        service_id
+    )
+VALUES
+    (?, ?, ?, ?) RETURNING id, timeframe_group_id, start_time, end_time, service_id, source_feed_id
+`
+
+type CreateTimeframeParams struct {
+	TimeframeGroupID string
+	StartTime        int64
+	EndTime          int64
+	ServiceID        string
+}
+
+func (q *Queries) CreateTimeframe(ctx context.Context, arg CreateTimeframeParams) (Timeframe, error) {
+	row := q.queryRow(ctx, q.createTimeframeStmt, createTimeframe,
+		arg.TimeframeGroupID,
+		arg.StartTime,
+		arg.EndTime,
+		arg.ServiceID,
+	)
+	var i Timeframe
+	err := row.Scan(
+		&i.ID,
+		&i.TimeframeGroupID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.ServiceID,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO
+    transfers (
+        from_stop_id,
+        to_stop_id,
+        from_route_id,
+        to_route_id,
+        from_trip_id,
+        to_trip_id,
+        transfer_type,
+        min_transfer_time
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, from_stop_id, to_stop_id, from_route_id, to_route_id, from_trip_id, to_trip_id, transfer_type, min_transfer_time, source_feed_id
+`
+
+type CreateTransferParams struct {
+	FromStopID      sql.NullString
+	ToStopID        sql.NullString
+	FromRouteID     sql.NullString
+	ToRouteID       sql.NullString
+	FromTripID      sql.NullString
+	ToTripID        sql.NullString
+	TransferType    int64
+	MinTransferTime sql.NullInt64
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error) {
+	row := q.queryRow(ctx, q.createTransferStmt, createTransfer,
+		arg.FromStopID,
+		arg.ToStopID,
+		arg.FromRouteID,
+		arg.ToRouteID,
+		arg.FromTripID,
+		arg.ToTripID,
+		arg.TransferType,
+		arg.MinTransferTime,
+	)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromStopID,
+		&i.ToStopID,
+		&i.FromRouteID,
+		&i.ToRouteID,
+		&i.FromTripID,
+		&i.ToTripID,
+		&i.TransferType,
+		&i.MinTransferTime,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const createTranslation = `-- name: CreateTranslation :one
+INSERT INTO
+    translations (
+        table_name,
+        field_name,
+        language,
+        translation,
+        record_id,
+        record_sub_id,
+        field_value
+    )
+VALUES
+    (?, ?, ?, ?, ?, ?, ?) RETURNING id, table_name, field_name, language, translation, record_id, record_sub_id, field_value, source_feed_id
+`
+
+type CreateTranslationParams struct {
+	TableName   string
+	FieldName   string
+	Language    string
+	Translation string
+	RecordID    sql.NullString
+	RecordSubID sql.NullString
+	FieldValue  sql.NullString
+}
+
+func (q *Queries) CreateTranslation(ctx context.Context, arg CreateTranslationParams) (Translation, error) {
+	row := q.queryRow(ctx, q.createTranslationStmt, createTranslation,
+		arg.TableName,
+		arg.FieldName,
+		arg.Language,
+		arg.Translation,
+		arg.RecordID,
+		arg.RecordSubID,
+		arg.FieldValue,
+	)
+	var i Translation
+	err := row.Scan(
+		&i.ID,
+		&i.TableName,
+		&i.FieldName,
+		&i.Language,
+		&i.Translation,
+		&i.RecordID,
+		&i.RecordSubID,
+		&i.FieldValue,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
@@ -442,7 +1546,7 @@ OR REPLACE INTO trips (
     bikes_allowed
 )
 VALUES
-    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
+    (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
 `
 
 type CreateTripParams struct {
@@ -483,10 +1587,62 @@ func (q *Queries) CreateTrip(ctx context.Context, arg CreateTripParams) (Trip, e
 		&i.ShapeID,
 		&i.WheelchairAccessible,
 		&i.BikesAllowed,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
+const createValidationFinding = `-- name: CreateValidationFinding :exec
+INSERT INTO
+    validation_findings (severity, code, file_name, entity_id, message)
+VALUES
+    (?, ?, ?, ?, ?)
+`
+
+type CreateValidationFindingParams struct {
+	Severity string
+	Code     string
+	FileName sql.NullString
+	EntityID sql.NullString
+	Message  string
+}
+
+func (q *Queries) CreateValidationFinding(ctx context.Context, arg CreateValidationFindingParams) error {
+	_, err := q.exec(ctx, q.createValidationFindingStmt, createValidationFinding,
+		arg.Severity,
+		arg.Code,
+		arg.FileName,
+		arg.EntityID,
+		arg.Message,
+	)
+	return err
+}
+
+const deleteArrivalAlarm = `-- name: DeleteArrivalAlarm :execrows
+DELETE FROM arrival_alarms
+WHERE
+    id = ?
+`
+
+func (q *Queries) DeleteArrivalAlarm(ctx context.Context, id string) (int64, error) {
+	result, err := q.exec(ctx, q.deleteArrivalAlarmStmt, deleteArrivalAlarm, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteImportMetadata = `-- name: DeleteImportMetadata :exec
+DELETE FROM import_metadata
+WHERE
+    feed_id = ?
+`
+
+func (q *Queries) DeleteImportMetadata(ctx context.Context, feedID string) error {
+	_, err := q.exec(ctx, q.deleteImportMetadataStmt, deleteImportMetadata, feedID)
+	return err
+}
+
 const getActiveServiceIDsForDate = `-- name: GetActiveServiceIDsForDate :many
 WITH formatted_date AS (
     SELECT STRFTIME('%w', SUBSTR(?1, 1, 4) || '-' || SUBSTR(?1, 5, 2) || '-' || SUBSTR(?1, 7, 2)) AS weekday
@@ -504,6 +1660,12 @@ WHERE c.start_date <= ?1
     (fd.weekday = '5' AND c.friday = 1) OR
     (fd.weekday = '6' AND c.saturday = 1)
     )
+  AND c.id NOT IN (
+    SELECT service_id
+    FROM calendar_dates
+    WHERE date = ?1
+      AND exception_type = 2
+    )
 UNION
 SELECT DISTINCT service_id
 FROM calendar_dates
@@ -611,7 +1773,7 @@ func (q *Queries) GetAgenciesForStops(ctx context.Context, stopIds []string) ([]
 
 const getAgency = `-- name: GetAgency :one
 SELECT
-    id, name, url, timezone, lang, phone, fare_url, email
+    id, name, url, timezone, lang, phone, fare_url, email, source_feed_id
 FROM
     agencies
 WHERE
@@ -632,6 +1794,7 @@ func (q *Queries) GetAgency(ctx context.Context, id string) (Agency, error) {
 		&i.Phone,
 		&i.FareUrl,
 		&i.Email,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
@@ -645,7 +1808,8 @@ SELECT DISTINCT
     a.lang,
     a.phone,
     a.fare_url,
-    a.email
+    a.email,
+    a.source_feed_id
 FROM
     agencies a
     JOIN routes r ON a.id = r.agency_id
@@ -671,13 +1835,63 @@ func (q *Queries) GetAgencyForStop(ctx context.Context, stopID string) (Agency,
 		&i.Phone,
 		&i.FareUrl,
 		&i.Email,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
+const getAgencyIDsForTrips = `-- name: GetAgencyIDsForTrips :many
+SELECT
+    t.id,
+    r.agency_id
+FROM
+    trips t
+    JOIN routes r ON t.route_id = r.id
+WHERE
+    t.id IN (/*SLICE:trip_ids*/?)
+`
+
+type GetAgencyIDsForTripsRow struct {
+	ID       string
+	AgencyID string
+}
+
+func (q *Queries) GetAgencyIDsForTrips(ctx context.Context, tripIds []string) ([]GetAgencyIDsForTripsRow, error) {
+	query := getAgencyIDsForTrips
+	var queryParams []interface{}
+	if len(tripIds) > 0 {
+		for _, v := range tripIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", strings.Repeat(",?", len(tripIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAgencyIDsForTripsRow
+	for rows.Next() {
+		var i GetAgencyIDsForTripsRow
+		if err := rows.Scan(&i.ID, &i.AgencyID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllShapes = `-- name: GetAllShapes :many
 SELECT
-    id, shape_id, lat, lon, shape_pt_sequence
+    id, shape_id, lat, lon, shape_pt_sequence, source_feed_id, shape_dist_traveled
 FROM
     shapes
 `
@@ -697,6 +1911,8 @@ func (q *Queries) GetAllShapes(ctx context.Context) ([]Shape, error) {
 			&i.Lat,
 			&i.Lon,
 			&i.ShapePtSequence,
+			&i.SourceFeedID,
+			&i.ShapeDistTraveled,
 		); err != nil {
 			return nil, err
 		}
@@ -712,7 +1928,7 @@ func (q *Queries) GetAllShapes(ctx context.Context) ([]Shape, error) {
 }
 
 const getAllTripsForRoute = `-- name: GetAllTripsForRoute :many
-SELECT DISTINCT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
+SELECT DISTINCT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
 FROM trips t
 WHERE t.route_id = ?1
 ORDER BY t.direction_id, t.trip_headsign
@@ -738,6 +1954,7 @@ func (q *Queries) GetAllTripsForRoute(ctx context.Context, routeID string) ([]Tr
 			&i.ShapeID,
 			&i.WheelchairAccessible,
 			&i.BikesAllowed,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -752,6 +1969,77 @@ func (q *Queries) GetAllTripsForRoute(ctx context.Context, routeID string) ([]Tr
 	return items, nil
 }
 
+const getAreasForStops = `-- name: GetAreasForStops :many
+SELECT
+    area_id, stop_id, source_feed_id
+FROM
+    stop_areas
+WHERE
+    stop_id IN (/*SLICE:stop_ids*/?)
+`
+
+func (q *Queries) GetAreasForStops(ctx context.Context, stopIds []string) ([]StopArea, error) {
+	query := getAreasForStops
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StopArea
+	for rows.Next() {
+		var i StopArea
+		if err := rows.Scan(&i.AreaID, &i.StopID, &i.SourceFeedID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getArrivalAlarm = `-- name: GetArrivalAlarm :one
+SELECT
+    id, agency_id, stop_id, trip_id, service_date, stop_sequence, vehicle_id, alarm_time_offset, on_arrival, callback_url, created_at, client_id
+FROM
+    arrival_alarms
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetArrivalAlarm(ctx context.Context, id string) (ArrivalAlarm, error) {
+	row := q.queryRow(ctx, q.getArrivalAlarmStmt, getArrivalAlarm, id)
+	var i ArrivalAlarm
+	err := row.Scan(
+		&i.ID,
+		&i.AgencyID,
+		&i.StopID,
+		&i.TripID,
+		&i.ServiceDate,
+		&i.StopSequence,
+		&i.VehicleID,
+		&i.AlarmTimeOffset,
+		&i.OnArrival,
+		&i.CallbackUrl,
+		&i.CreatedAt,
+		&i.ClientID,
+	)
+	return i, err
+}
+
 const getArrivalsAndDeparturesForStop = `-- name: GetArrivalsAndDeparturesForStop :many
 SELECT
     st.trip_id,
@@ -762,39 +2050,82 @@ SELECT
     t.service_id,
     t.route_id,
     t.trip_headsign,
+    t.trip_short_name,
+    t.direction_id,
     t.block_id,
-    r.id as route_id,
+    t.shape_id,
     r.agency_id,
-    r.short_name as route_short_name,
-    r.long_name as route_long_name
+    r.short_name AS route_short_name,
+    r.long_name AS route_long_name,
+    (SELECT COUNT(*) FROM stop_times st2 WHERE st2.trip_id = st.trip_id) AS total_stops_in_trip,
+    f.start_time AS frequency_start_time,
+    f.end_time AS frequency_end_time,
+    f.headway_secs AS frequency_headway_secs,
+    f.exact_times AS frequency_exact_times,
+    CAST(COALESCE((SELECT MIN(st0.departure_time) FROM stop_times st0 WHERE f.trip_id IS NOT NULL AND st0.trip_id = st.trip_id), 0) AS INTEGER) AS trip_start_time
 FROM
     stop_times st
         JOIN trips t ON st.trip_id = t.id
         JOIN routes r ON t.route_id = r.id
+        LEFT JOIN frequencies f ON f.trip_id = st.trip_id
 WHERE
-    st.stop_id = ?
+    st.stop_id = ?1
+    -- Frequency-based trips repeat their stop times, so their window is checked after expansion
+    AND (
+        f.trip_id IS NOT NULL
+        OR (st.departure_time >= ?2 AND st.arrival_time <= ?3)
+    )
+    AND t.service_id IN (/*SLICE:service_ids*/?)
 ORDER BY
-    st.arrival_time LIMIT 50
+    st.arrival_time, st.trip_id, f.start_time
 `
 
+type GetArrivalsAndDeparturesForStopParams struct {
+	StopID      string
+	WindowStart int64
+	WindowEnd   int64
+	ServiceIds  []string
+}
+
 type GetArrivalsAndDeparturesForStopRow struct {
-	TripID         string
-	ArrivalTime    int64
-	DepartureTime  int64
-	StopSequence   int64
-	StopHeadsign   sql.NullString
-	ServiceID      string
-	RouteID        string
-	TripHeadsign   sql.NullString
-	BlockID        sql.NullString
-	RouteID_2      string
-	AgencyID       string
-	RouteShortName sql.NullString
-	RouteLongName  sql.NullString
+	TripID               string
+	ArrivalTime          int64
+	DepartureTime        int64
+	StopSequence         int64
+	StopHeadsign         sql.NullString
+	ServiceID            string
+	RouteID              string
+	TripHeadsign         sql.NullString
+	TripShortName        sql.NullString
+	DirectionID          sql.NullInt64
+	BlockID              sql.NullString
+	ShapeID              sql.NullString
+	AgencyID             string
+	RouteShortName       sql.NullString
+	RouteLongName        sql.NullString
+	TotalStopsInTrip     int64
+	FrequencyStartTime   sql.NullInt64
+	FrequencyEndTime     sql.NullInt64
+	FrequencyHeadwaySecs sql.NullInt64
+	FrequencyExactTimes  sql.NullInt64
+	TripStartTime        int64
 }
 
-func (q *Queries) GetArrivalsAndDeparturesForStop(ctx context.Context, stopID string) ([]GetArrivalsAndDeparturesForStopRow, error) {
-	rows, err := q.query(ctx, q.getArrivalsAndDeparturesForStopStmt, getArrivalsAndDeparturesForStop, stopID)
+func (q *Queries) GetArrivalsAndDeparturesForStop(ctx context.Context, arg GetArrivalsAndDeparturesForStopParams) ([]GetArrivalsAndDeparturesForStopRow, error) {
+	query := getArrivalsAndDeparturesForStop
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.StopID)
+	queryParams = append(queryParams, arg.WindowStart)
+	queryParams = append(queryParams, arg.WindowEnd)
+	if len(arg.ServiceIds) > 0 {
+		for _, v := range arg.ServiceIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:service_ids*/?", strings.Repeat(",?", len(arg.ServiceIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:service_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -811,11 +2142,19 @@ func (q *Queries) GetArrivalsAndDeparturesForStop(ctx context.Context, stopID st
 			&i.ServiceID,
 			&i.RouteID,
 			&i.TripHeadsign,
+			&i.TripShortName,
+			&i.DirectionID,
 			&i.BlockID,
-			&i.RouteID_2,
+			&i.ShapeID,
 			&i.AgencyID,
 			&i.RouteShortName,
 			&i.RouteLongName,
+			&i.TotalStopsInTrip,
+			&i.FrequencyStartTime,
+			&i.FrequencyEndTime,
+			&i.FrequencyHeadwaySecs,
+			&i.FrequencyExactTimes,
+			&i.TripStartTime,
 		); err != nil {
 			return nil, err
 		}
@@ -920,9 +2259,58 @@ func (q *Queries) GetBlockIDByTripID(ctx context.Context, id string) (sql.NullSt
 	return block_id, err
 }
 
+const getBlockIDsForTrips = `-- name: GetBlockIDsForTrips :many
+SELECT
+    id,
+    block_id
+FROM
+    trips
+WHERE
+    id IN (/*SLICE:trip_ids*/?)
+    AND block_id IS NOT NULL
+`
+
+type GetBlockIDsForTripsRow struct {
+	ID      string
+	BlockID sql.NullString
+}
+
+func (q *Queries) GetBlockIDsForTrips(ctx context.Context, tripIds []string) ([]GetBlockIDsForTripsRow, error) {
+	query := getBlockIDsForTrips
+	var queryParams []interface{}
+	if len(tripIds) > 0 {
+		for _, v := range tripIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", strings.Repeat(",?", len(tripIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBlockIDsForTripsRow
+	for rows.Next() {
+		var i GetBlockIDsForTripsRow
+		if err := rows.Scan(&i.ID, &i.BlockID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCalendarByServiceID = `-- name: GetCalendarByServiceID :one
 SELECT
-    id, monday, tuesday, wednesday, thursday, friday, saturday, sunday, start_date, end_date
+    id, monday, tuesday, wednesday, thursday, friday, saturday, sunday, start_date, end_date, source_feed_id
 FROM
     calendar
 WHERE
@@ -943,13 +2331,14 @@ func (q *Queries) GetCalendarByServiceID(ctx context.Context, id string) (Calend
 		&i.Sunday,
 		&i.StartDate,
 		&i.EndDate,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
 const getCalendarDateExceptionsForServiceID = `-- name: GetCalendarDateExceptionsForServiceID :many
 SELECT
-    service_id, date, exception_type
+    service_id, date, exception_type, source_feed_id
 FROM
     calendar_dates
 WHERE
@@ -965,7 +2354,12 @@ func (q *Queries) GetCalendarDateExceptionsForServiceID(ctx context.Context, ser
 	var items []CalendarDate
 	for rows.Next() {
 		var i CalendarDate
-		if err := rows.Scan(&i.ServiceID, &i.Date, &i.ExceptionType); err != nil {
+		if err := rows.Scan(
+			&i.ServiceID,
+			&i.Date,
+			&i.ExceptionType,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -979,75 +2373,76 @@ func (q *Queries) GetCalendarDateExceptionsForServiceID(ctx context.Context, ser
 	return items, nil
 }
 
-const getImportMetadata = `-- name: GetImportMetadata :one
+const getCalendarDateRange = `-- name: GetCalendarDateRange :one
 SELECT
-    id, file_hash, import_time, file_source
+    CAST(COALESCE(MIN(start_date), '') AS TEXT) AS start_date,
+    CAST(COALESCE(MAX(end_date), '') AS TEXT) AS end_date
 FROM
-    import_metadata
-WHERE
-    id = 1
-`
-
-func (q *Queries) GetImportMetadata(ctx context.Context) (ImportMetadatum, error) {
-	row := q.queryRow(ctx, q.getImportMetadataStmt, getImportMetadata)
-	var i ImportMetadatum
-	err := row.Scan(
-		&i.ID,
-		&i.FileHash,
-		&i.ImportTime,
-		&i.FileSource,
-	)
-	return i, err
-}
-
-const getNextStopInTrip = `-- name: GetNextStopInTrip :one
-SELECT stops.lat, stops.lon, stops.id
-FROM stop_times
-JOIN stops ON stops.id = stop_times.stop_id
-WHERE stop_times.trip_id = ?
-  AND stop_times.stop_sequence > ?
-ORDER BY stop_times.stop_sequence ASC
-LIMIT 1
+    calendar
 `
 
-type GetNextStopInTripParams struct {
-	TripID       string
-	StopSequence int64
-}
-
-type GetNextStopInTripRow struct {
-	Lat float64
-	Lon float64
-	ID  string
+type GetCalendarDateRangeRow struct {
+	StartDate string
+	EndDate   string
 }
 
-func (q *Queries) GetNextStopInTrip(ctx context.Context, arg GetNextStopInTripParams) (GetNextStopInTripRow, error) {
-	row := q.queryRow(ctx, q.getNextStopInTripStmt, getNextStopInTrip, arg.TripID, arg.StopSequence)
-	var i GetNextStopInTripRow
-	err := row.Scan(&i.Lat, &i.Lon, &i.ID)
+func (q *Queries) GetCalendarDateRange(ctx context.Context) (GetCalendarDateRangeRow, error) {
+	row := q.queryRow(ctx, q.getCalendarDateRangeStmt, getCalendarDateRange)
+	var i GetCalendarDateRangeRow
+	err := row.Scan(&i.StartDate, &i.EndDate)
 	return i, err
 }
 
-const getOrderedStopIDsForTrip = `-- name: GetOrderedStopIDsForTrip :many
-SELECT stop_id
-FROM stop_times
-WHERE trip_id = ?
-ORDER BY stop_sequence
+const getChildStops = `-- name: GetChildStops :many
+SELECT
+    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code, parent_station, level_id, source_feed_id
+FROM
+    stops
+WHERE
+    parent_station IN (/*SLICE:parent_ids*/?)
+ORDER BY
+    id
 `
 
-func (q *Queries) GetOrderedStopIDsForTrip(ctx context.Context, tripID string) ([]string, error) {
-	rows, err := q.query(ctx, q.getOrderedStopIDsForTripStmt, getOrderedStopIDsForTrip, tripID)
+func (q *Queries) GetChildStops(ctx context.Context, parentIds []sql.NullString) ([]Stop, error) {
+	query := getChildStops
+	var queryParams []interface{}
+	if len(parentIds) > 0 {
+		for _, v := range parentIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:parent_ids*/?", strings.Repeat(",?", len(parentIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:parent_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []Stop
 	for rows.Next() {
-		var stop_id string
-		if err := rows.Scan(&stop_id); err != nil {
+		var i Stop
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Desc,
+			&i.Lat,
+			&i.Lon,
+			&i.ZoneID,
+			&i.Url,
+			&i.LocationType,
+			&i.Timezone,
+			&i.WheelchairBoarding,
+			&i.PlatformCode,
+			&i.ParentStation,
+			&i.LevelID,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, stop_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1058,57 +2453,68 @@ func (q *Queries) GetOrderedStopIDsForTrip(ctx context.Context, tripID string) (
 	return items, nil
 }
 
-const getRoute = `-- name: GetRoute :one
+const getFeedInfo = `-- name: GetFeedInfo :one
 SELECT
-    id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off
+    id, feed_id, feed_publisher_name, feed_publisher_url, feed_lang, default_lang, feed_start_date, feed_end_date, feed_version, feed_contact_email, feed_contact_url, source_feed_id
 FROM
-    routes
-WHERE
-    id = ?
+    feed_info
+ORDER BY
+    id
+LIMIT
+    1
 `
 
-func (q *Queries) GetRoute(ctx context.Context, id string) (Route, error) {
-	row := q.queryRow(ctx, q.getRouteStmt, getRoute, id)
-	var i Route
+func (q *Queries) GetFeedInfo(ctx context.Context) (FeedInfo, error) {
+	row := q.queryRow(ctx, q.getFeedInfoStmt, getFeedInfo)
+	var i FeedInfo
 	err := row.Scan(
 		&i.ID,
-		&i.AgencyID,
-		&i.ShortName,
-		&i.LongName,
-		&i.Desc,
-		&i.Type,
-		&i.Url,
-		&i.Color,
-		&i.TextColor,
-		&i.ContinuousPickup,
-		&i.ContinuousDropOff,
+		&i.FeedID,
+		&i.FeedPublisherName,
+		&i.FeedPublisherUrl,
+		&i.FeedLang,
+		&i.DefaultLang,
+		&i.FeedStartDate,
+		&i.FeedEndDate,
+		&i.FeedVersion,
+		&i.FeedContactEmail,
+		&i.FeedContactUrl,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
-const getRouteIDsForAgency = `-- name: GetRouteIDsForAgency :many
+const getFrequenciesForTrip = `-- name: GetFrequenciesForTrip :many
 SELECT
-    r.id
+    trip_id, start_time, end_time, headway_secs, exact_times, source_feed_id
 FROM
-    routes r
-    JOIN agencies a ON r.agency_id = a.id
+    frequencies
 WHERE
-    a.id = ?
+    trip_id = ?
+ORDER BY
+    start_time
 `
 
-func (q *Queries) GetRouteIDsForAgency(ctx context.Context, id string) ([]string, error) {
-	rows, err := q.query(ctx, q.getRouteIDsForAgencyStmt, getRouteIDsForAgency, id)
+func (q *Queries) GetFrequenciesForTrip(ctx context.Context, tripID string) ([]Frequency, error) {
+	rows, err := q.query(ctx, q.getFrequenciesForTripStmt, getFrequenciesForTrip, tripID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []Frequency
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var i Frequency
+		if err := rows.Scan(
+			&i.TripID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.HeadwaySecs,
+			&i.ExactTimes,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1119,30 +2525,47 @@ func (q *Queries) GetRouteIDsForAgency(ctx context.Context, id string) ([]string
 	return items, nil
 }
 
-const getRouteIDsForStop = `-- name: GetRouteIDsForStop :many
-SELECT DISTINCT
-    (routes.agency_id || '_' || routes.id) AS route_id
+const getFrequenciesForTrips = `-- name: GetFrequenciesForTrips :many
+SELECT
+    trip_id, start_time, end_time, headway_secs, exact_times, source_feed_id
 FROM
-    stop_times
-    JOIN trips ON stop_times.trip_id = trips.id
-    JOIN routes ON trips.route_id = routes.id
+    frequencies
 WHERE
-    stop_times.stop_id = ?
+    trip_id IN (/*SLICE:trip_ids*/?)
+ORDER BY
+    trip_id, start_time
 `
 
-func (q *Queries) GetRouteIDsForStop(ctx context.Context, stopID string) ([]interface{}, error) {
-	rows, err := q.query(ctx, q.getRouteIDsForStopStmt, getRouteIDsForStop, stopID)
+func (q *Queries) GetFrequenciesForTrips(ctx context.Context, tripIds []string) ([]Frequency, error) {
+	query := getFrequenciesForTrips
+	var queryParams []interface{}
+	if len(tripIds) > 0 {
+		for _, v := range tripIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", strings.Repeat(",?", len(tripIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []interface{}
+	var items []Frequency
 	for rows.Next() {
-		var route_id interface{}
-		if err := rows.Scan(&route_id); err != nil {
+		var i Frequency
+		if err := rows.Scan(
+			&i.TripID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.HeadwaySecs,
+			&i.ExactTimes,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, route_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1153,43 +2576,67 @@ func (q *Queries) GetRouteIDsForStop(ctx context.Context, stopID string) ([]inte
 	return items, nil
 }
 
-const getRouteIDsForStops = `-- name: GetRouteIDsForStops :many
-SELECT DISTINCT
-    routes.agency_id || '_' || routes.id AS route_id,
-    stop_times.stop_id
+const getImportMetadata = `-- name: GetImportMetadata :one
+SELECT
+    feed_id, file_hash, import_time, file_source, etag, last_modified, id_namespace
 FROM
-    stop_times
-    JOIN trips ON stop_times.trip_id = trips.id
-    JOIN routes ON trips.route_id = routes.id
+    import_metadata
 WHERE
-    stop_times.stop_id IN (/*SLICE:stop_ids*/?)
+    feed_id = ?
 `
 
-type GetRouteIDsForStopsRow struct {
-	RouteID interface{}
-	StopID  string
+func (q *Queries) GetImportMetadata(ctx context.Context, feedID string) (ImportMetadatum, error) {
+	row := q.queryRow(ctx, q.getImportMetadataStmt, getImportMetadata, feedID)
+	var i ImportMetadatum
+	err := row.Scan(
+		&i.FeedID,
+		&i.FileHash,
+		&i.ImportTime,
+		&i.FileSource,
+		&i.Etag,
+		&i.LastModified,
+		&i.IDNamespace,
+	)
+	return i, err
 }
 
-func (q *Queries) GetRouteIDsForStops(ctx context.Context, stopIds []string) ([]GetRouteIDsForStopsRow, error) {
-	query := getRouteIDsForStops
+const getLevelsByIDs = `-- name: GetLevelsByIDs :many
+SELECT
+    level_id, level_index, level_name, source_feed_id
+FROM
+    levels
+WHERE
+    level_id IN (/*SLICE:level_ids*/?)
+ORDER BY
+    level_index,
+    level_id
+`
+
+func (q *Queries) GetLevelsByIDs(ctx context.Context, levelIds []string) ([]Level, error) {
+	query := getLevelsByIDs
 	var queryParams []interface{}
-	if len(stopIds) > 0 {
-		for _, v := range stopIds {
+	if len(levelIds) > 0 {
+		for _, v := range levelIds {
 			queryParams = append(queryParams, v)
 		}
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+		query = strings.Replace(query, "/*SLICE:level_ids*/?", strings.Repeat(",?", len(levelIds))[1:], 1)
 	} else {
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+		query = strings.Replace(query, "/*SLICE:level_ids*/?", "NULL", 1)
 	}
 	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetRouteIDsForStopsRow
+	var items []Level
 	for rows.Next() {
-		var i GetRouteIDsForStopsRow
-		if err := rows.Scan(&i.RouteID, &i.StopID); err != nil {
+		var i Level
+		if err := rows.Scan(
+			&i.LevelID,
+			&i.LevelIndex,
+			&i.LevelName,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1203,19 +2650,17 @@ func (q *Queries) GetRouteIDsForStops(ctx context.Context, stopIds []string) ([]
 	return items, nil
 }
 
-const getRoutesByIDs = `-- name: GetRoutesByIDs :many
+const getNetworksForRoutes = `-- name: GetNetworksForRoutes :many
 SELECT
-    id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off
+    route_id, network_id, source_feed_id
 FROM
-    routes
+    route_networks
 WHERE
-    id IN (/*SLICE:route_ids*/?)
-ORDER BY
-    id
+    route_id IN (/*SLICE:route_ids*/?)
 `
 
-func (q *Queries) GetRoutesByIDs(ctx context.Context, routeIds []string) ([]Route, error) {
-	query := getRoutesByIDs
+func (q *Queries) GetNetworksForRoutes(ctx context.Context, routeIds []string) ([]RouteNetwork, error) {
+	query := getNetworksForRoutes
 	var queryParams []interface{}
 	if len(routeIds) > 0 {
 		for _, v := range routeIds {
@@ -1230,22 +2675,10 @@ func (q *Queries) GetRoutesByIDs(ctx context.Context, routeIds []string) ([]Rout
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Route
+	var items []RouteNetwork
 	for rows.Next() {
-		var i Route
-		if err := rows.Scan(
-			&i.ID,
-			&i.AgencyID,
-			&i.ShortName,
-			&i.LongName,
-			&i.Desc,
-			&i.Type,
-			&i.Url,
-			&i.Color,
-			&i.TextColor,
-			&i.ContinuousPickup,
-			&i.ContinuousDropOff,
-		); err != nil {
+		var i RouteNetwork
+		if err := rows.Scan(&i.RouteID, &i.NetworkID, &i.SourceFeedID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1259,42 +2692,54 @@ func (q *Queries) GetRoutesByIDs(ctx context.Context, routeIds []string) ([]Rout
 	return items, nil
 }
 
-const getRoutesForStop = `-- name: GetRoutesForStop :many
-SELECT DISTINCT
-    routes.id, routes.agency_id, routes.short_name, routes.long_name, routes."desc", routes.type, routes.url, routes.color, routes.text_color, routes.continuous_pickup, routes.continuous_drop_off
-FROM
-    stop_times
-    JOIN trips ON stop_times.trip_id = trips.id
-    JOIN routes ON trips.route_id = routes.id
-WHERE
-    stop_times.stop_id = ?
+const getNextStopInTrip = `-- name: GetNextStopInTrip :one
+SELECT stops.lat, stops.lon, stops.id
+FROM stop_times
+JOIN stops ON stops.id = stop_times.stop_id
+WHERE stop_times.trip_id = ?
+  AND stop_times.stop_sequence > ?
+ORDER BY stop_times.stop_sequence ASC
+LIMIT 1
 `
 
-func (q *Queries) GetRoutesForStop(ctx context.Context, stopID string) ([]Route, error) {
-	rows, err := q.query(ctx, q.getRoutesForStopStmt, getRoutesForStop, stopID)
+type GetNextStopInTripParams struct {
+	TripID       string
+	StopSequence int64
+}
+
+type GetNextStopInTripRow struct {
+	Lat float64
+	Lon float64
+	ID  string
+}
+
+func (q *Queries) GetNextStopInTrip(ctx context.Context, arg GetNextStopInTripParams) (GetNextStopInTripRow, error) {
+	row := q.queryRow(ctx, q.getNextStopInTripStmt, getNextStopInTrip, arg.TripID, arg.StopSequence)
+	var i GetNextStopInTripRow
+	err := row.Scan(&i.Lat, &i.Lon, &i.ID)
+	return i, err
+}
+
+const getOrderedStopIDsForTrip = `-- name: GetOrderedStopIDsForTrip :many
+SELECT stop_id
+FROM stop_times
+WHERE trip_id = ?
+ORDER BY stop_sequence
+`
+
+func (q *Queries) GetOrderedStopIDsForTrip(ctx context.Context, tripID string) ([]string, error) {
+	rows, err := q.query(ctx, q.getOrderedStopIDsForTripStmt, getOrderedStopIDsForTrip, tripID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Route
+	var items []string
 	for rows.Next() {
-		var i Route
-		if err := rows.Scan(
-			&i.ID,
-			&i.AgencyID,
-			&i.ShortName,
-			&i.LongName,
-			&i.Desc,
-			&i.Type,
-			&i.Url,
-			&i.Color,
-			&i.TextColor,
-			&i.ContinuousPickup,
-			&i.ContinuousDropOff,
-		); err != nil {
+		var stop_id string
+		if err := rows.Scan(&stop_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, stop_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1305,67 +2750,64 @@ func (q *Queries) GetRoutesForStop(ctx context.Context, stopID string) ([]Route,
 	return items, nil
 }
 
-const getRoutesForStops = `-- name: GetRoutesForStops :many
-
-SELECT DISTINCT
-    routes.id, routes.agency_id, routes.short_name, routes.long_name, routes."desc", routes.type, routes.url, routes.color, routes.text_color, routes.continuous_pickup, routes.continuous_drop_off,
-    stop_times.stop_id
+const getPathwaysForStops = `-- name: GetPathwaysForStops :many
+SELECT
+    pathway_id, from_stop_id, to_stop_id, pathway_mode, is_bidirectional, length, traversal_time, stair_count, max_slope, min_width, signposted_as, reversed_signposted_as, source_feed_id
 FROM
-    stop_times
-    JOIN trips ON stop_times.trip_id = trips.id
-    JOIN routes ON trips.route_id = routes.id
+    pathways
 WHERE
-    stop_times.stop_id IN (/*SLICE:stop_ids*/?)
+    from_stop_id IN (/*SLICE:from_stop_ids*/?)
+    OR to_stop_id IN (/*SLICE:to_stop_ids*/?)
+ORDER BY
+    pathway_id
 `
 
-type GetRoutesForStopsRow struct {
-	ID                string
-	AgencyID          string
-	ShortName         sql.NullString
-	LongName          sql.NullString
-	Desc              sql.NullString
-	Type              int64
-	Url               sql.NullString
-	Color             sql.NullString
-	TextColor         sql.NullString
-	ContinuousPickup  sql.NullInt64
-	ContinuousDropOff sql.NullInt64
-	StopID            string
+type GetPathwaysForStopsParams struct {
+	FromStopIds []string
+	ToStopIds   []string
 }
 
-// Batch queries to solve N+1 problems
-func (q *Queries) GetRoutesForStops(ctx context.Context, stopIds []string) ([]GetRoutesForStopsRow, error) {
-	query := getRoutesForStops
+func (q *Queries) GetPathwaysForStops(ctx context.Context, arg GetPathwaysForStopsParams) ([]Pathway, error) {
+	query := getPathwaysForStops
 	var queryParams []interface{}
-	if len(stopIds) > 0 {
-		for _, v := range stopIds {
+	if len(arg.FromStopIds) > 0 {
+		for _, v := range arg.FromStopIds {
 			queryParams = append(queryParams, v)
 		}
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+		query = strings.Replace(query, "/*SLICE:from_stop_ids*/?", strings.Repeat(",?", len(arg.FromStopIds))[1:], 1)
 	} else {
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+		query = strings.Replace(query, "/*SLICE:from_stop_ids*/?", "NULL", 1)
 	}
-	rows, err := q.query(ctx, nil, query, queryParams...)
+	if len(arg.ToStopIds) > 0 {
+		for _, v := range arg.ToStopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:to_stop_ids*/?", strings.Repeat(",?", len(arg.ToStopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:to_stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetRoutesForStopsRow
+	var items []Pathway
 	for rows.Next() {
-		var i GetRoutesForStopsRow
+		var i Pathway
 		if err := rows.Scan(
-			&i.ID,
-			&i.AgencyID,
-			&i.ShortName,
-			&i.LongName,
-			&i.Desc,
-			&i.Type,
-			&i.Url,
-			&i.Color,
-			&i.TextColor,
-			&i.ContinuousPickup,
-			&i.ContinuousDropOff,
-			&i.StopID,
+			&i.PathwayID,
+			&i.FromStopID,
+			&i.ToStopID,
+			&i.PathwayMode,
+			&i.IsBidirectional,
+			&i.Length,
+			&i.TraversalTime,
+			&i.StairCount,
+			&i.MaxSlope,
+			&i.MinWidth,
+			&i.SignpostedAs,
+			&i.ReversedSignpostedAs,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -1380,62 +2822,92 @@ func (q *Queries) GetRoutesForStops(ctx context.Context, stopIds []string) ([]Ge
 	return items, nil
 }
 
-const getScheduleForStop = `-- name: GetScheduleForStop :many
+const getProblemReport = `-- name: GetProblemReport :one
 SELECT
-    st.trip_id,
-    st.arrival_time,
-    st.departure_time,
-    st.stop_headsign,
-    t.service_id,
-    t.route_id,
-    t.trip_headsign,
-    r.id as route_id,
-    r.agency_id
+    id, report_type, trip_id, stop_id, code, service_date, vehicle_id, user_comment, user_on_vehicle, user_vehicle_number, user_lat, user_lon, user_location_accuracy, status, created_at, resolved_at, resolution_note
 FROM
-    stop_times st
-    JOIN trips t ON st.trip_id = t.id
-    JOIN routes r ON t.route_id = r.id
+    problem_reports
 WHERE
-    st.stop_id = ?
-ORDER BY
-    r.id, st.arrival_time
+    id = ?
 `
 
-type GetScheduleForStopRow struct {
-	TripID        string
-	ArrivalTime   int64
-	DepartureTime int64
-	StopHeadsign  sql.NullString
-	ServiceID     string
-	RouteID       string
-	TripHeadsign  sql.NullString
-	RouteID_2     string
-	AgencyID      string
+func (q *Queries) GetProblemReport(ctx context.Context, id int64) (ProblemReport, error) {
+	row := q.queryRow(ctx, q.getProblemReportStmt, getProblemReport, id)
+	var i ProblemReport
+	err := row.Scan(
+		&i.ID,
+		&i.ReportType,
+		&i.TripID,
+		&i.StopID,
+		&i.Code,
+		&i.ServiceDate,
+		&i.VehicleID,
+		&i.UserComment,
+		&i.UserOnVehicle,
+		&i.UserVehicleNumber,
+		&i.UserLat,
+		&i.UserLon,
+		&i.UserLocationAccuracy,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+		&i.ResolutionNote,
+	)
+	return i, err
 }
 
-func (q *Queries) GetScheduleForStop(ctx context.Context, stopID string) ([]GetScheduleForStopRow, error) {
-	rows, err := q.query(ctx, q.getScheduleForStopStmt, getScheduleForStop, stopID)
+const getRoute = `-- name: GetRoute :one
+SELECT
+    id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off, source_feed_id
+FROM
+    routes
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetRoute(ctx context.Context, id string) (Route, error) {
+	row := q.queryRow(ctx, q.getRouteStmt, getRoute, id)
+	var i Route
+	err := row.Scan(
+		&i.ID,
+		&i.AgencyID,
+		&i.ShortName,
+		&i.LongName,
+		&i.Desc,
+		&i.Type,
+		&i.Url,
+		&i.Color,
+		&i.TextColor,
+		&i.ContinuousPickup,
+		&i.ContinuousDropOff,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const getRouteIDsForAgency = `-- name: GetRouteIDsForAgency :many
+SELECT
+    r.id
+FROM
+    routes r
+    JOIN agencies a ON r.agency_id = a.id
+WHERE
+    a.id = ?
+`
+
+func (q *Queries) GetRouteIDsForAgency(ctx context.Context, id string) ([]string, error) {
+	rows, err := q.query(ctx, q.getRouteIDsForAgencyStmt, getRouteIDsForAgency, id)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetScheduleForStopRow
+	var items []string
 	for rows.Next() {
-		var i GetScheduleForStopRow
-		if err := rows.Scan(
-			&i.TripID,
-			&i.ArrivalTime,
-			&i.DepartureTime,
-			&i.StopHeadsign,
-			&i.ServiceID,
-			&i.RouteID,
-			&i.TripHeadsign,
-			&i.RouteID_2,
-			&i.AgencyID,
-		); err != nil {
+		var id string
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1446,34 +2918,31 @@ func (q *Queries) GetScheduleForStop(ctx context.Context, stopID string) ([]GetS
 	return items, nil
 }
 
-const getShapeByID = `-- name: GetShapeByID :many
-SELECT
-    id, shape_id, lat, lon, shape_pt_sequence
+const getRouteIDsForStop = `-- name: GetRouteIDsForStop :many
+SELECT DISTINCT
+    (routes.agency_id || '_' || substr(routes.id, instr(routes.id, char(31)) + 1)) AS route_id
 FROM
-    shapes
+    stop_times
+    JOIN trips ON stop_times.trip_id = trips.id
+    JOIN routes ON trips.route_id = routes.id
 WHERE
-    shape_id = ?
+    stop_times.stop_id = ?
 `
 
-func (q *Queries) GetShapeByID(ctx context.Context, shapeID string) ([]Shape, error) {
-	rows, err := q.query(ctx, q.getShapeByIDStmt, getShapeByID, shapeID)
+// Route IDs are combined as written in their feed, without the namespace of FeedNamespace
+func (q *Queries) GetRouteIDsForStop(ctx context.Context, stopID string) ([]interface{}, error) {
+	rows, err := q.query(ctx, q.getRouteIDsForStopStmt, getRouteIDsForStop, stopID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Shape
+	var items []interface{}
 	for rows.Next() {
-		var i Shape
-		if err := rows.Scan(
-			&i.ID,
-			&i.ShapeID,
-			&i.Lat,
-			&i.Lon,
-			&i.ShapePtSequence,
-		); err != nil {
+		var route_id interface{}
+		if err := rows.Scan(&route_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, route_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1484,38 +2953,44 @@ func (q *Queries) GetShapeByID(ctx context.Context, shapeID string) ([]Shape, er
 	return items, nil
 }
 
-const getShapePointsByTripID = `-- name: GetShapePointsByTripID :many
-SELECT
-    s.id,
-    s.shape_id,
-    s.lat,
-    s.lon,
-    s.shape_pt_sequence
+const getRouteIDsForStops = `-- name: GetRouteIDsForStops :many
+SELECT DISTINCT
+    routes.agency_id || '_' || substr(routes.id, instr(routes.id, char(31)) + 1) AS route_id,
+    stop_times.stop_id
 FROM
-    shapes s
-    JOIN trips t ON t.shape_id = s.shape_id
+    stop_times
+    JOIN trips ON stop_times.trip_id = trips.id
+    JOIN routes ON trips.route_id = routes.id
 WHERE
-    t.id = ?
-ORDER BY
-    s.shape_pt_sequence ASC
+    stop_times.stop_id IN (/*SLICE:stop_ids*/?)
 `
 
-func (q *Queries) GetShapePointsByTripID(ctx context.Context, id string) ([]Shape, error) {
-	rows, err := q.query(ctx, q.getShapePointsByTripIDStmt, getShapePointsByTripID, id)
+type GetRouteIDsForStopsRow struct {
+	RouteID interface{}
+	StopID  string
+}
+
+// Route IDs are combined as written in their feed, without the namespace of FeedNamespace
+func (q *Queries) GetRouteIDsForStops(ctx context.Context, stopIds []string) ([]GetRouteIDsForStopsRow, error) {
+	query := getRouteIDsForStops
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Shape
+	var items []GetRouteIDsForStopsRow
 	for rows.Next() {
-		var i Shape
-		if err := rows.Scan(
-			&i.ID,
-			&i.ShapeID,
-			&i.Lat,
-			&i.Lon,
-			&i.ShapePtSequence,
-		); err != nil {
+		var i GetRouteIDsForStopsRow
+		if err := rows.Scan(&i.RouteID, &i.StopID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1529,30 +3004,50 @@ func (q *Queries) GetShapePointsByTripID(ctx context.Context, id string) ([]Shap
 	return items, nil
 }
 
-const getShapePointsForTrip = `-- name: GetShapePointsForTrip :many
-SELECT DISTINCT shapes.lat, shapes.lon, shapes.shape_pt_sequence
-FROM shapes
-JOIN trips ON trips.shape_id = shapes.shape_id
-WHERE trips.id = ?
-ORDER BY shapes.shape_pt_sequence
+const getRoutesByIDs = `-- name: GetRoutesByIDs :many
+SELECT
+    id, agency_id, short_name, long_name, "desc", type, url, color, text_color, continuous_pickup, continuous_drop_off, source_feed_id
+FROM
+    routes
+WHERE
+    id IN (/*SLICE:route_ids*/?)
+ORDER BY
+    id
 `
 
-type GetShapePointsForTripRow struct {
-	Lat             float64
-	Lon             float64
-	ShapePtSequence int64
-}
-
-func (q *Queries) GetShapePointsForTrip(ctx context.Context, id string) ([]GetShapePointsForTripRow, error) {
-	rows, err := q.query(ctx, q.getShapePointsForTripStmt, getShapePointsForTrip, id)
+func (q *Queries) GetRoutesByIDs(ctx context.Context, routeIds []string) ([]Route, error) {
+	query := getRoutesByIDs
+	var queryParams []interface{}
+	if len(routeIds) > 0 {
+		for _, v := range routeIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:route_ids*/?", strings.Repeat(",?", len(routeIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:route_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetShapePointsForTripRow
+	var items []Route
 	for rows.Next() {
-		var i GetShapePointsForTripRow
-		if err := rows.Scan(&i.Lat, &i.Lon, &i.ShapePtSequence); err != nil {
+		var i Route
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgencyID,
+			&i.ShortName,
+			&i.LongName,
+			&i.Desc,
+			&i.Type,
+			&i.Url,
+			&i.Color,
+			&i.TextColor,
+			&i.ContinuousPickup,
+			&i.ContinuousDropOff,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1566,41 +3061,1021 @@ func (q *Queries) GetShapePointsForTrip(ctx context.Context, id string) ([]GetSh
 	return items, nil
 }
 
-const getShapesGroupedByTripHeadSign = `-- name: GetShapesGroupedByTripHeadSign :many
-SELECT DISTINCT s.lat, s.lon, s.shape_pt_sequence
-FROM shapes s
-         JOIN (
-    SELECT shape_id
-    FROM trips
-    WHERE route_id = ?1
-      AND trip_headsign = ?2
-      AND shape_id IS NOT NULL
-    LIMIT 1
-) t ON s.shape_id = t.shape_id
-ORDER BY s.shape_pt_sequence
-`
-
-type GetShapesGroupedByTripHeadSignParams struct {
-	RouteID      string
-	TripHeadsign sql.NullString
-}
+const getRoutesForStop = `-- name: GetRoutesForStop :many
+SELECT DISTINCT
+    routes.id, routes.agency_id, routes.short_name, routes.long_name, routes."desc", routes.type, routes.url, routes.color, routes.text_color, routes.continuous_pickup, routes.continuous_drop_off, routes.source_feed_id
+FROM
+    stop_times
+    JOIN trips ON stop_times.trip_id = trips.id
+    JOIN routes ON trips.route_id = routes.id
+WHERE
+    stop_times.stop_id = ?
+`
+
+func (q *Queries) GetRoutesForStop(ctx context.Context, stopID string) ([]Route, error) {
+	rows, err := q.query(ctx, q.getRoutesForStopStmt, getRoutesForStop, stopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Route
+	for rows.Next() {
+		var i Route
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgencyID,
+			&i.ShortName,
+			&i.LongName,
+			&i.Desc,
+			&i.Type,
+			&i.Url,
+			&i.Color,
+			&i.TextColor,
+			&i.ContinuousPickup,
+			&i.ContinuousDropOff,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoutesForStops = `-- name: GetRoutesForStops :many
+
+SELECT DISTINCT
+    routes.id, routes.agency_id, routes.short_name, routes.long_name, routes."desc", routes.type, routes.url, routes.color, routes.text_color, routes.continuous_pickup, routes.continuous_drop_off, routes.source_feed_id,
+    stop_times.stop_id
+FROM
+    stop_times
+    JOIN trips ON stop_times.trip_id = trips.id
+    JOIN routes ON trips.route_id = routes.id
+WHERE
+    stop_times.stop_id IN (/*SLICE:stop_ids*/?)
+`
+
+type GetRoutesForStopsRow struct {
+	ID                string
+	AgencyID          string
+	ShortName         sql.NullString
+	LongName          sql.NullString
+	Desc              sql.NullString
+	Type              int64
+	Url               sql.NullString
+	Color             sql.NullString
+	TextColor         sql.NullString
+	ContinuousPickup  sql.NullInt64
+	ContinuousDropOff sql.NullInt64
+	SourceFeedID      sql.NullString
+	StopID            string
+}
+
+// Batch queries to solve N+1 problems
+func (q *Queries) GetRoutesForStops(ctx context.Context, stopIds []string) ([]GetRoutesForStopsRow, error) {
+	query := getRoutesForStops
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRoutesForStopsRow
+	for rows.Next() {
+		var i GetRoutesForStopsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AgencyID,
+			&i.ShortName,
+			&i.LongName,
+			&i.Desc,
+			&i.Type,
+			&i.Url,
+			&i.Color,
+			&i.TextColor,
+			&i.ContinuousPickup,
+			&i.ContinuousDropOff,
+			&i.SourceFeedID,
+			&i.StopID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getScheduleForStop = `-- name: GetScheduleForStop :many
+SELECT
+    st.trip_id,
+    st.arrival_time,
+    st.departure_time,
+    st.stop_headsign,
+    st.stop_sequence,
+    t.service_id,
+    t.route_id,
+    t.trip_headsign,
+    r.id as route_id,
+    r.agency_id,
+    f.start_time AS frequency_start_time,
+    f.end_time AS frequency_end_time,
+    f.headway_secs AS frequency_headway_secs,
+    f.exact_times AS frequency_exact_times,
+    CAST(COALESCE((SELECT MIN(st0.departure_time) FROM stop_times st0 WHERE f.trip_id IS NOT NULL AND st0.trip_id = st.trip_id), 0) AS INTEGER) AS trip_start_time
+FROM
+    stop_times st
+    JOIN trips t ON st.trip_id = t.id
+    JOIN routes r ON t.route_id = r.id
+    LEFT JOIN frequencies f ON f.trip_id = st.trip_id
+WHERE
+    st.stop_id = ?
+ORDER BY
+    r.id, st.arrival_time, f.start_time
+`
+
+type GetScheduleForStopRow struct {
+	TripID               string
+	ArrivalTime          int64
+	DepartureTime        int64
+	StopHeadsign         sql.NullString
+	StopSequence         int64
+	ServiceID            string
+	RouteID              string
+	TripHeadsign         sql.NullString
+	RouteID_2            string
+	AgencyID             string
+	FrequencyStartTime   sql.NullInt64
+	FrequencyEndTime     sql.NullInt64
+	FrequencyHeadwaySecs sql.NullInt64
+	FrequencyExactTimes  sql.NullInt64
+	TripStartTime        int64
+}
+
+func (q *Queries) GetScheduleForStop(ctx context.Context, stopID string) ([]GetScheduleForStopRow, error) {
+	rows, err := q.query(ctx, q.getScheduleForStopStmt, getScheduleForStop, stopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetScheduleForStopRow
+	for rows.Next() {
+		var i GetScheduleForStopRow
+		if err := rows.Scan(
+			&i.TripID,
+			&i.ArrivalTime,
+			&i.DepartureTime,
+			&i.StopHeadsign,
+			&i.StopSequence,
+			&i.ServiceID,
+			&i.RouteID,
+			&i.TripHeadsign,
+			&i.RouteID_2,
+			&i.AgencyID,
+			&i.FrequencyStartTime,
+			&i.FrequencyEndTime,
+			&i.FrequencyHeadwaySecs,
+			&i.FrequencyExactTimes,
+			&i.TripStartTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShapeByID = `-- name: GetShapeByID :many
+SELECT
+    id, shape_id, lat, lon, shape_pt_sequence, source_feed_id, shape_dist_traveled
+FROM
+    shapes
+WHERE
+    shape_id = ?
+`
+
+func (q *Queries) GetShapeByID(ctx context.Context, shapeID string) ([]Shape, error) {
+	rows, err := q.query(ctx, q.getShapeByIDStmt, getShapeByID, shapeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shape
+	for rows.Next() {
+		var i Shape
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShapeID,
+			&i.Lat,
+			&i.Lon,
+			&i.ShapePtSequence,
+			&i.SourceFeedID,
+			&i.ShapeDistTraveled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShapePointsByTripID = `-- name: GetShapePointsByTripID :many
+SELECT
+    s.id,
+    s.shape_id,
+    s.lat,
+    s.lon,
+    s.shape_pt_sequence,
+    s.source_feed_id,
+    s.shape_dist_traveled
+FROM
+    shapes s
+    JOIN trips t ON t.shape_id = s.shape_id
+WHERE
+    t.id = ?
+ORDER BY
+    s.shape_pt_sequence ASC
+`
+
+func (q *Queries) GetShapePointsByTripID(ctx context.Context, id string) ([]Shape, error) {
+	rows, err := q.query(ctx, q.getShapePointsByTripIDStmt, getShapePointsByTripID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Shape
+	for rows.Next() {
+		var i Shape
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShapeID,
+			&i.Lat,
+			&i.Lon,
+			&i.ShapePtSequence,
+			&i.SourceFeedID,
+			&i.ShapeDistTraveled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShapePointsForTrip = `-- name: GetShapePointsForTrip :many
+SELECT DISTINCT shapes.lat, shapes.lon, shapes.shape_pt_sequence
+FROM shapes
+JOIN trips ON trips.shape_id = shapes.shape_id
+WHERE trips.id = ?
+ORDER BY shapes.shape_pt_sequence
+`
+
+type GetShapePointsForTripRow struct {
+	Lat             float64
+	Lon             float64
+	ShapePtSequence int64
+}
+
+func (q *Queries) GetShapePointsForTrip(ctx context.Context, id string) ([]GetShapePointsForTripRow, error) {
+	rows, err := q.query(ctx, q.getShapePointsForTripStmt, getShapePointsForTrip, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetShapePointsForTripRow
+	for rows.Next() {
+		var i GetShapePointsForTripRow
+		if err := rows.Scan(&i.Lat, &i.Lon, &i.ShapePtSequence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShapesGroupedByTripHeadSign = `-- name: GetShapesGroupedByTripHeadSign :many
+SELECT DISTINCT s.lat, s.lon, s.shape_pt_sequence
+FROM shapes s
+         JOIN (
+    SELECT shape_id
+    FROM trips
+    WHERE route_id = ?1
+      AND trip_headsign = ?2
+      AND shape_id IS NOT NULL
+    LIMIT 1
+) t ON s.shape_id = t.shape_id
+ORDER BY s.shape_pt_sequence
+`
+
+type GetShapesGroupedByTripHeadSignParams struct {
+	RouteID      string
+	TripHeadsign sql.NullString
+}
+
+type GetShapesGroupedByTripHeadSignRow struct {
+	Lat             float64
+	Lon             float64
+	ShapePtSequence int64
+}
+
+func (q *Queries) GetShapesGroupedByTripHeadSign(ctx context.Context, arg GetShapesGroupedByTripHeadSignParams) ([]GetShapesGroupedByTripHeadSignRow, error) {
+	rows, err := q.query(ctx, q.getShapesGroupedByTripHeadSignStmt, getShapesGroupedByTripHeadSign, arg.RouteID, arg.TripHeadsign)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetShapesGroupedByTripHeadSignRow
+	for rows.Next() {
+		var i GetShapesGroupedByTripHeadSignRow
+		if err := rows.Scan(&i.Lat, &i.Lon, &i.ShapePtSequence); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStop = `-- name: GetStop :one
+SELECT
+    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code, parent_station, level_id, source_feed_id
+FROM
+    stops
+WHERE
+    id = ?
+`
+
+func (q *Queries) GetStop(ctx context.Context, id string) (Stop, error) {
+	row := q.queryRow(ctx, q.getStopStmt, getStop, id)
+	var i Stop
+	err := row.Scan(
+		&i.ID,
+		&i.Code,
+		&i.Name,
+		&i.Desc,
+		&i.Lat,
+		&i.Lon,
+		&i.ZoneID,
+		&i.Url,
+		&i.LocationType,
+		&i.Timezone,
+		&i.WheelchairBoarding,
+		&i.PlatformCode,
+		&i.ParentStation,
+		&i.LevelID,
+		&i.SourceFeedID,
+	)
+	return i, err
+}
+
+const getStopIDsForAgency = `-- name: GetStopIDsForAgency :many
+SELECT
+    s.id
+FROM
+    stops s
+`
+
+func (q *Queries) GetStopIDsForAgency(ctx context.Context) ([]string, error) {
+	rows, err := q.query(ctx, q.getStopIDsForAgencyStmt, getStopIDsForAgency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopIDsForRoute = `-- name: GetStopIDsForRoute :many
+SELECT DISTINCT
+    stop_times.stop_id
+FROM
+    stop_times
+        JOIN trips ON stop_times.trip_id = trips.id
+WHERE
+    trips.route_id = ?
+`
+
+func (q *Queries) GetStopIDsForRoute(ctx context.Context, routeID string) ([]string, error) {
+	rows, err := q.query(ctx, q.getStopIDsForRouteStmt, getStopIDsForRoute, routeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var stop_id string
+		if err := rows.Scan(&stop_id); err != nil {
+			return nil, err
+		}
+		items = append(items, stop_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopIDsForTrip = `-- name: GetStopIDsForTrip :many
+SELECT DISTINCT
+    stop_times.stop_id
+FROM
+    stop_times
+WHERE
+    stop_times.trip_id = ?
+`
+
+func (q *Queries) GetStopIDsForTrip(ctx context.Context, tripID string) ([]string, error) {
+	rows, err := q.query(ctx, q.getStopIDsForTripStmt, getStopIDsForTrip, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var stop_id string
+		if err := rows.Scan(&stop_id); err != nil {
+			return nil, err
+		}
+		items = append(items, stop_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopIDsForTrips = `-- name: GetStopIDsForTrips :many
+SELECT DISTINCT
+    trip_id,
+    stop_id
+FROM
+    stop_times
+WHERE
+    trip_id IN (/*SLICE:trip_ids*/?)
+`
+
+type GetStopIDsForTripsRow struct {
+	TripID string
+	StopID string
+}
+
+func (q *Queries) GetStopIDsForTrips(ctx context.Context, tripIds []string) ([]GetStopIDsForTripsRow, error) {
+	query := getStopIDsForTrips
+	var queryParams []interface{}
+	if len(tripIds) > 0 {
+		for _, v := range tripIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", strings.Repeat(",?", len(tripIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:trip_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStopIDsForTripsRow
+	for rows.Next() {
+		var i GetStopIDsForTripsRow
+		if err := rows.Scan(&i.TripID, &i.StopID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopTimesByStopIDs = `-- name: GetStopTimesByStopIDs :many
+SELECT
+    trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint, source_feed_id
+FROM
+    stop_times
+WHERE
+    stop_id IN (/*SLICE:stop_ids*/?)
+`
+
+func (q *Queries) GetStopTimesByStopIDs(ctx context.Context, stopIds []string) ([]StopTime, error) {
+	query := getStopTimesByStopIDs
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StopTime
+	for rows.Next() {
+		var i StopTime
+		if err := rows.Scan(
+			&i.TripID,
+			&i.ArrivalTime,
+			&i.DepartureTime,
+			&i.StopID,
+			&i.StopSequence,
+			&i.StopHeadsign,
+			&i.PickupType,
+			&i.DropOffType,
+			&i.ShapeDistTraveled,
+			&i.Timepoint,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopTimesForTrip = `-- name: GetStopTimesForTrip :many
+SELECT
+    trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint, source_feed_id
+FROM
+    stop_times
+WHERE
+    trip_id = ?
+ORDER BY
+    stop_sequence
+`
+
+func (q *Queries) GetStopTimesForTrip(ctx context.Context, tripID string) ([]StopTime, error) {
+	rows, err := q.query(ctx, q.getStopTimesForTripStmt, getStopTimesForTrip, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StopTime
+	for rows.Next() {
+		var i StopTime
+		if err := rows.Scan(
+			&i.TripID,
+			&i.ArrivalTime,
+			&i.DepartureTime,
+			&i.StopID,
+			&i.StopSequence,
+			&i.StopHeadsign,
+			&i.PickupType,
+			&i.DropOffType,
+			&i.ShapeDistTraveled,
+			&i.Timepoint,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopZonesForTrip = `-- name: GetStopZonesForTrip :many
+SELECT
+    st.stop_sequence,
+    st.stop_id,
+    s.zone_id
+FROM
+    stop_times st
+    JOIN stops s ON st.stop_id = s.id
+WHERE
+    st.trip_id = ?
+ORDER BY
+    st.stop_sequence
+`
+
+type GetStopZonesForTripRow struct {
+	StopSequence int64
+	StopID       string
+	ZoneID       sql.NullString
+}
+
+func (q *Queries) GetStopZonesForTrip(ctx context.Context, tripID string) ([]GetStopZonesForTripRow, error) {
+	rows, err := q.query(ctx, q.getStopZonesForTripStmt, getStopZonesForTrip, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStopZonesForTripRow
+	for rows.Next() {
+		var i GetStopZonesForTripRow
+		if err := rows.Scan(&i.StopSequence, &i.StopID, &i.ZoneID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopsByIDs = `-- name: GetStopsByIDs :many
+SELECT
+    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code, parent_station, level_id, source_feed_id
+FROM
+    stops
+WHERE
+    id IN (/*SLICE:stop_ids*/?)
+ORDER BY
+    id
+`
+
+func (q *Queries) GetStopsByIDs(ctx context.Context, stopIds []string) ([]Stop, error) {
+	query := getStopsByIDs
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Stop
+	for rows.Next() {
+		var i Stop
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Desc,
+			&i.Lat,
+			&i.Lon,
+			&i.ZoneID,
+			&i.Url,
+			&i.LocationType,
+			&i.Timezone,
+			&i.WheelchairBoarding,
+			&i.PlatformCode,
+			&i.ParentStation,
+			&i.LevelID,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopsForRoute = `-- name: GetStopsForRoute :many
+SELECT DISTINCT
+    stops.id, stops.code, stops.name, stops."desc", stops.lat, stops.lon, stops.zone_id, stops.url, stops.location_type, stops.timezone, stops.wheelchair_boarding, stops.platform_code, stops.parent_station, stops.level_id, stops.source_feed_id
+FROM
+    stop_times
+    JOIN trips ON stop_times.trip_id = trips.id
+    JOIN routes ON trips.route_id = routes.id
+    JOIN stops ON stop_times.stop_id = stops.id
+WHERE
+    routes.id = ?
+`
+
+func (q *Queries) GetStopsForRoute(ctx context.Context, id string) ([]Stop, error) {
+	rows, err := q.query(ctx, q.getStopsForRouteStmt, getStopsForRoute, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Stop
+	for rows.Next() {
+		var i Stop
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Desc,
+			&i.Lat,
+			&i.Lon,
+			&i.ZoneID,
+			&i.Url,
+			&i.LocationType,
+			&i.Timezone,
+			&i.WheelchairBoarding,
+			&i.PlatformCode,
+			&i.ParentStation,
+			&i.LevelID,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopsWithTripContext = `-- name: GetStopsWithTripContext :many
+SELECT
+    s.id, s.lat, s.lon, s.name, s.code,
+    st.trip_id, st.stop_sequence,
+    t.shape_id
+FROM stops s
+JOIN stop_times st ON s.id = st.stop_id
+JOIN trips t ON st.trip_id = t.id
+WHERE s.id = ?
+`
+
+type GetStopsWithTripContextRow struct {
+	ID           string
+	Lat          float64
+	Lon          float64
+	Name         sql.NullString
+	Code         sql.NullString
+	TripID       string
+	StopSequence int64
+	ShapeID      sql.NullString
+}
+
+func (q *Queries) GetStopsWithTripContext(ctx context.Context, id string) ([]GetStopsWithTripContextRow, error) {
+	rows, err := q.query(ctx, q.getStopsWithTripContextStmt, getStopsWithTripContext, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStopsWithTripContextRow
+	for rows.Next() {
+		var i GetStopsWithTripContextRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Lat,
+			&i.Lon,
+			&i.Name,
+			&i.Code,
+			&i.TripID,
+			&i.StopSequence,
+			&i.ShapeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStopsWithinBounds = `-- name: GetStopsWithinBounds :many
+SELECT
+    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code, parent_station, level_id, source_feed_id
+FROM
+    stops
+WHERE
+    lat >= ? AND lat <= ?
+    AND lon >= ? AND lon <= ?
+`
+
+type GetStopsWithinBoundsParams struct {
+	Lat   float64
+	Lat_2 float64
+	Lon   float64
+	Lon_2 float64
+}
+
+func (q *Queries) GetStopsWithinBounds(ctx context.Context, arg GetStopsWithinBoundsParams) ([]Stop, error) {
+	rows, err := q.query(ctx, q.getStopsWithinBoundsStmt, getStopsWithinBounds,
+		arg.Lat,
+		arg.Lat_2,
+		arg.Lon,
+		arg.Lon_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Stop
+	for rows.Next() {
+		var i Stop
+		if err := rows.Scan(
+			&i.ID,
+			&i.Code,
+			&i.Name,
+			&i.Desc,
+			&i.Lat,
+			&i.Lon,
+			&i.ZoneID,
+			&i.Url,
+			&i.LocationType,
+			&i.Timezone,
+			&i.WheelchairBoarding,
+			&i.PlatformCode,
+			&i.ParentStation,
+			&i.LevelID,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransfersForStop = `-- name: GetTransfersForStop :many
+SELECT
+    id, from_stop_id, to_stop_id, from_route_id, to_route_id, from_trip_id, to_trip_id, transfer_type, min_transfer_time, source_feed_id
+FROM
+    transfers
+WHERE
+    from_stop_id = ?1
+    OR to_stop_id = ?1
+ORDER BY
+    from_stop_id, to_stop_id, id
+`
 
-type GetShapesGroupedByTripHeadSignRow struct {
-	Lat             float64
-	Lon             float64
-	ShapePtSequence int64
+func (q *Queries) GetTransfersForStop(ctx context.Context, stopID sql.NullString) ([]Transfer, error) {
+	rows, err := q.query(ctx, q.getTransfersForStopStmt, getTransfersForStop, stopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Transfer
+	for rows.Next() {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromStopID,
+			&i.ToStopID,
+			&i.FromRouteID,
+			&i.ToRouteID,
+			&i.FromTripID,
+			&i.ToTripID,
+			&i.TransferType,
+			&i.MinTransferTime,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) GetShapesGroupedByTripHeadSign(ctx context.Context, arg GetShapesGroupedByTripHeadSignParams) ([]GetShapesGroupedByTripHeadSignRow, error) {
-	rows, err := q.query(ctx, q.getShapesGroupedByTripHeadSignStmt, getShapesGroupedByTripHeadSign, arg.RouteID, arg.TripHeadsign)
+const getTransfersFromStops = `-- name: GetTransfersFromStops :many
+SELECT
+    id, from_stop_id, to_stop_id, from_route_id, to_route_id, from_trip_id, to_trip_id, transfer_type, min_transfer_time, source_feed_id
+FROM
+    transfers
+WHERE
+    from_stop_id IN (/*SLICE:stop_ids*/?)
+ORDER BY
+    from_stop_id, to_stop_id, id
+`
+
+func (q *Queries) GetTransfersFromStops(ctx context.Context, stopIds []sql.NullString) ([]Transfer, error) {
+	query := getTransfersFromStops
+	var queryParams []interface{}
+	if len(stopIds) > 0 {
+		for _, v := range stopIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetShapesGroupedByTripHeadSignRow
+	var items []Transfer
 	for rows.Next() {
-		var i GetShapesGroupedByTripHeadSignRow
-		if err := rows.Scan(&i.Lat, &i.Lon, &i.ShapePtSequence); err != nil {
+		var i Transfer
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromStopID,
+			&i.ToStopID,
+			&i.FromRouteID,
+			&i.ToRouteID,
+			&i.FromTripID,
+			&i.ToTripID,
+			&i.TransferType,
+			&i.MinTransferTime,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -1614,55 +4089,83 @@ func (q *Queries) GetShapesGroupedByTripHeadSign(ctx context.Context, arg GetSha
 	return items, nil
 }
 
-const getStop = `-- name: GetStop :one
+const getTrip = `-- name: GetTrip :one
 SELECT
-    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code
+    id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
 FROM
-    stops
+    trips
 WHERE
     id = ?
 `
 
-func (q *Queries) GetStop(ctx context.Context, id string) (Stop, error) {
-	row := q.queryRow(ctx, q.getStopStmt, getStop, id)
-	var i Stop
+func (q *Queries) GetTrip(ctx context.Context, id string) (Trip, error) {
+	row := q.queryRow(ctx, q.getTripStmt, getTrip, id)
+	var i Trip
 	err := row.Scan(
 		&i.ID,
-		&i.Code,
-		&i.Name,
-		&i.Desc,
-		&i.Lat,
-		&i.Lon,
-		&i.ZoneID,
-		&i.Url,
-		&i.LocationType,
-		&i.Timezone,
-		&i.WheelchairBoarding,
-		&i.PlatformCode,
+		&i.RouteID,
+		&i.ServiceID,
+		&i.TripHeadsign,
+		&i.TripShortName,
+		&i.DirectionID,
+		&i.BlockID,
+		&i.ShapeID,
+		&i.WheelchairAccessible,
+		&i.BikesAllowed,
+		&i.SourceFeedID,
 	)
 	return i, err
 }
 
-const getStopIDsForAgency = `-- name: GetStopIDsForAgency :many
+const getTripsByBlockID = `-- name: GetTripsByBlockID :many
 SELECT
-    s.id
+    id,
+    route_id,
+    service_id,
+    trip_headsign,
+    trip_short_name,
+    direction_id,
+    block_id,
+    shape_id
 FROM
-    stops s
+    trips
+WHERE
+    block_id = ?
 `
 
-func (q *Queries) GetStopIDsForAgency(ctx context.Context) ([]string, error) {
-	rows, err := q.query(ctx, q.getStopIDsForAgencyStmt, getStopIDsForAgency)
+type GetTripsByBlockIDRow struct {
+	ID            string
+	RouteID       string
+	ServiceID     string
+	TripHeadsign  sql.NullString
+	TripShortName sql.NullString
+	DirectionID   sql.NullInt64
+	BlockID       sql.NullString
+	ShapeID       sql.NullString
+}
+
+func (q *Queries) GetTripsByBlockID(ctx context.Context, blockID sql.NullString) ([]GetTripsByBlockIDRow, error) {
+	rows, err := q.query(ctx, q.getTripsByBlockIDStmt, getTripsByBlockID, blockID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetTripsByBlockIDRow
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var i GetTripsByBlockIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RouteID,
+			&i.ServiceID,
+			&i.TripHeadsign,
+			&i.TripShortName,
+			&i.DirectionID,
+			&i.BlockID,
+			&i.ShapeID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1673,29 +4176,42 @@ func (q *Queries) GetStopIDsForAgency(ctx context.Context) ([]string, error) {
 	return items, nil
 }
 
-const getStopIDsForRoute = `-- name: GetStopIDsForRoute :many
-SELECT DISTINCT
-    stop_times.stop_id
+const getTripsByBlockIDOrdered = `-- name: GetTripsByBlockIDOrdered :many
+SELECT
+    t.id,
+    t.block_id,
+    MIN(st.departure_time) AS first_departure_time
 FROM
-    stop_times
-        JOIN trips ON stop_times.trip_id = trips.id
+    trips t
+    JOIN stop_times st ON st.trip_id = t.id
 WHERE
-    trips.route_id = ?
+    t.block_id = ?
+GROUP BY
+    t.id,
+    t.block_id
+ORDER BY
+    MIN(st.departure_time)
 `
 
-func (q *Queries) GetStopIDsForRoute(ctx context.Context, routeID string) ([]string, error) {
-	rows, err := q.query(ctx, q.getStopIDsForRouteStmt, getStopIDsForRoute, routeID)
+type GetTripsByBlockIDOrderedRow struct {
+	ID                 string
+	BlockID            sql.NullString
+	FirstDepartureTime interface{}
+}
+
+func (q *Queries) GetTripsByBlockIDOrdered(ctx context.Context, blockID sql.NullString) ([]GetTripsByBlockIDOrderedRow, error) {
+	rows, err := q.query(ctx, q.getTripsByBlockIDOrderedStmt, getTripsByBlockIDOrdered, blockID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []GetTripsByBlockIDOrderedRow
 	for rows.Next() {
-		var stop_id string
-		if err := rows.Scan(&stop_id); err != nil {
+		var i GetTripsByBlockIDOrderedRow
+		if err := rows.Scan(&i.ID, &i.BlockID, &i.FirstDepartureTime); err != nil {
 			return nil, err
 		}
-		items = append(items, stop_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1706,28 +4222,106 @@ func (q *Queries) GetStopIDsForRoute(ctx context.Context, routeID string) ([]str
 	return items, nil
 }
 
-const getStopIDsForTrip = `-- name: GetStopIDsForTrip :many
-SELECT DISTINCT
-    stop_times.stop_id
-FROM
-    stop_times
-WHERE
-    stop_times.trip_id = ?
+const getTripsByServiceID = `-- name: GetTripsByServiceID :many
+SELECT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
+FROM trips
+WHERE service_id IN (/*SLICE:service_ids*/?)
 `
 
-func (q *Queries) GetStopIDsForTrip(ctx context.Context, tripID string) ([]string, error) {
-	rows, err := q.query(ctx, q.getStopIDsForTripStmt, getStopIDsForTrip, tripID)
+func (q *Queries) GetTripsByServiceID(ctx context.Context, serviceIds []string) ([]Trip, error) {
+	query := getTripsByServiceID
+	var queryParams []interface{}
+	if len(serviceIds) > 0 {
+		for _, v := range serviceIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:service_ids*/?", strings.Repeat(",?", len(serviceIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:service_ids*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []string
+	var items []Trip
 	for rows.Next() {
-		var stop_id string
-		if err := rows.Scan(&stop_id); err != nil {
+		var i Trip
+		if err := rows.Scan(
+			&i.ID,
+			&i.RouteID,
+			&i.ServiceID,
+			&i.TripHeadsign,
+			&i.TripShortName,
+			&i.DirectionID,
+			&i.BlockID,
+			&i.ShapeID,
+			&i.WheelchairAccessible,
+			&i.BikesAllowed,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTripsForRouteInActiveServiceIDs = `-- name: GetTripsForRouteInActiveServiceIDs :many
+SELECT DISTINCT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
+FROM trips t
+WHERE t.route_id = ?1
+  AND t.service_id IN (/*SLICE:('service_ids')*/?)
+ORDER BY t.direction_id, t.trip_headsign
+`
+
+type GetTripsForRouteInActiveServiceIDsParams struct {
+	RouteID    string
+	ServiceIds []string
+}
+
+func (q *Queries) GetTripsForRouteInActiveServiceIDs(ctx context.Context, arg GetTripsForRouteInActiveServiceIDsParams) ([]Trip, error) {
+	query := getTripsForRouteInActiveServiceIDs
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.RouteID)
+	if len(arg.ServiceIds) > 0 {
+		for _, v := range arg.ServiceIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:('service_ids')*/?", strings.Repeat(",?", len(arg.ServiceIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:('service_ids')*/?", "NULL", 1)
+	}
+	rows, err := q.query(ctx, nil, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trip
+	for rows.Next() {
+		var i Trip
+		if err := rows.Scan(
+			&i.ID,
+			&i.RouteID,
+			&i.ServiceID,
+			&i.TripHeadsign,
+			&i.TripShortName,
+			&i.DirectionID,
+			&i.BlockID,
+			&i.ShapeID,
+			&i.WheelchairAccessible,
+			&i.BikesAllowed,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
-		items = append(items, stop_id)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1738,45 +4332,34 @@ func (q *Queries) GetStopIDsForTrip(ctx context.Context, tripID string) ([]strin
 	return items, nil
 }
 
-const getStopTimesByStopIDs = `-- name: GetStopTimesByStopIDs :many
+const listAgencies = `-- name: ListAgencies :many
 SELECT
-    trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint
+    id, name, url, timezone, lang, phone, fare_url, email, source_feed_id
 FROM
-    stop_times
-WHERE
-    stop_id IN (/*SLICE:stop_ids*/?)
+    agencies
+ORDER BY
+    id
 `
 
-func (q *Queries) GetStopTimesByStopIDs(ctx context.Context, stopIds []string) ([]StopTime, error) {
-	query := getStopTimesByStopIDs
-	var queryParams []interface{}
-	if len(stopIds) > 0 {
-		for _, v := range stopIds {
-			queryParams = append(queryParams, v)
-		}
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
-	} else {
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
-	}
-	rows, err := q.query(ctx, nil, query, queryParams...)
+func (q *Queries) ListAgencies(ctx context.Context) ([]Agency, error) {
+	rows, err := q.query(ctx, q.listAgenciesStmt, listAgencies)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []StopTime
+	var items []Agency
 	for rows.Next() {
-		var i StopTime
+		var i Agency
 		if err := rows.Scan(
-			&i.TripID,
-			&i.ArrivalTime,
-			&i.DepartureTime,
-			&i.StopID,
-			&i.StopSequence,
-			&i.StopHeadsign,
-			&i.PickupType,
-			&i.DropOffType,
-			&i.ShapeDistTraveled,
-			&i.Timepoint,
+			&i.ID,
+			&i.Name,
+			&i.Url,
+			&i.Timezone,
+			&i.Lang,
+			&i.Phone,
+			&i.FareUrl,
+			&i.Email,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -1791,37 +4374,37 @@ func (q *Queries) GetStopTimesByStopIDs(ctx context.Context, stopIds []string) (
 	return items, nil
 }
 
-const getStopTimesForTrip = `-- name: GetStopTimesForTrip :many
+const listArrivalAlarms = `-- name: ListArrivalAlarms :many
 SELECT
-    trip_id, arrival_time, departure_time, stop_id, stop_sequence, stop_headsign, pickup_type, drop_off_type, shape_dist_traveled, timepoint
+    id, agency_id, stop_id, trip_id, service_date, stop_sequence, vehicle_id, alarm_time_offset, on_arrival, callback_url, created_at, client_id
 FROM
-    stop_times
-WHERE
-    trip_id = ?
+    arrival_alarms
 ORDER BY
-    stop_sequence
+    created_at, id
 `
 
-func (q *Queries) GetStopTimesForTrip(ctx context.Context, tripID string) ([]StopTime, error) {
-	rows, err := q.query(ctx, q.getStopTimesForTripStmt, getStopTimesForTrip, tripID)
+func (q *Queries) ListArrivalAlarms(ctx context.Context) ([]ArrivalAlarm, error) {
+	rows, err := q.query(ctx, q.listArrivalAlarmsStmt, listArrivalAlarms)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []StopTime
+	var items []ArrivalAlarm
 	for rows.Next() {
-		var i StopTime
+		var i ArrivalAlarm
 		if err := rows.Scan(
-			&i.TripID,
-			&i.ArrivalTime,
-			&i.DepartureTime,
+			&i.ID,
+			&i.AgencyID,
 			&i.StopID,
+			&i.TripID,
+			&i.ServiceDate,
 			&i.StopSequence,
-			&i.StopHeadsign,
-			&i.PickupType,
-			&i.DropOffType,
-			&i.ShapeDistTraveled,
-			&i.Timepoint,
+			&i.VehicleID,
+			&i.AlarmTimeOffset,
+			&i.OnArrival,
+			&i.CallbackUrl,
+			&i.CreatedAt,
+			&i.ClientID,
 		); err != nil {
 			return nil, err
 		}
@@ -1836,49 +4419,30 @@ func (q *Queries) GetStopTimesForTrip(ctx context.Context, tripID string) ([]Sto
 	return items, nil
 }
 
-const getStopsByIDs = `-- name: GetStopsByIDs :many
+const listCalendarDates = `-- name: ListCalendarDates :many
 SELECT
-    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code
+    service_id, date, exception_type, source_feed_id
 FROM
-    stops
-WHERE
-    id IN (/*SLICE:stop_ids*/?)
+    calendar_dates
 ORDER BY
-    id
+    service_id,
+    date
 `
 
-func (q *Queries) GetStopsByIDs(ctx context.Context, stopIds []string) ([]Stop, error) {
-	query := getStopsByIDs
-	var queryParams []interface{}
-	if len(stopIds) > 0 {
-		for _, v := range stopIds {
-			queryParams = append(queryParams, v)
-		}
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", strings.Repeat(",?", len(stopIds))[1:], 1)
-	} else {
-		query = strings.Replace(query, "/*SLICE:stop_ids*/?", "NULL", 1)
-	}
-	rows, err := q.query(ctx, nil, query, queryParams...)
+func (q *Queries) ListCalendarDates(ctx context.Context) ([]CalendarDate, error) {
+	rows, err := q.query(ctx, q.listCalendarDatesStmt, listCalendarDates)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Stop
+	var items []CalendarDate
 	for rows.Next() {
-		var i Stop
+		var i CalendarDate
 		if err := rows.Scan(
-			&i.ID,
-			&i.Code,
-			&i.Name,
-			&i.Desc,
-			&i.Lat,
-			&i.Lon,
-			&i.ZoneID,
-			&i.Url,
-			&i.LocationType,
-			&i.Timezone,
-			&i.WheelchairBoarding,
-			&i.PlatformCode,
+			&i.ServiceID,
+			&i.Date,
+			&i.ExceptionType,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -1893,40 +4457,36 @@ func (q *Queries) GetStopsByIDs(ctx context.Context, stopIds []string) ([]Stop,
 	return items, nil
 }
 
-const getStopsForRoute = `-- name: GetStopsForRoute :many
-SELECT DISTINCT
-    stops.id, stops.code, stops.name, stops."desc", stops.lat, stops.lon, stops.zone_id, stops.url, stops.location_type, stops.timezone, stops.wheelchair_boarding, stops.platform_code
+const listCalendars = `-- name: ListCalendars :many
+SELECT
+    id, monday, tuesday, wednesday, thursday, friday, saturday, sunday, start_date, end_date, source_feed_id
 FROM
-    stop_times
-    JOIN trips ON stop_times.trip_id = trips.id
-    JOIN routes ON trips.route_id = routes.id
-    JOIN stops ON stop_times.stop_id = stops.id
-WHERE
-    routes.id = ?
+    calendar
+ORDER BY
+    id
 `
 
-func (q *Queries) GetStopsForRoute(ctx context.Context, id string) ([]Stop, error) {
-	rows, err := q.query(ctx, q.getStopsForRouteStmt, getStopsForRoute, id)
+func (q *Queries) ListCalendars(ctx context.Context) ([]Calendar, error) {
+	rows, err := q.query(ctx, q.listCalendarsStmt, listCalendars)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Stop
+	var items []Calendar
 	for rows.Next() {
-		var i Stop
+		var i Calendar
 		if err := rows.Scan(
 			&i.ID,
-			&i.Code,
-			&i.Name,
-			&i.Desc,
-			&i.Lat,
-			&i.Lon,
-			&i.ZoneID,
-			&i.Url,
-			&i.LocationType,
-			&i.Timezone,
-			&i.WheelchairBoarding,
-			&i.PlatformCode,
+			&i.Monday,
+			&i.Tuesday,
+			&i.Wednesday,
+			&i.Thursday,
+			&i.Friday,
+			&i.Saturday,
+			&i.Sunday,
+			&i.StartDate,
+			&i.EndDate,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -1941,46 +4501,33 @@ func (q *Queries) GetStopsForRoute(ctx context.Context, id string) ([]Stop, erro
 	return items, nil
 }
 
-const getStopsWithTripContext = `-- name: GetStopsWithTripContext :many
+const listFareAttributes = `-- name: ListFareAttributes :many
 SELECT
-    s.id, s.lat, s.lon, s.name, s.code,
-    st.trip_id, st.stop_sequence,
-    t.shape_id
-FROM stops s
-JOIN stop_times st ON s.id = st.stop_id
-JOIN trips t ON st.trip_id = t.id
-WHERE s.id = ?
+    fare_id, price, currency_type, payment_method, transfers, agency_id, transfer_duration, source_feed_id
+FROM
+    fare_attributes
+ORDER BY
+    price, fare_id
 `
 
-type GetStopsWithTripContextRow struct {
-	ID           string
-	Lat          float64
-	Lon          float64
-	Name         sql.NullString
-	Code         sql.NullString
-	TripID       string
-	StopSequence int64
-	ShapeID      sql.NullString
-}
-
-func (q *Queries) GetStopsWithTripContext(ctx context.Context, id string) ([]GetStopsWithTripContextRow, error) {
-	rows, err := q.query(ctx, q.getStopsWithTripContextStmt, getStopsWithTripContext, id)
+func (q *Queries) ListFareAttributes(ctx context.Context) ([]FareAttribute, error) {
+	rows, err := q.query(ctx, q.listFareAttributesStmt, listFareAttributes)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetStopsWithTripContextRow
+	var items []FareAttribute
 	for rows.Next() {
-		var i GetStopsWithTripContextRow
+		var i FareAttribute
 		if err := rows.Scan(
-			&i.ID,
-			&i.Lat,
-			&i.Lon,
-			&i.Name,
-			&i.Code,
-			&i.TripID,
-			&i.StopSequence,
-			&i.ShapeID,
+			&i.FareID,
+			&i.Price,
+			&i.CurrencyType,
+			&i.PaymentMethod,
+			&i.Transfers,
+			&i.AgencyID,
+			&i.TransferDuration,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -1995,50 +4542,35 @@ func (q *Queries) GetStopsWithTripContext(ctx context.Context, id string) ([]Get
 	return items, nil
 }
 
-const getStopsWithinBounds = `-- name: GetStopsWithinBounds :many
+const listFareLegRules = `-- name: ListFareLegRules :many
 SELECT
-    id, code, name, "desc", lat, lon, zone_id, url, location_type, timezone, wheelchair_boarding, platform_code
+    id, leg_group_id, network_id, from_area_id, to_area_id, from_timeframe_group_id, to_timeframe_group_id, fare_product_id, rule_priority, source_feed_id
 FROM
-    stops
-WHERE
-    lat >= ? AND lat <= ?
-    AND lon >= ? AND lon <= ?
+    fare_leg_rules
+ORDER BY
+    rule_priority DESC, id
 `
 
-type GetStopsWithinBoundsParams struct {
-	Lat   float64
-	Lat_2 float64
-	Lon   float64
-	Lon_2 float64
-}
-
-func (q *Queries) GetStopsWithinBounds(ctx context.Context, arg GetStopsWithinBoundsParams) ([]Stop, error) {
-	rows, err := q.query(ctx, q.getStopsWithinBoundsStmt, getStopsWithinBounds,
-		arg.Lat,
-		arg.Lat_2,
-		arg.Lon,
-		arg.Lon_2,
-	)
+func (q *Queries) ListFareLegRules(ctx context.Context) ([]FareLegRule, error) {
+	rows, err := q.query(ctx, q.listFareLegRulesStmt, listFareLegRules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Stop
+	var items []FareLegRule
 	for rows.Next() {
-		var i Stop
+		var i FareLegRule
 		if err := rows.Scan(
 			&i.ID,
-			&i.Code,
-			&i.Name,
-			&i.Desc,
-			&i.Lat,
-			&i.Lon,
-			&i.ZoneID,
-			&i.Url,
-			&i.LocationType,
-			&i.Timezone,
-			&i.WheelchairBoarding,
-			&i.PlatformCode,
+			&i.LegGroupID,
+			&i.NetworkID,
+			&i.FromAreaID,
+			&i.ToAreaID,
+			&i.FromTimeframeGroupID,
+			&i.ToTimeframeGroupID,
+			&i.FareProductID,
+			&i.RulePriority,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -2050,81 +4582,34 @@ func (q *Queries) GetStopsWithinBounds(ctx context.Context, arg GetStopsWithinBo
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return items, nil
-}
-
-const getTrip = `-- name: GetTrip :one
-SELECT
-    id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
-FROM
-    trips
-WHERE
-    id = ?
-`
-
-func (q *Queries) GetTrip(ctx context.Context, id string) (Trip, error) {
-	row := q.queryRow(ctx, q.getTripStmt, getTrip, id)
-	var i Trip
-	err := row.Scan(
-		&i.ID,
-		&i.RouteID,
-		&i.ServiceID,
-		&i.TripHeadsign,
-		&i.TripShortName,
-		&i.DirectionID,
-		&i.BlockID,
-		&i.ShapeID,
-		&i.WheelchairAccessible,
-		&i.BikesAllowed,
-	)
-	return i, err
-}
-
-const getTripsByBlockID = `-- name: GetTripsByBlockID :many
-SELECT
-    id,
-    route_id,
-    service_id,
-    trip_headsign,
-    trip_short_name,
-    direction_id,
-    block_id,
-    shape_id
-FROM
-    trips
-WHERE
-    block_id = ?
-`
-
-type GetTripsByBlockIDRow struct {
-	ID            string
-	RouteID       string
-	ServiceID     string
-	TripHeadsign  sql.NullString
-	TripShortName sql.NullString
-	DirectionID   sql.NullInt64
-	BlockID       sql.NullString
-	ShapeID       sql.NullString
+	return items, nil
 }
 
-func (q *Queries) GetTripsByBlockID(ctx context.Context, blockID sql.NullString) ([]GetTripsByBlockIDRow, error) {
-	rows, err := q.query(ctx, q.getTripsByBlockIDStmt, getTripsByBlockID, blockID)
+const listFareProducts = `-- name: ListFareProducts :many
+SELECT
+    fare_product_id, fare_product_name, fare_media_id, amount, currency, source_feed_id
+FROM
+    fare_products
+ORDER BY
+    fare_product_id, amount
+`
+
+func (q *Queries) ListFareProducts(ctx context.Context) ([]FareProduct, error) {
+	rows, err := q.query(ctx, q.listFareProductsStmt, listFareProducts)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTripsByBlockIDRow
+	var items []FareProduct
 	for rows.Next() {
-		var i GetTripsByBlockIDRow
+		var i FareProduct
 		if err := rows.Scan(
-			&i.ID,
-			&i.RouteID,
-			&i.ServiceID,
-			&i.TripHeadsign,
-			&i.TripShortName,
-			&i.DirectionID,
-			&i.BlockID,
-			&i.ShapeID,
+			&i.FareProductID,
+			&i.FareProductName,
+			&i.FareMediaID,
+			&i.Amount,
+			&i.Currency,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -2139,39 +4624,33 @@ func (q *Queries) GetTripsByBlockID(ctx context.Context, blockID sql.NullString)
 	return items, nil
 }
 
-const getTripsByBlockIDOrdered = `-- name: GetTripsByBlockIDOrdered :many
+const listFareRules = `-- name: ListFareRules :many
 SELECT
-    t.id,
-    t.block_id,
-    MIN(st.departure_time) AS first_departure_time
+    id, fare_id, route_id, origin_id, destination_id, contains_id, source_feed_id
 FROM
-    trips t
-    JOIN stop_times st ON st.trip_id = t.id
-WHERE
-    t.block_id = ?
-GROUP BY
-    t.id,
-    t.block_id
+    fare_rules
 ORDER BY
-    MIN(st.departure_time)
+    fare_id, id
 `
 
-type GetTripsByBlockIDOrderedRow struct {
-	ID                 string
-	BlockID            sql.NullString
-	FirstDepartureTime interface{}
-}
-
-func (q *Queries) GetTripsByBlockIDOrdered(ctx context.Context, blockID sql.NullString) ([]GetTripsByBlockIDOrderedRow, error) {
-	rows, err := q.query(ctx, q.getTripsByBlockIDOrderedStmt, getTripsByBlockIDOrdered, blockID)
+func (q *Queries) ListFareRules(ctx context.Context) ([]FareRule, error) {
+	rows, err := q.query(ctx, q.listFareRulesStmt, listFareRules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTripsByBlockIDOrderedRow
+	var items []FareRule
 	for rows.Next() {
-		var i GetTripsByBlockIDOrderedRow
-		if err := rows.Scan(&i.ID, &i.BlockID, &i.FirstDepartureTime); err != nil {
+		var i FareRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.FareID,
+			&i.RouteID,
+			&i.OriginID,
+			&i.DestinationID,
+			&i.ContainsID,
+			&i.SourceFeedID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -2185,42 +4664,34 @@ func (q *Queries) GetTripsByBlockIDOrdered(ctx context.Context, blockID sql.Null
 	return items, nil
 }
 
-const getTripsByServiceID = `-- name: GetTripsByServiceID :many
-SELECT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
-FROM trips
-WHERE service_id IN (/*SLICE:service_ids*/?)
+const listFareTransferRules = `-- name: ListFareTransferRules :many
+SELECT
+    id, from_leg_group_id, to_leg_group_id, transfer_count, duration_limit, duration_limit_type, fare_transfer_type, fare_product_id, source_feed_id
+FROM
+    fare_transfer_rules
+ORDER BY
+    id
 `
 
-func (q *Queries) GetTripsByServiceID(ctx context.Context, serviceIds []string) ([]Trip, error) {
-	query := getTripsByServiceID
-	var queryParams []interface{}
-	if len(serviceIds) > 0 {
-		for _, v := range serviceIds {
-			queryParams = append(queryParams, v)
-		}
-		query = strings.Replace(query, "/*SLICE:service_ids*/?", strings.Repeat(",?", len(serviceIds))[1:], 1)
-	} else {
-		query = strings.Replace(query, "/*SLICE:service_ids*/?", "NULL", 1)
-	}
-	rows, err := q.query(ctx, nil, query, queryParams...)
+func (q *Queries) ListFareTransferRules(ctx context.Context) ([]FareTransferRule, error) {
+	rows, err := q.query(ctx, q.listFareTransferRulesStmt, listFareTransferRules)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Trip
+	var items []FareTransferRule
 	for rows.Next() {
-		var i Trip
+		var i FareTransferRule
 		if err := rows.Scan(
 			&i.ID,
-			&i.RouteID,
-			&i.ServiceID,
-			&i.TripHeadsign,
-			&i.TripShortName,
-			&i.DirectionID,
-			&i.BlockID,
-			&i.ShapeID,
-			&i.WheelchairAccessible,
-			&i.BikesAllowed,
+			&i.FromLegGroupID,
+			&i.ToLegGroupID,
+			&i.TransferCount,
+			&i.DurationLimit,
+			&i.DurationLimitType,
+			&i.FareTransferType,
+			&i.FareProductID,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -2235,50 +4706,32 @@ func (q *Queries) GetTripsByServiceID(ctx context.Context, serviceIds []string)
 	return items, nil
 }
 
-const getTripsForRouteInActiveServiceIDs = `-- name: GetTripsForRouteInActiveServiceIDs :many
-SELECT DISTINCT id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
-FROM trips t
-WHERE t.route_id = ?1
-  AND t.service_id IN (/*SLICE:('service_ids')*/?)
-ORDER BY t.direction_id, t.trip_headsign
+const listImportMetadata = `-- name: ListImportMetadata :many
+SELECT
+    feed_id, file_hash, import_time, file_source, etag, last_modified, id_namespace
+FROM
+    import_metadata
+ORDER BY
+    feed_id
 `
 
-type GetTripsForRouteInActiveServiceIDsParams struct {
-	RouteID    string
-	ServiceIds []string
-}
-
-func (q *Queries) GetTripsForRouteInActiveServiceIDs(ctx context.Context, arg GetTripsForRouteInActiveServiceIDsParams) ([]Trip, error) {
-	query := getTripsForRouteInActiveServiceIDs
-	var queryParams []interface{}
-	queryParams = append(queryParams, arg.RouteID)
-	if len(arg.ServiceIds) > 0 {
-		for _, v := range arg.ServiceIds {
-			queryParams = append(queryParams, v)
-		}
-		query = strings.Replace(query, "/*SLICE:('service_ids')*/?", strings.Repeat(",?", len(arg.ServiceIds))[1:], 1)
-	} else {
-		query = strings.Replace(query, "/*SLICE:('service_ids')*/?", "NULL", 1)
-	}
-	rows, err := q.query(ctx, nil, query, queryParams...)
+func (q *Queries) ListImportMetadata(ctx context.Context) ([]ImportMetadatum, error) {
+	rows, err := q.query(ctx, q.listImportMetadataStmt, listImportMetadata)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Trip
+	var items []ImportMetadatum
 	for rows.Next() {
-		var i Trip
+		var i ImportMetadatum
 		if err := rows.Scan(
-			&i.ID,
-			&i.RouteID,
-			&i.ServiceID,
-			&i.TripHeadsign,
-			&i.TripShortName,
-			&i.DirectionID,
-			&i.BlockID,
-			&i.ShapeID,
-			&i.WheelchairAccessible,
-			&i.BikesAllowed,
+			&i.FeedID,
+			&i.FileHash,
+			&i.ImportTime,
+			&i.FileSource,
+			&i.Etag,
+			&i.LastModified,
+			&i.IDNamespace,
 		); err != nil {
 			return nil, err
 		}
@@ -2293,33 +4746,77 @@ func (q *Queries) GetTripsForRouteInActiveServiceIDs(ctx context.Context, arg Ge
 	return items, nil
 }
 
-const listAgencies = `-- name: ListAgencies :many
+const listProblemReports = `-- name: ListProblemReports :many
 SELECT
-    id, name, url, timezone, lang, phone, fare_url, email
+    id, report_type, trip_id, stop_id, code, service_date, vehicle_id, user_comment, user_on_vehicle, user_vehicle_number, user_lat, user_lon, user_location_accuracy, status, created_at, resolved_at, resolution_note
 FROM
-    agencies
+    problem_reports
+WHERE
+    (CAST(?1 AS TEXT) IS NULL OR report_type = ?1)
+    AND (CAST(?2 AS TEXT) IS NULL OR status = ?2)
+    AND (CAST(?3 AS TEXT) IS NULL OR code = ?3)
+    AND (CAST(?4 AS TEXT) IS NULL OR trip_id = ?4)
+    AND (CAST(?5 AS TEXT) IS NULL OR stop_id = ?5)
+    AND (CAST(?6 AS INTEGER) IS NULL OR created_at >= ?6)
+    AND (CAST(?7 AS INTEGER) IS NULL OR created_at < ?7)
 ORDER BY
-    id
+    created_at DESC,
+    id DESC
+LIMIT
+    ?9
+OFFSET
+    ?8
 `
 
-func (q *Queries) ListAgencies(ctx context.Context) ([]Agency, error) {
-	rows, err := q.query(ctx, q.listAgenciesStmt, listAgencies)
+type ListProblemReportsParams struct {
+	ReportType    sql.NullString
+	Status        sql.NullString
+	Code          sql.NullString
+	TripID        sql.NullString
+	StopID        sql.NullString
+	CreatedAfter  sql.NullInt64
+	CreatedBefore sql.NullInt64
+	Skip          int64
+	MaxResults    int64
+}
+
+func (q *Queries) ListProblemReports(ctx context.Context, arg ListProblemReportsParams) ([]ProblemReport, error) {
+	rows, err := q.query(ctx, q.listProblemReportsStmt, listProblemReports,
+		arg.ReportType,
+		arg.Status,
+		arg.Code,
+		arg.TripID,
+		arg.StopID,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Skip,
+		arg.MaxResults,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Agency
+	var items []ProblemReport
 	for rows.Next() {
-		var i Agency
+		var i ProblemReport
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Url,
-			&i.Timezone,
-			&i.Lang,
-			&i.Phone,
-			&i.FareUrl,
-			&i.Email,
+			&i.ReportType,
+			&i.TripID,
+			&i.StopID,
+			&i.Code,
+			&i.ServiceDate,
+			&i.VehicleID,
+			&i.UserComment,
+			&i.UserOnVehicle,
+			&i.UserVehicleNumber,
+			&i.UserLat,
+			&i.UserLon,
+			&i.UserLocationAccuracy,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+			&i.ResolutionNote,
 		); err != nil {
 			return nil, err
 		}
@@ -2346,7 +4843,8 @@ SELECT
     color,
     text_color,
     continuous_pickup,
-    continuous_drop_off
+    continuous_drop_off,
+    source_feed_id
 FROM
     routes
 ORDER BY
@@ -2375,6 +4873,88 @@ func (q *Queries) ListRoutes(ctx context.Context) ([]Route, error) {
 			&i.TextColor,
 			&i.ContinuousPickup,
 			&i.ContinuousDropOff,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTimeframes = `-- name: ListTimeframes :many
+SELECT
+    id, timeframe_group_id, start_time, end_time, service_id, source_feed_id
+FROM
+    timeframes
+ORDER BY
+    timeframe_group_id, start_time
+`
+
+func (q *Queries) ListTimeframes(ctx context.Context) ([]Timeframe, error) {
+	rows, err := q.query(ctx, q.listTimeframesStmt, listTimeframes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Timeframe
+	for rows.Next() {
+		var i Timeframe
+		if err := rows.Scan(
+			&i.ID,
+			&i.TimeframeGroupID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.ServiceID,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslations = `-- name: ListTranslations :many
+SELECT
+    id, table_name, field_name, language, translation, record_id, record_sub_id, field_value, source_feed_id
+FROM
+    translations
+ORDER BY
+    id
+`
+
+func (q *Queries) ListTranslations(ctx context.Context) ([]Translation, error) {
+	rows, err := q.query(ctx, q.listTranslationsStmt, listTranslations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Translation
+	for rows.Next() {
+		var i Translation
+		if err := rows.Scan(
+			&i.ID,
+			&i.TableName,
+			&i.FieldName,
+			&i.Language,
+			&i.Translation,
+			&i.RecordID,
+			&i.RecordSubID,
+			&i.FieldValue,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -2391,7 +4971,7 @@ func (q *Queries) ListRoutes(ctx context.Context) ([]Route, error) {
 
 const listTrips = `-- name: ListTrips :many
 SELECT
-    id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed
+    id, route_id, service_id, trip_headsign, trip_short_name, direction_id, block_id, shape_id, wheelchair_accessible, bikes_allowed, source_feed_id
 FROM
     trips
 `
@@ -2416,6 +4996,61 @@ func (q *Queries) ListTrips(ctx context.Context) ([]Trip, error) {
 			&i.ShapeID,
 			&i.WheelchairAccessible,
 			&i.BikesAllowed,
+			&i.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listValidationFindings = `-- name: ListValidationFindings :many
+SELECT
+    id, severity, code, file_name, entity_id, message, source_feed_id
+FROM
+    validation_findings
+WHERE
+    (CAST(?1 AS TEXT) IS NULL OR source_feed_id = ?1)
+    AND (CAST(?2 AS TEXT) IS NULL OR severity = ?2)
+ORDER BY
+    source_feed_id,
+    CASE severity
+        WHEN 'error' THEN 0
+        WHEN 'warning' THEN 1
+        ELSE 2
+    END,
+    id
+`
+
+type ListValidationFindingsParams struct {
+	FeedID   sql.NullString
+	Severity sql.NullString
+}
+
+func (q *Queries) ListValidationFindings(ctx context.Context, arg ListValidationFindingsParams) ([]ValidationFinding, error) {
+	rows, err := q.query(ctx, q.listValidationFindingsStmt, listValidationFindings, arg.FeedID, arg.Severity)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ValidationFinding
+	for rows.Next() {
+		var i ValidationFinding
+		if err := rows.Scan(
+			&i.ID,
+			&i.Severity,
+			&i.Code,
+			&i.FileName,
+			&i.EntityID,
+			&i.Message,
+			&i.SourceFeedID,
 		); err != nil {
 			return nil, err
 		}
@@ -2430,32 +5065,106 @@ func (q *Queries) ListTrips(ctx context.Context) ([]Trip, error) {
 	return items, nil
 }
 
+const rebuildRoutesSearchIndex = `-- name: RebuildRoutesSearchIndex :exec
+INSERT INTO routes_fts (routes_fts) VALUES ('rebuild')
+`
+
+func (q *Queries) RebuildRoutesSearchIndex(ctx context.Context) error {
+	_, err := q.exec(ctx, q.rebuildRoutesSearchIndexStmt, rebuildRoutesSearchIndex)
+	return err
+}
+
+const rebuildStopsSearchIndex = `-- name: RebuildStopsSearchIndex :exec
+INSERT INTO stops_fts (stops_fts) VALUES ('rebuild')
+`
+
+func (q *Queries) RebuildStopsSearchIndex(ctx context.Context) error {
+	_, err := q.exec(ctx, q.rebuildStopsSearchIndexStmt, rebuildStopsSearchIndex)
+	return err
+}
+
+const resolveProblemReport = `-- name: ResolveProblemReport :execrows
+UPDATE problem_reports
+SET
+    status = 'resolved',
+    resolved_at = ?,
+    resolution_note = ?
+WHERE
+    id = ?
+`
+
+type ResolveProblemReportParams struct {
+	ResolvedAt     sql.NullInt64
+	ResolutionNote sql.NullString
+	ID             int64
+}
+
+func (q *Queries) ResolveProblemReport(ctx context.Context, arg ResolveProblemReportParams) (int64, error) {
+	result, err := q.exec(ctx, q.resolveProblemReportStmt, resolveProblemReport, arg.ResolvedAt, arg.ResolutionNote, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateImportMetadataValidators = `-- name: UpdateImportMetadataValidators :exec
+UPDATE import_metadata
+SET
+    etag = ?,
+    last_modified = ?
+WHERE
+    feed_id = ?
+`
+
+type UpdateImportMetadataValidatorsParams struct {
+	Etag         sql.NullString
+	LastModified sql.NullString
+	FeedID       string
+}
+
+func (q *Queries) UpdateImportMetadataValidators(ctx context.Context, arg UpdateImportMetadataValidatorsParams) error {
+	_, err := q.exec(ctx, q.updateImportMetadataValidatorsStmt, updateImportMetadataValidators, arg.Etag, arg.LastModified, arg.FeedID)
+	return err
+}
+
 const upsertImportMetadata = `-- name: UpsertImportMetadata :one
 INSERT
 OR REPLACE INTO import_metadata (
-    id,
+    feed_id,
     file_hash,
     import_time,
-    file_source
+    file_source,
+    id_namespace
 )
 VALUES
-    (1, ?, ?, ?) RETURNING id, file_hash, import_time, file_source
+    (?, ?, ?, ?, ?) RETURNING feed_id, file_hash, import_time, file_source, etag, last_modified, id_namespace
 `
 
 type UpsertImportMetadataParams struct {
-	FileHash   string
-	ImportTime int64
-	FileSource string
+	FeedID      string
+	FileHash    string
+	ImportTime  int64
+	FileSource  string
+	IDNamespace string
 }
 
 func (q *Queries) UpsertImportMetadata(ctx context.Context, arg UpsertImportMetadataParams) (ImportMetadatum, error) {
-	row := q.queryRow(ctx, q.upsertImportMetadataStmt, upsertImportMetadata, arg.FileHash, arg.ImportTime, arg.FileSource)
+	row := q.queryRow(ctx, q.upsertImportMetadataStmt, upsertImportMetadata,
+		arg.FeedID,
+		arg.FileHash,
+		arg.ImportTime,
+		arg.FileSource,
+		arg.IDNamespace,
+	)
 	var i ImportMetadatum
 	err := row.Scan(
-		&i.ID,
+		&i.FeedID,
 		&i.FileHash,
 		&i.ImportTime,
 		&i.FileSource,
+		&i.Etag,
+		&i.LastModified,
+		&i.IDNamespace,
 	)
 	return i, err
 }
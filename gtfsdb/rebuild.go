@@ -0,0 +1,284 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// userTables hold rows written by riders and operators rather than imported from a feed. They
+// are kept in the user database, which is never rebuilt.
+var userTables = []string{
+	"arrival_alarms",
+	"problem_reports",
+}
+
+// requiredFeedTables must hold rows for every feed in a usable database.
+var requiredFeedTables = []string{
+	"agencies",
+	"routes",
+	"stops",
+	"trips",
+	"stop_times",
+}
+
+// rebuildPath returns the path a database is rebuilt at before it replaces the one at dbPath.
+// In-memory databases are rebuilt in memory.
+func rebuildPath(dbPath string) string {
+	if dbPath == ":memory:" {
+		return dbPath
+	}
+	return dbPath + ".rebuild"
+}
+
+// NewRebuildClient creates an empty database beside live, to import feeds into while live keeps
+// serving. It shares live's user database, and replaces live once passed to Promote.
+func NewRebuildClient(live *Client) (*Client, error) {
+	path := rebuildPath(live.config.DBPath)
+	if path != live.config.DBPath {
+		// A rebuild interrupted by a crash leaves its files behind
+		for _, stale := range []string{path, path + "-journal", path + "-wal", path + "-shm"} {
+			if err := os.Remove(stale); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("error removing stale rebuild file: %w", err)
+			}
+		}
+	}
+
+	rebuildConfig := live.config
+	rebuildConfig.DBPath = path
+	return NewClient(rebuildConfig)
+}
+
+// Validate checks that the database is intact and that every feed in feedIDs was imported
+// completely.
+func (c *Client) Validate(ctx context.Context, feedIDs []string) error {
+	var result string
+	if err := c.DB.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("error checking database integrity: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("database integrity check failed: %s", result)
+	}
+
+	for _, feedID := range feedIDs {
+		if _, err := c.Queries.GetImportMetadata(ctx, feedID); err != nil {
+			return fmt.Errorf("feed %s has not been imported: %w", feedID, err)
+		}
+		for _, table := range requiredFeedTables {
+			var found bool
+			query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE source_feed_id = ?)", table)
+			if err := c.DB.QueryRowContext(ctx, query, feedID).Scan(&found); err != nil {
+				return fmt.Errorf("error checking %s of feed %s: %w", table, feedID, err)
+			}
+			if !found {
+				return fmt.Errorf("feed %s has no %s", feedID, table)
+			}
+		}
+	}
+	return nil
+}
+
+// Promote makes a database created by NewRebuildClient replace live. User tables live in a
+// database of their own, so writes to them through either client are kept. It returns the
+// client to use from then on, and live should be closed once requests still using it have
+// finished. c is consumed whether or not it succeeds, and must not be used or closed afterwards;
+// on failure the live database is left in place.
+func (c *Client) Promote(live *Client) (*Client, error) {
+	if c.config.DBPath == live.config.DBPath {
+		// In memory there is no file to move, so the rebuilt database is used as it is
+		return c, nil
+	}
+
+	if err := c.Close(); err != nil {
+		return nil, fmt.Errorf("error closing rebuilt database: %w", err)
+	}
+
+	// The live file is set aside rather than replaced until the rebuilt one has opened in its
+	// place, so that it can be put back
+	previous := previousPath(live.config.DBPath)
+	if err := os.Rename(live.config.DBPath, previous); err != nil {
+		return nil, fmt.Errorf("error setting aside live database: %w", err)
+	}
+	if err := os.Rename(c.config.DBPath, live.config.DBPath); err != nil {
+		return nil, errors.Join(fmt.Errorf("error moving rebuilt database into place: %w", err),
+			restoreLiveDatabase(previous, live.config.DBPath))
+	}
+	promoted, err := NewClient(live.config)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("error opening rebuilt database: %w", err),
+			restoreLiveDatabase(previous, live.config.DBPath))
+	}
+
+	// Requests still using live keep reading the removed file until it is closed
+	if err := os.Remove(previous); err != nil {
+		logging.LogError(slog.Default().With(slog.String("component", "gtfs_rebuild")),
+			"Error removing replaced database", err)
+	}
+	return promoted, nil
+}
+
+// previousPath returns the path the live database at dbPath is set aside at while a rebuilt one
+// is promoted.
+func previousPath(dbPath string) string {
+	return dbPath + ".previous"
+}
+
+// restoreLiveDatabase puts the live database set aside at previous back at dbPath, after the
+// rebuilt database failed to replace it.
+func restoreLiveDatabase(previous, dbPath string) error {
+	if err := os.Rename(previous, dbPath); err != nil {
+		return fmt.Errorf("error restoring live database: %w", err)
+	}
+	return nil
+}
+
+// CopyFeedFrom copies the rows and import state of a feed from the database of another client,
+// such as the live database into a rebuild, so that a feed that hasn't changed is kept without
+// being downloaded and imported again.
+func (c *Client) CopyFeedFrom(ctx context.Context, from *Client, feedID string) error {
+	c.importMutex.Lock()
+	defer c.importMutex.Unlock()
+
+	if _, err := from.Queries.GetImportMetadata(ctx, feedID); err != nil {
+		return fmt.Errorf("feed %s has not been imported: %w", feedID, err)
+	}
+
+	tx, err := c.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"copy_feed_"+feedID)
+
+	// Tables are filled in the reverse of the order they are cleared in
+	for i := len(feedTables) - 1; i >= 0; i-- {
+		if err := copyRows(ctx, from.DB, tx, feedTables[i], "source_feed_id = ?", feedID); err != nil {
+			return fmt.Errorf("error copying %s: %w", feedTables[i], err)
+		}
+	}
+	if err := copyRows(ctx, from.DB, tx, "import_metadata", "feed_id = ?", feedID); err != nil {
+		return fmt.Errorf("error copying import metadata: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return c.rebuildSearchIndexes(ctx)
+}
+
+// copyRows inserts the rows of table matching where in the database from through tx. A column
+// aliasing the rowid is left out, so that rows are numbered anew alongside those already there.
+func copyRows(ctx context.Context, from *sql.DB, tx *sql.Tx, table, where string, args ...interface{}) error {
+	columns, err := copiedColumns(ctx, tx, table)
+	if err != nil {
+		return err
+	}
+
+	rows, err := from.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s WHERE %s",
+		strings.Join(columns, ", "), table, where), args...)
+	if err != nil {
+		return err
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"database_rows")
+
+	return insertRows(ctx, tx, table, rows)
+}
+
+// copiedColumns returns the columns of table other than an INTEGER PRIMARY KEY, which aliases
+// the rowid.
+func copiedColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name, type, pk FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"database_rows")
+
+	var columns []string
+	for rows.Next() {
+		var name, columnType string
+		var pk int
+		if err := rows.Scan(&name, &columnType, &pk); err != nil {
+			return nil, err
+		}
+		if pk == 1 && strings.EqualFold(columnType, "INTEGER") {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s has no columns", table)
+	}
+	return columns, nil
+}
+
+// copyTable replaces the rows of table in the database to with those read through from.
+func copyTable(ctx context.Context, from *sql.Conn, to *sql.DB, table string) error {
+	rows, err := from.QueryContext(ctx, fmt.Sprintf("SELECT * FROM %s", table))
+	if err != nil {
+		return err
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"database_rows")
+
+	tx, err := to.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"copy_"+table)
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s", table)); err != nil {
+		return err
+	}
+	if err := insertRows(ctx, tx, table, rows); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertRows inserts every row read from rows into the columns of table of the same names.
+func insertRows(ctx context.Context, tx *sql.Tx, table string, rows *sql.Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	insert, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		table, strings.Join(columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")))
+	if err != nil {
+		return err
+	}
+	defer logging.SafeCloseWithLogging(insert,
+		slog.Default().With(slog.String("component", "gtfs_rebuild")),
+		"insert_statement")
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		if _, err := insert.ExecContext(ctx, values...); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
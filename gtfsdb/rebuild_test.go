@@ -0,0 +1,270 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestRebuildAndPromote(t *testing.T) {
+	ctx := context.Background()
+	config := NewConfig(filepath.Join(t.TempDir(), "gtfs.db"), appconf.Development, false)
+
+	live, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = live.Close() }()
+	require.NoError(t, live.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+
+	report, err := live.UserQueries.CreateProblemReport(ctx, CreateProblemReportParams{
+		ReportType: "stop",
+		StopID:     sql.NullString{String: "SH_A", Valid: true},
+		Code:       "stop_name_wrong",
+		CreatedAt:  1735689600000,
+	})
+	require.NoError(t, err)
+
+	next, err := NewRebuildClient(live)
+	require.NoError(t, err)
+	assert.Equal(t, config.DBPath+".rebuild", next.config.DBPath)
+	require.NoError(t, next.ImportFeedFromFile(ctx, "raba", getTestFixturePath(t, "raba.zip")))
+
+	assert.Error(t, next.Validate(ctx, []string{"raba", "shuttle"}), "shuttle was not imported")
+	require.NoError(t, next.Validate(ctx, []string{"raba"}))
+
+	promoted, err := next.Promote(live)
+	require.NoError(t, err)
+	defer func() { _ = promoted.Close() }()
+	assert.Equal(t, config.DBPath, promoted.config.DBPath)
+
+	_, err = os.Stat(config.DBPath + ".rebuild")
+	assert.True(t, os.IsNotExist(err), "the rebuilt file is moved into place")
+	_, err = os.Stat(config.DBPath + ".previous")
+	assert.True(t, os.IsNotExist(err), "the replaced file is removed")
+
+	agencies, err := promoted.Queries.ListAgencies(ctx)
+	require.NoError(t, err)
+	require.Len(t, agencies, 1)
+	assert.Equal(t, "25", agencies[0].ID)
+
+	reports, err := promoted.UserQueries.ListProblemReports(ctx, ListProblemReportsParams{MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, report, reports[0])
+
+	// Requests already using the replaced client keep reading the old data
+	stop, err := live.Queries.GetStop(ctx, ScopedID("shuttle", "SH_A"))
+	require.NoError(t, err)
+	assert.Equal(t, "Shuttle Stop A", stop.Name.String)
+
+	reopened, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+	_, err = reopened.Queries.GetStop(ctx, ScopedID("raba", "1001"))
+	assert.NoError(t, err, "the rebuilt database is kept across restarts")
+}
+
+func TestPromoteFailureKeepsTheLiveDatabase(t *testing.T) {
+	ctx := context.Background()
+	config := NewConfig(filepath.Join(t.TempDir(), "gtfs.db"), appconf.Development, false)
+
+	live, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = live.Close() }()
+	require.NoError(t, live.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+
+	next, err := NewRebuildClient(live)
+	require.NoError(t, err)
+	// The rebuilt file is damaged after validation, so that it fails to open once moved
+	require.NoError(t, os.WriteFile(next.config.DBPath, []byte("not a database"), 0o600))
+
+	_, err = next.Promote(live)
+	require.Error(t, err)
+
+	_, err = os.Stat(config.DBPath + ".previous")
+	assert.True(t, os.IsNotExist(err), "the live database is put back")
+	reopened, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+	_, err = reopened.Queries.GetStop(ctx, ScopedID("shuttle", "SH_A"))
+	assert.NoError(t, err, "the live database is kept across restarts")
+}
+
+func TestNewRebuildClientRemovesStaleFiles(t *testing.T) {
+	config := NewConfig(filepath.Join(t.TempDir(), "gtfs.db"), appconf.Development, false)
+	live, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = live.Close() }()
+	require.NoError(t, os.WriteFile(config.DBPath+".rebuild", []byte("not a database"), 0o600))
+	require.NoError(t, os.WriteFile(config.DBPath+".rebuild-journal", []byte("not a journal"), 0o600))
+
+	next, err := NewRebuildClient(live)
+	require.NoError(t, err)
+	defer func() { _ = next.Close() }()
+
+	_, err = os.Stat(config.DBPath + ".rebuild-journal")
+	assert.True(t, os.IsNotExist(err))
+	agencies, err := next.Queries.ListAgencies(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, agencies)
+}
+
+func TestWritesDuringPromotionAreKept(t *testing.T) {
+	for _, dbPath := range []string{":memory:", filepath.Join(t.TempDir(), "gtfs.db")} {
+		t.Run(filepath.Base(dbPath), func(t *testing.T) {
+			ctx := context.Background()
+			config := NewConfig(dbPath, appconf.Development, false)
+
+			live, err := NewClient(config)
+			require.NoError(t, err)
+			defer func() { _ = live.Close() }()
+			require.NoError(t, live.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+
+			next, err := NewRebuildClient(live)
+			require.NoError(t, err)
+			require.NoError(t, next.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+
+			// Requests keep writing through the live client while it is replaced, and after
+			report := func(code string) {
+				_, err := live.UserQueries.CreateProblemReport(ctx, CreateProblemReportParams{
+					ReportType: "stop",
+					StopID:     sql.NullString{String: "SH_A", Valid: true},
+					Code:       code,
+					CreatedAt:  1735689600000,
+				})
+				assert.NoError(t, err)
+			}
+			done := make(chan struct{})
+			written := make(chan int)
+			go func() {
+				count := 0
+				for {
+					select {
+					case <-done:
+						written <- count
+						return
+					default:
+					}
+					report("stop_name_wrong")
+					count++
+				}
+			}()
+
+			promoted, err := next.Promote(live)
+			require.NoError(t, err)
+			defer func() { _ = promoted.Close() }()
+			close(done)
+			count := <-written
+			report("stop_location_wrong")
+			_, err = live.UserQueries.CreateArrivalAlarm(ctx, CreateArrivalAlarmParams{
+				ID:          "alarm1",
+				AgencyID:    "SHUTTLE",
+				StopID:      "SH_A",
+				TripID:      "LOOP_1",
+				CallbackUrl: "https://example.com/alarm",
+			})
+			require.NoError(t, err)
+
+			reports, err := promoted.UserQueries.ListProblemReports(ctx, ListProblemReportsParams{MaxResults: int64(count + 10)})
+			require.NoError(t, err)
+			assert.Len(t, reports, count+1, "every report written through the replaced client is kept")
+			_, err = promoted.UserQueries.GetArrivalAlarm(ctx, "alarm1")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUserTablesAreMovedOutOfTheGTFSDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "gtfs.db")
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE problem_reports (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		report_type TEXT NOT NULL,
+		trip_id TEXT,
+		stop_id TEXT,
+		code TEXT NOT NULL,
+		service_date INTEGER,
+		vehicle_id TEXT,
+		user_comment TEXT,
+		user_on_vehicle INTEGER,
+		user_vehicle_number TEXT,
+		user_lat REAL,
+		user_lon REAL,
+		user_location_accuracy REAL,
+		status TEXT NOT NULL DEFAULT 'open',
+		created_at INTEGER NOT NULL,
+		resolved_at INTEGER,
+		resolution_note TEXT
+	)`)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO problem_reports (report_type, stop_id, code, created_at) VALUES ('stop', '1001', 'other', 1)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	client, err := NewClient(NewConfig(dbPath, appconf.Development, false))
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	reports, err := client.UserQueries.ListProblemReports(context.Background(), ListProblemReportsParams{MaxResults: 10})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "1001", reports[0].StopID.String)
+
+	var remaining int
+	require.NoError(t, client.DB.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE name = 'problem_reports'").Scan(&remaining))
+	assert.Zero(t, remaining, "the GTFS database no longer holds user tables")
+	_, err = os.Stat(filepath.Join(filepath.Dir(dbPath), "gtfs-user.db"))
+	assert.NoError(t, err)
+}
+
+func TestCopyFeedFrom(t *testing.T) {
+	ctx := context.Background()
+	config := NewConfig(filepath.Join(t.TempDir(), "gtfs.db"), appconf.Development, false)
+
+	live, err := NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = live.Close() }()
+	require.NoError(t, live.ImportFeedFromFile(ctx, "raba", getTestFixturePath(t, "raba.zip")))
+	require.NoError(t, live.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+	liveMetadata, err := live.Queries.GetImportMetadata(ctx, "raba")
+	require.NoError(t, err)
+
+	next, err := NewRebuildClient(live)
+	require.NoError(t, err)
+	defer func() { _ = next.Close() }()
+	// The other feed's rows are numbered first, so copied rows must not keep their numbers
+	require.NoError(t, next.ImportFeedFromFile(ctx, "shuttle", models.BuildShuttleFeed(t)))
+	require.NoError(t, next.CopyFeedFrom(ctx, live, "raba"))
+	require.NoError(t, next.Validate(ctx, []string{"raba", "shuttle"}))
+
+	metadata, err := next.Queries.GetImportMetadata(ctx, "raba")
+	require.NoError(t, err)
+	assert.Equal(t, liveMetadata, metadata)
+
+	for _, table := range feedTables {
+		var liveCount, nextCount int
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE source_feed_id = 'raba'", table)
+		require.NoError(t, live.DB.QueryRowContext(ctx, query).Scan(&liveCount))
+		require.NoError(t, next.DB.QueryRowContext(ctx, query).Scan(&nextCount))
+		assert.Equal(t, liveCount, nextCount, table)
+	}
+
+	stops, err := next.SearchStops(ctx, "Northpoint", 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, stops, "copied stops are searchable")
+	var stopCount, indexedCount int
+	require.NoError(t, next.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM stops").Scan(&stopCount))
+	require.NoError(t, next.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM stops_rtree").Scan(&indexedCount))
+	assert.Equal(t, stopCount, indexedCount, "copied stops are indexed by location")
+
+	assert.Error(t, next.CopyFeedFrom(ctx, live, "missing"), "only imported feeds are copied")
+}
@@ -47,6 +47,12 @@ CREATE TABLE
         platform_code TEXT
     );
 
+-- migrate
+ALTER TABLE stops ADD COLUMN parent_station TEXT;
+
+-- migrate
+ALTER TABLE stops ADD COLUMN level_id TEXT;
+
 -- migrate
 CREATE VIRTUAL TABLE IF NOT EXISTS stops_rtree USING rtree (
     id, -- Integer primary key for the R*Tree
@@ -100,6 +106,35 @@ WHERE
 
 END;
 
+-- migrate
+CREATE VIRTUAL TABLE IF NOT EXISTS stops_fts USING fts5 (
+    id UNINDEXED,
+    code,
+    name,
+    content = 'stops',
+    content_rowid = 'rowid',
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '1 2 3'
+);
+
+-- migrate
+CREATE VIRTUAL TABLE IF NOT EXISTS stops_fts_vocab USING fts5vocab (stops_fts, 'row');
+
+-- migrate
+CREATE VIRTUAL TABLE IF NOT EXISTS routes_fts USING fts5 (
+    id UNINDEXED,
+    short_name,
+    long_name,
+    desc,
+    content = 'routes',
+    content_rowid = 'rowid',
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '1 2 3'
+);
+
+-- migrate
+CREATE VIRTUAL TABLE IF NOT EXISTS routes_fts_vocab USING fts5vocab (routes_fts, 'row');
+
 -- migrate
 CREATE TABLE
     IF NOT EXISTS calendar (
@@ -160,6 +195,34 @@ CREATE TABLE
         PRIMARY KEY (trip_id, stop_sequence)
     );
 
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS frequencies (
+        trip_id TEXT NOT NULL,
+        start_time INTEGER NOT NULL,
+        end_time INTEGER NOT NULL,
+        headway_secs INTEGER NOT NULL,
+        exact_times INTEGER NOT NULL DEFAULT 0,
+        FOREIGN KEY (trip_id) REFERENCES trips (id),
+        PRIMARY KEY (trip_id, start_time)
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS transfers (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        from_stop_id TEXT,
+        to_stop_id TEXT,
+        from_route_id TEXT,
+        to_route_id TEXT,
+        from_trip_id TEXT,
+        to_trip_id TEXT,
+        transfer_type INTEGER NOT NULL DEFAULT 0,
+        min_transfer_time INTEGER,
+        FOREIGN KEY (from_stop_id) REFERENCES stops (id),
+        FOREIGN KEY (to_stop_id) REFERENCES stops (id)
+    );
+
 -- migrate
 CREATE TABLE
     IF NOT EXISTS calendar_dates (
@@ -169,15 +232,258 @@ CREATE TABLE
         PRIMARY KEY (service_id, date)
     );
 
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_attributes (
+        fare_id TEXT PRIMARY KEY,
+        price REAL NOT NULL,
+        currency_type TEXT NOT NULL,
+        payment_method INTEGER NOT NULL,
+        transfers INTEGER, -- NULL means unlimited transfers
+        agency_id TEXT,
+        transfer_duration INTEGER
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_rules (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        fare_id TEXT NOT NULL,
+        route_id TEXT,
+        origin_id TEXT,
+        destination_id TEXT,
+        contains_id TEXT,
+        FOREIGN KEY (fare_id) REFERENCES fare_attributes (fare_id)
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_media (
+        fare_media_id TEXT PRIMARY KEY,
+        fare_media_name TEXT,
+        fare_media_type INTEGER NOT NULL
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_products (
+        fare_product_id TEXT NOT NULL,
+        fare_product_name TEXT,
+        fare_media_id TEXT NOT NULL DEFAULT '',
+        amount REAL NOT NULL,
+        currency TEXT NOT NULL,
+        PRIMARY KEY (fare_product_id, fare_media_id)
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS areas (area_id TEXT PRIMARY KEY, area_name TEXT);
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS stop_areas (
+        area_id TEXT NOT NULL,
+        stop_id TEXT NOT NULL,
+        PRIMARY KEY (area_id, stop_id)
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS networks (network_id TEXT PRIMARY KEY, network_name TEXT);
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS route_networks (
+        route_id TEXT PRIMARY KEY,
+        network_id TEXT NOT NULL
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS timeframes (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        timeframe_group_id TEXT NOT NULL,
+        start_time INTEGER NOT NULL, -- nanoseconds since midnight
+        end_time INTEGER NOT NULL,
+        service_id TEXT NOT NULL
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_leg_rules (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        leg_group_id TEXT,
+        network_id TEXT,
+        from_area_id TEXT,
+        to_area_id TEXT,
+        from_timeframe_group_id TEXT,
+        to_timeframe_group_id TEXT,
+        fare_product_id TEXT NOT NULL,
+        rule_priority INTEGER NOT NULL DEFAULT 0
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS fare_transfer_rules (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        from_leg_group_id TEXT,
+        to_leg_group_id TEXT,
+        transfer_count INTEGER,
+        duration_limit INTEGER,
+        duration_limit_type INTEGER,
+        fare_transfer_type INTEGER NOT NULL,
+        fare_product_id TEXT
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS levels (
+        level_id TEXT PRIMARY KEY,
+        level_index REAL NOT NULL,
+        level_name TEXT
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS pathways (
+        pathway_id TEXT PRIMARY KEY,
+        from_stop_id TEXT NOT NULL,
+        to_stop_id TEXT NOT NULL,
+        pathway_mode INTEGER NOT NULL,
+        is_bidirectional INTEGER NOT NULL,
+        length REAL, -- meters
+        traversal_time INTEGER, -- seconds
+        stair_count INTEGER,
+        max_slope REAL,
+        min_width REAL, -- meters
+        signposted_as TEXT,
+        reversed_signposted_as TEXT,
+        FOREIGN KEY (from_stop_id) REFERENCES stops (id),
+        FOREIGN KEY (to_stop_id) REFERENCES stops (id)
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS translations (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        table_name TEXT NOT NULL,
+        field_name TEXT NOT NULL,
+        language TEXT NOT NULL,
+        translation TEXT NOT NULL,
+        record_id TEXT,
+        record_sub_id TEXT,
+        field_value TEXT
+    );
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS feed_info (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        feed_id TEXT,
+        feed_publisher_name TEXT NOT NULL,
+        feed_publisher_url TEXT NOT NULL,
+        feed_lang TEXT NOT NULL,
+        default_lang TEXT,
+        feed_start_date TEXT,
+        feed_end_date TEXT,
+        feed_version TEXT,
+        feed_contact_email TEXT,
+        feed_contact_url TEXT
+    );
+
 -- migrate
 CREATE TABLE
     IF NOT EXISTS import_metadata (
-        id INTEGER PRIMARY KEY CHECK (id = 1), -- Only allow one row
+        feed_id TEXT PRIMARY KEY,
         file_hash TEXT NOT NULL,
         import_time INTEGER NOT NULL,
         file_source TEXT NOT NULL
     );
 
+-- HTTP cache validators of the last download, for conditional requests
+-- migrate
+ALTER TABLE import_metadata ADD COLUMN etag TEXT;
+
+-- migrate
+ALTER TABLE import_metadata ADD COLUMN last_modified TEXT;
+
+-- Rows of every static table record the import_metadata feed_id of the feed they were imported
+-- from, so that feeds can be reimported independently of each other
+-- migrate
+ALTER TABLE agencies ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE routes ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE stops ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE calendar ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE calendar_dates ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE trips ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE shapes ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE stop_times ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE frequencies ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE transfers ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_attributes ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_rules ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_media ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_products ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE areas ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE stop_areas ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE networks ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE route_networks ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE timeframes ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_leg_rules ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE fare_transfer_rules ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE levels ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE pathways ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE translations ADD COLUMN source_feed_id TEXT;
+
+-- migrate
+ALTER TABLE feed_info ADD COLUMN source_feed_id TEXT;
+
 -- migrate
 CREATE INDEX IF NOT EXISTS idx_routes_agency_id ON routes (agency_id);
 
@@ -196,5 +502,47 @@ CREATE INDEX IF NOT EXISTS idx_stop_times_stop_id ON stop_times (stop_id);
 -- migrate
 CREATE INDEX IF NOT EXISTS idx_stop_times_stop_id_trip_id ON stop_times (stop_id, trip_id);
 
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_fare_rules_fare_id ON fare_rules (fare_id);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_stop_areas_stop_id ON stop_areas (stop_id);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_stops_parent_station ON stops (parent_station);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_pathways_from_stop_id ON pathways (from_stop_id);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_pathways_to_stop_id ON pathways (to_stop_id);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_transfers_from_stop_id ON transfers (from_stop_id);
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_transfers_to_stop_id ON transfers (to_stop_id);
+
 -- migrate
 CREATE INDEX IF NOT EXISTS idx_calendar_dates_service_id ON calendar_dates (service_id);
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS validation_findings (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        severity TEXT NOT NULL, -- 'error', 'warning' or 'info'
+        code TEXT NOT NULL,
+        file_name TEXT,
+        entity_id TEXT,
+        message TEXT NOT NULL,
+        source_feed_id TEXT
+    );
+
+-- Distance along the shape in the feed's own units, matching stop_times.shape_dist_traveled
+-- migrate
+ALTER TABLE shapes ADD COLUMN shape_dist_traveled REAL;
+
+-- The namespace the feed's IDs were imported into, so that a feed imported into a different
+-- namespace is imported again
+-- migrate
+ALTER TABLE import_metadata ADD COLUMN id_namespace TEXT NOT NULL DEFAULT '';
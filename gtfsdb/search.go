@@ -0,0 +1,352 @@
+package gtfsdb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// The FTS5 MATCH and fts5vocab queries below are not understood by sqlc, so they are
+// written by hand. The virtual tables themselves are declared in schema.sql and are
+// rebuilt by rebuildSearchIndexes at the end of every import.
+
+const searchStopsQuery = `
+SELECT s.id, s.code, s.name, s.desc, s.lat, s.lon, s.zone_id, s.url,
+       s.location_type, s.timezone, s.wheelchair_boarding, s.platform_code,
+       s.parent_station, s.source_feed_id
+FROM stops_fts
+JOIN stops s ON s.rowid = stops_fts.rowid
+WHERE stops_fts MATCH ?
+ORDER BY bm25(stops_fts, 0.0, 10.0, 1.0), s.id
+LIMIT ?`
+
+const searchRoutesQuery = `
+SELECT r.id, r.agency_id, r.short_name, r.long_name, r.desc, r.type, r.url,
+       r.color, r.text_color, r.continuous_pickup, r.continuous_drop_off
+FROM routes_fts
+JOIN routes r ON r.rowid = routes_fts.rowid
+WHERE routes_fts MATCH ?
+ORDER BY bm25(routes_fts, 0.0, 10.0, 5.0, 1.0), r.id
+LIMIT ?`
+
+// rebuildSearchIndexes repopulates the full-text search tables from the stops and routes tables.
+func (c *Client) rebuildSearchIndexes(ctx context.Context) error {
+	if err := c.Queries.RebuildStopsSearchIndex(ctx); err != nil {
+		return fmt.Errorf("error rebuilding stop search index: %w", err)
+	}
+	if err := c.Queries.RebuildRoutesSearchIndex(ctx); err != nil {
+		return fmt.Errorf("error rebuilding route search index: %w", err)
+	}
+
+	c.vocabularyMutex.Lock()
+	c.vocabularies = nil
+	c.vocabularyGeneration++
+	c.vocabularyMutex.Unlock()
+	return nil
+}
+
+// SearchStops returns up to limit stops whose name or code matches the input, best matches first.
+// Every input term is treated as a prefix; when the prefix search does not fill the limit,
+// indexed terms within a small edit distance of the input terms are also tried.
+func (c *Client) SearchStops(ctx context.Context, input string, limit int) ([]Stop, error) {
+	terms := tokenizeSearchInput(input)
+	if len(terms) == 0 || limit <= 0 {
+		return []Stop{}, nil
+	}
+
+	results, err := c.searchStopsWithExpression(ctx, buildMatchExpression(terms, nil), limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) >= limit {
+		return results, nil
+	}
+
+	vocabulary, err := c.searchVocabulary(ctx, "stops_fts_vocab")
+	if err != nil {
+		return nil, err
+	}
+	fuzzyExpression := buildMatchExpression(terms, vocabulary)
+	if fuzzyExpression == "" {
+		return results, nil
+	}
+
+	fuzzyResults, err := c.searchStopsWithExpression(ctx, fuzzyExpression, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(results))
+	for _, stop := range results {
+		seen[stop.ID] = true
+	}
+	for _, stop := range fuzzyResults {
+		if len(results) >= limit {
+			break
+		}
+		if !seen[stop.ID] {
+			seen[stop.ID] = true
+			results = append(results, stop)
+		}
+	}
+	return results, nil
+}
+
+// SearchRoutes returns up to limit routes whose short name, long name or description matches the
+// input, best matches first. Matching follows the same rules as SearchStops.
+func (c *Client) SearchRoutes(ctx context.Context, input string, limit int) ([]Route, error) {
+	terms := tokenizeSearchInput(input)
+	if len(terms) == 0 || limit <= 0 {
+		return []Route{}, nil
+	}
+
+	results, err := c.searchRoutesWithExpression(ctx, buildMatchExpression(terms, nil), limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) >= limit {
+		return results, nil
+	}
+
+	vocabulary, err := c.searchVocabulary(ctx, "routes_fts_vocab")
+	if err != nil {
+		return nil, err
+	}
+	fuzzyExpression := buildMatchExpression(terms, vocabulary)
+	if fuzzyExpression == "" {
+		return results, nil
+	}
+
+	fuzzyResults, err := c.searchRoutesWithExpression(ctx, fuzzyExpression, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(results))
+	for _, route := range results {
+		seen[route.ID] = true
+	}
+	for _, route := range fuzzyResults {
+		if len(results) >= limit {
+			break
+		}
+		if !seen[route.ID] {
+			seen[route.ID] = true
+			results = append(results, route)
+		}
+	}
+	return results, nil
+}
+
+func (c *Client) searchStopsWithExpression(ctx context.Context, expression string, limit int) ([]Stop, error) {
+	rows, err := c.DB.QueryContext(ctx, searchStopsQuery, expression, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching stops: %w", err)
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "search")),
+		"database_rows")
+
+	stops := []Stop{}
+	for rows.Next() {
+		var s Stop
+		if err := rows.Scan(
+			&s.ID,
+			&s.Code,
+			&s.Name,
+			&s.Desc,
+			&s.Lat,
+			&s.Lon,
+			&s.ZoneID,
+			&s.Url,
+			&s.LocationType,
+			&s.Timezone,
+			&s.WheelchairBoarding,
+			&s.PlatformCode,
+			&s.ParentStation,
+			&s.SourceFeedID,
+		); err != nil {
+			return nil, err
+		}
+		stops = append(stops, s)
+	}
+	return stops, rows.Err()
+}
+
+func (c *Client) searchRoutesWithExpression(ctx context.Context, expression string, limit int) ([]Route, error) {
+	rows, err := c.DB.QueryContext(ctx, searchRoutesQuery, expression, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error searching routes: %w", err)
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "search")),
+		"database_rows")
+
+	routes := []Route{}
+	for rows.Next() {
+		var r Route
+		if err := rows.Scan(
+			&r.ID,
+			&r.AgencyID,
+			&r.ShortName,
+			&r.LongName,
+			&r.Desc,
+			&r.Type,
+			&r.Url,
+			&r.Color,
+			&r.TextColor,
+			&r.ContinuousPickup,
+			&r.ContinuousDropOff,
+		); err != nil {
+			return nil, err
+		}
+		routes = append(routes, r)
+	}
+	return routes, rows.Err()
+}
+
+// searchVocabulary returns every term in the given fts5vocab table. The terms are read once and
+// cached until the search indexes are rebuilt.
+func (c *Client) searchVocabulary(ctx context.Context, vocabTable string) ([]string, error) {
+	c.vocabularyMutex.Lock()
+	terms, ok := c.vocabularies[vocabTable]
+	generation := c.vocabularyGeneration
+	c.vocabularyMutex.Unlock()
+	if ok {
+		return terms, nil
+	}
+
+	terms, err := c.readSearchVocabulary(ctx, vocabTable)
+	if err != nil {
+		return nil, err
+	}
+
+	c.vocabularyMutex.Lock()
+	defer c.vocabularyMutex.Unlock()
+	// Terms read while an import rebuilt the indexes are used once but not kept
+	if generation == c.vocabularyGeneration {
+		if c.vocabularies == nil {
+			c.vocabularies = make(map[string][]string)
+		}
+		c.vocabularies[vocabTable] = terms
+	}
+	return terms, nil
+}
+
+// readSearchVocabulary reads every term in the given fts5vocab table.
+func (c *Client) readSearchVocabulary(ctx context.Context, vocabTable string) ([]string, error) {
+	rows, err := c.DB.QueryContext(ctx, "SELECT term FROM "+vocabTable)
+	if err != nil {
+		return nil, fmt.Errorf("error reading search vocabulary: %w", err)
+	}
+	defer logging.SafeCloseWithLogging(rows,
+		slog.Default().With(slog.String("component", "search")),
+		"database_rows")
+
+	var terms []string
+	for rows.Next() {
+		var term string
+		if err := rows.Scan(&term); err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, rows.Err()
+}
+
+// tokenizeSearchInput lowercases the input and splits it into letter/digit runs, mirroring the
+// unicode61 tokenizer used by the search tables.
+func tokenizeSearchInput(input string) []string {
+	return strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// buildMatchExpression builds an FTS5 MATCH expression requiring every term. Each term matches as
+// a prefix; when a vocabulary is supplied, indexed terms within maxSearchEdits of the term are
+// accepted as alternatives. An empty string is returned if the vocabulary offers no alternatives.
+func buildMatchExpression(terms []string, vocabulary []string) string {
+	clauses := make([]string, 0, len(terms))
+	hasAlternatives := false
+
+	for _, term := range terms {
+		alternatives := []string{quoteSearchTerm(term) + "*"}
+
+		if vocabulary != nil {
+			maxEdits := maxSearchEdits(term)
+			for _, candidate := range vocabulary {
+				if candidate == term || strings.HasPrefix(candidate, term) {
+					continue
+				}
+				if levenshteinDistance(term, candidate, maxEdits) <= maxEdits {
+					alternatives = append(alternatives, quoteSearchTerm(candidate))
+					hasAlternatives = true
+				}
+			}
+		}
+
+		if len(alternatives) == 1 {
+			clauses = append(clauses, alternatives[0])
+		} else {
+			clauses = append(clauses, "("+strings.Join(alternatives, " OR ")+")")
+		}
+	}
+
+	if vocabulary != nil && !hasAlternatives {
+		return ""
+	}
+	return strings.Join(clauses, " AND ")
+}
+
+// maxSearchEdits is the number of typos tolerated for a term of the given length.
+func maxSearchEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func quoteSearchTerm(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+// levenshteinDistance returns the edit distance between a and b. Once the distance is known to
+// exceed limit, limit+1 is returned early.
+func levenshteinDistance(a, b string, limit int) int {
+	ar, br := []rune(a), []rune(b)
+	if diff := len(ar) - len(br); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		rowMin := current[0]
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			rowMin = min(rowMin, current[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
@@ -0,0 +1,130 @@
+package gtfsdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+)
+
+func newSearchTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := NewClient(Config{
+		DBPath: ":memory:",
+		Env:    appconf.Test,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	err = client.ImportFromFile(context.Background(), getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestSearchStops(t *testing.T) {
+	client := newSearchTestClient(t)
+	ctx := context.Background()
+
+	t.Run("matches name terms", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, "Northpoint Lake", 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, stops)
+		assert.Equal(t, "1001", stops[0].ID)
+	})
+
+	t.Run("matches prefixes", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, "northp", 50)
+		require.NoError(t, err)
+		require.NotEmpty(t, stops)
+		// Prefix matches rank ahead of fuzzy matches such as "north"
+		assert.Contains(t, strings.ToLower(stops[0].Name.String), "northp")
+	})
+
+	t.Run("matches stop code", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, "1002", 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, stops)
+		assert.Equal(t, "1002", stops[0].ID)
+	})
+
+	t.Run("tolerates typos", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, "Nortpoint", 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, stops)
+		assert.Contains(t, stops[0].Name.String, "Northpoint")
+	})
+
+	t.Run("respects limit", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, "Blvd", 3)
+		require.NoError(t, err)
+		assert.Len(t, stops, 3)
+	})
+
+	t.Run("ignores punctuation only input", func(t *testing.T) {
+		stops, err := client.SearchStops(ctx, `" * (`, 10)
+		require.NoError(t, err)
+		assert.Empty(t, stops)
+	})
+}
+
+func TestSearchRoutes(t *testing.T) {
+	client := newSearchTestClient(t)
+	ctx := context.Background()
+
+	routes, err := client.SearchRoutes(ctx, "299X", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, routes)
+	assert.Equal(t, "161", routes[0].ID)
+
+	routes, err = client.SearchRoutes(ctx, "rout", 100)
+	require.NoError(t, err)
+	assert.NotEmpty(t, routes)
+}
+
+func TestSearchIndexRebuiltOnReimport(t *testing.T) {
+	client := newSearchTestClient(t)
+	ctx := context.Background()
+
+	before, err := client.SearchStops(ctx, "Northpoint", 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, before)
+
+	_, modifiedData := createTestData(t)
+	err = client.processAndStoreGTFSDataWithSource(modifiedData, "modified-source")
+	require.NoError(t, err)
+
+	after, err := client.SearchStops(ctx, "Northpoint", 100)
+	require.NoError(t, err)
+	assert.Len(t, after, len(before), "reimport should not leave stale or duplicate index entries")
+}
+
+func TestSearchVocabularyCachedUntilReimport(t *testing.T) {
+	client := newSearchTestClient(t)
+	ctx := context.Background()
+
+	// A typo falls through to the vocabulary
+	results, err := client.SearchStops(ctx, "Nortpoint", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Contains(t, client.vocabularies, "stops_fts_vocab")
+	assert.Contains(t, client.vocabularies["stops_fts_vocab"], "northpoint")
+
+	require.NoError(t, client.rebuildSearchIndexes(ctx))
+	assert.Empty(t, client.vocabularies, "the vocabulary is read again after the indexes change")
+
+	results, err = client.SearchStops(ctx, "Nortpoint", 10)
+	require.NoError(t, err)
+	assert.NotEmpty(t, results)
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	assert.Equal(t, 0, levenshteinDistance("main", "main", 2))
+	assert.Equal(t, 1, levenshteinDistance("main", "man", 2))
+	assert.Equal(t, 2, levenshteinDistance("street", "strt", 2))
+	assert.Equal(t, 3, levenshteinDistance("abc", "xyzabc", 2))
+}
@@ -0,0 +1,30 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+)
+
+func TestImportShapeDistTraveled(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	feed, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "raba"))
+
+	// The trip follows shape 7cxh, whose second point is 82.28 along it
+	points, err := client.Queries.GetShapePointsByTripID(ctx, "Route 15 Northbound 2 Vets Home")
+	require.NoError(t, err)
+	require.Greater(t, len(points), 1)
+	assert.True(t, points[0].ShapeDistTraveled.Valid)
+	assert.Equal(t, 0.0, points[0].ShapeDistTraveled.Float64)
+	assert.InDelta(t, 82.28, points[1].ShapeDistTraveled.Float64, 0.01)
+}
@@ -0,0 +1,105 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportStations(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	feed, err := os.ReadFile(models.BuildStationFeed(t, getTestFixturePath(t, "raba.zip")))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "stations"))
+
+	station, err := client.Queries.GetStop(ctx, "STN")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), station.LocationType.Int64)
+	assert.False(t, station.ParentStation.Valid)
+
+	platform, err := client.Queries.GetStop(ctx, "STN_P1")
+	require.NoError(t, err)
+	assert.False(t, platform.LocationType.Valid, "platforms keep location_type 0")
+	assert.Equal(t, "STN", platform.ParentStation.String)
+	assert.Equal(t, "L1", platform.LevelID.String)
+
+	boardingArea, err := client.Queries.GetStop(ctx, "STN_P1_B")
+	require.NoError(t, err)
+	assert.Equal(t, "STN_P1", boardingArea.ParentStation.String)
+	assert.Equal(t, platform.Lat, boardingArea.Lat, "stops without coordinates use their parent's")
+	assert.Equal(t, platform.Lon, boardingArea.Lon)
+
+	children, err := client.Queries.GetChildStops(ctx, []sql.NullString{{String: "STN", Valid: true}})
+	require.NoError(t, err)
+	var childIDs []string
+	for _, child := range children {
+		childIDs = append(childIDs, child.ID)
+	}
+	assert.Equal(t, []string{"STN_E", "STN_N", "STN_P1", "STN_P2"}, childIDs)
+
+	regular, err := client.Queries.GetStop(ctx, "1001")
+	require.NoError(t, err)
+	assert.False(t, regular.ParentStation.Valid)
+	assert.False(t, regular.LevelID.Valid)
+
+	levels, err := client.Queries.GetLevelsByIDs(ctx, []string{"L0", "L1"})
+	require.NoError(t, err)
+	require.Len(t, levels, 2)
+	assert.Equal(t, "L1", levels[0].LevelID, "levels are ordered by index")
+	assert.Equal(t, -1.0, levels[0].LevelIndex)
+	assert.Equal(t, "Concourse", levels[0].LevelName.String)
+
+	ids := []string{"STN_E", "STN_N", "STN_P1", "STN_P2", "STN_P1_B"}
+	pathways, err := client.Queries.GetPathwaysForStops(ctx, GetPathwaysForStopsParams{FromStopIds: ids, ToStopIds: ids})
+	require.NoError(t, err)
+	require.Len(t, pathways, 5, "pathways to unknown stops are skipped")
+	byID := make(map[string]Pathway)
+	for _, pathway := range pathways {
+		byID[pathway.PathwayID] = pathway
+	}
+	assert.Equal(t, 25.5, byID["PW_WALK"].Length.Float64)
+	assert.Equal(t, "To trains", byID["PW_WALK"].SignpostedAs.String)
+	assert.Equal(t, int64(-24), byID["PW_STAIRS"].StairCount.Int64)
+	assert.Equal(t, int64(60), byID["PW_ELEVATOR"].TraversalTime.Int64)
+	assert.Equal(t, int64(0), byID["PW_ESCALATOR"].IsBidirectional)
+
+	t.Run("reimport clears levels and pathways", func(t *testing.T) {
+		original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "stations"))
+
+		levels, err := client.Queries.GetLevelsByIDs(ctx, []string{"L0", "L1"})
+		require.NoError(t, err)
+		assert.Empty(t, levels)
+		pathways, err := client.Queries.GetPathwaysForStops(ctx, GetPathwaysForStopsParams{FromStopIds: ids, ToStopIds: ids})
+		require.NoError(t, err)
+		assert.Empty(t, pathways)
+	})
+}
+
+func TestMigrationAddsStopColumnsToExistingDatabase(t *testing.T) {
+	path := t.TempDir() + "/gtfs.db"
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE stops (id TEXT PRIMARY KEY, code TEXT, name TEXT, desc TEXT, lat REAL NOT NULL, lon REAL NOT NULL,
+		zone_id TEXT, url TEXT, location_type INTEGER DEFAULT 0, timezone TEXT, wheelchair_boarding INTEGER DEFAULT 0, platform_code TEXT)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, performDatabaseMigration(ctx, db))
+	require.NoError(t, performDatabaseMigration(ctx, db), "migrating twice is harmless")
+
+	_, err = db.Exec(`INSERT INTO stops (id, lat, lon, parent_station, level_id) VALUES ('a', 1, 2, 'b', 'c')`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
@@ -0,0 +1,62 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportTransfers(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	t.Run("same-stop transfers from the fixture", func(t *testing.T) {
+		original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "transfers"))
+
+		transfers, err := client.Queries.GetTransfersForStop(ctx, sql.NullString{String: "1000", Valid: true})
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		assert.Equal(t, "1000", transfers[0].FromStopID.String)
+		assert.Equal(t, "1000", transfers[0].ToStopID.String)
+		assert.Equal(t, int64(1), transfers[0].TransferType)
+		assert.False(t, transfers[0].FromRouteID.Valid)
+	})
+
+	t.Run("route and trip columns", func(t *testing.T) {
+		feedPath := models.BuildFeedWithFiles(t, getTestFixturePath(t, "raba.zip"), map[string]string{
+			"transfers.txt": "\ufefffrom_stop_id,to_stop_id,from_route_id,to_route_id,from_trip_id,to_trip_id,transfer_type,min_transfer_time\n" +
+				"1000,2000,151,152,,,2,180\n" +
+				"2000,1000,,,,,0,\n" +
+				"1000,3000,,,,,9,\n",
+		})
+		feed, err := os.ReadFile(feedPath)
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "transfers"))
+
+		transfers, err := client.Queries.GetTransfersFromStops(ctx, []sql.NullString{{String: "1000", Valid: true}})
+		require.NoError(t, err)
+		require.Len(t, transfers, 1, "rows with an invalid transfer_type are skipped and old rows cleared")
+
+		transfer := transfers[0]
+		assert.Equal(t, "2000", transfer.ToStopID.String)
+		assert.Equal(t, "151", transfer.FromRouteID.String)
+		assert.Equal(t, "152", transfer.ToRouteID.String)
+		assert.False(t, transfer.FromTripID.Valid)
+		assert.Equal(t, int64(2), transfer.TransferType)
+		assert.Equal(t, sql.NullInt64{Int64: 180, Valid: true}, transfer.MinTransferTime)
+
+		transfers, err = client.Queries.GetTransfersForStop(ctx, sql.NullString{String: "2000", Valid: true})
+		require.NoError(t, err)
+		assert.Len(t, transfers, 2, "both incoming and outgoing transfers are returned")
+	})
+}
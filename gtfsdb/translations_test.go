@@ -0,0 +1,55 @@
+package gtfsdb
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestImportTranslations(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	feed, err := os.ReadFile(models.BuildTranslationFeed(t, getTestFixturePath(t, "raba.zip")))
+	require.NoError(t, err)
+	require.NoError(t, client.processAndStoreGTFSDataWithSource(feed, "translations"))
+
+	translations, err := client.Queries.ListTranslations(ctx)
+	require.NoError(t, err)
+	require.Len(t, translations, 6, "rows without a language or a record are skipped")
+
+	stop := translations[0]
+	assert.Equal(t, "stops", stop.TableName)
+	assert.Equal(t, "stop_name", stop.FieldName)
+	assert.Equal(t, "es", stop.Language)
+	assert.Equal(t, "Northpoint Dr en Lake Blvd", stop.Translation)
+	assert.Equal(t, "1001", stop.RecordID.String)
+	assert.False(t, stop.RecordSubID.Valid)
+	assert.False(t, stop.FieldValue.Valid)
+
+	headsign := translations[2]
+	assert.Equal(t, "trip_headsign", headsign.FieldName)
+	assert.False(t, headsign.RecordID.Valid)
+	assert.Equal(t, "Shasta Lake", headsign.FieldValue.String)
+
+	feedInfo := translations[4]
+	assert.Equal(t, "feed_info", feedInfo.TableName)
+	assert.False(t, feedInfo.RecordID.Valid, "feed_info translations need no record")
+
+	t.Run("a feed without translations clears them", func(t *testing.T) {
+		original, err := os.ReadFile(getTestFixturePath(t, "raba.zip"))
+		require.NoError(t, err)
+		require.NoError(t, client.processAndStoreGTFSDataWithSource(original, "raba"))
+
+		translations, err := client.Queries.ListTranslations(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, translations)
+	})
+}
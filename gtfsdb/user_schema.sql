@@ -0,0 +1,48 @@
+-- migrate
+-- Tables of rows written by riders and operators rather than imported from a feed. They live in
+-- a database of their own, which is never rebuilt when feeds are refreshed.
+CREATE TABLE
+    IF NOT EXISTS arrival_alarms (
+        id TEXT PRIMARY KEY,
+        agency_id TEXT NOT NULL,
+        stop_id TEXT NOT NULL,
+        trip_id TEXT NOT NULL,
+        service_date INTEGER NOT NULL, -- milliseconds since the epoch
+        stop_sequence INTEGER NOT NULL,
+        vehicle_id TEXT,
+        alarm_time_offset INTEGER NOT NULL, -- seconds before the arrival or departure
+        on_arrival INTEGER NOT NULL,
+        callback_url TEXT NOT NULL,
+        created_at INTEGER NOT NULL
+    );
+
+-- migrate
+ALTER TABLE arrival_alarms ADD COLUMN client_id TEXT NOT NULL DEFAULT ''; -- IP address the alarm was registered from
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_arrival_alarms_client_id ON arrival_alarms (client_id);
+
+-- migrate
+CREATE TABLE
+    IF NOT EXISTS problem_reports (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        report_type TEXT NOT NULL, -- 'trip' or 'stop'
+        trip_id TEXT,
+        stop_id TEXT,
+        code TEXT NOT NULL,
+        service_date INTEGER, -- milliseconds since the epoch
+        vehicle_id TEXT,
+        user_comment TEXT,
+        user_on_vehicle INTEGER,
+        user_vehicle_number TEXT,
+        user_lat REAL,
+        user_lon REAL,
+        user_location_accuracy REAL,
+        status TEXT NOT NULL DEFAULT 'open', -- 'open' or 'resolved'
+        created_at INTEGER NOT NULL,
+        resolved_at INTEGER,
+        resolution_note TEXT
+    );
+
+-- migrate
+CREATE INDEX IF NOT EXISTS idx_problem_reports_created_at ON problem_reports (created_at);
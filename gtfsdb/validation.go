@@ -0,0 +1,372 @@
+package gtfsdb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs/warnings"
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// Severities of validation findings
+const (
+	// SeverityError marks data that is dropped on import or wrong for riders
+	SeverityError = "error"
+	// SeverityWarning marks data that is suspicious but usable
+	SeverityWarning = "warning"
+	// SeverityInfo marks findings that need no action
+	SeverityInfo = "info"
+)
+
+const (
+	// maxFindingsPerCode limits the findings kept of each kind, so that one systematic problem
+	// doesn't flood the report. A summary finding counts the rest.
+	maxFindingsPerCode = 100
+	// minCalendarGapDays is the shortest run of days without any service reported as a gap.
+	// Shorter runs are usually weekends and holidays.
+	minCalendarGapDays = 7
+)
+
+// feedValidator collects the validation findings of one feed.
+type feedValidator struct {
+	findings []CreateValidationFindingParams
+	counts   map[string]int
+}
+
+func (v *feedValidator) add(severity, code, fileName, entityID, message string) {
+	if v.counts == nil {
+		v.counts = make(map[string]int)
+	}
+	v.counts[code]++
+	if v.counts[code] > maxFindingsPerCode {
+		return
+	}
+	v.findings = append(v.findings, CreateValidationFindingParams{
+		Severity: severity,
+		Code:     code,
+		FileName: toNullString(fileName),
+		EntityID: toNullString(entityID),
+		Message:  message,
+	})
+}
+
+// results returns the findings, with a summary for each kind that went over the limit.
+func (v *feedValidator) results() []CreateValidationFindingParams {
+	findings := v.findings
+	codes := make([]string, 0, len(v.counts))
+	for code, count := range v.counts {
+		if count > maxFindingsPerCode {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		findings = append(findings, CreateValidationFindingParams{
+			Severity: SeverityInfo,
+			Code:     "too_many_findings",
+			EntityID: toNullString(code),
+			Message: fmt.Sprintf("%d more %s findings were not recorded",
+				v.counts[code]-maxFindingsPerCode, code),
+		})
+	}
+	return findings
+}
+
+// validateFeed checks the zipped GTFS feed in feed for duplicate IDs, references to missing
+// records, stop times that go back in time, stops without service and gaps in the service
+// calendar. The files are read directly, as go-gtfs drops the rows these checks are about.
+// Warnings raised while parsing the feed are included.
+func validateFeed(feed []byte, parseWarnings []warnings.StaticWarning) ([]CreateValidationFindingParams, error) {
+	files := make(map[string][]map[string]string)
+	for _, name := range []string{
+		"agency.txt", "stops.txt", "routes.txt", "trips.txt", "stop_times.txt", "calendar.txt",
+		"calendar_dates.txt", "shapes.txt",
+	} {
+		rows, err := readFeedFile(feed, name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = rows
+	}
+
+	v := &feedValidator{}
+
+	for _, w := range parseWarnings {
+		v.add(SeverityWarning, "parse_warning", string(w.File), "",
+			fmt.Sprintf("row %d: %v", w.RowNumber, w.Kind))
+	}
+
+	// Duplicate IDs make all but one of the records unreachable
+	agencyIDs := v.checkUniqueIDs(files["agency.txt"], "agency.txt", "agency_id")
+	stopIDs := v.checkUniqueIDs(files["stops.txt"], "stops.txt", "stop_id")
+	routeIDs := v.checkUniqueIDs(files["routes.txt"], "routes.txt", "route_id")
+	tripIDs := v.checkUniqueIDs(files["trips.txt"], "trips.txt", "trip_id")
+	calendarIDs := v.checkUniqueIDs(files["calendar.txt"], "calendar.txt", "service_id")
+
+	serviceIDs := make(map[string]bool, len(calendarIDs))
+	for id := range calendarIDs {
+		serviceIDs[id] = true
+	}
+	for _, row := range files["calendar_dates.txt"] {
+		serviceIDs[row["service_id"]] = true
+	}
+	shapeIDs := make(map[string]bool)
+	for _, row := range files["shapes.txt"] {
+		shapeIDs[row["shape_id"]] = true
+	}
+
+	if len(agencyIDs) > 1 {
+		for _, row := range files["routes.txt"] {
+			if row["agency_id"] == "" || !agencyIDs[row["agency_id"]] {
+				v.add(SeverityError, "route_unknown_agency", "routes.txt", row["route_id"],
+					fmt.Sprintf("route %s refers to unknown agency %q", row["route_id"], row["agency_id"]))
+			}
+		}
+	}
+
+	for _, row := range files["trips.txt"] {
+		tripID := row["trip_id"]
+		if !routeIDs[row["route_id"]] {
+			v.add(SeverityError, "trip_unknown_route", "trips.txt", tripID,
+				fmt.Sprintf("trip %s refers to unknown route %q", tripID, row["route_id"]))
+		}
+		if !serviceIDs[row["service_id"]] {
+			v.add(SeverityError, "trip_unknown_service", "trips.txt", tripID,
+				fmt.Sprintf("trip %s refers to unknown service %q", tripID, row["service_id"]))
+		}
+		if row["shape_id"] != "" && !shapeIDs[row["shape_id"]] {
+			v.add(SeverityWarning, "trip_unknown_shape", "trips.txt", tripID,
+				fmt.Sprintf("trip %s refers to unknown shape %q", tripID, row["shape_id"]))
+		}
+	}
+
+	servedStops := v.checkStopTimes(files["stop_times.txt"], tripIDs, stopIDs)
+
+	for _, row := range files["stops.txt"] {
+		locationType := row["location_type"]
+		if (locationType == "" || locationType == "0") && !servedStops[row["stop_id"]] {
+			v.add(SeverityWarning, "stop_without_service", "stops.txt", row["stop_id"],
+				fmt.Sprintf("no trips stop at stop %s", row["stop_id"]))
+		}
+	}
+
+	v.checkCalendarGaps(files["calendar.txt"], files["calendar_dates.txt"], files["trips.txt"])
+
+	return v.results(), nil
+}
+
+// checkUniqueIDs reports rows of file that repeat an ID, and returns the IDs found.
+func (v *feedValidator) checkUniqueIDs(rows []map[string]string, file, column string) map[string]bool {
+	ids := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		id := row[column]
+		if ids[id] {
+			v.add(SeverityError, "duplicate_id", file, id,
+				fmt.Sprintf("%s %q appears more than once in %s", column, id, file))
+		}
+		ids[id] = true
+	}
+	return ids
+}
+
+// checkStopTimes reports stop times of unknown trips or at unknown stops, and trips whose times
+// go backwards. It returns the stops that trips stop at.
+func (v *feedValidator) checkStopTimes(rows []map[string]string, tripIDs, stopIDs map[string]bool) map[string]bool {
+	type stopTime struct {
+		sequence           int64
+		arrival, departure time.Duration
+		hasTimes           bool
+	}
+	byTrip := make(map[string][]stopTime)
+	servedStops := make(map[string]bool)
+
+	for i, row := range rows {
+		tripID, stopID := row["trip_id"], row["stop_id"]
+		if !tripIDs[tripID] {
+			v.add(SeverityError, "stop_time_unknown_trip", "stop_times.txt", tripID,
+				fmt.Sprintf("row %d refers to unknown trip %q", i+2, tripID))
+			continue
+		}
+		if !stopIDs[stopID] {
+			v.add(SeverityError, "stop_time_unknown_stop", "stop_times.txt", tripID,
+				fmt.Sprintf("trip %s stops at unknown stop %q", tripID, stopID))
+			continue
+		}
+		servedStops[stopID] = true
+
+		sequence, err := strconv.ParseInt(row["stop_sequence"], 10, 64)
+		if err != nil {
+			v.add(SeverityError, "invalid_stop_sequence", "stop_times.txt", tripID,
+				fmt.Sprintf("trip %s has invalid stop_sequence %q", tripID, row["stop_sequence"]))
+			continue
+		}
+		st := stopTime{sequence: sequence}
+		arrival, arrivalErr := parseFeedTime(row["arrival_time"], -1)
+		departure, departureErr := parseFeedTime(row["departure_time"], -1)
+		if arrivalErr != nil || departureErr != nil {
+			v.add(SeverityError, "invalid_stop_time", "stop_times.txt", tripID,
+				fmt.Sprintf("trip %s has an invalid time at stop_sequence %d", tripID, sequence))
+			continue
+		}
+		// Stops between timepoints may leave out times; one given time stands for both
+		if arrival < 0 {
+			arrival = departure
+		}
+		if departure < 0 {
+			departure = arrival
+		}
+		st.arrival, st.departure, st.hasTimes = arrival, departure, arrival >= 0
+		byTrip[tripID] = append(byTrip[tripID], st)
+	}
+
+	tripIDsWithTimes := make([]string, 0, len(byTrip))
+	for tripID := range byTrip {
+		tripIDsWithTimes = append(tripIDsWithTimes, tripID)
+	}
+	sort.Strings(tripIDsWithTimes)
+
+	for _, tripID := range tripIDsWithTimes {
+		stopTimes := byTrip[tripID]
+		sort.Slice(stopTimes, func(i, j int) bool { return stopTimes[i].sequence < stopTimes[j].sequence })
+
+		var previous *stopTime
+		for i := range stopTimes {
+			st := &stopTimes[i]
+			if previous != nil && st.sequence == previous.sequence {
+				v.add(SeverityError, "duplicate_stop_sequence", "stop_times.txt", tripID,
+					fmt.Sprintf("trip %s repeats stop_sequence %d", tripID, st.sequence))
+			}
+			if !st.hasTimes {
+				continue
+			}
+			if st.departure < st.arrival {
+				v.add(SeverityError, "departure_before_arrival", "stop_times.txt", tripID,
+					fmt.Sprintf("trip %s departs before it arrives at stop_sequence %d", tripID, st.sequence))
+			}
+			if previous != nil && st.arrival < previous.departure {
+				v.add(SeverityError, "stop_times_decrease", "stop_times.txt", tripID,
+					fmt.Sprintf("trip %s arrives at stop_sequence %d before leaving stop_sequence %d",
+						tripID, st.sequence, previous.sequence))
+			}
+			previous = st
+		}
+	}
+	return servedStops
+}
+
+// checkCalendarGaps reports runs of at least minCalendarGapDays days, between the first and
+// last day of service, on which no trip runs.
+func (v *feedValidator) checkCalendarGaps(calendarRows, calendarDateRows, tripRows []map[string]string) {
+	usedServices := make(map[string]bool)
+	for _, row := range tripRows {
+		usedServices[row["service_id"]] = true
+	}
+
+	// Dates each service runs on, as calendar.txt and then calendar_dates.txt have them
+	serviceDates := make(map[string]map[string]bool)
+	datesOf := func(serviceID string) map[string]bool {
+		if serviceDates[serviceID] == nil {
+			serviceDates[serviceID] = make(map[string]bool)
+		}
+		return serviceDates[serviceID]
+	}
+
+	weekdayColumns := [7]string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	for _, row := range calendarRows {
+		serviceID := row["service_id"]
+		if !usedServices[serviceID] {
+			continue
+		}
+		start, startErr := time.Parse("20060102", row["start_date"])
+		end, endErr := time.Parse("20060102", row["end_date"])
+		if startErr != nil || endErr != nil || end.Before(start) {
+			v.add(SeverityError, "invalid_calendar_range", "calendar.txt", serviceID,
+				fmt.Sprintf("service %s has an invalid date range", serviceID))
+			continue
+		}
+		dates := datesOf(serviceID)
+		for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+			if row[weekdayColumns[date.Weekday()]] == "1" {
+				dates[date.Format("20060102")] = true
+			}
+		}
+	}
+	for _, row := range calendarDateRows {
+		serviceID := row["service_id"]
+		if !usedServices[serviceID] {
+			continue
+		}
+		switch row["exception_type"] {
+		case "1":
+			datesOf(serviceID)[row["date"]] = true
+		case "2":
+			delete(datesOf(serviceID), row["date"])
+		}
+	}
+
+	var first, last time.Time
+	active := make(map[string]bool)
+	for _, dates := range serviceDates {
+		for value := range dates {
+			date, err := time.Parse("20060102", value)
+			if err != nil {
+				continue
+			}
+			active[value] = true
+			if first.IsZero() || date.Before(first) {
+				first = date
+			}
+			if last.IsZero() || date.After(last) {
+				last = date
+			}
+		}
+	}
+	if first.IsZero() {
+		v.add(SeverityError, "no_service", "calendar.txt", "", "no trips run on any day")
+		return
+	}
+
+	var gapStart time.Time
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		if !active[date.Format("20060102")] {
+			if gapStart.IsZero() {
+				gapStart = date
+			}
+			continue
+		}
+		if gapStart.IsZero() {
+			continue
+		}
+		gapEnd := date.AddDate(0, 0, -1)
+		if days := int(date.Sub(gapStart).Hours() / 24); days >= minCalendarGapDays {
+			v.add(SeverityWarning, "calendar_gap", "calendar.txt", "",
+				fmt.Sprintf("no trips run from %s to %s (%d days)",
+					gapStart.Format("20060102"), gapEnd.Format("20060102"), days))
+		}
+		gapStart = time.Time{}
+	}
+}
+
+func (c *Client) bulkInsertValidationFindings(ctx context.Context, findings []CreateValidationFindingParams) error {
+	db := c.DB
+	queries := c.Queries
+	logger := slog.Default().With(slog.String("component", "bulk_insert"))
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer logging.SafeRollbackWithLogging(tx, logger, "bulk_insert_validation_findings")
+
+	qtx := queries.WithTx(tx)
+	for _, params := range findings {
+		if err := qtx.CreateValidationFinding(ctx, params); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,124 @@
+package gtfsdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestValidateFeed(t *testing.T) {
+	shuttlePath := models.BuildShuttleFeed(t)
+
+	t.Run("clean feed", func(t *testing.T) {
+		feed, err := os.ReadFile(shuttlePath)
+		require.NoError(t, err)
+		findings, err := validateFeed(feed, nil)
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("broken feed", func(t *testing.T) {
+		feed, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+			"stops.txt": "stop_id,stop_code,stop_name,stop_lat,stop_lon\n" +
+				"SH_A,A,Shuttle Stop A,40.5870,-122.3920\n" +
+				"SH_B,B,Shuttle Stop B,40.5880,-122.3930\n" +
+				"SH_B,B,Shuttle Stop B again,40.5880,-122.3930\n" +
+				"SH_C,C,Shuttle Stop C,40.5890,-122.3940\n",
+			"trips.txt": "route_id,service_id,trip_id,trip_headsign,shape_id\n" +
+				"LOOP,SH_WEEKDAY,LOOP_1,Shuttle Stop B,\n" +
+				"EXPRESS,SH_WEEKDAY,LOOP_2,Shuttle Stop B,LOOP_SHAPE\n",
+			"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+				"LOOP_1,08:00:00,08:00:00,SH_A,1\n" +
+				"LOOP_1,07:50:00,07:50:00,SH_B,2\n" +
+				"LOOP_1,08:20:00,08:20:00,SH_Z,3\n" +
+				"LOOP_2,09:00:00,09:00:00,SH_A,1\n",
+			// A week without service in August
+			"calendar_dates.txt": "service_id,date,exception_type\n" +
+				"SH_WEEKDAY,20250804,2\n" +
+				"SH_WEEKDAY,20250805,2\n" +
+				"SH_WEEKDAY,20250806,2\n" +
+				"SH_WEEKDAY,20250807,2\n" +
+				"SH_WEEKDAY,20250808,2\n",
+		}))
+		require.NoError(t, err)
+
+		findings, err := validateFeed(feed, nil)
+		require.NoError(t, err)
+
+		type finding struct{ severity, code, entityID string }
+		var got []finding
+		for _, f := range findings {
+			got = append(got, finding{f.Severity, f.Code, f.EntityID.String})
+		}
+		assert.ElementsMatch(t, []finding{
+			{SeverityError, "duplicate_id", "SH_B"},
+			{SeverityError, "trip_unknown_route", "LOOP_2"},
+			{SeverityWarning, "trip_unknown_shape", "LOOP_2"},
+			{SeverityError, "stop_time_unknown_stop", "LOOP_1"},
+			{SeverityError, "stop_times_decrease", "LOOP_1"},
+			{SeverityWarning, "stop_without_service", "SH_C"},
+			{SeverityWarning, "calendar_gap", ""},
+		}, got)
+
+		for _, f := range findings {
+			if f.Code == "calendar_gap" {
+				assert.Equal(t, "no trips run from 20250802 to 20250810 (9 days)", f.Message)
+			}
+		}
+	})
+}
+
+func TestValidationFindingsAreLimited(t *testing.T) {
+	v := &feedValidator{}
+	for range maxFindingsPerCode + 5 {
+		v.add(SeverityWarning, "stop_without_service", "stops.txt", "S", "no trips stop at stop S")
+	}
+
+	findings := v.results()
+	require.Len(t, findings, maxFindingsPerCode+1)
+	summary := findings[maxFindingsPerCode]
+	assert.Equal(t, "too_many_findings", summary.Code)
+	assert.Equal(t, "5 more stop_without_service findings were not recorded", summary.Message)
+}
+
+func TestImportStoresValidationFindings(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewClient(Config{DBPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	shuttlePath := models.BuildShuttleFeed(t)
+	feed, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+		"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+			"LOOP_1,08:00:00,08:00:00,SH_A,1\n" +
+			"LOOP_1,07:50:00,07:50:00,SH_B,2\n",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, client.ImportFeedDownload(ctx, "shuttle", &FeedDownload{Data: feed}, "shuttle"))
+
+	findings, err := client.Queries.ListValidationFindings(ctx, ListValidationFindingsParams{})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "stop_times_decrease", findings[0].Code)
+	assert.Equal(t, "shuttle", findings[0].SourceFeedID.String)
+
+	warnings, err := client.Queries.ListValidationFindings(ctx, ListValidationFindingsParams{
+		Severity: sql.NullString{String: SeverityWarning, Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	// Reimporting a fixed feed replaces the findings
+	original, err := os.ReadFile(shuttlePath)
+	require.NoError(t, err)
+	require.NoError(t, client.ImportFeedDownload(ctx, "shuttle", &FeedDownload{Data: original}, "shuttle"))
+	findings, err = client.Queries.ListValidationFindings(ctx, ListValidationFindingsParams{})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
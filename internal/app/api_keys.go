@@ -22,3 +22,24 @@ func (app *Application) IsInvalidAPIKey(key string) bool {
 
 	return true
 }
+
+func (app *Application) RequestHasInvalidAdminAPIKey(r *http.Request) bool {
+	key := r.URL.Query().Get("key")
+	return app.IsInvalidAdminAPIKey(key)
+}
+
+// IsInvalidAdminAPIKey reports whether key is not one of the configured admin keys. Regular API
+// keys are not accepted by admin endpoints.
+func (app *Application) IsInvalidAdminAPIKey(key string) bool {
+	if key == "" {
+		return true
+	}
+
+	for _, adminKey := range app.Config.AdminApiKeys {
+		if key == adminKey {
+			return false
+		}
+	}
+
+	return true
+}
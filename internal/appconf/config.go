@@ -6,11 +6,12 @@ package appconf
 // Application (development, staging, production, etc.). We will read in these
 // configuration settings from command-line flags when the Application starts.
 type Config struct {
-	Port      int
-	Env       Environment
-	ApiKeys   []string
-	Verbose   bool
-	RateLimit int // Requests per second per API key for rate limiting
+	Port         int
+	Env          Environment
+	ApiKeys      []string
+	AdminApiKeys []string // Keys accepted by the operator-only /api/admin endpoints
+	Verbose      bool
+	RateLimit    int // Requests per second per API key for rate limiting
 }
 
 // Environment is an enumerated type representing various stages or configurations in the system's lifecycle.
@@ -0,0 +1,95 @@
+package gtfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// ErrAlarmCallbackNotAllowed is returned for alarm callback URLs the server won't POST to
+var ErrAlarmCallbackNotAllowed = errors.New("alarm callback URL is not allowed")
+
+// ValidateAlarmCallbackURL checks that arrival alarms may be POSTed to a callback URL. It must
+// be http or https, and its host must resolve only to public addresses, so that clients can't
+// make the server call into the network it runs in. Hosts in Config.AlarmCallbackAllowedHosts
+// are exempt from the address check.
+func (manager *Manager) ValidateAlarmCallbackURL(ctx context.Context, callbackURL string) error {
+	parsed, err := url.Parse(callbackURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return ErrAlarmCallbackNotAllowed
+	}
+
+	host := parsed.Hostname()
+	if alarmCallbackHostAllowed(manager.config.AlarmCallbackAllowedHosts, host) {
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAlarmCallbackNotAllowed, err)
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrAlarmCallbackNotAllowed, host, addr)
+		}
+	}
+	return nil
+}
+
+// newAlarmCallbackClient returns the HTTP client that arrival alarms are POSTed with. Addresses
+// are checked again as connections are made, since a host can resolve differently than it did
+// when the alarm was registered. Redirects are not followed, and proxies are not used, since
+// either would let the request reach an address that wasn't checked.
+func newAlarmCallbackClient(allowedHosts []string) *http.Client {
+	unrestricted := &net.Dialer{Timeout: arrivalAlarmCallbackTimeout}
+	restricted := &net.Dialer{
+		Timeout: arrivalAlarmCallbackTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s is not a public address", ErrAlarmCallbackNotAllowed, addrPort.Addr())
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if host, _, err := net.SplitHostPort(address); err == nil && alarmCallbackHostAllowed(allowedHosts, host) {
+			return unrestricted.DialContext(ctx, network, address)
+		}
+		return restricted.DialContext(ctx, network, address)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicAddress reports whether an address is reachable on the public internet, rather than
+// a loopback, private, link-local, multicast or unspecified address.
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+func alarmCallbackHostAllowed(allowedHosts []string, host string) bool {
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,337 @@
+package gtfs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/logging"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+const (
+	// arrivalAlarmCallbackTimeout bounds each POST to an alarm's callback URL
+	arrivalAlarmCallbackTimeout = 10 * time.Second
+	// arrivalAlarmExpiry is how long after the arrival or departure an undelivered alarm is kept
+	arrivalAlarmExpiry = 30 * time.Minute
+	// arrivalAlarmWorkers is how many alarm callbacks are POSTed at once, so that slow receivers
+	// hold up only the workers delivering to them
+	arrivalAlarmWorkers = 8
+	// maxArrivalAlarmsPerClient and maxArrivalAlarms cap the alarms waiting to fire that were
+	// registered by one client, and by all clients together
+	maxArrivalAlarmsPerClient = 50
+	maxArrivalAlarms          = 10000
+	// ArrivalAlarmServiceDateWindow is how far before or after now the service date of a new
+	// alarm may be
+	ArrivalAlarmServiceDateWindow = 48 * time.Hour
+)
+
+// ErrTooManyArrivalAlarms is returned when registering an alarm would exceed the limit of
+// alarms per client, or of alarms in all
+var ErrTooManyArrivalAlarms = errors.New("too many arrival alarms")
+
+// RegisterArrivalAlarm stores a new alarm on behalf of a client, identified by its IP address,
+// unless the client or the server already has as many alarms waiting to fire as allowed.
+func (manager *Manager) RegisterArrivalAlarm(ctx context.Context, clientID string, params gtfsdb.CreateArrivalAlarmParams) (gtfsdb.ArrivalAlarm, error) {
+	manager.alarmRegistrationMutex.Lock()
+	defer manager.alarmRegistrationMutex.Unlock()
+
+	queries := manager.GtfsDB().UserQueries
+	total, err := queries.CountArrivalAlarms(ctx)
+	if err != nil {
+		return gtfsdb.ArrivalAlarm{}, err
+	}
+	forClient, err := queries.CountArrivalAlarmsForClient(ctx, clientID)
+	if err != nil {
+		return gtfsdb.ArrivalAlarm{}, err
+	}
+	if total >= maxArrivalAlarms || forClient >= maxArrivalAlarmsPerClient {
+		return gtfsdb.ArrivalAlarm{}, ErrTooManyArrivalAlarms
+	}
+
+	params.ClientID = clientID
+	return queries.CreateArrivalAlarm(ctx, params)
+}
+
+// arrivalAlarmDelivery is a claimed alarm waiting for a worker to POST it
+type arrivalAlarmDelivery struct {
+	alarm        gtfsdb.ArrivalAlarm
+	notification ArrivalAlarmNotification
+}
+
+// ArrivalAlarmNotification is the JSON body POSTed to an alarm's callback URL when it fires.
+// IDs are combined with the agency ID and times are in milliseconds since the epoch.
+type ArrivalAlarmNotification struct {
+	AlarmID         string `json:"alarmId"`
+	StopID          string `json:"stopId"`
+	TripID          string `json:"tripId"`
+	ServiceDate     int64  `json:"serviceDate"`
+	StopSequence    int64  `json:"stopSequence"`
+	VehicleID       string `json:"vehicleId"`
+	OnArrival       bool   `json:"onArrival"`
+	AlarmTimeOffset int64  `json:"alarmTimeOffset"`
+	ScheduledTime   int64  `json:"scheduledTime"`
+	PredictedTime   int64  `json:"predictedTime"`
+	Predicted       bool   `json:"predicted"`
+}
+
+// evaluateArrivalAlarms fires every registered alarm whose arrival or departure, as currently
+// predicted, is no more than the alarm's offset away. Fired alarms are removed; alarms whose
+// callback fails are retried on the next evaluation until they expire. Callbacks are POSTed by
+// a pool of workers, and each is given its own time limit.
+func (manager *Manager) evaluateArrivalAlarms(ctx context.Context, now time.Time) {
+	logger := logging.FromContext(ctx).With(slog.String("component", "arrival_alarms"))
+
+	alarms, err := manager.GtfsDB().UserQueries.ListArrivalAlarms(ctx)
+	if err != nil {
+		logging.LogError(logger, "Error listing arrival alarms", err)
+		return
+	}
+
+	deliveries := make(chan arrivalAlarmDelivery)
+	var wg sync.WaitGroup
+	for range arrivalAlarmWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range deliveries {
+				manager.deliverArrivalAlarm(ctx, logger, delivery)
+			}
+		}()
+	}
+	defer func() {
+		close(deliveries)
+		wg.Wait()
+	}()
+
+	for _, alarm := range alarms {
+		if ctx.Err() != nil {
+			return
+		}
+
+		notification, err := manager.buildArrivalAlarmNotification(ctx, alarm)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// The trip or stop is gone from the static feed, so the alarm can never fire
+				manager.deleteArrivalAlarm(ctx, logger, alarm.ID)
+				continue
+			}
+			logging.LogError(logger, "Error evaluating arrival alarm", err, slog.String("alarm_id", alarm.ID))
+			continue
+		}
+
+		predictedTime := time.UnixMilli(notification.PredictedTime)
+		if now.After(predictedTime.Add(arrivalAlarmExpiry)) {
+			logging.LogOperation(logger, "arrival_alarm_expired", slog.String("alarm_id", alarm.ID))
+			manager.deleteArrivalAlarm(ctx, logger, alarm.ID)
+			continue
+		}
+
+		alarmTime := predictedTime.Add(-time.Duration(alarm.AlarmTimeOffset) * time.Second)
+		if now.Before(alarmTime) {
+			continue
+		}
+
+		// Claiming the alarm removes it, so that no other evaluation delivers it as well
+		claimed, err := manager.GtfsDB().UserQueries.ClaimArrivalAlarm(ctx, alarm.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Cancelled or delivered since it was listed
+			continue
+		} else if err != nil {
+			logging.LogError(logger, "Error claiming arrival alarm", err, slog.String("alarm_id", alarm.ID))
+			continue
+		}
+
+		deliveries <- arrivalAlarmDelivery{alarm: claimed, notification: notification}
+	}
+}
+
+// deliverArrivalAlarm POSTs a claimed alarm to its callback URL, and registers it again if that
+// fails. A claimed alarm is delivered or restored even once the evaluation has run out of time.
+func (manager *Manager) deliverArrivalAlarm(ctx context.Context, logger *slog.Logger, delivery arrivalAlarmDelivery) {
+	ctx = context.WithoutCancel(ctx)
+	alarm := delivery.alarm
+
+	if err := manager.postArrivalAlarm(ctx, alarm.CallbackUrl, delivery.notification); err != nil {
+		logging.LogError(logger, "Error delivering arrival alarm", err,
+			slog.String("alarm_id", alarm.ID),
+			slog.String("url", alarm.CallbackUrl))
+		manager.restoreArrivalAlarm(ctx, logger, alarm)
+		return
+	}
+
+	logging.LogOperation(logger, "arrival_alarm_delivered", slog.String("alarm_id", alarm.ID))
+}
+
+// evaluateArrivalAlarmsPeriodically evaluates the registered alarms as often as the most
+// frequently refreshed realtime feed. There is one evaluator per manager, however many feeds
+// it serves.
+func (manager *Manager) evaluateArrivalAlarmsPeriodically() {
+	defer manager.wg.Done()
+
+	logger := slog.Default().With(slog.String("component", "arrival_alarms"))
+
+	ticker := time.NewTicker(manager.arrivalAlarmInterval())
+	defer ticker.Stop()
+
+	for { // nolint
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			ctx = logging.WithLogger(ctx, logger)
+			manager.evaluateArrivalAlarms(ctx, time.Now())
+			cancel()
+		case <-manager.shutdownChan:
+			logging.LogOperation(logger, "shutting_down_arrival_alarms")
+			return
+		}
+	}
+}
+
+// arrivalAlarmInterval returns the shortest realtime refresh interval of the manager's feeds.
+func (manager *Manager) arrivalAlarmInterval() time.Duration {
+	interval := DefaultRealTimeRefreshInterval
+	first := true
+	for _, feed := range manager.feeds {
+		if !feed.realTimeDataEnabled() {
+			continue
+		}
+		if first || feed.realTimeRefreshInterval() < interval {
+			interval = feed.realTimeRefreshInterval()
+			first = false
+		}
+	}
+	return interval
+}
+
+// restoreArrivalAlarm registers a claimed alarm again after its callback failed, so that it is
+// retried on the next evaluation.
+func (manager *Manager) restoreArrivalAlarm(ctx context.Context, logger *slog.Logger, alarm gtfsdb.ArrivalAlarm) {
+	_, err := manager.GtfsDB().UserQueries.CreateArrivalAlarm(ctx, gtfsdb.CreateArrivalAlarmParams{
+		ID:              alarm.ID,
+		AgencyID:        alarm.AgencyID,
+		StopID:          alarm.StopID,
+		TripID:          alarm.TripID,
+		ServiceDate:     alarm.ServiceDate,
+		StopSequence:    alarm.StopSequence,
+		VehicleID:       alarm.VehicleID,
+		AlarmTimeOffset: alarm.AlarmTimeOffset,
+		OnArrival:       alarm.OnArrival,
+		CallbackUrl:     alarm.CallbackUrl,
+		CreatedAt:       alarm.CreatedAt,
+		ClientID:        alarm.ClientID,
+	})
+	if err != nil {
+		logging.LogError(logger, "Error restoring arrival alarm", err, slog.String("alarm_id", alarm.ID))
+	}
+}
+
+func (manager *Manager) deleteArrivalAlarm(ctx context.Context, logger *slog.Logger, alarmID string) {
+	if _, err := manager.GtfsDB().UserQueries.DeleteArrivalAlarm(ctx, alarmID); err != nil {
+		logging.LogError(logger, "Error deleting arrival alarm", err, slog.String("alarm_id", alarmID))
+	}
+}
+
+// buildArrivalAlarmNotification works out the scheduled and predicted times of the alarm's stop
+// visit. It returns sql.ErrNoRows if the alarm's trip no longer visits the stop.
+func (manager *Manager) buildArrivalAlarmNotification(ctx context.Context, alarm gtfsdb.ArrivalAlarm) (ArrivalAlarmNotification, error) {
+	agency, err := manager.GtfsDB().Queries.GetAgency(ctx, alarm.AgencyID)
+	if err != nil {
+		return ArrivalAlarmNotification{}, err
+	}
+
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		return ArrivalAlarmNotification{}, err
+	}
+
+	stopTimes, err := manager.GtfsDB().Queries.GetStopTimesForTrip(ctx, alarm.TripID)
+	if err != nil {
+		return ArrivalAlarmNotification{}, err
+	}
+
+	var stopTime *gtfsdb.StopTime
+	for i := range stopTimes {
+		if stopTimes[i].StopSequence == alarm.StopSequence && stopTimes[i].StopID == alarm.StopID {
+			stopTime = &stopTimes[i]
+			break
+		}
+	}
+	if stopTime == nil {
+		return ArrivalAlarmNotification{}, fmt.Errorf("trip %s does not visit stop %s at sequence %d: %w",
+			alarm.TripID, alarm.StopID, alarm.StopSequence, sql.ErrNoRows)
+	}
+
+	serviceDate := time.UnixMilli(alarm.ServiceDate).In(loc)
+	serviceMidnight := time.Date(serviceDate.Year(), serviceDate.Month(), serviceDate.Day(), 0, 0, 0, 0, loc)
+
+	onArrival := alarm.OnArrival != 0
+	// Stop times are stored in nanoseconds since midnight of the service date
+	scheduled := serviceMidnight.Add(time.Duration(stopTime.DepartureTime))
+	if onArrival {
+		scheduled = serviceMidnight.Add(time.Duration(stopTime.ArrivalTime))
+	}
+
+	predicted, isPredicted := scheduled, false
+	if realtimeTrip := manager.tripUpdateForServiceDate(alarm.TripID, serviceMidnight); realtimeTrip != nil {
+		prediction := PredictStopTimes(serviceMidnight, stopTimes, realtimeTrip.StopTimeUpdates)
+		if stop, ok := prediction.ForStop(stopTime.StopID, stopTime.StopSequence); ok {
+			predicted = stop.PredictedDeparture
+			if onArrival {
+				predicted = stop.PredictedArrival
+			}
+		}
+		isPredicted = true
+	}
+
+	return ArrivalAlarmNotification{
+		AlarmID:         utils.FormCombinedID(alarm.AgencyID, alarm.ID),
+		StopID:          utils.FormCombinedID(alarm.AgencyID, alarm.StopID),
+		TripID:          utils.FormCombinedID(alarm.AgencyID, alarm.TripID),
+		ServiceDate:     alarm.ServiceDate,
+		StopSequence:    alarm.StopSequence,
+		VehicleID:       utils.FormCombinedID(alarm.AgencyID, alarm.VehicleID.String),
+		OnArrival:       onArrival,
+		AlarmTimeOffset: alarm.AlarmTimeOffset,
+		ScheduledTime:   scheduled.UnixMilli(),
+		PredictedTime:   predicted.UnixMilli(),
+		Predicted:       isPredicted,
+	}, nil
+}
+
+func (manager *Manager) postArrivalAlarm(ctx context.Context, callbackURL string, notification ArrivalAlarmNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, arrivalAlarmCallbackTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := manager.alarmCallbackClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer logging.SafeCloseWithLogging(resp.Body,
+		slog.Default().With(slog.String("component", "arrival_alarms")),
+		"http_response_body")
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+	return nil
+}
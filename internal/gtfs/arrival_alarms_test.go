@@ -0,0 +1,318 @@
+package gtfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+type alarmReceiver struct {
+	mu            sync.Mutex
+	notifications []ArrivalAlarmNotification
+	status        int
+}
+
+func newAlarmReceiver(t *testing.T) (*alarmReceiver, *httptest.Server) {
+	receiver := &alarmReceiver{status: http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var notification ArrivalAlarmNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.notifications = append(receiver.notifications, notification)
+		w.WriteHeader(receiver.status)
+	}))
+	t.Cleanup(server.Close)
+	return receiver, server
+}
+
+func (r *alarmReceiver) received() []ArrivalAlarmNotification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ArrivalAlarmNotification(nil), r.notifications...)
+}
+
+// setUpAlarm registers an alarm for the first stop time of a RABA trip on 2025-06-12 and returns
+// the scheduled arrival at that stop.
+func setUpAlarm(t *testing.T, manager *Manager, callbackURL string, offset time.Duration) (gtfsdb.ArrivalAlarm, time.Time) {
+	t.Helper()
+	ctx := context.Background()
+
+	var stopTime gtfsdb.StopTime
+	row := manager.GtfsDB().DB.QueryRowContext(ctx,
+		"SELECT trip_id, stop_id, stop_sequence, arrival_time FROM stop_times WHERE stop_id = '1030' ORDER BY trip_id LIMIT 1")
+	require.NoError(t, row.Scan(&stopTime.TripID, &stopTime.StopID, &stopTime.StopSequence, &stopTime.ArrivalTime))
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 12, 0, 0, 0, 0, loc)
+
+	alarm, err := manager.GtfsDB().UserQueries.CreateArrivalAlarm(ctx, gtfsdb.CreateArrivalAlarmParams{
+		ID:              "alarm1",
+		AgencyID:        "25",
+		StopID:          stopTime.StopID,
+		TripID:          stopTime.TripID,
+		ServiceDate:     serviceDate.UnixMilli(),
+		StopSequence:    stopTime.StopSequence,
+		AlarmTimeOffset: int64(offset.Seconds()),
+		OnArrival:       1,
+		CallbackUrl:     callbackURL,
+		CreatedAt:       time.Now().UnixMilli(),
+	})
+	require.NoError(t, err)
+
+	return alarm, serviceDate.Add(time.Duration(stopTime.ArrivalTime))
+}
+
+func newAlarmTestManager(t *testing.T) *Manager {
+	manager, err := InitGTFSManager(Config{
+		GtfsURL:      models.GetFixturePath(t, "raba.zip"),
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+		// The callback receivers are test servers on the loopback interface
+		AlarmCallbackAllowedHosts: []string{"127.0.0.1"},
+	})
+	require.NoError(t, err)
+	t.Cleanup(manager.Shutdown)
+	return manager
+}
+
+func TestEvaluateArrivalAlarms(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	receiver, server := newAlarmReceiver(t)
+	ctx := context.Background()
+
+	alarm, scheduled := setUpAlarm(t, manager, server.URL, 5*time.Minute)
+
+	// Not yet within the offset
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(-6*time.Minute))
+	assert.Empty(t, receiver.received())
+
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(-4*time.Minute))
+	notifications := receiver.received()
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "25_alarm1", notifications[0].AlarmID)
+	assert.Equal(t, "25_"+alarm.TripID, notifications[0].TripID)
+	assert.Equal(t, "25_1030", notifications[0].StopID)
+	assert.True(t, notifications[0].OnArrival)
+	assert.False(t, notifications[0].Predicted)
+	assert.Equal(t, scheduled.UnixMilli(), notifications[0].ScheduledTime)
+	assert.Equal(t, scheduled.UnixMilli(), notifications[0].PredictedTime)
+
+	// Delivered alarms fire only once
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(-3*time.Minute))
+	assert.Len(t, receiver.received(), 1)
+
+	_, err := manager.GtfsDB().UserQueries.GetArrivalAlarm(ctx, alarm.ID)
+	assert.Error(t, err)
+}
+
+func TestEvaluateArrivalAlarmsUsesRealtimeDelay(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	receiver, server := newAlarmReceiver(t)
+	ctx := context.Background()
+
+	alarm, scheduled := setUpAlarm(t, manager, server.URL, 5*time.Minute)
+
+	delay := 10 * time.Minute
+	sequence := uint32(alarm.StopSequence)
+	setRealTimeData(manager, realTimeFeedData{trips: []gtfs.Trip{{
+		ID: gtfs.TripID{ID: alarm.TripID},
+		StopTimeUpdates: []gtfs.StopTimeUpdate{{
+			StopSequence: &sequence,
+			Arrival:      &gtfs.StopTimeEvent{Delay: &delay},
+		}},
+	}}})
+
+	// Would be due on schedule, but the vehicle is running ten minutes late
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(-4*time.Minute))
+	assert.Empty(t, receiver.received())
+
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(6*time.Minute))
+	notifications := receiver.received()
+	require.Len(t, notifications, 1)
+	assert.True(t, notifications[0].Predicted)
+	assert.Equal(t, scheduled.Add(delay).UnixMilli(), notifications[0].PredictedTime)
+}
+
+func TestEvaluateArrivalAlarmsRetriesFailedCallbacks(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	receiver, server := newAlarmReceiver(t)
+	receiver.status = http.StatusInternalServerError
+	ctx := context.Background()
+
+	alarm, scheduled := setUpAlarm(t, manager, server.URL, 0)
+
+	manager.evaluateArrivalAlarms(ctx, scheduled)
+	require.Len(t, receiver.received(), 1)
+	_, err := manager.GtfsDB().UserQueries.GetArrivalAlarm(ctx, alarm.ID)
+	require.NoError(t, err, "an undelivered alarm should be kept for another attempt")
+
+	// Once expired the alarm is dropped without another attempt
+	manager.evaluateArrivalAlarms(ctx, scheduled.Add(arrivalAlarmExpiry+time.Minute))
+	assert.Len(t, receiver.received(), 1)
+	_, err = manager.GtfsDB().UserQueries.GetArrivalAlarm(ctx, alarm.ID)
+	assert.Error(t, err)
+}
+
+func TestOverlappingArrivalAlarmEvaluationsDeliverOnce(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	receiver, server := newAlarmReceiver(t)
+	ctx := context.Background()
+
+	alarm, scheduled := setUpAlarm(t, manager, server.URL, 0)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manager.evaluateArrivalAlarms(ctx, scheduled)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, receiver.received(), 1)
+	_, err := manager.GtfsDB().UserQueries.GetArrivalAlarm(ctx, alarm.ID)
+	assert.Error(t, err)
+}
+
+func TestArrivalAlarmInterval(t *testing.T) {
+	manager := &Manager{feeds: []FeedConfig{
+		{ID: "static"},
+		{ID: "slow", TripUpdatesURL: "https://example.com/tu", VehiclePositionsURL: "https://example.com/vp", RealTimeRefreshInterval: time.Minute},
+		{ID: "fast", TripUpdatesURL: "https://example.com/tu", VehiclePositionsURL: "https://example.com/vp", RealTimeRefreshInterval: 10 * time.Second},
+	}}
+	assert.Equal(t, 10*time.Second, manager.arrivalAlarmInterval())
+
+	manager = &Manager{feeds: []FeedConfig{{ID: "static"}}}
+	assert.Equal(t, DefaultRealTimeRefreshInterval, manager.arrivalAlarmInterval())
+}
+
+func TestAlarmCallbacksOnlyReachPublicAddresses(t *testing.T) {
+	manager := &Manager{}
+	ctx := context.Background()
+
+	for _, callbackURL := range []string{
+		"ftp://example.com/alarm",
+		"http://127.0.0.1:8080/alarm",
+		"http://localhost/alarm",
+		"http://10.1.2.3/alarm",
+		"http://192.168.0.1/alarm",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/alarm",
+		"http://[fe80::1]/alarm",
+	} {
+		assert.ErrorIs(t, manager.ValidateAlarmCallbackURL(ctx, callbackURL), ErrAlarmCallbackNotAllowed, callbackURL)
+	}
+	assert.NoError(t, manager.ValidateAlarmCallbackURL(ctx, "https://203.0.113.10/alarm"))
+
+	allowed := &Manager{config: Config{AlarmCallbackAllowedHosts: []string{"localhost"}}}
+	assert.NoError(t, allowed.ValidateAlarmCallbackURL(ctx, "http://localhost:9999/alarm"))
+	assert.ErrorIs(t, allowed.ValidateAlarmCallbackURL(ctx, "http://127.0.0.1:9999/alarm"), ErrAlarmCallbackNotAllowed)
+}
+
+func TestAlarmCallbackClientRefusesPrivateAddressesAndRedirects(t *testing.T) {
+	receiver, server := newAlarmReceiver(t)
+	redirect := httptest.NewServer(http.RedirectHandler(server.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(redirect.Close)
+	ctx := context.Background()
+
+	// A host that passed registration but now resolves to a loopback address is refused
+	manager := &Manager{alarmCallbackClient: newAlarmCallbackClient(nil)}
+	err := manager.postArrivalAlarm(ctx, server.URL, ArrivalAlarmNotification{AlarmID: "1_a"})
+	assert.ErrorIs(t, err, ErrAlarmCallbackNotAllowed)
+	assert.Empty(t, receiver.received())
+
+	manager = &Manager{alarmCallbackClient: newAlarmCallbackClient([]string{"127.0.0.1"})}
+	assert.Error(t, manager.postArrivalAlarm(ctx, redirect.URL, ArrivalAlarmNotification{AlarmID: "1_a"}))
+	assert.Empty(t, receiver.received(), "redirects are not followed")
+
+	require.NoError(t, manager.postArrivalAlarm(ctx, server.URL, ArrivalAlarmNotification{AlarmID: "1_a"}))
+	assert.Len(t, receiver.received(), 1)
+}
+
+func TestRegisterArrivalAlarmLimitsAlarmsPerClient(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	ctx := context.Background()
+
+	register := func(clientID, alarmID string) error {
+		_, err := manager.RegisterArrivalAlarm(ctx, clientID, gtfsdb.CreateArrivalAlarmParams{
+			ID:          alarmID,
+			AgencyID:    "25",
+			StopID:      "1030",
+			TripID:      "trip",
+			CallbackUrl: "https://203.0.113.10/alarm",
+			CreatedAt:   time.Now().UnixMilli(),
+		})
+		return err
+	}
+
+	for i := range maxArrivalAlarmsPerClient {
+		require.NoError(t, register("198.51.100.1", fmt.Sprintf("a%d", i)))
+	}
+	assert.ErrorIs(t, register("198.51.100.1", "one-too-many"), ErrTooManyArrivalAlarms)
+	assert.NoError(t, register("198.51.100.2", "b0"), "other clients have limits of their own")
+
+	alarm, err := manager.GtfsDB().UserQueries.GetArrivalAlarm(ctx, "b0")
+	require.NoError(t, err)
+	assert.Equal(t, "198.51.100.2", alarm.ClientID)
+}
+
+func TestSlowAlarmCallbacksDoNotHoldUpOthers(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	receiver, server := newAlarmReceiver(t)
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(slow.Close)
+
+	// The slow receiver's alarm is listed first
+	alarm, scheduled := setUpAlarm(t, manager, slow.URL, 0)
+	_, err := manager.GtfsDB().UserQueries.CreateArrivalAlarm(ctx, gtfsdb.CreateArrivalAlarmParams{
+		ID:              "alarm2",
+		AgencyID:        alarm.AgencyID,
+		StopID:          alarm.StopID,
+		TripID:          alarm.TripID,
+		ServiceDate:     alarm.ServiceDate,
+		StopSequence:    alarm.StopSequence,
+		AlarmTimeOffset: alarm.AlarmTimeOffset,
+		OnArrival:       alarm.OnArrival,
+		CallbackUrl:     server.URL,
+		CreatedAt:       alarm.CreatedAt + 1,
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		manager.evaluateArrivalAlarms(ctx, scheduled)
+	}()
+
+	require.Eventually(t, func() bool { return len(receiver.received()) == 1 }, 5*time.Second, 10*time.Millisecond,
+		"the second alarm is delivered while the first callback is still waiting")
+	close(release)
+	<-done
+}
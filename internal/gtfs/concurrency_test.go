@@ -30,7 +30,6 @@ func TestConcurrentGTFSDataAccess(t *testing.T) {
 				{Id: "route1", ShortName: "R1"},
 			},
 		},
-		realTimeMutex: sync.RWMutex{},
 	}
 
 	// Test concurrent reads
@@ -128,7 +127,6 @@ func TestSafeGTFSDataAccess(t *testing.T) {
 					{Id: "test-agency", Name: "Test Agency"},
 				},
 			},
-			realTimeMutex: sync.RWMutex{},
 		},
 		staticMutex: sync.RWMutex{}, // This will be added to the real Manager
 	}
@@ -206,14 +204,12 @@ func TestSafeGTFSDataAccess(t *testing.T) {
 }
 
 func TestConcurrentVehicleUpdates(t *testing.T) {
-	// Test that real-time data updates are already safe (they use realTimeMutex)
+	// Test that readers never race with publishes of new real-time data
 	manager := &Manager{
 		gtfsData: &gtfs.Static{
 			Routes: []gtfs.Route{},
 		},
-		realTimeVehicles: []gtfs.Vehicle{},
-		realTimeMutex:    sync.RWMutex{},
-		staticMutex:      sync.RWMutex{},
+		staticMutex: sync.RWMutex{},
 	}
 
 	var wg sync.WaitGroup
@@ -246,13 +242,10 @@ func TestConcurrentVehicleUpdates(t *testing.T) {
 				case <-done:
 					return
 				default:
-					// Use direct assignment to realTimeVehicles for testing
-					manager.realTimeMutex.Lock()
 					testVehicleID := gtfs.VehicleID{ID: "test-vehicle"}
-					manager.realTimeVehicles = []gtfs.Vehicle{
+					setRealTimeData(manager, realTimeFeedData{vehicles: []gtfs.Vehicle{
 						{ID: &testVehicleID},
-					}
-					manager.realTimeMutex.Unlock()
+					}})
 					time.Sleep(time.Millisecond)
 				}
 			}
@@ -1,21 +1,162 @@
 package gtfs
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/appconf"
 )
 
+// Default refresh schedules of a feed
+const (
+	DefaultStaticRefreshInterval   = 24 * time.Hour
+	DefaultRealTimeRefreshInterval = 30 * time.Second
+)
+
 type Config struct {
+	// GtfsURL and the realtime settings below describe a single feed. They are ignored when
+	// Feeds is set.
 	GtfsURL                 string
 	TripUpdatesURL          string
 	VehiclePositionsURL     string
 	ServiceAlertsURL        string
 	RealTimeAuthHeaderKey   string
 	RealTimeAuthHeaderValue string
-	GTFSDataPath            string
-	Env                     appconf.Environment
-	Verbose                 bool
+	// Feeds are the static feeds to serve together, each with its own realtime feeds. Feeds may
+	// reuse each other's stop, route, trip and other IDs, since combined IDs are resolved to a
+	// feed through their agency, but importing a feed that reuses another's agency IDs fails.
+	Feeds []FeedConfig
+	// StaticDownload limits downloads of the static feeds
+	StaticDownload gtfsdb.DownloadConfig
+	GTFSDataPath   string
+	Env            appconf.Environment
+	Verbose        bool
+	// AlarmCallbackAllowedHosts are hosts that arrival alarm callbacks may be POSTed to even
+	// though they aren't public, such as localhost in development
+	AlarmCallbackAllowedHosts []string
+}
+
+// dbConfig returns the configuration of the GTFS database.
+func (config Config) dbConfig() gtfsdb.Config {
+	dbConfig := gtfsdb.NewConfig(config.GTFSDataPath, config.Env, config.Verbose)
+	dbConfig.Download = config.StaticDownload
+	return dbConfig
+}
+
+// FeedConfig describes one static GTFS feed and the realtime feeds that go with it.
+type FeedConfig struct {
+	// ID identifies the feed's data in the database, and must stay the same between runs
+	ID                      string `json:"id"`
+	GtfsURL                 string `json:"gtfsUrl"`
+	TripUpdatesURL          string `json:"tripUpdatesUrl"`
+	VehiclePositionsURL     string `json:"vehiclePositionsUrl"`
+	ServiceAlertsURL        string `json:"serviceAlertsUrl"`
+	RealTimeAuthHeaderKey   string `json:"realTimeAuthHeaderName"`
+	RealTimeAuthHeaderValue string `json:"realTimeAuthHeaderValue"`
+	// StaticRefreshInterval and RealTimeRefreshInterval default to once a day and every
+	// 30 seconds
+	StaticRefreshInterval   time.Duration `json:"-"`
+	RealTimeRefreshInterval time.Duration `json:"-"`
+}
+
+// feeds returns the configured feeds, treating the single-feed settings as a feed of their own
+// when Feeds is empty.
+func (config Config) feeds() []FeedConfig {
+	if len(config.Feeds) > 0 {
+		return config.Feeds
+	}
+	return []FeedConfig{{
+		ID:                      gtfsdb.DefaultFeedID,
+		GtfsURL:                 config.GtfsURL,
+		TripUpdatesURL:          config.TripUpdatesURL,
+		VehiclePositionsURL:     config.VehiclePositionsURL,
+		ServiceAlertsURL:        config.ServiceAlertsURL,
+		RealTimeAuthHeaderKey:   config.RealTimeAuthHeaderKey,
+		RealTimeAuthHeaderValue: config.RealTimeAuthHeaderValue,
+	}}
+}
+
+// realTimeDataEnabled reports whether the feed has any realtime feed to fetch. Feeds publishing
+// only vehicle positions or only alerts are refreshed as well.
+func (feed FeedConfig) realTimeDataEnabled() bool {
+	return feed.TripUpdatesURL != "" || feed.VehiclePositionsURL != "" || feed.ServiceAlertsURL != ""
 }
 
-func (config Config) realTimeDataEnabled() bool {
-	return config.TripUpdatesURL != "" && config.VehiclePositionsURL != ""
+func (feed FeedConfig) isLocalFile() bool {
+	return !strings.HasPrefix(feed.GtfsURL, "http://") && !strings.HasPrefix(feed.GtfsURL, "https://")
+}
+
+func (feed FeedConfig) staticRefreshInterval() time.Duration {
+	if feed.StaticRefreshInterval > 0 {
+		return feed.StaticRefreshInterval
+	}
+	return DefaultStaticRefreshInterval
+}
+
+func (feed FeedConfig) realTimeRefreshInterval() time.Duration {
+	if feed.RealTimeRefreshInterval > 0 {
+		return feed.RealTimeRefreshInterval
+	}
+	return DefaultRealTimeRefreshInterval
+}
+
+// realTimeHeaders returns the headers sent with the feed's realtime requests.
+func (feed FeedConfig) realTimeHeaders() map[string]string {
+	headers := map[string]string{}
+	if feed.RealTimeAuthHeaderKey != "" && feed.RealTimeAuthHeaderValue != "" {
+		headers[feed.RealTimeAuthHeaderKey] = feed.RealTimeAuthHeaderValue
+	}
+	return headers
+}
+
+// LoadFeedsConfig reads a list of feeds from a JSON file. Each entry has the JSON fields of
+// FeedConfig, with the refresh intervals given as durations such as "12h" in
+// staticRefreshInterval and realTimeRefreshInterval.
+func LoadFeedsConfig(path string) ([]FeedConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading feeds config: %w", err)
+	}
+
+	var entries []struct {
+		FeedConfig
+		StaticRefreshInterval   string `json:"staticRefreshInterval"`
+		RealTimeRefreshInterval string `json:"realTimeRefreshInterval"`
+	}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("error parsing feeds config: %w", err)
+	}
+
+	feeds := make([]FeedConfig, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		feed := entry.FeedConfig
+		if feed.ID == "" || feed.GtfsURL == "" {
+			return nil, fmt.Errorf("feed %d needs an id and a gtfsUrl", i+1)
+		}
+		if seen[feed.ID] {
+			return nil, fmt.Errorf("duplicate feed id %q", feed.ID)
+		}
+		seen[feed.ID] = true
+
+		if entry.StaticRefreshInterval != "" {
+			if feed.StaticRefreshInterval, err = time.ParseDuration(entry.StaticRefreshInterval); err != nil {
+				return nil, fmt.Errorf("feed %q has an invalid staticRefreshInterval: %w", feed.ID, err)
+			}
+		}
+		if entry.RealTimeRefreshInterval != "" {
+			if feed.RealTimeRefreshInterval, err = time.ParseDuration(entry.RealTimeRefreshInterval); err != nil {
+				return nil, fmt.Errorf("feed %q has an invalid realTimeRefreshInterval: %w", feed.ID, err)
+			}
+		}
+		feeds = append(feeds, feed)
+	}
+	if len(feeds) == 0 {
+		return nil, fmt.Errorf("feeds config %s lists no feeds", path)
+	}
+	return feeds, nil
 }
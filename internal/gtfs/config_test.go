@@ -0,0 +1,96 @@
+package gtfs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+func writeFeedsConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "feeds.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestLoadFeedsConfig(t *testing.T) {
+	path := writeFeedsConfig(t, `[
+		{
+			"id": "raba",
+			"gtfsUrl": "https://example.com/raba.zip",
+			"tripUpdatesUrl": "https://example.com/raba/trip-updates",
+			"vehiclePositionsUrl": "https://example.com/raba/vehicle-positions",
+			"realTimeAuthHeaderName": "x-api-key",
+			"realTimeAuthHeaderValue": "secret",
+			"staticRefreshInterval": "12h",
+			"realTimeRefreshInterval": "15s"
+		},
+		{"id": "shuttle", "gtfsUrl": "/data/shuttle.zip"},
+		{
+			"id": "positions",
+			"gtfsUrl": "https://example.com/positions.zip",
+			"vehiclePositionsUrl": "https://example.com/positions/vehicle-positions"
+		}
+	]`)
+
+	feeds, err := LoadFeedsConfig(path)
+	require.NoError(t, err)
+	require.Len(t, feeds, 3)
+
+	raba := feeds[0]
+	assert.Equal(t, "raba", raba.ID)
+	assert.Equal(t, "https://example.com/raba/trip-updates", raba.TripUpdatesURL)
+	assert.Equal(t, 12*time.Hour, raba.staticRefreshInterval())
+	assert.Equal(t, 15*time.Second, raba.realTimeRefreshInterval())
+	assert.Equal(t, map[string]string{"x-api-key": "secret"}, raba.realTimeHeaders())
+	assert.True(t, raba.realTimeDataEnabled())
+	assert.False(t, raba.isLocalFile())
+
+	shuttle := feeds[1]
+	assert.Equal(t, DefaultStaticRefreshInterval, shuttle.staticRefreshInterval())
+	assert.Equal(t, DefaultRealTimeRefreshInterval, shuttle.realTimeRefreshInterval())
+	assert.False(t, shuttle.realTimeDataEnabled())
+	assert.True(t, shuttle.isLocalFile())
+
+	positions := feeds[2]
+	assert.True(t, positions.realTimeDataEnabled(), "a feed publishing only vehicle positions has realtime data")
+}
+
+func TestLoadFeedsConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents string
+	}{
+		{name: "MissingID", contents: `[{"gtfsUrl": "a.zip"}]`},
+		{name: "MissingURL", contents: `[{"id": "a"}]`},
+		{name: "DuplicateID", contents: `[{"id": "a", "gtfsUrl": "a.zip"}, {"id": "a", "gtfsUrl": "b.zip"}]`},
+		{name: "InvalidInterval", contents: `[{"id": "a", "gtfsUrl": "a.zip", "staticRefreshInterval": "daily"}]`},
+		{name: "NoFeeds", contents: `[]`},
+		{name: "InvalidJSON", contents: `{`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadFeedsConfig(writeFeedsConfig(t, tc.contents))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfigFeedsFallsBackToSingleFeed(t *testing.T) {
+	config := Config{GtfsURL: "raba.zip", TripUpdatesURL: "trips", VehiclePositionsURL: "vehicles"}
+
+	feeds := config.feeds()
+	require.Len(t, feeds, 1)
+	assert.Equal(t, gtfsdb.DefaultFeedID, feeds[0].ID)
+	assert.Equal(t, "raba.zip", feeds[0].GtfsURL)
+	assert.True(t, feeds[0].realTimeDataEnabled())
+
+	config.Feeds = []FeedConfig{{ID: "a", GtfsURL: "a.zip"}, {ID: "b", GtfsURL: "b.zip"}}
+	assert.Equal(t, config.Feeds, config.feeds())
+}
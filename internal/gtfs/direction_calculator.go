@@ -11,15 +11,21 @@ import (
 const unknownDirection = models.UnknownValue
 
 type DirectionCalculator struct {
-	queries *gtfsdb.Queries
+	manager *Manager
 }
 
-func NewDirectionCalculator(queries *gtfsdb.Queries) *DirectionCalculator {
+func NewDirectionCalculator(manager *Manager) *DirectionCalculator {
 	return &DirectionCalculator{
-		queries: queries,
+		manager: manager,
 	}
 }
 
+// queries returns the queries of the manager's current database, which changes when the
+// database is rebuilt
+func (dc *DirectionCalculator) queries() *gtfsdb.Queries {
+	return dc.manager.GtfsDB().Queries
+}
+
 // CalculateStopDirection determines the compass direction for a stop
 func (dc *DirectionCalculator) CalculateStopDirection(ctx context.Context, stopID string) string {
 	// Strategy 1: Try shape-based calculation
@@ -38,7 +44,7 @@ func (dc *DirectionCalculator) CalculateStopDirection(ctx context.Context, stopI
 
 func (dc *DirectionCalculator) calculateFromShape(ctx context.Context, stopID string) string {
 	// Get trips serving this stop
-	stopTrips, err := dc.queries.GetStopsWithTripContext(ctx, stopID)
+	stopTrips, err := dc.queries().GetStopsWithTripContext(ctx, stopID)
 	if err != nil || len(stopTrips) == 0 {
 		return unknownDirection
 	}
@@ -51,7 +57,7 @@ func (dc *DirectionCalculator) calculateFromShape(ctx context.Context, stopID st
 		}
 
 		// Get shape points for this trip
-		shapePoints, err := dc.queries.GetShapePointsForTrip(ctx, stopTrip.TripID)
+		shapePoints, err := dc.queries().GetShapePointsForTrip(ctx, stopTrip.TripID)
 		if err != nil || len(shapePoints) < 2 {
 			continue
 		}
@@ -75,7 +81,7 @@ func (dc *DirectionCalculator) calculateFromShape(ctx context.Context, stopID st
 }
 
 func (dc *DirectionCalculator) calculateFromNextStop(ctx context.Context, stopID string) string {
-	stopTrips, err := dc.queries.GetStopsWithTripContext(ctx, stopID)
+	stopTrips, err := dc.queries().GetStopsWithTripContext(ctx, stopID)
 	if err != nil || len(stopTrips) == 0 {
 		return unknownDirection
 	}
@@ -83,7 +89,7 @@ func (dc *DirectionCalculator) calculateFromNextStop(ctx context.Context, stopID
 	directions := make(map[string]int)
 
 	for _, stopTrip := range stopTrips {
-		nextStop, err := dc.queries.GetNextStopInTrip(ctx, gtfsdb.GetNextStopInTripParams{
+		nextStop, err := dc.queries().GetNextStopInTrip(ctx, gtfsdb.GetNextStopInTripParams{
 			TripID:       stopTrip.TripID,
 			StopSequence: stopTrip.StopSequence,
 		})
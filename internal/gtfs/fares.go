@@ -0,0 +1,198 @@
+package gtfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// ErrStopsNotOnTrip is returned when a fare is requested between stops the trip does not
+// visit in that order.
+var ErrStopsNotOnTrip = errors.New("stops are not visited by the trip in the given order")
+
+// FareRide describes a ride to price with GTFS Fares v1.
+type FareRide struct {
+	AgencyID        string
+	RouteID         string
+	OriginZone      string
+	DestinationZone string
+	// ContainsZones are the zones of every stop from boarding to alighting, inclusive
+	ContainsZones []string
+}
+
+// FaresForTrip returns the Fares v1 fares that apply to riding a trip from one stop to
+// another, cheapest first.
+func (manager *Manager) FaresForTrip(ctx context.Context, tripID, fromStopID, toStopID string) ([]gtfsdb.FareAttribute, error) {
+	queries := manager.GtfsDB().Queries
+
+	trip, err := queries.GetTrip(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading trip %s: %w", tripID, err)
+	}
+	route, err := queries.GetRoute(ctx, trip.RouteID)
+	if err != nil {
+		return nil, fmt.Errorf("error loading route %s: %w", trip.RouteID, err)
+	}
+
+	stops, err := queries.GetStopZonesForTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Board at the first visit to fromStopID and alight at the next visit to toStopID, so
+	// loop routes that pass a stop twice are priced for the shorter ride.
+	from, to := -1, -1
+	for i, stop := range stops {
+		if from == -1 && stop.StopID == fromStopID {
+			from = i
+		} else if from != -1 && stop.StopID == toStopID {
+			to = i
+			break
+		}
+	}
+	if from == -1 || to == -1 {
+		return nil, ErrStopsNotOnTrip
+	}
+
+	ride := FareRide{
+		AgencyID:        route.AgencyID,
+		RouteID:         route.ID,
+		OriginZone:      stops[from].ZoneID.String,
+		DestinationZone: stops[to].ZoneID.String,
+	}
+	for _, stop := range stops[from : to+1] {
+		if stop.ZoneID.String != "" {
+			ride.ContainsZones = append(ride.ContainsZones, stop.ZoneID.String)
+		}
+	}
+
+	attributes, err := queries.ListFareAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := queries.ListFareRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return MatchFares(attributes, rules, ride), nil
+}
+
+// FaresForRoute returns the Fares v1 fares that may apply to some ride on a route, cheapest
+// first.
+func (manager *Manager) FaresForRoute(ctx context.Context, agencyID, routeID string) ([]gtfsdb.FareAttribute, error) {
+	attributes, err := manager.GtfsDB().Queries.ListFareAttributes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := manager.GtfsDB().Queries.ListFareRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByFare := groupFareRules(rules)
+	var fares []gtfsdb.FareAttribute
+	for _, attribute := range attributes {
+		if !fareServesAgency(attribute, agencyID) {
+			continue
+		}
+		fareRules := rulesByFare[attribute.FareID]
+		matches := len(fareRules) == 0
+		for _, rule := range fareRules {
+			if !rule.RouteID.Valid || rule.RouteID.String == routeID {
+				matches = true
+				break
+			}
+		}
+		if matches {
+			fares = append(fares, attribute)
+		}
+	}
+	return fares, nil
+}
+
+// MatchFares returns the attributes, in their given order, that apply to a ride. A fare
+// applies when one of its rules matches the route, origin and destination zones, with blank
+// rule fields matching anything. Rules sharing a route, origin and destination list their
+// contains_id values together, and must name exactly the zones the ride passes through.
+// Fares without rules apply to every ride of their agency.
+func MatchFares(attributes []gtfsdb.FareAttribute, rules []gtfsdb.FareRule, ride FareRide) []gtfsdb.FareAttribute {
+	rideZones := make(map[string]bool, len(ride.ContainsZones))
+	for _, zone := range ride.ContainsZones {
+		rideZones[zone] = true
+	}
+
+	rulesByFare := groupFareRules(rules)
+	var fares []gtfsdb.FareAttribute
+	for _, attribute := range attributes {
+		if !fareServesAgency(attribute, ride.AgencyID) {
+			continue
+		}
+		fareRules := rulesByFare[attribute.FareID]
+		if len(fareRules) == 0 || fareRulesMatch(fareRules, ride, rideZones) {
+			fares = append(fares, attribute)
+		}
+	}
+	return fares
+}
+
+type fareRuleKey struct {
+	routeID, originID, destinationID string
+}
+
+func fareRulesMatch(rules []gtfsdb.FareRule, ride FareRide, rideZones map[string]bool) bool {
+	containsByKey := make(map[fareRuleKey]map[string]bool)
+	var keys []fareRuleKey
+	for _, rule := range rules {
+		key := fareRuleKey{rule.RouteID.String, rule.OriginID.String, rule.DestinationID.String}
+		if _, seen := containsByKey[key]; !seen {
+			containsByKey[key] = make(map[string]bool)
+			keys = append(keys, key)
+		}
+		if rule.ContainsID.Valid {
+			containsByKey[key][rule.ContainsID.String] = true
+		}
+	}
+
+	for _, key := range keys {
+		if key.routeID != "" && key.routeID != ride.RouteID {
+			continue
+		}
+		if key.originID != "" && key.originID != ride.OriginZone {
+			continue
+		}
+		if key.destinationID != "" && key.destinationID != ride.DestinationZone {
+			continue
+		}
+		if contains := containsByKey[key]; len(contains) > 0 && !sameZones(contains, rideZones) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func groupFareRules(rules []gtfsdb.FareRule) map[string][]gtfsdb.FareRule {
+	rulesByFare := make(map[string][]gtfsdb.FareRule)
+	for _, rule := range rules {
+		rulesByFare[rule.FareID] = append(rulesByFare[rule.FareID], rule)
+	}
+	return rulesByFare
+}
+
+func fareServesAgency(attribute gtfsdb.FareAttribute, agencyID string) bool {
+	return !attribute.AgencyID.Valid || attribute.AgencyID.String == agencyID
+}
+
+func sameZones(a, b map[string]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for zone := range a {
+		if !b[zone] {
+			return false
+		}
+	}
+	return true
+}
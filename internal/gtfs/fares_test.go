@@ -0,0 +1,122 @@
+package gtfs
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func fareRule(fareID, routeID, originID, destinationID, containsID string) gtfsdb.FareRule {
+	toNull := func(s string) sql.NullString { return sql.NullString{String: s, Valid: s != ""} }
+	return gtfsdb.FareRule{
+		FareID:        fareID,
+		RouteID:       toNull(routeID),
+		OriginID:      toNull(originID),
+		DestinationID: toNull(destinationID),
+		ContainsID:    toNull(containsID),
+	}
+}
+
+func fareIDs(fares []gtfsdb.FareAttribute) []string {
+	ids := []string{}
+	for _, fare := range fares {
+		ids = append(ids, fare.FareID)
+	}
+	return ids
+}
+
+func TestMatchFares(t *testing.T) {
+	attributes := []gtfsdb.FareAttribute{
+		{FareID: "local", Price: 1.5},
+		{FareID: "express", Price: 3},
+		{FareID: "zone_a_to_b", Price: 2},
+		{FareID: "through_abc", Price: 4},
+		{FareID: "anywhere", Price: 5, AgencyID: sql.NullString{String: "1", Valid: true}},
+		{FareID: "other_agency", Price: 6, AgencyID: sql.NullString{String: "2", Valid: true}},
+	}
+	rules := []gtfsdb.FareRule{
+		fareRule("local", "R1", "", "", ""),
+		fareRule("express", "X1", "", "", ""),
+		fareRule("zone_a_to_b", "", "A", "B", ""),
+		fareRule("through_abc", "", "", "", "A"),
+		fareRule("through_abc", "", "", "", "B"),
+		fareRule("through_abc", "", "", "", "C"),
+	}
+
+	tests := []struct {
+		name     string
+		ride     FareRide
+		expected []string
+	}{
+		{
+			name:     "route match",
+			ride:     FareRide{AgencyID: "1", RouteID: "R1", OriginZone: "A", DestinationZone: "A", ContainsZones: []string{"A"}},
+			expected: []string{"local", "anywhere"},
+		},
+		{
+			name:     "origin and destination zones",
+			ride:     FareRide{AgencyID: "1", RouteID: "R2", OriginZone: "A", DestinationZone: "B", ContainsZones: []string{"A", "B"}},
+			expected: []string{"zone_a_to_b", "anywhere"},
+		},
+		{
+			name:     "reverse direction does not match origin and destination",
+			ride:     FareRide{AgencyID: "1", RouteID: "R2", OriginZone: "B", DestinationZone: "A", ContainsZones: []string{"B", "A"}},
+			expected: []string{"anywhere"},
+		},
+		{
+			name:     "contains requires every zone passed through",
+			ride:     FareRide{AgencyID: "1", RouteID: "R2", OriginZone: "A", DestinationZone: "C", ContainsZones: []string{"A", "B", "C", "B"}},
+			expected: []string{"through_abc", "anywhere"},
+		},
+		{
+			name:     "contains does not match a subset",
+			ride:     FareRide{AgencyID: "1", RouteID: "R2", OriginZone: "A", DestinationZone: "B", ContainsZones: []string{"A", "B"}},
+			expected: []string{"zone_a_to_b", "anywhere"},
+		},
+		{
+			name:     "fares for other agencies are excluded",
+			ride:     FareRide{AgencyID: "2", RouteID: "X1"},
+			expected: []string{"express", "other_agency"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, fareIDs(MatchFares(attributes, rules, tt.ride)))
+		})
+	}
+}
+
+func TestFaresForTrip(t *testing.T) {
+	manager, err := InitGTFSManager(Config{
+		GtfsURL:      models.GetFixturePath(t, "raba.zip"),
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	})
+	require.NoError(t, err)
+	t.Cleanup(manager.Shutdown)
+
+	ctx := context.Background()
+	const tripID = "84f4520e-88b6-4ee6-8975-856799bc1359"
+	stopIDs, err := manager.GtfsDB().Queries.GetOrderedStopIDsForTrip(ctx, tripID)
+	require.NoError(t, err)
+	require.Greater(t, len(stopIDs), 2)
+
+	fares, err := manager.FaresForTrip(ctx, tripID, stopIDs[0], stopIDs[2])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"63"}, fareIDs(fares), "route 151 uses the local fare")
+	assert.Equal(t, 2.0, fares[0].Price)
+
+	_, err = manager.FaresForTrip(ctx, tripID, stopIDs[2], stopIDs[0])
+	assert.ErrorIs(t, err, ErrStopsNotOnTrip)
+
+	routeFares, err := manager.FaresForRoute(ctx, "25", "161")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"64"}, fareIDs(routeFares))
+}
@@ -0,0 +1,427 @@
+package gtfs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+var (
+	// ErrNoFaresV2Data is returned when the feed has no fare_leg_rules.txt to price with.
+	ErrNoFaresV2Data = errors.New("the feed has no Fares v2 data")
+	// ErrNoFareForLeg is returned when no fare leg rule applies to a leg.
+	ErrNoFareForLeg = errors.New("no fare leg rule applies to the leg")
+	// ErrMixedCurrencies is returned when an itinerary's fare products use different currencies.
+	ErrMixedCurrencies = errors.New("fare products for the itinerary use different currencies")
+)
+
+// Values of fare_transfer_type in fare_transfer_rules.txt
+const (
+	FromLegPlusTransfer         = 0 // A + AB: the next leg is covered by the transfer
+	FromLegPlusTransferPlusNext = 1 // A + AB + B
+	TransferOnly                = 2 // AB: the transfer replaces both legs
+)
+
+// Values of duration_limit_type in fare_transfer_rules.txt
+const (
+	durationDepartureToArrival   = 0
+	durationDepartureToDeparture = 1
+	durationArrivalToDeparture   = 2
+	durationArrivalToArrival     = 3
+)
+
+// FareLegError identifies the leg of an itinerary that could not be priced.
+type FareLegError struct {
+	Leg int
+	Err error
+}
+
+func (e *FareLegError) Error() string {
+	return fmt.Sprintf("leg %d: %v", e.Leg, e.Err)
+}
+
+func (e *FareLegError) Unwrap() error {
+	return e.Err
+}
+
+// FareLeg is one ride of an itinerary: a trip on a service date, boarded at one stop and
+// left at a later one. ServiceDate is midnight of the service day in the agency's timezone.
+type FareLeg struct {
+	TripID      string
+	FromStopID  string
+	ToStopID    string
+	ServiceDate time.Time
+}
+
+// PricedFareLeg is the fare product chosen for a leg. Amount is what the leg costs once
+// transfer rules are applied, which may be less than the product's amount.
+type PricedFareLeg struct {
+	LegGroupID string
+	Product    gtfsdb.FareProduct
+	Amount     float64
+}
+
+// PricedFareTransfer is a transfer rule applied between two consecutive legs. Product is nil
+// when the transfer itself is free.
+type PricedFareTransfer struct {
+	FromLeg int
+	ToLeg   int
+	Rule    gtfsdb.FareTransferRule
+	Product *gtfsdb.FareProduct
+	Amount  float64
+}
+
+// ItineraryFare is the price of an itinerary under GTFS Fares v2.
+type ItineraryFare struct {
+	Legs      []PricedFareLeg
+	Transfers []PricedFareTransfer
+	Currency  string
+	Total     float64
+}
+
+// fareLegContext is what a leg is matched against: the values of each fare leg rule field,
+// and the validation times that transfer duration limits are measured between.
+type fareLegContext struct {
+	Networks       []string
+	FromAreas      []string
+	ToAreas        []string
+	FromTimeframes []string
+	ToTimeframes   []string
+	Departure      time.Time
+	Arrival        time.Time
+}
+
+type faresV2Data struct {
+	products      map[string][]gtfsdb.FareProduct
+	legRules      []gtfsdb.FareLegRule
+	transferRules []gtfsdb.FareTransferRule
+}
+
+// PriceItinerary prices a multi-leg itinerary with GTFS Fares v2, choosing the cheapest
+// product for each leg and the cheapest transfer rule between consecutive legs. Failures
+// specific to one leg are returned as a *FareLegError.
+func (manager *Manager) PriceItinerary(ctx context.Context, legs []FareLeg) (*ItineraryFare, error) {
+	queries := manager.GtfsDB().Queries
+
+	legRules, err := queries.ListFareLegRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(legRules) == 0 {
+		return nil, ErrNoFaresV2Data
+	}
+	transferRules, err := queries.ListFareTransferRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	products, err := queries.ListFareProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeframes, err := queries.ListTimeframes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := faresV2Data{
+		products:      make(map[string][]gtfsdb.FareProduct),
+		legRules:      legRules,
+		transferRules: transferRules,
+	}
+	for _, product := range products {
+		data.products[product.FareProductID] = append(data.products[product.FareProductID], product)
+	}
+
+	contexts := make([]fareLegContext, len(legs))
+	for i, leg := range legs {
+		legContext, err := manager.fareLegContext(ctx, leg, timeframes)
+		if err != nil {
+			return nil, &FareLegError{Leg: i, Err: err}
+		}
+		contexts[i] = legContext
+	}
+
+	return data.priceItinerary(contexts)
+}
+
+// fareLegContext resolves the network, areas and timeframes a leg is matched on.
+func (manager *Manager) fareLegContext(ctx context.Context, leg FareLeg, timeframes []gtfsdb.Timeframe) (fareLegContext, error) {
+	queries := manager.GtfsDB().Queries
+
+	trip, err := queries.GetTrip(ctx, leg.TripID)
+	if err != nil {
+		return fareLegContext{}, fmt.Errorf("trip %s: %w", leg.TripID, err)
+	}
+
+	stopTimes, err := queries.GetStopTimesForTrip(ctx, leg.TripID)
+	if err != nil {
+		return fareLegContext{}, err
+	}
+	from, to := -1, -1
+	for i, stopTime := range stopTimes {
+		if from == -1 && stopTime.StopID == leg.FromStopID {
+			from = i
+		} else if from != -1 && stopTime.StopID == leg.ToStopID {
+			to = i
+			break
+		}
+	}
+	if from == -1 || to == -1 {
+		return fareLegContext{}, ErrStopsNotOnTrip
+	}
+
+	legContext := fareLegContext{
+		Departure: leg.ServiceDate.Add(time.Duration(stopTimes[from].DepartureTime)),
+		Arrival:   leg.ServiceDate.Add(time.Duration(stopTimes[to].ArrivalTime)),
+	}
+
+	networks, err := queries.GetNetworksForRoutes(ctx, []string{trip.RouteID})
+	if err != nil {
+		return fareLegContext{}, err
+	}
+	for _, network := range networks {
+		legContext.Networks = append(legContext.Networks, network.NetworkID)
+	}
+
+	areas, err := queries.GetAreasForStops(ctx, []string{leg.FromStopID, leg.ToStopID})
+	if err != nil {
+		return fareLegContext{}, err
+	}
+	for _, area := range areas {
+		if area.StopID == leg.FromStopID {
+			legContext.FromAreas = append(legContext.FromAreas, area.AreaID)
+		}
+		if area.StopID == leg.ToStopID {
+			legContext.ToAreas = append(legContext.ToAreas, area.AreaID)
+		}
+	}
+
+	activeServices := make(map[string]bool)
+	legContext.FromTimeframes = manager.timeframeGroupsAt(ctx, timeframes, legContext.Departure, activeServices)
+	legContext.ToTimeframes = manager.timeframeGroupsAt(ctx, timeframes, legContext.Arrival, activeServices)
+
+	return legContext, nil
+}
+
+// timeframeGroupsAt returns the timeframe groups containing a moment. Timeframes are
+// measured from local midnight of the moment's day, and apply only on dates their service is
+// active. activeServices caches service lookups across calls.
+func (manager *Manager) timeframeGroupsAt(ctx context.Context, timeframes []gtfsdb.Timeframe, at time.Time, activeServices map[string]bool) []string {
+	midnight := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	offset := int64(at.Sub(midnight))
+
+	var groups []string
+	for _, timeframe := range timeframes {
+		if offset < timeframe.StartTime || offset >= timeframe.EndTime {
+			continue
+		}
+		cacheKey := timeframe.ServiceID + "/" + midnight.Format("20060102")
+		active, ok := activeServices[cacheKey]
+		if !ok {
+			isActive, err := manager.IsServiceActiveOnDate(ctx, timeframe.ServiceID, midnight)
+			active = err == nil && isActive > 0
+			activeServices[cacheKey] = active
+		}
+		if active && !slices.Contains(groups, timeframe.TimeframeGroupID) {
+			groups = append(groups, timeframe.TimeframeGroupID)
+		}
+	}
+	return groups
+}
+
+func (data faresV2Data) priceItinerary(legs []fareLegContext) (*ItineraryFare, error) {
+	fare := &ItineraryFare{}
+	for i, leg := range legs {
+		legGroupID, product, ok := data.priceLeg(leg)
+		if !ok {
+			return nil, &FareLegError{Leg: i, Err: ErrNoFareForLeg}
+		}
+		fare.Legs = append(fare.Legs, PricedFareLeg{
+			LegGroupID: legGroupID,
+			Product:    product,
+			Amount:     product.Amount,
+		})
+	}
+
+	// transferCount is the number of transfers in the current chain of discounted legs
+	transferCount := int64(0)
+	for i := 0; i+1 < len(legs); i++ {
+		transfer, fromAmount, toAmount, ok := data.priceTransfer(fare.Legs[i], fare.Legs[i+1], legs[i], legs[i+1], transferCount+1)
+		if !ok {
+			transferCount = 0
+			continue
+		}
+		transferCount++
+		transfer.FromLeg, transfer.ToLeg = i, i+1
+		fare.Legs[i].Amount, fare.Legs[i+1].Amount = fromAmount, toAmount
+		fare.Transfers = append(fare.Transfers, transfer)
+	}
+
+	for _, leg := range fare.Legs {
+		if err := fare.addAmount(leg.Product.Currency, leg.Amount); err != nil {
+			return nil, err
+		}
+	}
+	for _, transfer := range fare.Transfers {
+		if transfer.Product == nil {
+			continue
+		}
+		if err := fare.addAmount(transfer.Product.Currency, transfer.Amount); err != nil {
+			return nil, err
+		}
+	}
+	fare.Total = math.Round(fare.Total*1e4) / 1e4
+	return fare, nil
+}
+
+func (fare *ItineraryFare) addAmount(currency string, amount float64) error {
+	if fare.Currency == "" {
+		fare.Currency = currency
+	} else if fare.Currency != currency {
+		return ErrMixedCurrencies
+	}
+	fare.Total += amount
+	return nil
+}
+
+// priceLeg returns the cheapest product of the highest-priority fare leg rules matching a
+// leg, along with the leg group of the rule it came from.
+func (data faresV2Data) priceLeg(leg fareLegContext) (string, gtfsdb.FareProduct, bool) {
+	matchNetwork := fareFieldMatcher(data.legRules, func(r gtfsdb.FareLegRule) sql.NullString { return r.NetworkID }, leg.Networks)
+	matchFromArea := fareFieldMatcher(data.legRules, func(r gtfsdb.FareLegRule) sql.NullString { return r.FromAreaID }, leg.FromAreas)
+	matchToArea := fareFieldMatcher(data.legRules, func(r gtfsdb.FareLegRule) sql.NullString { return r.ToAreaID }, leg.ToAreas)
+	matchFromTimeframe := fareFieldMatcher(data.legRules, func(r gtfsdb.FareLegRule) sql.NullString { return r.FromTimeframeGroupID }, leg.FromTimeframes)
+	matchToTimeframe := fareFieldMatcher(data.legRules, func(r gtfsdb.FareLegRule) sql.NullString { return r.ToTimeframeGroupID }, leg.ToTimeframes)
+
+	var (
+		best       gtfsdb.FareProduct
+		legGroupID string
+		found      bool
+		priority   int64
+	)
+	for _, rule := range data.legRules {
+		if found && rule.RulePriority < priority {
+			// Rules are ordered by descending priority, so lower ones no longer apply
+			break
+		}
+		if !matchNetwork(rule.NetworkID) || !matchFromArea(rule.FromAreaID) || !matchToArea(rule.ToAreaID) ||
+			!matchFromTimeframe(rule.FromTimeframeGroupID) || !matchToTimeframe(rule.ToTimeframeGroupID) {
+			continue
+		}
+		product, ok := data.cheapestProduct(rule.FareProductID)
+		if !ok {
+			continue
+		}
+		if !found || product.Amount < best.Amount {
+			best, legGroupID, found, priority = product, rule.LegGroupID.String, true, rule.RulePriority
+		}
+	}
+	return legGroupID, best, found
+}
+
+// priceTransfer finds the transfer rule between two legs that makes them cheapest, returning
+// the transfer and the amounts then charged for each leg. transferCount is the position of
+// this transfer in the current chain of transfers.
+func (data faresV2Data) priceTransfer(from, to PricedFareLeg, fromLeg, toLeg fareLegContext, transferCount int64) (PricedFareTransfer, float64, float64, bool) {
+	matchFromGroup := fareFieldMatcher(data.transferRules, func(r gtfsdb.FareTransferRule) sql.NullString { return r.FromLegGroupID }, nonEmpty(from.LegGroupID))
+	matchToGroup := fareFieldMatcher(data.transferRules, func(r gtfsdb.FareTransferRule) sql.NullString { return r.ToLegGroupID }, nonEmpty(to.LegGroupID))
+
+	var (
+		best             PricedFareTransfer
+		bestFrom, bestTo float64
+		bestCost         float64
+		found            bool
+	)
+	for _, rule := range data.transferRules {
+		if !matchFromGroup(rule.FromLegGroupID) || !matchToGroup(rule.ToLegGroupID) {
+			continue
+		}
+		if rule.TransferCount.Valid && rule.TransferCount.Int64 != -1 && transferCount > rule.TransferCount.Int64 {
+			continue
+		}
+		if rule.DurationLimit.Valid && transferDuration(rule.DurationLimitType.Int64, fromLeg, toLeg) > time.Duration(rule.DurationLimit.Int64)*time.Second {
+			continue
+		}
+
+		transfer := PricedFareTransfer{Rule: rule}
+		if rule.FareProductID.Valid {
+			product, ok := data.cheapestProduct(rule.FareProductID.String)
+			if !ok {
+				continue
+			}
+			transfer.Product = &product
+			transfer.Amount = product.Amount
+		}
+
+		fromAmount, toAmount := from.Amount, to.Amount
+		switch rule.FareTransferType {
+		case FromLegPlusTransfer:
+			toAmount = 0
+		case TransferOnly:
+			fromAmount, toAmount = 0, 0
+		}
+
+		cost := fromAmount + toAmount + transfer.Amount
+		if !found || cost < bestCost {
+			best, bestFrom, bestTo, bestCost, found = transfer, fromAmount, toAmount, cost, true
+		}
+	}
+	return best, bestFrom, bestTo, found
+}
+
+func (data faresV2Data) cheapestProduct(productID string) (gtfsdb.FareProduct, bool) {
+	var cheapest gtfsdb.FareProduct
+	found := false
+	for _, product := range data.products[productID] {
+		if !found || product.Amount < cheapest.Amount {
+			cheapest, found = product, true
+		}
+	}
+	return cheapest, found
+}
+
+// transferDuration measures the time between two legs as a duration_limit_type defines it.
+func transferDuration(durationLimitType int64, from, to fareLegContext) time.Duration {
+	switch durationLimitType {
+	case durationDepartureToDeparture:
+		return to.Departure.Sub(from.Departure)
+	case durationArrivalToDeparture:
+		return to.Departure.Sub(from.Arrival)
+	case durationArrivalToArrival:
+		return to.Arrival.Sub(from.Arrival)
+	default:
+		return to.Arrival.Sub(from.Departure)
+	}
+}
+
+// fareFieldMatcher implements the Fares v2 rule for one matchable field. When some rule
+// names one of the leg's values, only rules naming one of them match; otherwise only rules
+// leaving the field blank do.
+func fareFieldMatcher[R any](rules []R, field func(R) sql.NullString, values []string) func(sql.NullString) bool {
+	explicit := false
+	for _, rule := range rules {
+		if value := field(rule); value.Valid && slices.Contains(values, value.String) {
+			explicit = true
+			break
+		}
+	}
+	return func(value sql.NullString) bool {
+		if explicit {
+			return value.Valid && slices.Contains(values, value.String)
+		}
+		return !value.Valid
+	}
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,232 @@
+package gtfs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullInt(n int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: n, Valid: true}
+}
+
+func newTestFaresV2Data() faresV2Data {
+	products := []gtfsdb.FareProduct{
+		{FareProductID: "local", FareMediaID: "cash", Amount: 2.5, Currency: "USD"},
+		{FareProductID: "local", FareMediaID: "card", Amount: 2.0, Currency: "USD"},
+		{FareProductID: "express", Amount: 4.0, Currency: "USD"},
+		{FareProductID: "airport", Amount: 7.0, Currency: "USD"},
+		{FareProductID: "off_peak", Amount: 1.5, Currency: "USD"},
+		{FareProductID: "upgrade", Amount: 1.0, Currency: "USD"},
+		{FareProductID: "euro", Amount: 3.0, Currency: "EUR"},
+	}
+	data := faresV2Data{
+		products: make(map[string][]gtfsdb.FareProduct),
+		legRules: []gtfsdb.FareLegRule{
+			// Ordered by descending priority, as ListFareLegRules returns them
+			{LegGroupID: nullString("airport"), NetworkID: nullString("bus"), ToAreaID: nullString("airport"), FareProductID: "airport", RulePriority: 1},
+			{LegGroupID: nullString("local"), NetworkID: nullString("bus"), FareProductID: "local"},
+			{LegGroupID: nullString("local"), NetworkID: nullString("bus"), FromTimeframeGroupID: nullString("off_peak"), FareProductID: "off_peak"},
+			{LegGroupID: nullString("express"), NetworkID: nullString("express"), FareProductID: "express"},
+			{LegGroupID: nullString("rail"), NetworkID: nullString("rail"), FareProductID: "euro"},
+		},
+		transferRules: []gtfsdb.FareTransferRule{
+			{FromLegGroupID: nullString("local"), ToLegGroupID: nullString("local"), TransferCount: nullInt(1), DurationLimit: nullInt(5400), DurationLimitType: nullInt(durationDepartureToDeparture), FareTransferType: FromLegPlusTransfer},
+			{FromLegGroupID: nullString("local"), ToLegGroupID: nullString("express"), FareTransferType: FromLegPlusTransferPlusNext, FareProductID: nullString("upgrade")},
+		},
+	}
+	for _, product := range products {
+		data.products[product.FareProductID] = append(data.products[product.FareProductID], product)
+	}
+	return data
+}
+
+func TestPriceItineraryLegs(t *testing.T) {
+	data := newTestFaresV2Data()
+	start := time.Date(2025, 6, 12, 8, 0, 0, 0, time.UTC)
+
+	t.Run("cheapest product of the matching rule", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{{Networks: []string{"bus"}, Departure: start, Arrival: start.Add(20 * time.Minute)}})
+		require.NoError(t, err)
+		assert.Equal(t, "local", fare.Legs[0].LegGroupID)
+		assert.Equal(t, "card", fare.Legs[0].Product.FareMediaID)
+		assert.Equal(t, 2.0, fare.Total)
+		assert.Equal(t, "USD", fare.Currency)
+	})
+
+	t.Run("explicit timeframe rule replaces blank one", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{{Networks: []string{"bus"}, FromTimeframes: []string{"off_peak"}}})
+		require.NoError(t, err)
+		assert.Equal(t, "off_peak", fare.Legs[0].Product.FareProductID)
+	})
+
+	t.Run("higher priority rule wins", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{{Networks: []string{"bus"}, ToAreas: []string{"airport"}}})
+		require.NoError(t, err)
+		assert.Equal(t, "airport", fare.Legs[0].Product.FareProductID, "priority 1 applies even though it costs more")
+	})
+
+	t.Run("no matching rule", func(t *testing.T) {
+		_, err := data.priceItinerary([]fareLegContext{{Networks: []string{"bus"}}, {Networks: []string{"ferry"}}})
+		var legErr *FareLegError
+		require.True(t, errors.As(err, &legErr))
+		assert.Equal(t, 1, legErr.Leg)
+		assert.ErrorIs(t, err, ErrNoFareForLeg)
+	})
+
+	t.Run("mixed currencies", func(t *testing.T) {
+		_, err := data.priceItinerary([]fareLegContext{{Networks: []string{"bus"}}, {Networks: []string{"rail"}}})
+		assert.ErrorIs(t, err, ErrMixedCurrencies)
+	})
+}
+
+func TestPriceItineraryTransfers(t *testing.T) {
+	data := newTestFaresV2Data()
+	start := time.Date(2025, 6, 12, 8, 0, 0, 0, time.UTC)
+	busLeg := func(departure time.Duration) fareLegContext {
+		return fareLegContext{Networks: []string{"bus"}, Departure: start.Add(departure), Arrival: start.Add(departure + 20*time.Minute)}
+	}
+
+	t.Run("free transfer within the duration limit", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{busLeg(0), busLeg(30 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, fare.Transfers, 1)
+		assert.Nil(t, fare.Transfers[0].Product)
+		assert.Equal(t, 0.0, fare.Legs[1].Amount)
+		assert.Equal(t, 2.0, fare.Total)
+	})
+
+	t.Run("duration limit exceeded", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{busLeg(0), busLeg(2 * time.Hour)})
+		require.NoError(t, err)
+		assert.Empty(t, fare.Transfers)
+		assert.Equal(t, 4.0, fare.Total)
+	})
+
+	t.Run("transfer count limits the chain", func(t *testing.T) {
+		fare, err := data.priceItinerary([]fareLegContext{busLeg(0), busLeg(30 * time.Minute), busLeg(60 * time.Minute), busLeg(80 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, fare.Transfers, 2, "the second transfer exceeds transfer_count and starts a new chain")
+		assert.Equal(t, 0, fare.Transfers[0].FromLeg)
+		assert.Equal(t, 2, fare.Transfers[1].FromLeg)
+		assert.Equal(t, 4.0, fare.Total)
+	})
+
+	t.Run("upgrade product adds to both legs", func(t *testing.T) {
+		express := fareLegContext{Networks: []string{"express"}, Departure: start.Add(30 * time.Minute), Arrival: start.Add(time.Hour)}
+		fare, err := data.priceItinerary([]fareLegContext{busLeg(0), express})
+		require.NoError(t, err)
+		require.Len(t, fare.Transfers, 1)
+		assert.Equal(t, "upgrade", fare.Transfers[0].Product.FareProductID)
+		assert.Equal(t, 7.0, fare.Total)
+	})
+
+	t.Run("transfer-only product replaces both legs", func(t *testing.T) {
+		data := newTestFaresV2Data()
+		data.transferRules = []gtfsdb.FareTransferRule{
+			{FromLegGroupID: nullString("local"), ToLegGroupID: nullString("express"), FareTransferType: TransferOnly, FareProductID: nullString("express")},
+		}
+		express := fareLegContext{Networks: []string{"express"}}
+		fare, err := data.priceItinerary([]fareLegContext{busLeg(0), express})
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, fare.Legs[0].Amount)
+		assert.Equal(t, 0.0, fare.Legs[1].Amount)
+		assert.Equal(t, 4.0, fare.Total)
+	})
+}
+
+func TestFareFieldMatcher(t *testing.T) {
+	rules := []gtfsdb.FareLegRule{
+		{NetworkID: nullString("bus")},
+		{},
+	}
+	network := func(r gtfsdb.FareLegRule) sql.NullString { return r.NetworkID }
+
+	matchBus := fareFieldMatcher(rules, network, []string{"bus"})
+	assert.True(t, matchBus(nullString("bus")))
+	assert.False(t, matchBus(sql.NullString{}), "blank only matches when no rule names the value")
+
+	matchRail := fareFieldMatcher(rules, network, []string{"rail"})
+	assert.False(t, matchRail(nullString("bus")))
+	assert.True(t, matchRail(sql.NullString{}))
+}
+
+func TestManagerPriceItinerary(t *testing.T) {
+	ctx := context.Background()
+	const tripID = "84f4520e-88b6-4ee6-8975-856799bc1359"
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	thursday := time.Date(2025, 6, 12, 0, 0, 0, 0, loc)
+	leg := FareLeg{TripID: tripID, FromStopID: "1030", ToStopID: "1032", ServiceDate: thursday}
+
+	t.Run("feed without fares v2", func(t *testing.T) {
+		manager, err := InitGTFSManager(Config{
+			GtfsURL:      models.GetFixturePath(t, "raba.zip"),
+			GTFSDataPath: ":memory:",
+			Env:          appconf.Test,
+		})
+		require.NoError(t, err)
+		t.Cleanup(manager.Shutdown)
+
+		_, err = manager.PriceItinerary(ctx, []FareLeg{leg})
+		assert.ErrorIs(t, err, ErrNoFaresV2Data)
+	})
+
+	feedPath := models.BuildFeedWithFiles(t, models.GetFixturePath(t, "raba.zip"), map[string]string{
+		"fare_products.txt": "fare_product_id,amount,currency\n" +
+			"local,2.00,USD\n" +
+			"early_bird,1.25,USD\n",
+		"networks.txt":       "network_id,network_name\nbus,Local bus\n",
+		"route_networks.txt": "network_id,route_id\nbus,151\n",
+		"timeframes.txt": "timeframe_group_id,start_time,end_time,service_id\n" +
+			"early,05:00:00,06:00:00,c_1658_b_18260_d_31\n",
+		"fare_leg_rules.txt": "leg_group_id,network_id,from_timeframe_group_id,fare_product_id\n" +
+			"local,bus,,local\n" +
+			"local,bus,early,early_bird\n",
+	})
+	manager, err := InitGTFSManager(Config{
+		GtfsURL:      feedPath,
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	})
+	require.NoError(t, err)
+	t.Cleanup(manager.Shutdown)
+
+	t.Run("timeframe applies on an active service date", func(t *testing.T) {
+		fare, err := manager.PriceItinerary(ctx, []FareLeg{leg})
+		require.NoError(t, err)
+		require.Len(t, fare.Legs, 1)
+		assert.Equal(t, "early_bird", fare.Legs[0].Product.FareProductID)
+		assert.Equal(t, 1.25, fare.Total)
+	})
+
+	t.Run("timeframe does not apply when its service is inactive", func(t *testing.T) {
+		sunday := leg
+		sunday.ServiceDate = time.Date(2025, 6, 15, 0, 0, 0, 0, loc)
+		fare, err := manager.PriceItinerary(ctx, []FareLeg{sunday})
+		require.NoError(t, err)
+		assert.Equal(t, "local", fare.Legs[0].Product.FareProductID)
+	})
+
+	t.Run("stops out of order", func(t *testing.T) {
+		reversed := leg
+		reversed.FromStopID, reversed.ToStopID = leg.ToStopID, leg.FromStopID
+		_, err := manager.PriceItinerary(ctx, []FareLeg{leg, reversed})
+		var legErr *FareLegError
+		require.True(t, errors.As(err, &legErr))
+		assert.Equal(t, 1, legErr.Leg)
+		assert.ErrorIs(t, err, ErrStopsNotOnTrip)
+	})
+}
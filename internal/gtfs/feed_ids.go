@@ -0,0 +1,132 @@
+package gtfs
+
+import (
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"google.golang.org/protobuf/proto"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// scopeStaticIDs moves the IDs of a feed's static data into namespace, as the importer does
+// with its rows in the database. Trips, stop times and transfers point at the routes, services,
+// stops and shapes they refer to, so those follow.
+func scopeStaticIDs(staticData *gtfs.Static, namespace string) {
+	if namespace == "" {
+		return
+	}
+	for i := range staticData.Routes {
+		staticData.Routes[i].Id = gtfsdb.ScopedID(namespace, staticData.Routes[i].Id)
+	}
+	for i := range staticData.Stops {
+		stop := &staticData.Stops[i]
+		stop.Id = gtfsdb.ScopedID(namespace, stop.Id)
+		stop.ZoneId = gtfsdb.ScopedID(namespace, stop.ZoneId)
+	}
+	for i := range staticData.Services {
+		staticData.Services[i].Id = gtfsdb.ScopedID(namespace, staticData.Services[i].Id)
+	}
+	for i := range staticData.Shapes {
+		staticData.Shapes[i].ID = gtfsdb.ScopedID(namespace, staticData.Shapes[i].ID)
+	}
+	for i := range staticData.Trips {
+		trip := &staticData.Trips[i]
+		trip.ID = gtfsdb.ScopedID(namespace, trip.ID)
+		trip.BlockID = gtfsdb.ScopedID(namespace, trip.BlockID)
+	}
+}
+
+// scopeRealTimeIDs moves the trip, route, stop and vehicle IDs of a feed's realtime data into
+// namespace, so that they match its static data and resolve from combined IDs like it. Alert
+// IDs are looked up as they are written, and are left unchanged.
+func scopeRealTimeIDs(data *realTimeFeedData, namespace string) {
+	if namespace == "" {
+		return
+	}
+
+	// Trips and vehicles point at each other, and share stop time updates with the copies held
+	// in the slices, so each is scoped once, as a copy
+	scopedTrips := make(map[*gtfs.Trip]*gtfs.Trip)
+	scopedVehicles := make(map[*gtfs.Vehicle]*gtfs.Vehicle)
+	var scopeTrip func(trip *gtfs.Trip) *gtfs.Trip
+	var scopeVehicle func(vehicle *gtfs.Vehicle) *gtfs.Vehicle
+	scopeTrip = func(trip *gtfs.Trip) *gtfs.Trip {
+		if scoped, ok := scopedTrips[trip]; ok {
+			return scoped
+		}
+		scoped := *trip
+		scopedTrips[trip] = &scoped
+		scoped.ID.ID = gtfsdb.ScopedID(namespace, trip.ID.ID)
+		scoped.ID.RouteID = gtfsdb.ScopedID(namespace, trip.ID.RouteID)
+		scoped.StopTimeUpdates = make([]gtfs.StopTimeUpdate, len(trip.StopTimeUpdates))
+		for i, update := range trip.StopTimeUpdates {
+			update.StopID = scopedIDPointer(namespace, update.StopID)
+			scoped.StopTimeUpdates[i] = update
+		}
+		if trip.Vehicle != nil {
+			scoped.Vehicle = scopeVehicle(trip.Vehicle)
+		}
+		return &scoped
+	}
+	scopeVehicle = func(vehicle *gtfs.Vehicle) *gtfs.Vehicle {
+		if scoped, ok := scopedVehicles[vehicle]; ok {
+			return scoped
+		}
+		scoped := *vehicle
+		scopedVehicles[vehicle] = &scoped
+		scoped.StopID = scopedIDPointer(namespace, vehicle.StopID)
+		if vehicle.ID != nil {
+			id := *vehicle.ID
+			id.ID = gtfsdb.ScopedID(namespace, id.ID)
+			scoped.ID = &id
+		}
+		if vehicle.Trip != nil {
+			scoped.Trip = scopeTrip(vehicle.Trip)
+		}
+		return &scoped
+	}
+
+	for i := range data.trips {
+		data.trips[i] = *scopeTrip(&data.trips[i])
+	}
+	for i := range data.vehicles {
+		data.vehicles[i] = *scopeVehicle(&data.vehicles[i])
+	}
+	for i := range data.alerts {
+		for j := range data.alerts[i].InformedEntities {
+			entity := &data.alerts[i].InformedEntities[j]
+			entity.RouteID = scopedIDPointer(namespace, entity.RouteID)
+			entity.StopID = scopedIDPointer(namespace, entity.StopID)
+			if entity.TripID != nil {
+				tripID := *entity.TripID
+				tripID.ID = gtfsdb.ScopedID(namespace, tripID.ID)
+				tripID.RouteID = gtfsdb.ScopedID(namespace, tripID.RouteID)
+				entity.TripID = &tripID
+			}
+		}
+	}
+
+	if len(data.tripProperties) > 0 {
+		tripProperties := make(map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties, len(data.tripProperties))
+		for key, properties := range data.tripProperties {
+			key.tripID = gtfsdb.ScopedID(namespace, key.tripID)
+			scoped := proto.Clone(properties).(*gtfsrt.TripUpdate_TripProperties)
+			if scoped.TripId != nil {
+				scoped.TripId = proto.String(gtfsdb.ScopedID(namespace, scoped.GetTripId()))
+			}
+			if scoped.ShapeId != nil {
+				scoped.ShapeId = proto.String(gtfsdb.ScopedID(namespace, scoped.GetShapeId()))
+			}
+			tripProperties[key] = scoped
+		}
+		data.tripProperties = tripProperties
+	}
+}
+
+// scopedIDPointer returns a pointer to id moved into namespace, or nil when id is nil.
+func scopedIDPointer(namespace string, id *string) *string {
+	if id == nil {
+		return nil
+	}
+	scoped := gtfsdb.ScopedID(namespace, *id)
+	return &scoped
+}
@@ -0,0 +1,63 @@
+package gtfs
+
+import (
+	"testing"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+func TestScopeRealTimeIDs(t *testing.T) {
+	stopID := "A"
+	vehicle := &gtfs.Vehicle{ID: &gtfs.VehicleID{ID: "bus"}, StopID: &stopID}
+	trip := &gtfs.Trip{
+		ID:              gtfs.TripID{ID: "T1", RouteID: "R1"},
+		StopTimeUpdates: []gtfs.StopTimeUpdate{{StopID: &stopID}},
+		Vehicle:         vehicle,
+	}
+	vehicle.Trip = trip
+
+	data := realTimeFeedData{
+		// As parsed, the slices hold copies that share stop time updates with the linked trip
+		trips:    []gtfs.Trip{*trip},
+		vehicles: []gtfs.Vehicle{*vehicle},
+		alerts: []gtfs.Alert{{
+			ID:               "alert",
+			InformedEntities: []gtfs.AlertInformedEntity{{StopID: &stopID, TripID: &gtfs.TripID{ID: "T1"}}},
+		}},
+		tripProperties: map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties{
+			{tripID: "T1"}: {TripId: proto.String("T1-copy")},
+		},
+	}
+	scopeRealTimeIDs(&data, "feed")
+
+	scopedStop := gtfsdb.ScopedID("feed", "A")
+	require.Len(t, data.trips, 1)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "T1"), data.trips[0].ID.ID)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "R1"), data.trips[0].ID.RouteID)
+	assert.Equal(t, scopedStop, *data.trips[0].StopTimeUpdates[0].StopID)
+	assert.Equal(t, scopedStop, *data.trips[0].Vehicle.StopID)
+
+	require.Len(t, data.vehicles, 1)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "bus"), data.vehicles[0].ID.ID)
+	assert.Equal(t, data.vehicles[0].ID.ID, data.trips[0].Vehicle.ID.ID)
+	assert.Equal(t, scopedStop, *data.vehicles[0].StopID)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "T1"), data.vehicles[0].Trip.ID.ID)
+	assert.Equal(t, scopedStop, *data.vehicles[0].Trip.StopTimeUpdates[0].StopID, "shared updates are scoped once")
+
+	assert.Equal(t, "alert", data.alerts[0].ID, "alert IDs are left as they are")
+	assert.Equal(t, scopedStop, *data.alerts[0].InformedEntities[0].StopID)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "T1"), data.alerts[0].InformedEntities[0].TripID.ID)
+
+	properties := data.tripProperties[tripDescriptorKey{tripID: gtfsdb.ScopedID("feed", "T1")}]
+	require.NotNil(t, properties)
+	assert.Equal(t, gtfsdb.ScopedID("feed", "T1-copy"), properties.GetTripId())
+
+	assert.Equal(t, "A", stopID, "the parsed data is left unchanged")
+	assert.Equal(t, "T1", trip.ID.ID)
+	assert.Equal(t, "bus", vehicle.ID.ID)
+}
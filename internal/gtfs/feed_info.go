@@ -0,0 +1,42 @@
+package gtfs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// checkFeedValidity logs a warning when the given time falls outside the service dates
+// covered by the imported feed, which usually means the feed is stale or not yet in effect.
+func (manager *Manager) checkFeedValidity(ctx context.Context, now time.Time) {
+	logger := slog.Default().With(slog.String("component", "gtfs_manager"))
+
+	startDate, endDate, err := manager.GtfsDB().FeedValidityWindow(ctx)
+	if err != nil {
+		logging.LogError(logger, "Error reading feed validity window", err)
+		return
+	}
+
+	if agencies := manager.GetAgencies(); len(agencies) > 0 {
+		if loc, err := time.LoadLocation(agencies[0].Timezone); err == nil {
+			now = now.In(loc)
+		}
+	}
+
+	today := now.Format("20060102")
+	if isOutsideValidityWindow(startDate, endDate, today) {
+		logger.LogAttrs(ctx, slog.LevelWarn, "feed_outside_validity_window",
+			slog.String("today", today),
+			slog.String("feed_start_date", startDate),
+			slog.String("feed_end_date", endDate),
+			slog.Int("feed_count", len(manager.feeds)))
+	}
+}
+
+// isOutsideValidityWindow reports whether date lies before startDate or after endDate. All
+// dates are YYYYMMDD, so they compare lexically; an empty bound is unbounded.
+func isOutsideValidityWindow(startDate, endDate, date string) bool {
+	return (startDate != "" && date < startDate) || (endDate != "" && date > endDate)
+}
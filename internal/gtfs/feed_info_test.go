@@ -0,0 +1,32 @@
+package gtfs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOutsideValidityWindow(t *testing.T) {
+	tests := []struct {
+		name      string
+		startDate string
+		endDate   string
+		date      string
+		expected  bool
+	}{
+		{"within window", "20250101", "20251231", "20250612", false},
+		{"first day", "20250101", "20251231", "20250101", false},
+		{"last day", "20250101", "20251231", "20251231", false},
+		{"before start", "20250101", "20251231", "20241231", true},
+		{"after end", "20250101", "20251231", "20260101", true},
+		{"open start", "", "20251231", "20200101", false},
+		{"open end", "20250101", "", "20300101", false},
+		{"no window", "", "", "20250612", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isOutsideValidityWindow(tt.startDate, tt.endDate, tt.date))
+		})
+	}
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"os"
 	"sort"
-	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"maglev.onebusaway.org/gtfsdb"
@@ -21,58 +22,79 @@ const NoRadiusLimit = -1
 
 // Manager manages the GTFS data and provides methods to access it
 type Manager struct {
-	gtfsSource       string
-	gtfsData         *gtfs.Static
-	GtfsDB           *gtfsdb.Client
-	lastUpdated      time.Time
-	isLocalFile      bool
-	realTimeTrips    []gtfs.Trip
-	realTimeVehicles []gtfs.Vehicle
-	realTimeMutex    sync.RWMutex
-	realTimeAlerts   []gtfs.Alert
-	staticMutex      sync.RWMutex // Protects gtfsData and lastUpdated
-	config           Config
-	shutdownChan     chan struct{}
-	wg               sync.WaitGroup
-	shutdownOnce     sync.Once
+	feeds                  []FeedConfig
+	feedData               map[string]*gtfs.Static // Static data of each feed, by feed ID
+	gtfsData               *gtfs.Static            // Static data of all feeds together
+	gtfsDB                 atomic.Pointer[gtfsdb.Client]
+	rebuildMutex           sync.Mutex // Serializes rebuilds of gtfsDB
+	rebuildStats           RebuildStats
+	rebuildStatsMutex      sync.Mutex // Protects rebuildStats
+	serviceCalendar        atomic.Pointer[ServiceCalendar]
+	serviceCalendarMutex   sync.Mutex // Serializes loads of serviceCalendar
+	lastUpdated            time.Time
+	realTimeFeeds          map[string]*realTimeFeedData // Realtime data of each feed, by feed ID
+	realTime               atomic.Pointer[realTimeSnapshot]
+	realTimeMutex          sync.Mutex   // Serializes refreshes of realTimeFeeds and realTime
+	staticMutex            sync.RWMutex // Protects feedData, gtfsData, lastUpdated and translations
+	translations           *Translations
+	alarmCallbackClient    *http.Client // POSTs arrival alarms to their callback URLs
+	alarmRegistrationMutex sync.Mutex   // Serializes checks of the alarm limits with the inserts
+	config                 Config
+	shutdownChan           chan struct{}
+	wg                     sync.WaitGroup
+	shutdownOnce           sync.Once
 }
 
-// InitGTFSManager initializes the Manager with the GTFS data from the given source
-// The source can be either a URL or a local file path
+// InitGTFSManager initializes the Manager with the GTFS data of the configured feeds
+// Each feed's source can be either a URL or a local file path
 func InitGTFSManager(config Config) (*Manager, error) {
-	isLocalFile := !strings.HasPrefix(config.GtfsURL, "http://") && !strings.HasPrefix(config.GtfsURL, "https://")
-
-	staticData, err := loadGTFSData(config.GtfsURL, isLocalFile)
-	if err != nil {
-		return nil, err
+	manager := &Manager{
+		feeds:               config.feeds(),
+		config:              config,
+		alarmCallbackClient: newAlarmCallbackClient(config.AlarmCallbackAllowedHosts),
+		shutdownChan:        make(chan struct{}),
 	}
 
-	manager := &Manager{
-		gtfsSource:   config.GtfsURL,
-		isLocalFile:  isLocalFile,
-		config:       config,
-		shutdownChan: make(chan struct{}),
+	// Each feed is fetched once, for both the in-memory data and the database
+	downloads := make(map[string]*gtfsdb.FeedDownload, len(manager.feeds))
+	for _, feed := range manager.feeds {
+		download, err := fetchFeed(context.Background(), feed, gtfsdb.FeedValidators{}, config.StaticDownload)
+		if err != nil {
+			return nil, fmt.Errorf("error loading feed %s: %w", feed.ID, err)
+		}
+		staticData, err := gtfs.ParseStatic(download.Data, gtfs.ParseStaticOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error parsing feed %s: %w", feed.ID, err)
+		}
+		manager.setStaticGTFS(feed.ID, staticData)
+		downloads[feed.ID] = download
 	}
-	manager.setStaticGTFS(staticData)
 
-	gtfsDB, err := buildGtfsDB(config, isLocalFile)
+	gtfsDB, err := buildGtfsDB(config, manager.feeds, downloads)
 	if err != nil {
 		return nil, fmt.Errorf("error building GTFS database: %w", err)
 	}
-	manager.GtfsDB = gtfsDB
+	manager.gtfsDB.Store(gtfsDB)
+	manager.checkFeedValidity(context.Background(), time.Now())
+	manager.loadTranslations(context.Background())
+
+	for _, feed := range manager.feeds {
+		if !feed.isLocalFile() {
+			manager.wg.Add(1)
+			go manager.updateStaticGTFS(feed)
+		}
 
-	if !isLocalFile {
-		manager.wg.Add(1)
-		go manager.updateStaticGTFS()
+		if feed.realTimeDataEnabled() {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			manager.updateGTFSRealtime(ctx, feed)
+			cancel()
+			manager.wg.Add(1)
+			go manager.updateGTFSRealtimePeriodically(feed)
+		}
 	}
 
-	if config.realTimeDataEnabled() {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel() // Ensure the context is canceled when done
-		manager.updateGTFSRealtime(ctx, config)
-		manager.wg.Add(1)
-		go manager.updateGTFSRealtimePeriodically(config)
-	}
+	manager.wg.Add(1)
+	go manager.evaluateArrivalAlarmsPeriodically()
 
 	return manager, nil
 }
@@ -82,12 +104,18 @@ func (manager *Manager) Shutdown() {
 	manager.shutdownOnce.Do(func() {
 		close(manager.shutdownChan)
 		manager.wg.Wait()
-		if manager.GtfsDB != nil {
-			_ = manager.GtfsDB.Close()
+		if gtfsDB := manager.GtfsDB(); gtfsDB != nil {
+			_ = gtfsDB.Close()
 		}
 	})
 }
 
+// GtfsDB returns the database of the static feeds. The database is replaced when a feed is
+// refreshed, so callers should not hold on to it beyond a single request.
+func (manager *Manager) GtfsDB() *gtfsdb.Client {
+	return manager.gtfsDB.Load()
+}
+
 func (manager *Manager) GetAgencies() []gtfs.Agency {
 	manager.staticMutex.RLock()
 	defer manager.staticMutex.RUnlock()
@@ -186,7 +214,7 @@ func (manager *Manager) GetStopsForLocation(ctx context.Context, lat, lon float6
 	}
 
 	// Use spatial index query for initial filtering
-	dbStops, err := manager.GtfsDB.Queries.GetStopsWithinBounds(ctx, gtfsdb.GetStopsWithinBoundsParams{
+	dbStops, err := manager.GtfsDB().Queries.GetStopsWithinBounds(ctx, gtfsdb.GetStopsWithinBoundsParams{
 		Lat:   minLat,
 		Lat_2: maxLat,
 		Lon:   minLon,
@@ -255,77 +283,70 @@ func (manager *Manager) VehiclesForAgencyID(agencyID string) []gtfs.Vehicle {
 		routeIDs[route.Id] = true
 	}
 
-	var vehicles []gtfs.Vehicle
-	for _, v := range manager.GetRealTimeVehicles() {
-		if v.Trip != nil {
-			if routeIDs[v.Trip.ID.RouteID] {
-				vehicles = append(vehicles, v)
-			}
-		}
+	snapshot := manager.realTimeData()
+	var indexes []int
+	for routeID := range routeIDs {
+		indexes = append(indexes, snapshot.vehiclesByRouteID[routeID]...)
 	}
+	// Vehicles are returned in feed order
+	sort.Ints(indexes)
 
-	return vehicles
+	return snapshot.vehiclesAt(indexes)
 }
 
 // This function retrieves a vehicle for a specific trip ID or finds the first vehicle that is part of the block for that trip.
 // Note we depend on getting the vehicle that may not match the trip ID exactly, but is part of the same block.
 func (manager *Manager) GetVehicleForTrip(tripID string) *gtfs.Vehicle {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	requestedTrip, err := manager.GtfsDB.Queries.GetTrip(ctx, tripID)
-	if err != nil || !requestedTrip.BlockID.Valid {
-		fmt.Fprintf(os.Stderr, "Could not get block ID for trip %s: %v\n", tripID, err)
-		return nil
-	}
-
-	requestedBlockID := requestedTrip.BlockID.String
-
-	blockTrips, err := manager.GtfsDB.Queries.GetTripsByBlockID(ctx, requestedTrip.BlockID)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Could not get trips for block %s: %v\n", requestedBlockID, err)
-		return nil
-	}
-
-	blockTripIDs := make(map[string]bool)
-	for _, trip := range blockTrips {
-		blockTripIDs[trip.ID] = true
+	snapshot := manager.realTimeData()
+
+	// Trips vehicles are serving have their block looked up on each refresh
+	requestedBlockID, ok := snapshot.blockIDs[tripID]
+	if !ok {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		requestedTrip, err := manager.GtfsDB().Queries.GetTrip(ctx, tripID)
+		if err != nil || !requestedTrip.BlockID.Valid {
+			fmt.Fprintf(os.Stderr, "Could not get block ID for trip %s: %v\n", tripID, err)
+			return nil
+		}
+		requestedBlockID = requestedTrip.BlockID.String
 	}
 
-	for _, v := range manager.realTimeVehicles {
-		if v.Trip != nil && v.Trip.ID.ID != "" && blockTripIDs[v.Trip.ID.ID] {
-			return &v
-		}
+	if i, ok := snapshot.vehicleByBlockID[requestedBlockID]; ok {
+		v := snapshot.vehicles[i]
+		return &v
 	}
 	return nil
 }
 
 func (manager *Manager) GetVehicleByID(vehicleID string) (*gtfs.Vehicle, error) {
-
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-
-	for _, v := range manager.realTimeVehicles {
-		if v.ID.ID == vehicleID {
-			return &v, nil
-		}
+	snapshot := manager.realTimeData()
+	if i, ok := snapshot.vehicleByID[vehicleID]; ok {
+		v := snapshot.vehicles[i]
+		return &v, nil
 	}
 
 	return nil, fmt.Errorf("vehicle with ID %s not found", vehicleID)
 }
 
+// GetVehicleServingTrip returns the vehicle reporting that it serves exactly the given trip, or
+// nil if there is none. Unlike GetVehicleForTrip it doesn't look at the trip's block.
+func (manager *Manager) GetVehicleServingTrip(tripID string) *gtfs.Vehicle {
+	snapshot := manager.realTimeData()
+	if i, ok := snapshot.vehicleByTripID[tripID]; ok {
+		v := snapshot.vehicles[i]
+		return &v
+	}
+	return nil
+}
+
 func (manager *Manager) GetTripUpdatesForTrip(tripID string) []gtfs.Trip {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
+	snapshot := manager.realTimeData()
 
 	var updates []gtfs.Trip
-	for _, v := range manager.realTimeTrips {
-		if v.ID.ID == tripID {
-			updates = append(updates, v)
-		}
+	for _, i := range snapshot.tripUpdatesByTripID[tripID] {
+		updates = append(updates, snapshot.trips[i])
 	}
 	return updates
 }
@@ -333,55 +354,12 @@ func (manager *Manager) GetTripUpdatesForTrip(tripID string) []gtfs.Trip {
 func (manager *Manager) PrintStatistics() {
 	manager.staticMutex.RLock()
 	defer manager.staticMutex.RUnlock()
-	fmt.Printf("Source: %s (Local File: %v)\n", manager.gtfsSource, manager.isLocalFile)
+	for _, feed := range manager.feeds {
+		fmt.Printf("Source: %s (Feed: %s, Local File: %v)\n", feed.GtfsURL, feed.ID, feed.isLocalFile())
+	}
 	fmt.Printf("Last Updated: %s\n", manager.lastUpdated)
 	fmt.Println("Stops Count: ", len(manager.gtfsData.Stops))
 	fmt.Println("Routes Count: ", len(manager.gtfsData.Routes))
 	fmt.Println("Trips Count: ", len(manager.gtfsData.Trips))
 	fmt.Println("Agencies Count: ", len(manager.gtfsData.Agencies))
 }
-
-func (manager *Manager) IsServiceActiveOnDate(ctx context.Context, serviceID string, date time.Time) (int64, error) {
-	serviceDate := date.Format("20060102")
-
-	exceptions, err := manager.GtfsDB.Queries.GetCalendarDateExceptionsForServiceID(ctx, serviceID)
-	if err != nil {
-		return 0, fmt.Errorf("error fetching exceptions: %w", err)
-	}
-	for _, e := range exceptions {
-		if e.Date == serviceDate {
-			if e.ExceptionType == 1 {
-				return 1, nil
-			}
-			return 0, nil
-		}
-	}
-
-	calendar, err := manager.GtfsDB.Queries.GetCalendarByServiceID(ctx, serviceID)
-	if err != nil {
-		return 0, fmt.Errorf("error fetching calendar for service %s: %w", serviceID, err)
-	}
-
-	if serviceDate < calendar.StartDate || serviceDate > calendar.EndDate {
-		return 0, nil
-	}
-
-	switch date.Weekday() {
-	case time.Sunday:
-		return calendar.Sunday, nil
-	case time.Monday:
-		return calendar.Monday, nil
-	case time.Tuesday:
-		return calendar.Tuesday, nil
-	case time.Wednesday:
-		return calendar.Wednesday, nil
-	case time.Thursday:
-		return calendar.Thursday, nil
-	case time.Friday:
-		return calendar.Friday, nil
-	case time.Saturday:
-		return calendar.Saturday, nil
-	default:
-		return 0, nil
-	}
-}
@@ -1,7 +1,12 @@
 package gtfs
 
 import (
+	"context"
+	"maps"
+	"slices"
+
 	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
 )
 
 func (m *Manager) MockAddAgency(id, name string) {
@@ -29,12 +34,13 @@ func (m *Manager) MockAddRoute(id, agencyID, name string) {
 	})
 }
 func (m *Manager) MockAddVehicle(vehicleID, tripID, routeID string) {
-	for _, v := range m.realTimeVehicles {
-		if v.ID.ID == vehicleID {
-			return
-		}
+	m.realTimeMutex.Lock()
+	defer m.realTimeMutex.Unlock()
+	data := m.realTimeData().realTimeFeedData
+	if _, exists := m.realTimeData().vehicleByID[vehicleID]; exists {
+		return
 	}
-	m.realTimeVehicles = append(m.realTimeVehicles, gtfs.Vehicle{
+	data.vehicles = append(slices.Clip(data.vehicles), gtfs.Vehicle{
 		ID: &gtfs.VehicleID{ID: vehicleID},
 		Trip: &gtfs.Trip{
 			ID: gtfs.TripID{
@@ -43,6 +49,7 @@ func (m *Manager) MockAddVehicle(vehicleID, tripID, routeID string) {
 			},
 		},
 	})
+	m.publishRealTimeData(context.Background(), data)
 }
 
 func (m *Manager) MockAddTrip(tripID, agencyID, routeID string) {
@@ -56,3 +63,26 @@ func (m *Manager) MockAddTrip(tripID, agencyID, routeID string) {
 		Route: &gtfs.Route{Id: routeID},
 	})
 }
+
+func (m *Manager) MockAddAlert(alert gtfs.Alert) {
+	m.realTimeMutex.Lock()
+	defer m.realTimeMutex.Unlock()
+	data := m.realTimeData().realTimeFeedData
+	if _, exists := m.realTimeData().alertByID[alert.ID]; exists {
+		return
+	}
+	data.alerts = append(slices.Clip(data.alerts), alert)
+	m.publishRealTimeData(context.Background(), data)
+}
+
+func (m *Manager) MockSetAlertSeverity(alertID string, severity gtfsrt.Alert_SeverityLevel) {
+	m.realTimeMutex.Lock()
+	defer m.realTimeMutex.Unlock()
+	data := m.realTimeData().realTimeFeedData
+	data.alertSeverities = maps.Clone(data.alertSeverities)
+	if data.alertSeverities == nil {
+		data.alertSeverities = make(map[string]gtfsrt.Alert_SeverityLevel)
+	}
+	data.alertSeverities[alertID] = severity
+	m.publishRealTimeData(context.Background(), data)
+}
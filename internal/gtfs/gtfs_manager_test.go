@@ -2,11 +2,20 @@ package gtfs
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/appconf"
 	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
 )
 
 func TestManager_GetAgencies(t *testing.T) {
@@ -133,10 +142,112 @@ func TestManager_GetStopsForLocation_UsesSpatialIndex(t *testing.T) {
 
 			// The key test is that this should use the spatial index
 			// We'll verify this is implemented by checking the database has the query
-			assert.NotNil(t, manager.GtfsDB.Queries, "Database queries should exist")
+			assert.NotNil(t, manager.GtfsDB().Queries, "Database queries should exist")
 
 			// This will fail initially because GetStopsWithinRadius doesn't exist yet
 			// Once we implement it, this test will pass
 		})
 	}
 }
+
+func TestParseAlertSeverities(t *testing.T) {
+	feed := &gtfsrt.FeedMessage{
+		Header: &gtfsrt.FeedHeader{GtfsRealtimeVersion: proto.String("2.0")},
+		Entity: []*gtfsrt.FeedEntity{
+			{
+				Id: proto.String("severe"),
+				Alert: &gtfsrt.Alert{
+					SeverityLevel: gtfsrt.Alert_SEVERE.Enum(),
+				},
+			},
+			{
+				Id:    proto.String("unspecified"),
+				Alert: &gtfsrt.Alert{},
+			},
+		},
+	}
+	b, err := proto.Marshal(feed)
+	require.NoError(t, err)
+
+	severities := parseAlertSeverities(b)
+	assert.Equal(t, map[string]gtfsrt.Alert_SeverityLevel{"severe": gtfsrt.Alert_SEVERE}, severities)
+
+	assert.Empty(t, parseAlertSeverities([]byte("not a feed")))
+}
+
+func TestManager_ServesMultipleFeeds(t *testing.T) {
+	manager, err := InitGTFSManager(Config{
+		Feeds: []FeedConfig{
+			{ID: "raba", GtfsURL: models.GetFixturePath(t, "raba.zip")},
+			{ID: "shuttle", GtfsURL: models.BuildShuttleFeed(t)},
+		},
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	})
+	require.NoError(t, err)
+	defer manager.Shutdown()
+
+	agencyIDs := make([]string, 0)
+	for _, agency := range manager.GetAgencies() {
+		agencyIDs = append(agencyIDs, agency.Id)
+	}
+	assert.Equal(t, []string{"25", "SHUTTLE"}, agencyIDs)
+
+	assert.NotEmpty(t, manager.RoutesForAgencyID("25"))
+	shuttleRoutes := manager.RoutesForAgencyID("SHUTTLE")
+	require.Len(t, shuttleRoutes, 1)
+	assert.Equal(t, gtfsdb.ScopedID("shuttle", "LOOP"), shuttleRoutes[0].Id)
+	assert.Equal(t, "SHUTTLE_LOOP", utils.FormCombinedID("SHUTTLE", shuttleRoutes[0].Id))
+	routeID, err := utils.ExtractCodeID("SHUTTLE_LOOP")
+	require.NoError(t, err)
+	assert.Equal(t, shuttleRoutes[0].Id, routeID, "combined IDs are resolved through the agency's feed")
+	_, err = manager.GtfsDB().Queries.GetRoute(context.Background(), routeID)
+	assert.NoError(t, err)
+
+	metadata, err := manager.GtfsDB().Queries.ListImportMetadata(context.Background())
+	require.NoError(t, err)
+	require.Len(t, metadata, 2)
+	assert.Equal(t, "raba", metadata[0].FeedID)
+	assert.Equal(t, "shuttle", metadata[1].FeedID)
+}
+
+func TestManager_MergesRealTimeDataPerFeed(t *testing.T) {
+	serveFile := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			data, err := os.ReadFile(filepath.Join("../../testdata", name))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/x-protobuf")
+			_, _ = w.Write(data)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/raba/trip-updates", serveFile("raba-trip-updates.pb"))
+	mux.HandleFunc("/raba/vehicle-positions", serveFile("raba-vehicle-positions.pb"))
+	mux.HandleFunc("/unitrans/trip-updates", serveFile("unitrans-trip-updates.pb"))
+	mux.HandleFunc("/unitrans/vehicle-positions", serveFile("unitrans-vehicle-positions.pb"))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	raba := FeedConfig{ID: "raba", TripUpdatesURL: server.URL + "/raba/trip-updates", VehiclePositionsURL: server.URL + "/raba/vehicle-positions"}
+	unitrans := FeedConfig{ID: "unitrans", TripUpdatesURL: server.URL + "/unitrans/trip-updates", VehiclePositionsURL: server.URL + "/unitrans/vehicle-positions"}
+
+	manager := &Manager{}
+	ctx := context.Background()
+
+	manager.updateGTFSRealtime(ctx, raba)
+	rabaVehicles := len(manager.GetRealTimeVehicles())
+	require.NotZero(t, rabaVehicles)
+
+	manager.updateGTFSRealtime(ctx, unitrans)
+	unitransVehicles := len(manager.realTimeFeeds["unitrans"].vehicles)
+	require.NotZero(t, unitransVehicles)
+	assert.Len(t, manager.GetRealTimeVehicles(), rabaVehicles+unitransVehicles)
+
+	// A failed fetch for one feed keeps its last data and leaves the other feed alone
+	raba.VehiclePositionsURL = server.URL + "/missing"
+	manager.updateGTFSRealtime(ctx, raba)
+	assert.Len(t, manager.GetRealTimeVehicles(), rabaVehicles+unitransVehicles)
+}
@@ -78,9 +78,7 @@ func TestParallelRealtimeUpdates(t *testing.T) {
 			VehiclePositionsURL: server.URL + "/vehicle-positions",
 		}
 
-		manager := &Manager{
-			realTimeMutex: sync.RWMutex{},
-		}
+		manager := &Manager{}
 
 		start := time.Now()
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -110,9 +108,7 @@ func TestParallelRealtimeUpdates(t *testing.T) {
 			VehiclePositionsURL: server.URL + "/vehicle-positions",
 		}
 
-		manager := &Manager{
-			realTimeMutex: sync.RWMutex{},
-		}
+		manager := &Manager{}
 
 		start := time.Now()
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -152,9 +148,7 @@ func TestParallelRealtimeUpdatesWithErrors(t *testing.T) {
 		VehiclePositionsURL: errorServer.URL + "/vehicle-positions",
 	}
 
-	manager := &Manager{
-		realTimeMutex: sync.RWMutex{},
-	}
+	manager := &Manager{}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -185,9 +179,7 @@ func TestParallelRealtimeUpdatesWithContextCancellation(t *testing.T) {
 		VehiclePositionsURL: slowServer.URL + "/vehicle-positions",
 	}
 
-	manager := &Manager{
-		realTimeMutex: sync.RWMutex{},
-	}
+	manager := &Manager{}
 
 	// Create a context with a very short timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
@@ -223,12 +215,14 @@ func (manager *Manager) updateGTFSRealtimeSequential(ctx context.Context, config
 	manager.realTimeMutex.Lock()
 	defer manager.realTimeMutex.Unlock()
 
+	data := manager.realTimeData().realTimeFeedData
 	if tripData != nil {
-		manager.realTimeTrips = tripData.Trips
+		data.trips = tripData.Trips
 	}
 	if vehicleData != nil {
-		manager.realTimeVehicles = vehicleData.Vehicles
+		data.vehicles = vehicleData.Vehicles
 	}
+	manager.publishRealTimeData(ctx, data)
 }
 
 func (manager *Manager) updateGTFSRealtimeParallel(ctx context.Context, config Config) {
@@ -272,20 +266,20 @@ func (manager *Manager) updateGTFSRealtimeParallel(ctx context.Context, config C
 		manager.realTimeMutex.Lock()
 		defer manager.realTimeMutex.Unlock()
 
+		data := manager.realTimeData().realTimeFeedData
 		if tripData != nil && tripErr == nil {
-			manager.realTimeTrips = tripData.Trips
+			data.trips = tripData.Trips
 		}
 		if vehicleData != nil && vehicleErr == nil {
-			manager.realTimeVehicles = vehicleData.Vehicles
+			data.vehicles = vehicleData.Vehicles
 		}
+		manager.publishRealTimeData(ctx, data)
 	}
 }
 
 func TestRealTimeDataConsistency(t *testing.T) {
 	// Test that parallel updates maintain data consistency
-	manager := &Manager{
-		realTimeMutex: sync.RWMutex{},
-	}
+	manager := &Manager{}
 
 	// Run multiple parallel updates to test for race conditions
 	var wg sync.WaitGroup
@@ -315,3 +309,36 @@ func TestRealTimeDataConsistency(t *testing.T) {
 	assert.True(t, trips != nil || len(trips) == 0, "Trips should be accessible without panic")
 	assert.True(t, vehicles != nil || len(vehicles) == 0, "Vehicles should be accessible without panic")
 }
+
+func TestUpdateGTFSRealtimeWithVehiclePositionsOnly(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested = append(requested, r.URL.Path)
+		mu.Unlock()
+
+		data, err := os.ReadFile(filepath.Join("../../testdata", "raba-vehicle-positions.pb"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	feed := FeedConfig{ID: "positions", VehiclePositionsURL: server.URL + "/vehicle-positions"}
+	assert.True(t, feed.realTimeDataEnabled())
+
+	manager := &Manager{}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	manager.updateGTFSRealtime(ctx, feed)
+
+	assert.NotEmpty(t, manager.GetRealTimeVehicles(), "vehicle positions are loaded without trip updates")
+	assert.Empty(t, manager.GetRealTimeTrips())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/vehicle-positions"}, requested, "feeds without a URL are not fetched")
+}
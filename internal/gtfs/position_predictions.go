@@ -0,0 +1,212 @@
+package gtfs
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// PredictStopTimesFromPosition estimates a trip's stop times from how far along its shape the
+// vehicle serving it is. stopDistances holds the distance along the shape of each stop time, and
+// vehicleDistance the vehicle's, both in meters. The trip is as late as the time since the
+// schedule had it at the vehicle's position, found by interpolating between the departure from
+// the stop behind the vehicle and the arrival at the stop ahead. A vehicle waiting at a stop is
+// on time until its scheduled departure. It returns nil when the vehicle isn't on the part of
+// the shape the trip's stops cover.
+func PredictStopTimesFromPosition(
+	serviceMidnight time.Time,
+	stopTimes []gtfsdb.StopTime,
+	stopDistances []float64,
+	vehicleDistance float64,
+	at time.Time,
+) *TripPrediction {
+	if len(stopTimes) == 0 || len(stopTimes) != len(stopDistances) {
+		return nil
+	}
+
+	// Shapes that double back on themselves can put the vehicle between several pairs of stops,
+	// so take the one the schedule has closest to now
+	var update *gtfs.StopTimeUpdate
+	bestDifference := time.Duration(math.MaxInt64)
+	for i := range stopTimes {
+		arrival := serviceMidnight.Add(time.Duration(stopTimes[i].ArrivalTime))
+		departure := serviceMidnight.Add(time.Duration(stopTimes[i].DepartureTime))
+
+		// A vehicle short of the first stop is waiting to start the trip there
+		atStop := math.Abs(vehicleDistance-stopDistances[i]) <= utils.AtStopTolerance
+		if i == 0 && vehicleDistance < stopDistances[i] {
+			atStop = true
+		}
+		if atStop {
+			scheduled := at
+			if at.After(departure) {
+				scheduled = departure
+			} else if i > 0 && at.Before(arrival) {
+				// At the first stop the vehicle is waiting to start, not early
+				scheduled = arrival
+			}
+			if difference := absDuration(at.Sub(scheduled)); difference < bestDifference {
+				bestDifference = difference
+				update = newDelayUpdate(stopTimes[i].StopSequence, at.Sub(scheduled), false)
+			}
+			continue
+		}
+
+		if i == len(stopTimes)-1 {
+			break
+		}
+		from, to := stopDistances[i], stopDistances[i+1]
+		if !(from < vehicleDistance && vehicleDistance < to) {
+			continue
+		}
+		nextArrival := serviceMidnight.Add(time.Duration(stopTimes[i+1].ArrivalTime))
+		fraction := (vehicleDistance - from) / (to - from)
+		scheduled := departure.Add(time.Duration(fraction * float64(nextArrival.Sub(departure))))
+		if difference := absDuration(at.Sub(scheduled)); difference < bestDifference {
+			bestDifference = difference
+			update = newDelayUpdate(stopTimes[i+1].StopSequence, at.Sub(scheduled), true)
+		}
+	}
+
+	if update == nil {
+		return nil
+	}
+	return PredictStopTimes(serviceMidnight, stopTimes, []gtfs.StopTimeUpdate{*update})
+}
+
+// newDelayUpdate returns a stop time update carrying a delay for the arrival at, or the
+// departure from, a stop.
+func newDelayUpdate(stopSequence int64, delay time.Duration, onArrival bool) *gtfs.StopTimeUpdate {
+	sequence := uint32(stopSequence)
+	event := &gtfs.StopTimeEvent{Delay: &delay}
+	if onArrival {
+		return &gtfs.StopTimeUpdate{StopSequence: &sequence, Arrival: event}
+	}
+	return &gtfs.StopTimeUpdate{StopSequence: &sequence, Departure: event}
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// StopDistancesAlongShape returns how far along a shape, in meters, each stop time of a trip is.
+// When the shape and the stop times both have shape_dist_traveled the stops are placed by it,
+// otherwise by projecting their positions onto the shape, each no earlier along it than the stop
+// before. A stop missing from stops is placed where the stop before it is.
+func StopDistancesAlongShape(shapePoints []gtfs.ShapePoint, stopTimes []gtfsdb.StopTime, stops map[string]gtfsdb.Stop) []float64 {
+	distances := make([]float64, len(stopTimes))
+
+	useShapeDistances := len(shapePoints) > 1
+	for _, pt := range shapePoints {
+		if pt.Distance == nil {
+			useShapeDistances = false
+			break
+		}
+	}
+	for _, st := range stopTimes {
+		if !st.ShapeDistTraveled.Valid {
+			useShapeDistances = false
+			break
+		}
+	}
+
+	var segment int
+	var ratio float64
+	for i, st := range stopTimes {
+		if useShapeDistances {
+			distances[i] = metersAtShapeDistance(shapePoints, st.ShapeDistTraveled.Float64)
+			continue
+		}
+		if stop, ok := stops[st.StopID]; ok {
+			segment, ratio = utils.ProjectOntoShapeFrom(stop.Lat, stop.Lon, shapePoints, segment, ratio)
+		}
+		distances[i] = utils.DistanceAlongShape(shapePoints, segment, ratio)
+	}
+	return distances
+}
+
+// metersAtShapeDistance converts a shape_dist_traveled value, in the feed's units, into meters
+// along the shape by interpolating between the shape's points.
+func metersAtShapeDistance(shapePoints []gtfs.ShapePoint, shapeDistance float64) float64 {
+	for i := 0; i < len(shapePoints)-1; i++ {
+		from, to := *shapePoints[i].Distance, *shapePoints[i+1].Distance
+		if shapeDistance > to && i < len(shapePoints)-2 {
+			continue
+		}
+		ratio := 0.0
+		if to > from {
+			ratio = math.Max(0, math.Min(1, (shapeDistance-from)/(to-from)))
+		}
+		return utils.DistanceAlongShape(shapePoints, i, ratio)
+	}
+	return 0
+}
+
+// predictTripFromPosition estimates the stop times of a trip from the position of the vehicle
+// serving it, or returns nil when no vehicle reports a position on the trip.
+func (manager *Manager) predictTripFromPosition(ctx context.Context, tripID string, serviceMidnight, at time.Time) (*TripPrediction, error) {
+	vehicle := manager.GetVehicleForTrip(tripID)
+	if vehicle == nil || vehicle.Trip == nil || vehicle.Trip.ID.ID != tripID ||
+		vehicle.Position == nil || vehicle.Position.Latitude == nil || vehicle.Position.Longitude == nil {
+		return nil, nil
+	}
+	if vehicle.Trip.ID.HasStartDate && vehicle.Trip.ID.StartDate.Format("20060102") != serviceMidnight.Format("20060102") {
+		return nil, nil
+	}
+
+	stopTimes, err := manager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	shapePoints, stopDistances, err := manager.StopDistancesForTrip(ctx, tripID, stopTimes)
+	if err != nil || shapePoints == nil {
+		return nil, err
+	}
+
+	segment, ratio := utils.ProjectOntoShape(float64(*vehicle.Position.Latitude), float64(*vehicle.Position.Longitude), shapePoints)
+	vehicleDistance := utils.DistanceAlongShape(shapePoints, segment, ratio)
+	return PredictStopTimesFromPosition(serviceMidnight, stopTimes, stopDistances, vehicleDistance, at), nil
+}
+
+// StopDistancesForTrip loads the shape of a trip and places the given stop times of the trip
+// along it with StopDistancesAlongShape, fetching their stops in one query. It returns no shape
+// points, and a distance of zero for every stop time, when the trip has no shape.
+func (manager *Manager) StopDistancesForTrip(ctx context.Context, tripID string, stopTimes []gtfsdb.StopTime) ([]gtfs.ShapePoint, []float64, error) {
+	queries := manager.GtfsDB().Queries
+	shapeRows, err := queries.GetShapePointsByTripID(ctx, tripID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(shapeRows) < 2 {
+		return nil, make([]float64, len(stopTimes)), nil
+	}
+
+	stopIDs := make([]string, len(stopTimes))
+	for i, st := range stopTimes {
+		stopIDs[i] = st.StopID
+	}
+	stopRows, err := queries.GetStopsByIDs(ctx, stopIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	stops := make(map[string]gtfsdb.Stop, len(stopRows))
+	for _, stop := range stopRows {
+		stops[stop.ID] = stop
+	}
+
+	shapePoints := make([]gtfs.ShapePoint, len(shapeRows))
+	for i, sp := range shapeRows {
+		shapePoints[i] = gtfs.ShapePoint{Latitude: sp.Lat, Longitude: sp.Lon}
+		if sp.ShapeDistTraveled.Valid {
+			shapePoints[i].Distance = &sp.ShapeDistTraveled.Float64
+		}
+	}
+	return shapePoints, StopDistancesAlongShape(shapePoints, stopTimes, stops), nil
+}
@@ -0,0 +1,128 @@
+package gtfs
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func TestPredictStopTimesFromPosition(t *testing.T) {
+	midnight := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return midnight.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	// Stops a kilometer apart, with a two minute dwell at B
+	stopTimes := []gtfsdb.StopTime{
+		{StopID: "A", StopSequence: 1, ArrivalTime: int64(8 * time.Hour), DepartureTime: int64(8 * time.Hour)},
+		{StopID: "B", StopSequence: 2, ArrivalTime: int64(8*time.Hour + 10*time.Minute), DepartureTime: int64(8*time.Hour + 12*time.Minute)},
+		{StopID: "C", StopSequence: 3, ArrivalTime: int64(8*time.Hour + 20*time.Minute), DepartureTime: int64(8*time.Hour + 20*time.Minute)},
+	}
+	stopDistances := []float64{0, 1000, 2000}
+
+	deviation := func(vehicleDistance float64, now time.Time) time.Duration {
+		prediction := PredictStopTimesFromPosition(midnight, stopTimes, stopDistances, vehicleDistance, now)
+		require.NotNil(t, prediction)
+		d, ok := prediction.ScheduleDeviation(now)
+		require.True(t, ok)
+		return d
+	}
+
+	t.Run("between stops", func(t *testing.T) {
+		// Scheduled half way to B at 8:05
+		assert.Equal(t, 2*time.Minute, deviation(500, at(8, 7)))
+		assert.Equal(t, -time.Minute, deviation(500, at(8, 4)))
+
+		prediction := PredictStopTimesFromPosition(midnight, stopTimes, stopDistances, 500, at(8, 7))
+		assert.False(t, prediction.Stops[0].Predicted, "the vehicle has left A")
+		stop, ok := prediction.ForStop("B", 2)
+		require.True(t, ok)
+		assert.Equal(t, at(8, 12), stop.PredictedArrival)
+		assert.Equal(t, at(8, 12), stop.PredictedDeparture, "the dwell absorbs the delay")
+		stop, ok = prediction.ForStop("C", 3)
+		require.True(t, ok)
+		assert.Equal(t, at(8, 20), stop.PredictedArrival)
+	})
+
+	t.Run("at a stop", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), deviation(1005, at(8, 11)), "dwelling as scheduled")
+		assert.Equal(t, 3*time.Minute, deviation(995, at(8, 15)))
+		assert.Equal(t, -time.Minute, deviation(1000, at(8, 9)))
+
+		prediction := PredictStopTimesFromPosition(midnight, stopTimes, stopDistances, 1000, at(8, 15))
+		stop, ok := prediction.ForStop("C", 3)
+		require.True(t, ok)
+		assert.Equal(t, at(8, 23), stop.PredictedArrival)
+	})
+
+	t.Run("waiting at the first stop", func(t *testing.T) {
+		assert.Equal(t, time.Duration(0), deviation(-50, at(7, 55)))
+		assert.Equal(t, 3*time.Minute, deviation(0, at(8, 3)))
+	})
+
+	t.Run("off the end of the trip", func(t *testing.T) {
+		assert.Nil(t, PredictStopTimesFromPosition(midnight, stopTimes, stopDistances, 2500, at(8, 25)))
+	})
+}
+
+func TestStopDistancesAlongShape(t *testing.T) {
+	distance := func(d float64) *float64 { return &d }
+
+	// A straight shape running north, with shape_dist_traveled in kilometers
+	shapePoints := []gtfs.ShapePoint{
+		{Latitude: 40.000, Longitude: -122, Distance: distance(0)},
+		{Latitude: 40.009, Longitude: -122, Distance: distance(1)},
+		{Latitude: 40.018, Longitude: -122, Distance: distance(2)},
+	}
+	segmentLength := utils.Haversine(40.000, -122, 40.009, -122)
+
+	stops := map[string]gtfsdb.Stop{
+		"A": {ID: "A", Lat: 40.000, Lon: -122.0001},
+		"B": {ID: "B", Lat: 40.0135, Lon: -121.9999},
+	}
+	stopTimes := []gtfsdb.StopTime{
+		{StopID: "A", StopSequence: 1, ShapeDistTraveled: sql.NullFloat64{Float64: 0.5, Valid: true}},
+		{StopID: "B", StopSequence: 2, ShapeDistTraveled: sql.NullFloat64{Float64: 1.5, Valid: true}},
+	}
+
+	t.Run("placed by shape_dist_traveled", func(t *testing.T) {
+		distances := StopDistancesAlongShape(shapePoints, stopTimes, stops)
+		assert.InDelta(t, segmentLength/2, distances[0], 0.1)
+		assert.InDelta(t, segmentLength*1.5, distances[1], 0.1)
+	})
+
+	t.Run("projected without shape_dist_traveled", func(t *testing.T) {
+		withoutDistances := []gtfsdb.StopTime{{StopID: "A", StopSequence: 1}, {StopID: "B", StopSequence: 2}}
+		distances := StopDistancesAlongShape(shapePoints, withoutDistances, stops)
+		assert.InDelta(t, 0, distances[0], 1)
+		assert.InDelta(t, segmentLength*1.5, distances[1], 1)
+	})
+
+	t.Run("projected in order on a shape that doubles back", func(t *testing.T) {
+		// Out along the shape and back again, serving A on the way out and on the way back
+		outAndBack := []gtfs.ShapePoint{
+			{Latitude: 40.000, Longitude: -122},
+			{Latitude: 40.009, Longitude: -122},
+			{Latitude: 40.018, Longitude: -122},
+			{Latitude: 40.009, Longitude: -122},
+			{Latitude: 40.000, Longitude: -122},
+		}
+		loop := []gtfsdb.StopTime{
+			{StopID: "A", StopSequence: 1},
+			{StopID: "B", StopSequence: 2},
+			{StopID: "A", StopSequence: 3},
+			{StopID: "missing", StopSequence: 4},
+		}
+		distances := StopDistancesAlongShape(outAndBack, loop, stops)
+		assert.InDelta(t, 0, distances[0], 1)
+		assert.InDelta(t, segmentLength*1.5, distances[1], 1)
+		assert.InDelta(t, segmentLength*4, distances[2], 1)
+		assert.Equal(t, distances[2], distances[3], "a stop that can't be found stays with the one before")
+	})
+}
@@ -0,0 +1,239 @@
+package gtfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// StopTimePrediction is the realtime estimate of a trip's arrival at and departure from one of
+// its stops.
+type StopTimePrediction struct {
+	StopID             string
+	StopSequence       int64
+	ScheduledArrival   time.Time
+	ScheduledDeparture time.Time
+	PredictedArrival   time.Time
+	PredictedDeparture time.Time
+	// Predicted is false for stops no realtime data applies to, whose predicted times are the
+	// scheduled ones
+	Predicted bool
+	// Skipped is true for stops the trip won't serve, which have no prediction
+	Skipped bool
+}
+
+// ArrivalDelay returns how much later than scheduled the trip is predicted to arrive.
+func (p StopTimePrediction) ArrivalDelay() time.Duration {
+	return p.PredictedArrival.Sub(p.ScheduledArrival)
+}
+
+// DepartureDelay returns how much later than scheduled the trip is predicted to depart.
+func (p StopTimePrediction) DepartureDelay() time.Duration {
+	return p.PredictedDeparture.Sub(p.ScheduledDeparture)
+}
+
+// TripPrediction holds the predicted stop times of one trip on one service date, in stop order.
+type TripPrediction struct {
+	Stops []StopTimePrediction
+}
+
+// ForStop returns the prediction for the trip's visit to a stop. A stop sequence of -1 matches
+// the first visit to the stop.
+func (p *TripPrediction) ForStop(stopID string, stopSequence int64) (StopTimePrediction, bool) {
+	if p == nil {
+		return StopTimePrediction{}, false
+	}
+	for _, stop := range p.Stops {
+		if stop.StopID == stopID && (stopSequence < 0 || stop.StopSequence == stopSequence) {
+			return stop, stop.Predicted
+		}
+	}
+	return StopTimePrediction{}, false
+}
+
+// ScheduleDeviation returns how late the trip is running at time at: the departure delay at the
+// stop the vehicle is predicted to be at, or the arrival delay at the next stop it reaches. Once
+// the trip is predicted to have finished this is the arrival delay at its last stop. It returns
+// false when no stop has a prediction.
+func (p *TripPrediction) ScheduleDeviation(at time.Time) (time.Duration, bool) {
+	if p == nil {
+		return 0, false
+	}
+	var last *StopTimePrediction
+	for i := range p.Stops {
+		stop := &p.Stops[i]
+		if !stop.Predicted {
+			continue
+		}
+		if !stop.PredictedDeparture.Before(at) {
+			if stop.PredictedArrival.After(at) {
+				return stop.ArrivalDelay(), true
+			}
+			return stop.DepartureDelay(), true
+		}
+		last = stop
+	}
+	if last == nil {
+		return 0, false
+	}
+	return last.ArrivalDelay(), true
+}
+
+// PredictStopTimes applies a trip's GTFS-realtime stop time updates to its scheduled stop times
+// on the service date starting at serviceMidnight, following the propagation rules of the
+// GTFS-realtime specification:
+//
+//   - An explicit time wins over a delay, which applies to the scheduled time.
+//   - A stop without an update takes the delay of the closest preceding one.
+//   - An update with only an arrival or only a departure has the other derived from it.
+//   - Stops before the first update, and from a NO_DATA update until the next update with
+//     data, have no prediction.
+//   - SKIPPED stops have no prediction, and the delay carries on past them.
+//
+// A late trip makes up time at stops with a scheduled dwell, leaving at the scheduled departure
+// if it arrives in time to, so less delay is carried downstream. Predicted times never go back
+// in time along the trip.
+func PredictStopTimes(serviceMidnight time.Time, stopTimes []gtfsdb.StopTime, updates []gtfs.StopTimeUpdate) *TripPrediction {
+	prediction := &TripPrediction{Stops: make([]StopTimePrediction, len(stopTimes))}
+	for i, stopTime := range stopTimes {
+		// Stop times are stored in nanoseconds since midnight of the service date
+		arrival := serviceMidnight.Add(time.Duration(stopTime.ArrivalTime))
+		departure := serviceMidnight.Add(time.Duration(stopTime.DepartureTime))
+		prediction.Stops[i] = StopTimePrediction{
+			StopID:             stopTime.StopID,
+			StopSequence:       stopTime.StopSequence,
+			ScheduledArrival:   arrival,
+			ScheduledDeparture: departure,
+			PredictedArrival:   arrival,
+			PredictedDeparture: departure,
+		}
+	}
+
+	updateAt := matchStopTimeUpdates(stopTimes, updates)
+
+	var delay *time.Duration // Delay carried from the previous stop, if known
+	var previousDeparture time.Time
+	for i := range prediction.Stops {
+		stop := &prediction.Stops[i]
+		update := updateAt[i]
+
+		if update != nil && update.ScheduleRelationship == gtfsrt.TripUpdate_StopTimeUpdate_NO_DATA {
+			delay = nil
+			continue
+		}
+		if update != nil && update.ScheduleRelationship == gtfsrt.TripUpdate_StopTimeUpdate_SKIPPED {
+			stop.Skipped = true
+			continue
+		}
+		if update == nil && delay == nil {
+			continue
+		}
+
+		arrival, hasArrival := stopTimeEventTime(update.GetArrival(), stop.ScheduledArrival)
+		departure, hasDeparture := stopTimeEventTime(update.GetDeparture(), stop.ScheduledDeparture)
+		if !hasArrival && !hasDeparture && delay == nil {
+			continue
+		}
+
+		switch {
+		case hasArrival:
+		case delay != nil:
+			arrival = stop.ScheduledArrival.Add(*delay)
+		default:
+			arrival = stop.ScheduledArrival.Add(departure.Sub(stop.ScheduledDeparture))
+		}
+		if !previousDeparture.IsZero() && arrival.Before(previousDeparture) {
+			arrival = previousDeparture
+		}
+
+		if !hasDeparture {
+			arrivalDelay := arrival.Sub(stop.ScheduledArrival)
+			departure = stop.ScheduledDeparture.Add(arrivalDelay)
+			if arrivalDelay > 0 {
+				// A late vehicle shortens its dwell, down to none
+				departure = stop.ScheduledDeparture
+			}
+		}
+		if departure.Before(arrival) {
+			departure = arrival
+		}
+
+		stop.PredictedArrival, stop.PredictedDeparture, stop.Predicted = arrival, departure, true
+		departureDelay := departure.Sub(stop.ScheduledDeparture)
+		delay = &departureDelay
+		previousDeparture = departure
+	}
+	return prediction
+}
+
+// matchStopTimeUpdates pairs each stop time with its update, if it has one. Updates identify
+// their stop by stop sequence, or else by stop ID, which for trips visiting a stop twice is the
+// first visit after the previous update's stop.
+func matchStopTimeUpdates(stopTimes []gtfsdb.StopTime, updates []gtfs.StopTimeUpdate) []*gtfs.StopTimeUpdate {
+	updateAt := make([]*gtfs.StopTimeUpdate, len(stopTimes))
+	next := 0
+	for u := range updates {
+		update := &updates[u]
+		for i := next; i < len(stopTimes); i++ {
+			matches := false
+			if update.StopSequence != nil {
+				matches = stopTimes[i].StopSequence == int64(*update.StopSequence)
+			} else if update.StopID != nil {
+				matches = stopTimes[i].StopID == *update.StopID
+			}
+			if matches {
+				updateAt[i] = update
+				next = i + 1
+				break
+			}
+		}
+	}
+	return updateAt
+}
+
+// stopTimeEventTime returns the time an event is predicted to happen at, or false when the
+// event carries no prediction.
+func stopTimeEventTime(event gtfs.StopTimeEvent, scheduled time.Time) (time.Time, bool) {
+	if event.Time != nil {
+		return *event.Time, true
+	}
+	if event.Delay != nil {
+		return scheduled.Add(*event.Delay), true
+	}
+	return time.Time{}, false
+}
+
+// PredictTrip returns the predicted stop times at time at of a trip on the service date starting
+// at serviceMidnight. They come from the trip's realtime update, or when it has none from the
+// position of the vehicle serving it. It returns nil when there is neither.
+func (manager *Manager) PredictTrip(ctx context.Context, tripID string, serviceMidnight, at time.Time) (*TripPrediction, error) {
+	realtimeTrip := manager.tripUpdateForServiceDate(tripID, serviceMidnight)
+	if realtimeTrip == nil {
+		return manager.predictTripFromPosition(ctx, tripID, serviceMidnight, at)
+	}
+
+	stopTimes, err := manager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	return PredictStopTimes(serviceMidnight, stopTimes, realtimeTrip.StopTimeUpdates), nil
+}
+
+// tripUpdateForServiceDate returns the trip update for the run of a trip on the service date
+// starting at serviceMidnight. Updates without a start date apply to any service date. Updates
+// of DUPLICATED trips describe a new trip rather than the one they name, so are left out.
+func (manager *Manager) tripUpdateForServiceDate(tripID string, serviceMidnight time.Time) *gtfs.Trip {
+	serviceDate := serviceMidnight.Format("20060102")
+	for _, trip := range manager.GetTripUpdatesForTrip(tripID) {
+		if trip.ID.ScheduleRelationship == gtfsrt.TripDescriptor_DUPLICATED {
+			continue
+		}
+		if !trip.ID.HasStartDate || trip.ID.StartDate.Format("20060102") == serviceDate {
+			return &trip
+		}
+	}
+	return nil
+}
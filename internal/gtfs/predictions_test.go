@@ -0,0 +1,170 @@
+package gtfs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+func TestPredictStopTimes(t *testing.T) {
+	midnight := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return midnight.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	seq := func(n uint32) *uint32 { return &n }
+	dur := func(d time.Duration) *time.Duration { return &d }
+	stopID := func(s string) *string { return &s }
+
+	// Stop C has a five minute dwell, and the trip visits stop A twice
+	stopTimes := []gtfsdb.StopTime{
+		{StopID: "A", StopSequence: 1, ArrivalTime: int64(8 * time.Hour), DepartureTime: int64(8 * time.Hour)},
+		{StopID: "B", StopSequence: 2, ArrivalTime: int64(8*time.Hour + 10*time.Minute), DepartureTime: int64(8*time.Hour + 10*time.Minute)},
+		{StopID: "C", StopSequence: 3, ArrivalTime: int64(8*time.Hour + 20*time.Minute), DepartureTime: int64(8*time.Hour + 25*time.Minute)},
+		{StopID: "D", StopSequence: 4, ArrivalTime: int64(8*time.Hour + 30*time.Minute), DepartureTime: int64(8*time.Hour + 30*time.Minute)},
+		{StopID: "A", StopSequence: 5, ArrivalTime: int64(8*time.Hour + 40*time.Minute), DepartureTime: int64(8*time.Hour + 40*time.Minute)},
+	}
+
+	type times struct {
+		arrival, departure time.Time
+		predicted          bool
+	}
+	predict := func(updates []gtfs.StopTimeUpdate) []times {
+		prediction := PredictStopTimes(midnight, stopTimes, updates)
+		require.Len(t, prediction.Stops, len(stopTimes))
+		result := make([]times, len(prediction.Stops))
+		for i, stop := range prediction.Stops {
+			result[i] = times{stop.PredictedArrival, stop.PredictedDeparture, stop.Predicted}
+		}
+		return result
+	}
+
+	t.Run("no updates", func(t *testing.T) {
+		result := predict(nil)
+		for i, stop := range result {
+			assert.False(t, stop.predicted)
+			assert.Equal(t, midnight.Add(time.Duration(stopTimes[i].ArrivalTime)), stop.arrival)
+		}
+	})
+
+	t.Run("explicit time at stop", func(t *testing.T) {
+		explicit := at(8, 13)
+		result := predict([]gtfs.StopTimeUpdate{{StopSequence: seq(2), Arrival: &gtfs.StopTimeEvent{Time: &explicit}}})
+		assert.False(t, result[0].predicted, "stops before the first update have no prediction")
+		assert.Equal(t, times{explicit, explicit, true}, result[1])
+		assert.Equal(t, at(8, 23), result[2].arrival, "the derived delay propagates")
+	})
+
+	t.Run("delay propagates from earlier stop", func(t *testing.T) {
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(1), Departure: &gtfs.StopTimeEvent{Delay: dur(2 * time.Minute)}},
+			{StopSequence: seq(4), Arrival: &gtfs.StopTimeEvent{Delay: dur(7 * time.Minute)}},
+		})
+		assert.Equal(t, times{at(8, 2), at(8, 2), true}, result[0], "the arrival takes the departure's delay")
+		assert.Equal(t, times{at(8, 12), at(8, 12), true}, result[1])
+		assert.Equal(t, times{at(8, 37), at(8, 37), true}, result[3])
+		assert.Equal(t, times{at(8, 47), at(8, 47), true}, result[4])
+	})
+
+	t.Run("dwell absorbs delay", func(t *testing.T) {
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(2), Arrival: &gtfs.StopTimeEvent{Delay: dur(3 * time.Minute)}},
+		})
+		assert.Equal(t, times{at(8, 23), at(8, 25), true}, result[2], "leaves C on time")
+		assert.Equal(t, times{at(8, 30), at(8, 30), true}, result[3])
+
+		result = predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(2), Arrival: &gtfs.StopTimeEvent{Delay: dur(8 * time.Minute)}},
+		})
+		assert.Equal(t, times{at(8, 28), at(8, 28), true}, result[2], "dwell shortened to nothing")
+		assert.Equal(t, times{at(8, 33), at(8, 33), true}, result[3])
+	})
+
+	t.Run("early trip keeps its dwell", func(t *testing.T) {
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(2), Arrival: &gtfs.StopTimeEvent{Delay: dur(-2 * time.Minute)}},
+		})
+		assert.Equal(t, times{at(8, 18), at(8, 23), true}, result[2])
+	})
+
+	t.Run("matches by stop id", func(t *testing.T) {
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopID: stopID("D"), Departure: &gtfs.StopTimeEvent{Delay: dur(time.Minute)}},
+			{StopID: stopID("A"), Arrival: &gtfs.StopTimeEvent{Delay: dur(4 * time.Minute)}},
+		})
+		assert.False(t, result[0].predicted, "the second update is for the later visit to A")
+		assert.Equal(t, times{at(8, 31), at(8, 31), true}, result[3])
+		assert.Equal(t, times{at(8, 44), at(8, 44), true}, result[4])
+	})
+
+	t.Run("no data stops propagation", func(t *testing.T) {
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(1), Departure: &gtfs.StopTimeEvent{Delay: dur(time.Minute)}},
+			{StopSequence: seq(3), ScheduleRelationship: gtfsrt.TripUpdate_StopTimeUpdate_NO_DATA},
+			{StopSequence: seq(5), Arrival: &gtfs.StopTimeEvent{Delay: dur(2 * time.Minute)}},
+		})
+		assert.True(t, result[1].predicted)
+		assert.False(t, result[2].predicted)
+		assert.False(t, result[3].predicted)
+		assert.Equal(t, times{at(8, 42), at(8, 42), true}, result[4])
+	})
+
+	t.Run("skipped stop carries the delay past it", func(t *testing.T) {
+		prediction := PredictStopTimes(midnight, stopTimes, []gtfs.StopTimeUpdate{
+			{StopSequence: seq(1), Departure: &gtfs.StopTimeEvent{Delay: dur(2 * time.Minute)}},
+			{StopSequence: seq(2), ScheduleRelationship: gtfsrt.TripUpdate_StopTimeUpdate_SKIPPED},
+		})
+		skipped := prediction.Stops[1]
+		assert.True(t, skipped.Skipped)
+		assert.False(t, skipped.Predicted)
+		_, ok := prediction.ForStop("B", 2)
+		assert.False(t, ok)
+		assert.Equal(t, at(8, 22), prediction.Stops[2].PredictedArrival)
+	})
+
+	t.Run("times never go backwards", func(t *testing.T) {
+		early := at(8, 5)
+		result := predict([]gtfs.StopTimeUpdate{
+			{StopSequence: seq(1), Departure: &gtfs.StopTimeEvent{Delay: dur(9 * time.Minute)}},
+			{StopSequence: seq(2), Arrival: &gtfs.StopTimeEvent{Time: &early}},
+		})
+		assert.Equal(t, at(8, 9), result[1].arrival)
+	})
+}
+
+func TestTripPredictionScheduleDeviation(t *testing.T) {
+	midnight := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	stopTimes := []gtfsdb.StopTime{
+		{StopID: "A", StopSequence: 1, ArrivalTime: int64(8 * time.Hour), DepartureTime: int64(8 * time.Hour)},
+		{StopID: "B", StopSequence: 2, ArrivalTime: int64(8*time.Hour + 10*time.Minute), DepartureTime: int64(8*time.Hour + 15*time.Minute)},
+		{StopID: "C", StopSequence: 3, ArrivalTime: int64(8*time.Hour + 20*time.Minute), DepartureTime: int64(8*time.Hour + 20*time.Minute)},
+	}
+	delay := 7 * time.Minute
+	prediction := PredictStopTimes(midnight, stopTimes, []gtfs.StopTimeUpdate{{
+		StopID:  func() *string { s := "A"; return &s }(),
+		Arrival: &gtfs.StopTimeEvent{Delay: &delay},
+	}})
+
+	deviation := func(clock time.Duration) time.Duration {
+		d, ok := prediction.ScheduleDeviation(midnight.Add(clock))
+		require.True(t, ok)
+		return d
+	}
+	assert.Equal(t, 7*time.Minute, deviation(8*time.Hour), "on the way to A")
+	assert.Equal(t, 7*time.Minute, deviation(8*time.Hour+12*time.Minute), "on the way to B")
+	assert.Equal(t, 2*time.Minute, deviation(8*time.Hour+17*time.Minute), "at B, where the dwell makes up five minutes")
+	assert.Equal(t, 2*time.Minute, deviation(9*time.Hour), "finished")
+
+	stop, ok := prediction.ForStop("B", -1)
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Minute, stop.ArrivalDelay())
+	assert.Equal(t, 2*time.Minute, stop.DepartureDelay())
+
+	var none *TripPrediction
+	_, ok = none.ScheduleDeviation(midnight)
+	assert.False(t, ok)
+}
@@ -2,31 +2,139 @@ package gtfs
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"google.golang.org/protobuf/proto"
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/logging"
 )
 
 // GetRealTimeTrips returns the real-time trip updates
 func (manager *Manager) GetRealTimeTrips() []gtfs.Trip {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-	return manager.realTimeTrips
+	return manager.realTimeData().trips
 }
 
 // GetRealTimeVehicles returns the real-time vehicle positions
 func (manager *Manager) GetRealTimeVehicles() []gtfs.Vehicle {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-	return manager.realTimeVehicles
+	return manager.realTimeData().vehicles
 }
 
 func loadRealtimeData(ctx context.Context, source string, headers map[string]string) (*gtfs.Realtime, error) {
+	b, err := fetchRealtimeFeed(ctx, source, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return gtfs.ParseRealtime(b, &gtfs.ParseRealtimeOptions{})
+}
+
+// loadRealtimeAlerts loads a service alerts feed. Alongside the parsed feed it returns the severity
+// level of each alert, keyed by alert ID, since go-gtfs does not expose that field.
+func loadRealtimeAlerts(ctx context.Context, source string, headers map[string]string) (*gtfs.Realtime, map[string]gtfsrt.Alert_SeverityLevel, error) {
+	b, err := fetchRealtimeFeed(ctx, source, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	realtime, err := gtfs.ParseRealtime(b, &gtfs.ParseRealtimeOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return realtime, parseAlertSeverities(b), nil
+}
+
+// parseAlertSeverities returns the severity level of every alert in the feed that declares one.
+func parseAlertSeverities(b []byte) map[string]gtfsrt.Alert_SeverityLevel {
+	severities := make(map[string]gtfsrt.Alert_SeverityLevel)
+
+	feedMessage := &gtfsrt.FeedMessage{}
+	if err := proto.Unmarshal(b, feedMessage); err != nil {
+		return severities
+	}
+
+	for _, entity := range feedMessage.GetEntity() {
+		alert := entity.GetAlert()
+		if alert == nil || alert.SeverityLevel == nil {
+			continue
+		}
+		severities[entity.GetId()] = alert.GetSeverityLevel()
+	}
+	return severities
+}
+
+// loadRealtimeTripUpdates loads a trip updates feed. Alongside the parsed feed it returns the
+// properties of every duplicated trip, keyed by the trip descriptor of the trip it duplicates,
+// since go-gtfs does not expose them.
+func loadRealtimeTripUpdates(ctx context.Context, source string, headers map[string]string) (*gtfs.Realtime, map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties, error) {
+	b, err := fetchRealtimeFeed(ctx, source, headers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	realtime, err := gtfs.ParseRealtime(b, &gtfs.ParseRealtimeOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return realtime, parseTripProperties(b), nil
+}
+
+// tripDescriptorKey identifies a trip update by the trip ID, start date and start time of its
+// trip descriptor, as written in the feed.
+type tripDescriptorKey struct {
+	tripID    string
+	startDate string
+	startTime string
+}
+
+// tripDescriptorKeyFor returns the key of a trip update parsed by go-gtfs.
+func tripDescriptorKeyFor(id gtfs.TripID) tripDescriptorKey {
+	key := tripDescriptorKey{tripID: id.ID}
+	if id.HasStartDate {
+		key.startDate = id.StartDate.Format("20060102")
+	}
+	if id.HasStartTime {
+		seconds := int(id.StartTime / time.Second)
+		key.startTime = fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return key
+}
+
+// parseTripProperties returns the trip properties of every DUPLICATED trip update in the feed.
+func parseTripProperties(b []byte) map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties {
+	properties := make(map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties)
+
+	feedMessage := &gtfsrt.FeedMessage{}
+	if err := proto.Unmarshal(b, feedMessage); err != nil {
+		return properties
+	}
+
+	for _, entity := range feedMessage.GetEntity() {
+		tripUpdate := entity.GetTripUpdate()
+		trip := tripUpdate.GetTrip()
+		if tripUpdate.GetTripProperties() == nil || trip.GetScheduleRelationship() != gtfsrt.TripDescriptor_DUPLICATED {
+			continue
+		}
+		key := tripDescriptorKey{
+			tripID:    trip.GetTripId(),
+			startDate: trip.GetStartDate(),
+			startTime: trip.GetStartTime(),
+		}
+		properties[key] = tripUpdate.GetTripProperties()
+	}
+	return properties
+}
+
+func fetchRealtimeFeed(ctx context.Context, source string, headers map[string]string) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", source, nil)
 	if err != nil {
 		return nil, err
@@ -44,115 +152,99 @@ func loadRealtimeData(ctx context.Context, source string, headers map[string]str
 		slog.Default().With(slog.String("component", "gtfs_realtime_downloader")),
 		"http_response_body")
 
-	b, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	return io.ReadAll(resp.Body)
+}
+
+// GetAlertByID returns the real-time service alert with the given ID, or nil if there is none.
+func (manager *Manager) GetAlertByID(alertID string) *gtfs.Alert {
+	snapshot := manager.realTimeData()
+	if i, ok := snapshot.alertByID[alertID]; ok {
+		alert := snapshot.alerts[i]
+		return &alert
 	}
+	return nil
+}
 
-	return gtfs.ParseRealtime(b, &gtfs.ParseRealtimeOptions{})
+// GetAlertSeverity returns the severity level declared by the alert with the given ID. The
+// second return value is false when the alert does not declare a severity.
+func (manager *Manager) GetAlertSeverity(alertID string) (gtfsrt.Alert_SeverityLevel, bool) {
+	severity, ok := manager.realTimeData().alertSeverities[alertID]
+	return severity, ok
 }
 
 func (manager *Manager) GetAlertsForRoute(routeID string) []gtfs.Alert {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-
-	var alerts []gtfs.Alert
-	for _, alert := range manager.realTimeAlerts {
-		if alert.InformedEntities != nil {
-			for _, entity := range alert.InformedEntities {
-				if entity.RouteID != nil && *entity.RouteID == routeID {
-					alerts = append(alerts, alert)
-					break
-				}
-			}
-		}
-	}
-	return alerts
+	snapshot := manager.realTimeData()
+	return snapshot.alertsAt(snapshot.alertsByRouteID[routeID])
 }
 
 func (manager *Manager) GetAlertsForTrip(tripID string) []gtfs.Alert {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-
-	var alerts []gtfs.Alert
-	for _, alert := range manager.realTimeAlerts {
-		if alert.InformedEntities != nil {
-			for _, entity := range alert.InformedEntities {
-				if entity.TripID != nil && entity.TripID.ID == tripID {
-					alerts = append(alerts, alert)
-					break
-				}
-			}
-		}
-	}
-	return alerts
+	snapshot := manager.realTimeData()
+	return snapshot.alertsAt(snapshot.alertsByTripID[tripID])
 }
 
 func (manager *Manager) GetAlertsForStop(stopID string) []gtfs.Alert {
-	manager.realTimeMutex.RLock()
-	defer manager.realTimeMutex.RUnlock()
-
-	var alerts []gtfs.Alert
-	for _, alert := range manager.realTimeAlerts {
-		if alert.InformedEntities != nil {
-			for _, entity := range alert.InformedEntities {
-				if entity.StopID != nil && *entity.StopID == stopID {
-					alerts = append(alerts, alert)
-					break
-				}
-			}
-		}
-	}
-	return alerts
+	snapshot := manager.realTimeData()
+	return snapshot.alertsAt(snapshot.alertsByStopID[stopID])
+}
+
+// realTimeFeedData is the latest realtime data of one feed, or of every feed merged.
+type realTimeFeedData struct {
+	trips           []gtfs.Trip
+	vehicles        []gtfs.Vehicle
+	alerts          []gtfs.Alert
+	alertSeverities map[string]gtfsrt.Alert_SeverityLevel
+	tripProperties  map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties
 }
 
-func (manager *Manager) updateGTFSRealtime(ctx context.Context, config Config) {
-	logger := logging.FromContext(ctx).With(slog.String("component", "gtfs_realtime"))
+func (manager *Manager) updateGTFSRealtime(ctx context.Context, feed FeedConfig) {
+	logger := logging.FromContext(ctx).With(slog.String("component", "gtfs_realtime"), slog.String("feed_id", feed.ID))
 
-	headers := map[string]string{}
-	if config.RealTimeAuthHeaderKey != "" && config.RealTimeAuthHeaderValue != "" {
-		headers[config.RealTimeAuthHeaderKey] = config.RealTimeAuthHeaderValue
-	}
+	headers := feed.realTimeHeaders()
 
 	var wg sync.WaitGroup
 	var tripData, vehicleData, alertData *gtfs.Realtime
+	var alertSeverities map[string]gtfsrt.Alert_SeverityLevel
+	var tripProperties map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties
 	var tripErr, vehicleErr, alertErr error
 
-	// Fetch trip updates in parallel
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		tripData, tripErr = loadRealtimeData(ctx, config.TripUpdatesURL, headers)
-		if tripErr != nil {
-			logging.LogError(logger, "Error loading GTFS-RT trip updates data", tripErr,
-				slog.String("url", config.TripUpdatesURL))
-		}
-	}()
-
-	// Fetch vehicle positions in parallel
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		vehicleData, vehicleErr = loadRealtimeData(ctx, config.VehiclePositionsURL, headers)
-		if vehicleErr != nil {
-			logging.LogError(logger, "Error loading GTFS-RT vehicle positions data", vehicleErr,
-				slog.String("url", config.VehiclePositionsURL))
-		}
-	}()
+	// Fetch each configured realtime feed in parallel
+	if feed.TripUpdatesURL != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tripData, tripProperties, tripErr = loadRealtimeTripUpdates(ctx, feed.TripUpdatesURL, headers)
+			if tripErr != nil {
+				logging.LogError(logger, "Error loading GTFS-RT trip updates data", tripErr,
+					slog.String("url", feed.TripUpdatesURL))
+			}
+		}()
+	}
 
-	if config.ServiceAlertsURL != "" {
+	if feed.VehiclePositionsURL != "" {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			alertData, alertErr = loadRealtimeData(ctx, config.ServiceAlertsURL, headers)
+			vehicleData, vehicleErr = loadRealtimeData(ctx, feed.VehiclePositionsURL, headers)
+			if vehicleErr != nil {
+				logging.LogError(logger, "Error loading GTFS-RT vehicle positions data", vehicleErr,
+					slog.String("url", feed.VehiclePositionsURL))
+			}
+		}()
+	}
+
+	if feed.ServiceAlertsURL != "" {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			alertData, alertSeverities, alertErr = loadRealtimeAlerts(ctx, feed.ServiceAlertsURL, headers)
 			if alertErr != nil {
 				logging.LogError(logger, "Error loading GTFS-RT service alerts data", alertErr,
-					slog.String("url", config.ServiceAlertsURL))
+					slog.String("url", feed.ServiceAlertsURL))
 			}
 		}()
 	}
 
-	// Wait for both to complete
+	// Wait for all to complete
 	wg.Wait()
 
 	// Check for context cancellation
@@ -160,30 +252,88 @@ func (manager *Manager) updateGTFSRealtime(ctx context.Context, config Config) {
 		return
 	}
 
+	// The feed refers to its static data by the IDs written in it, which were imported into the
+	// feed's namespace
+	fetched := realTimeFeedData{alertSeverities: alertSeverities, tripProperties: tripProperties}
+	if tripData != nil {
+		fetched.trips = tripData.Trips
+	}
+	if vehicleData != nil {
+		fetched.vehicles = vehicleData.Vehicles
+	}
+	if alertData != nil {
+		fetched.alerts = alertData.Alerts
+	}
+	scopeRealTimeIDs(&fetched, gtfsdb.FeedNamespace(feed.ID))
+
 	// Update data if at least one fetch succeeded
 	manager.realTimeMutex.Lock()
 	defer manager.realTimeMutex.Unlock()
 
+	if manager.realTimeFeeds == nil {
+		manager.realTimeFeeds = make(map[string]*realTimeFeedData)
+	}
+	feedData := manager.realTimeFeeds[feed.ID]
+	if feedData == nil {
+		feedData = &realTimeFeedData{}
+		manager.realTimeFeeds[feed.ID] = feedData
+	}
+
 	if tripData != nil && tripErr == nil {
-		manager.realTimeTrips = tripData.Trips
+		feedData.trips = fetched.trips
+		feedData.tripProperties = fetched.tripProperties
 	}
 	if vehicleData != nil && vehicleErr == nil {
-		manager.realTimeVehicles = vehicleData.Vehicles
+		feedData.vehicles = fetched.vehicles
 	}
 
 	if alertData != nil && alertErr == nil {
-		manager.realTimeAlerts = alertData.Alerts
+		feedData.alerts = fetched.alerts
+		feedData.alertSeverities = fetched.alertSeverities
+	}
+
+	manager.publishRealTimeData(ctx, manager.mergeRealTimeData())
+}
+
+// mergeRealTimeData combines the realtime data of every feed. It must be called with
+// realTimeMutex held.
+func (manager *Manager) mergeRealTimeData() realTimeFeedData {
+	feedIDs := make([]string, 0, len(manager.realTimeFeeds))
+	for feedID := range manager.realTimeFeeds {
+		feedIDs = append(feedIDs, feedID)
+	}
+	sort.Strings(feedIDs)
+
+	if len(feedIDs) == 1 {
+		return *manager.realTimeFeeds[feedIDs[0]]
+	}
+
+	merged := realTimeFeedData{
+		alertSeverities: make(map[string]gtfsrt.Alert_SeverityLevel),
+		tripProperties:  make(map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties),
+	}
+	for _, feedID := range feedIDs {
+		feedData := manager.realTimeFeeds[feedID]
+		merged.trips = append(merged.trips, feedData.trips...)
+		merged.vehicles = append(merged.vehicles, feedData.vehicles...)
+		merged.alerts = append(merged.alerts, feedData.alerts...)
+		for alertID, severity := range feedData.alertSeverities {
+			merged.alertSeverities[alertID] = severity
+		}
+		for key, properties := range feedData.tripProperties {
+			merged.tripProperties[key] = properties
+		}
 	}
+	return merged
 }
 
-func (manager *Manager) updateGTFSRealtimePeriodically(config Config) {
+func (manager *Manager) updateGTFSRealtimePeriodically(feed FeedConfig) {
 	defer manager.wg.Done()
 
 	// Create a logger for this goroutine
-	logger := slog.Default().With(slog.String("component", "gtfs_realtime_updater"))
+	logger := slog.Default().With(slog.String("component", "gtfs_realtime_updater"), slog.String("feed_id", feed.ID))
 
-	// Update every 30 seconds
-	ticker := time.NewTicker(30 * time.Second)
+	ticker := time.NewTicker(feed.realTimeRefreshInterval())
 	defer ticker.Stop()
 
 	for { // nolint
@@ -195,7 +345,7 @@ func (manager *Manager) updateGTFSRealtimePeriodically(config Config) {
 
 			// Download realtime data
 			logging.LogOperation(logger, "updating_gtfs_realtime_data")
-			manager.updateGTFSRealtime(ctx, config)
+			manager.updateGTFSRealtime(ctx, feed)
 			cancel() // Ensure the context is canceled when done
 		case <-manager.shutdownChan:
 			logging.LogOperation(logger, "shutting_down_realtime_updates")
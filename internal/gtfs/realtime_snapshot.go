@@ -0,0 +1,223 @@
+package gtfs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// realTimeSnapshot is the merged realtime data of every feed, indexed for lookups. A published
+// snapshot is never modified: each refresh builds a new one and swaps it in, so readers never
+// wait on a refresh. Indexes hold positions in the data's slices, in feed order.
+type realTimeSnapshot struct {
+	realTimeFeedData
+	blockIDs            map[string]string // Block of each trip a vehicle serves, by trip ID
+	vehicleByID         map[string]int
+	vehicleByTripID     map[string]int
+	vehicleByBlockID    map[string]int // First vehicle serving a trip of each block
+	vehiclesByRouteID   map[string][]int
+	tripUpdatesByTripID map[string][]int
+	realtimeTripsByStop map[string][]int // ADDED and DUPLICATED trips, by the stops they may visit
+	alertByID           map[string]int
+	alertsByRouteID     map[string][]int
+	alertsByTripID      map[string][]int
+	alertsByStopID      map[string][]int
+}
+
+// emptyRealTimeSnapshot is what readers see before the first refresh.
+var emptyRealTimeSnapshot = newRealTimeSnapshot(realTimeFeedData{}, nil, nil)
+
+// newRealTimeSnapshot indexes merged realtime data. blockIDs maps the trips vehicles serve to
+// their blocks, and copiedTripStops the trips DUPLICATED trips copy to the stops they serve.
+func newRealTimeSnapshot(data realTimeFeedData, blockIDs map[string]string, copiedTripStops map[string][]string) *realTimeSnapshot {
+	snapshot := &realTimeSnapshot{
+		realTimeFeedData:    data,
+		blockIDs:            blockIDs,
+		vehicleByID:         make(map[string]int, len(data.vehicles)),
+		vehicleByTripID:     make(map[string]int, len(data.vehicles)),
+		vehicleByBlockID:    make(map[string]int),
+		vehiclesByRouteID:   make(map[string][]int),
+		tripUpdatesByTripID: make(map[string][]int, len(data.trips)),
+		realtimeTripsByStop: make(map[string][]int),
+		alertByID:           make(map[string]int, len(data.alerts)),
+		alertsByRouteID:     make(map[string][]int),
+		alertsByTripID:      make(map[string][]int),
+		alertsByStopID:      make(map[string][]int),
+	}
+
+	for i, vehicle := range data.vehicles {
+		if vehicle.ID != nil {
+			if _, exists := snapshot.vehicleByID[vehicle.ID.ID]; !exists {
+				snapshot.vehicleByID[vehicle.ID.ID] = i
+			}
+		}
+		if vehicle.Trip == nil {
+			continue
+		}
+		if _, exists := snapshot.vehicleByTripID[vehicle.Trip.ID.ID]; !exists {
+			snapshot.vehicleByTripID[vehicle.Trip.ID.ID] = i
+		}
+		snapshot.vehiclesByRouteID[vehicle.Trip.ID.RouteID] = append(snapshot.vehiclesByRouteID[vehicle.Trip.ID.RouteID], i)
+		if blockID, ok := blockIDs[vehicle.Trip.ID.ID]; ok && vehicle.Trip.ID.ID != "" {
+			if _, exists := snapshot.vehicleByBlockID[blockID]; !exists {
+				snapshot.vehicleByBlockID[blockID] = i
+			}
+		}
+	}
+
+	for i, trip := range data.trips {
+		snapshot.tripUpdatesByTripID[trip.ID.ID] = append(snapshot.tripUpdatesByTripID[trip.ID.ID], i)
+
+		// ADDED trips visit the stops of their updates, and DUPLICATED ones those of the trip
+		// they copy
+		switch trip.ID.ScheduleRelationship {
+		case gtfsrt.TripDescriptor_ADDED:
+			for _, update := range trip.StopTimeUpdates {
+				if update.StopID != nil {
+					indexOnce(snapshot.realtimeTripsByStop, *update.StopID, i)
+				}
+			}
+		case gtfsrt.TripDescriptor_DUPLICATED:
+			for _, stopID := range copiedTripStops[trip.ID.ID] {
+				indexOnce(snapshot.realtimeTripsByStop, stopID, i)
+			}
+		}
+	}
+
+	for i, alert := range data.alerts {
+		if _, exists := snapshot.alertByID[alert.ID]; !exists {
+			snapshot.alertByID[alert.ID] = i
+		}
+		for _, entity := range alert.InformedEntities {
+			if entity.RouteID != nil {
+				indexOnce(snapshot.alertsByRouteID, *entity.RouteID, i)
+			}
+			if entity.TripID != nil {
+				indexOnce(snapshot.alertsByTripID, entity.TripID.ID, i)
+			}
+			if entity.StopID != nil {
+				indexOnce(snapshot.alertsByStopID, *entity.StopID, i)
+			}
+		}
+	}
+	return snapshot
+}
+
+// indexOnce adds a position to the index of one key, once however many times the alert or trip
+// at that position names the key.
+func indexOnce(index map[string][]int, key string, position int) {
+	positions := index[key]
+	if len(positions) > 0 && positions[len(positions)-1] == position {
+		return
+	}
+	index[key] = append(positions, position)
+}
+
+// vehiclesAt returns copies of the vehicles at the given positions.
+func (snapshot *realTimeSnapshot) vehiclesAt(indexes []int) []gtfs.Vehicle {
+	if len(indexes) == 0 {
+		return nil
+	}
+	vehicles := make([]gtfs.Vehicle, len(indexes))
+	for i, index := range indexes {
+		vehicles[i] = snapshot.vehicles[index]
+	}
+	return vehicles
+}
+
+// alertsAt returns copies of the alerts at the given positions.
+func (snapshot *realTimeSnapshot) alertsAt(indexes []int) []gtfs.Alert {
+	if len(indexes) == 0 {
+		return nil
+	}
+	alerts := make([]gtfs.Alert, len(indexes))
+	for i, index := range indexes {
+		alerts[i] = snapshot.alerts[index]
+	}
+	return alerts
+}
+
+// realTimeData returns the latest published realtime snapshot.
+func (manager *Manager) realTimeData() *realTimeSnapshot {
+	if snapshot := manager.realTime.Load(); snapshot != nil {
+		return snapshot
+	}
+	return emptyRealTimeSnapshot
+}
+
+// publishRealTimeData indexes merged realtime data and makes it the data readers see. It must
+// be called with realTimeMutex held.
+func (manager *Manager) publishRealTimeData(ctx context.Context, data realTimeFeedData) {
+	manager.realTime.Store(newRealTimeSnapshot(data,
+		manager.blockIDsForVehicles(ctx, data.vehicles),
+		manager.stopIDsForCopiedTrips(ctx, data.trips)))
+}
+
+// blockIDsForVehicles looks up the block of every trip the vehicles serve.
+func (manager *Manager) blockIDsForVehicles(ctx context.Context, vehicles []gtfs.Vehicle) map[string]string {
+	client := manager.GtfsDB()
+	if client == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var tripIDs []string
+	for _, vehicle := range vehicles {
+		if vehicle.Trip == nil || vehicle.Trip.ID.ID == "" || seen[vehicle.Trip.ID.ID] {
+			continue
+		}
+		seen[vehicle.Trip.ID.ID] = true
+		tripIDs = append(tripIDs, vehicle.Trip.ID.ID)
+	}
+	if len(tripIDs) == 0 {
+		return nil
+	}
+
+	rows, err := client.Queries.GetBlockIDsForTrips(ctx, tripIDs)
+	if err != nil {
+		logger := logging.FromContext(ctx).With(slog.String("component", "gtfs_realtime"))
+		logging.LogError(logger, "Error looking up the blocks of vehicle trips", err)
+		return nil
+	}
+	blockIDs := make(map[string]string, len(rows))
+	for _, row := range rows {
+		blockIDs[row.ID] = row.BlockID.String
+	}
+	return blockIDs
+}
+
+// stopIDsForCopiedTrips looks up the stops of every trip that DUPLICATED trips copy.
+func (manager *Manager) stopIDsForCopiedTrips(ctx context.Context, trips []gtfs.Trip) map[string][]string {
+	client := manager.GtfsDB()
+	if client == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var tripIDs []string
+	for _, trip := range trips {
+		if trip.ID.ScheduleRelationship != gtfsrt.TripDescriptor_DUPLICATED || trip.ID.ID == "" || seen[trip.ID.ID] {
+			continue
+		}
+		seen[trip.ID.ID] = true
+		tripIDs = append(tripIDs, trip.ID.ID)
+	}
+	if len(tripIDs) == 0 {
+		return nil
+	}
+
+	rows, err := client.Queries.GetStopIDsForTrips(ctx, tripIDs)
+	if err != nil {
+		logger := logging.FromContext(ctx).With(slog.String("component", "gtfs_realtime"))
+		logging.LogError(logger, "Error looking up the stops of duplicated trips", err)
+		return nil
+	}
+	stopIDs := make(map[string][]string, len(tripIDs))
+	for _, row := range rows {
+		stopIDs[row.TripID] = append(stopIDs[row.TripID], row.StopID)
+	}
+	return stopIDs
+}
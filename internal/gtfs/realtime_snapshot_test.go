@@ -0,0 +1,112 @@
+package gtfs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/OneBusAway/go-gtfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setRealTimeData replaces the realtime data readers see.
+func setRealTimeData(manager *Manager, data realTimeFeedData) {
+	manager.realTimeMutex.Lock()
+	defer manager.realTimeMutex.Unlock()
+	manager.publishRealTimeData(context.Background(), data)
+}
+
+func TestRealTimeSnapshotLookups(t *testing.T) {
+	stopID := func(s string) *string { return &s }
+	vehicle := func(vehicleID, tripID, routeID string) gtfs.Vehicle {
+		return gtfs.Vehicle{
+			ID:   &gtfs.VehicleID{ID: vehicleID},
+			Trip: &gtfs.Trip{ID: gtfs.TripID{ID: tripID, RouteID: routeID}},
+		}
+	}
+
+	manager := &Manager{}
+	assert.Empty(t, manager.GetRealTimeVehicles(), "nothing is published before the first refresh")
+	assert.Nil(t, manager.GetAlertByID("a1"))
+
+	setRealTimeData(manager, realTimeFeedData{
+		vehicles: []gtfs.Vehicle{
+			vehicle("v1", "t1", "r1"),
+			vehicle("v2", "t2", "r2"),
+			vehicle("v3", "t3", "r1"),
+			{Trip: &gtfs.Trip{ID: gtfs.TripID{ID: "t4"}}}, // No vehicle ID
+		},
+		trips: []gtfs.Trip{
+			{ID: gtfs.TripID{ID: "t1"}},
+			{ID: gtfs.TripID{ID: "t2"}},
+			{ID: gtfs.TripID{ID: "t1", HasStartDate: true}},
+		},
+		alerts: []gtfs.Alert{
+			{ID: "a1", InformedEntities: []gtfs.AlertInformedEntity{{StopID: stopID("s1")}, {StopID: stopID("s1")}}},
+			{ID: "a2", InformedEntities: []gtfs.AlertInformedEntity{{TripID: &gtfs.TripID{ID: "t1"}}, {StopID: stopID("s1")}}},
+		},
+	})
+
+	found, err := manager.GetVehicleByID("v2")
+	require.NoError(t, err)
+	assert.Equal(t, "t2", found.Trip.ID.ID)
+	_, err = manager.GetVehicleByID("missing")
+	assert.Error(t, err)
+
+	assert.Equal(t, "v3", manager.GetVehicleServingTrip("t3").ID.ID)
+	assert.Nil(t, manager.GetVehicleServingTrip("t5"))
+
+	assert.Len(t, manager.GetTripUpdatesForTrip("t1"), 2)
+	assert.Empty(t, manager.GetTripUpdatesForTrip("t3"))
+
+	stopAlerts := manager.GetAlertsForStop("s1")
+	require.Len(t, stopAlerts, 2, "an alert naming a stop twice is listed once")
+	assert.Equal(t, "a1", stopAlerts[0].ID)
+	assert.Equal(t, "a2", stopAlerts[1].ID)
+	assert.Len(t, manager.GetAlertsForTrip("t1"), 1)
+	assert.Equal(t, "a2", manager.GetAlertByID("a2").ID)
+
+	byRoute := manager.realTimeData().vehiclesAt(manager.realTimeData().vehiclesByRouteID["r1"])
+	require.Len(t, byRoute, 2)
+	assert.Equal(t, "v1", byRoute[0].ID.ID)
+	assert.Equal(t, "v3", byRoute[1].ID.ID)
+
+	// Readers holding the previous snapshot are unaffected by a refresh
+	previous := manager.realTimeData()
+	setRealTimeData(manager, realTimeFeedData{})
+	assert.Len(t, previous.vehicles, 4)
+	assert.Empty(t, manager.GetRealTimeVehicles())
+}
+
+func TestGetVehicleForTripUsesBlocks(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	ctx := context.Background()
+
+	// Two trips of the same block
+	var blockID string
+	row := manager.GtfsDB().DB.QueryRowContext(ctx,
+		"SELECT block_id FROM trips WHERE block_id IS NOT NULL GROUP BY block_id HAVING COUNT(*) > 1 ORDER BY block_id LIMIT 1")
+	require.NoError(t, row.Scan(&blockID))
+	rows, err := manager.GtfsDB().DB.QueryContext(ctx, "SELECT id FROM trips WHERE block_id = ? ORDER BY id LIMIT 2", blockID)
+	require.NoError(t, err)
+	var tripIDs []string
+	for rows.Next() {
+		var id string
+		require.NoError(t, rows.Scan(&id))
+		tripIDs = append(tripIDs, id)
+	}
+	require.NoError(t, rows.Close())
+	require.Len(t, tripIDs, 2)
+
+	setRealTimeData(manager, realTimeFeedData{vehicles: []gtfs.Vehicle{{
+		ID:   &gtfs.VehicleID{ID: "bus"},
+		Trip: &gtfs.Trip{ID: gtfs.TripID{ID: tripIDs[0]}},
+	}}})
+
+	assert.Equal(t, blockID, manager.realTimeData().blockIDs[tripIDs[0]])
+	for _, tripID := range tripIDs {
+		vehicle := manager.GetVehicleForTrip(tripID)
+		require.NotNil(t, vehicle, "the vehicle serves the block of trip %s", tripID)
+		assert.Equal(t, "bus", vehicle.ID.ID)
+	}
+}
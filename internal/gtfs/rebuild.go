@@ -0,0 +1,123 @@
+package gtfs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// retiredGtfsDBGracePeriod is how long a replaced database stays open for requests that were
+// already using it
+const retiredGtfsDBGracePeriod = time.Minute
+
+// RebuildStats counts the rebuilds of the GTFS database and describes the last swap
+type RebuildStats struct {
+	Succeeded    int64
+	Failed       int64
+	LastSwapTime time.Time     // Zero until the first swap
+	LastDuration time.Duration // How long the rebuild before the last swap took
+}
+
+// RebuildStats returns the counts of GTFS database rebuilds so far
+func (manager *Manager) RebuildStats() RebuildStats {
+	manager.rebuildStatsMutex.Lock()
+	defer manager.rebuildStatsMutex.Unlock()
+	return manager.rebuildStats
+}
+
+// rebuildGtfsDB builds a new database beside the live one from download for the feed with the
+// given ID and the other feeds' live rows, and swaps it in once it validates. Requests keep using the live
+// database until the swap, and the replaced database is closed after a grace period.
+func (manager *Manager) rebuildGtfsDB(ctx context.Context, feedID string, download *gtfsdb.FeedDownload) error {
+	manager.rebuildMutex.Lock()
+	defer manager.rebuildMutex.Unlock()
+
+	logger := slog.Default().With(slog.String("component", "gtfs_rebuild"), slog.String("feed_id", feedID))
+	logging.LogOperation(logger, "gtfs_db_rebuild_started")
+
+	startTime := time.Now()
+	next, err := manager.buildNextGtfsDB(ctx, feedID, download)
+	if err != nil {
+		manager.rebuildStatsMutex.Lock()
+		manager.rebuildStats.Failed++
+		manager.rebuildStatsMutex.Unlock()
+		logging.LogError(logger, "GTFS database rebuild failed, keeping the live database", err)
+		return err
+	}
+
+	retired := manager.gtfsDB.Swap(next)
+	manager.retireGtfsDB(retired)
+
+	duration := time.Since(startTime)
+	manager.rebuildStatsMutex.Lock()
+	manager.rebuildStats.Succeeded++
+	manager.rebuildStats.LastSwapTime = time.Now()
+	manager.rebuildStats.LastDuration = duration
+	manager.rebuildStatsMutex.Unlock()
+
+	logging.LogOperation(logger, "gtfs_db_swapped",
+		slog.Duration("duration", duration))
+	return nil
+}
+
+// buildNextGtfsDB imports the refreshed feed into a new database alongside the other feeds'
+// rows, copied from the live database so that they match the other feeds' in-memory data, and
+// promotes it in place of the live one, returning the client to swap in.
+func (manager *Manager) buildNextGtfsDB(ctx context.Context, feedID string, download *gtfsdb.FeedDownload) (*gtfsdb.Client, error) {
+	live := manager.GtfsDB()
+	next, err := gtfsdb.NewRebuildClient(live)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GTFS database client: %w", err)
+	}
+
+	feedIDs := make([]string, 0, len(manager.feeds))
+	for _, feed := range manager.feeds {
+		if feed.ID == feedID {
+			err = next.ImportFeedDownload(ctx, feed.ID, download, feed.GtfsURL)
+		} else {
+			err = next.CopyFeedFrom(ctx, live, feed.ID)
+		}
+		if err != nil {
+			_ = next.Close()
+			return nil, fmt.Errorf("error importing feed %s: %w", feed.ID, err)
+		}
+		feedIDs = append(feedIDs, feed.ID)
+	}
+
+	if err := next.Validate(ctx, feedIDs); err != nil {
+		_ = next.Close()
+		return nil, fmt.Errorf("rebuilt database is invalid: %w", err)
+	}
+
+	// Promote consumes next, so it is not closed here on failure
+	promoted, err := next.Promote(live)
+	if err != nil {
+		return nil, fmt.Errorf("error promoting rebuilt database: %w", err)
+	}
+	return promoted, nil
+}
+
+// retireGtfsDB closes a replaced database once requests that started before the swap have had
+// time to finish, or at shutdown.
+func (manager *Manager) retireGtfsDB(client *gtfsdb.Client) {
+	manager.wg.Add(1)
+	go func() {
+		defer manager.wg.Done()
+
+		timer := time.NewTimer(retiredGtfsDBGracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-manager.shutdownChan:
+		}
+
+		if err := client.Close(); err != nil {
+			logging.LogError(slog.Default().With(slog.String("component", "gtfs_rebuild")),
+				"Error closing replaced GTFS database", err)
+		}
+	}()
+}
@@ -0,0 +1,166 @@
+package gtfs
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestRefreshStaticGTFSRebuildsDatabase(t *testing.T) {
+	shuttlePath := models.BuildShuttleFeed(t)
+	feedPath := filepath.Join(t.TempDir(), "feed.zip")
+	original, err := os.ReadFile(shuttlePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(feedPath, original, 0o600))
+
+	feed := FeedConfig{ID: "shuttle", GtfsURL: feedPath}
+	manager, err := InitGTFSManager(Config{Feeds: []FeedConfig{feed}, GTFSDataPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer manager.Shutdown()
+
+	ctx := context.Background()
+	liveDB := manager.GtfsDB()
+	_, err = liveDB.UserQueries.CreateProblemReport(ctx, gtfsdb.CreateProblemReportParams{
+		ReportType: "stop",
+		StopID:     sql.NullString{String: "SH_A", Valid: true},
+		Code:       "stop_name_wrong",
+		CreatedAt:  1735689600000,
+	})
+	require.NoError(t, err)
+
+	t.Run("unchanged feed keeps the database", func(t *testing.T) {
+		require.NoError(t, manager.refreshStaticGTFS(feed))
+		assert.Same(t, liveDB, manager.GtfsDB())
+	})
+
+	t.Run("changed feed is swapped in under load", func(t *testing.T) {
+		changed, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+			"routes.txt": "route_id,agency_id,route_short_name,route_long_name,route_type\n" +
+				"LOOP,SHUTTLE,L,Downtown Express Loop,3\n",
+		}))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(feedPath, changed, 0o600))
+		succeeded := manager.RebuildStats().Succeeded
+
+		// Requests running through the swap must keep working
+		var failures atomic.Int64
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					if _, err := manager.GtfsDB().Queries.GetRoute(ctx, gtfsdb.ScopedID("shuttle", "LOOP")); err != nil {
+						failures.Add(1)
+					}
+				}
+			}()
+		}
+
+		err = manager.refreshStaticGTFS(feed)
+		close(done)
+		wg.Wait()
+		require.NoError(t, err)
+		assert.Zero(t, failures.Load())
+
+		assert.NotSame(t, liveDB, manager.GtfsDB())
+		route, err := manager.GtfsDB().Queries.GetRoute(ctx, gtfsdb.ScopedID("shuttle", "LOOP"))
+		require.NoError(t, err)
+		assert.Equal(t, "Downtown Express Loop", route.LongName.String)
+		assert.Equal(t, "Downtown Express Loop", manager.GetStaticData().Routes[0].LongName)
+
+		reports, err := manager.GtfsDB().UserQueries.ListProblemReports(ctx, gtfsdb.ListProblemReportsParams{MaxResults: 10})
+		require.NoError(t, err)
+		assert.Len(t, reports, 1, "problem reports survive the rebuild")
+
+		_, err = liveDB.Queries.GetRoute(ctx, gtfsdb.ScopedID("shuttle", "LOOP"))
+		assert.NoError(t, err, "the replaced database stays open for requests still using it")
+		stats := manager.RebuildStats()
+		assert.Equal(t, succeeded+1, stats.Succeeded)
+		assert.False(t, stats.LastSwapTime.IsZero())
+	})
+
+	t.Run("invalid feed keeps the database", func(t *testing.T) {
+		current := manager.GtfsDB()
+		broken, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+			"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n",
+		}))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(feedPath, broken, 0o600))
+		failed := manager.RebuildStats().Failed
+
+		assert.Error(t, manager.refreshStaticGTFS(feed))
+		assert.Same(t, current, manager.GtfsDB())
+		assert.Equal(t, "Downtown Express Loop", manager.GetStaticData().Routes[0].LongName)
+		assert.Equal(t, failed+1, manager.RebuildStats().Failed)
+	})
+}
+
+func TestRebuildKeepsUnchangedFeedsWithoutDownloadingThem(t *testing.T) {
+	shuttlePath := models.BuildShuttleFeed(t)
+	original, err := os.ReadFile(shuttlePath)
+	require.NoError(t, err)
+	raba, err := os.ReadFile(models.GetFixturePath(t, "raba.zip"))
+	require.NoError(t, err)
+
+	shuttleFeeds := &feedServer{}
+	shuttleFeeds.set(original, `"1"`)
+	shuttleServer := httptest.NewServer(shuttleFeeds)
+	defer shuttleServer.Close()
+	rabaFeeds := &feedServer{}
+	rabaFeeds.set(raba, `"1"`)
+	rabaServer := httptest.NewServer(rabaFeeds)
+	defer rabaServer.Close()
+
+	shuttle := FeedConfig{ID: "shuttle", GtfsURL: shuttleServer.URL + "/shuttle.zip"}
+	manager, err := InitGTFSManager(Config{
+		Feeds:        []FeedConfig{{ID: "raba", GtfsURL: rabaServer.URL + "/raba.zip"}, shuttle},
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	})
+	require.NoError(t, err)
+	defer manager.Shutdown()
+	require.Equal(t, 1, rabaFeeds.downloads())
+
+	// A newer version of the other feed is published, but only the shuttle is refreshed
+	rabaFeeds.set(nil, `"2"`)
+	changed, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+		"routes.txt": "route_id,agency_id,route_short_name,route_long_name,route_type\n" +
+			"LOOP,SHUTTLE,L,Uptown Loop,3\n",
+	}))
+	require.NoError(t, err)
+	shuttleFeeds.set(changed, `"2"`)
+	liveDB := manager.GtfsDB()
+
+	require.NoError(t, manager.refreshStaticGTFS(shuttle))
+	assert.NotSame(t, liveDB, manager.GtfsDB())
+	assert.Equal(t, 1, rabaFeeds.downloads(), "the unchanged feed is not downloaded again")
+
+	ctx := context.Background()
+	stop, err := manager.GtfsDB().Queries.GetStop(ctx, gtfsdb.ScopedID("raba", "1001"))
+	require.NoError(t, err)
+	assert.Equal(t, "raba", stop.SourceFeedID.String)
+	metadata, err := manager.GtfsDB().Queries.GetImportMetadata(ctx, "raba")
+	require.NoError(t, err)
+	assert.Equal(t, `"1"`, metadata.Etag.String, "the database matches the feed's in-memory data")
+	route, err := manager.GtfsDB().Queries.GetRoute(ctx, gtfsdb.ScopedID("shuttle", "LOOP"))
+	require.NoError(t, err)
+	assert.Equal(t, "Uptown Loop", route.LongName.String)
+}
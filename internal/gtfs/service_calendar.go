@@ -0,0 +1,210 @@
+package gtfs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// serviceCalendarCacheSize bounds the number of dates whose active services are cached. The
+// cache starts over once it is full.
+const serviceCalendarCacheSize = 1024
+
+// serviceDateLayout is the GTFS date format, used for the dates of the service calendar
+const serviceDateLayout = "20060102"
+
+// ServiceCalendar resolves the days services run on from calendar.txt and calendar_dates.txt.
+// A calendar_dates.txt exception for a date overrides calendar.txt, so a service runs on a date
+// when it is added that day, or when it isn't removed that day and its calendar covers it.
+type ServiceCalendar struct {
+	calendars map[string]gtfsdb.Calendar
+	// exceptions holds each service's exception types, by service ID and then date
+	exceptions map[string]map[string]int64
+	// servicesByDate holds the services with an exception on each date
+	servicesByDate map[string][]string
+
+	mu     sync.Mutex
+	active map[string][]string // Cached active service IDs, by date
+	client *gtfsdb.Client      // Database the calendar was loaded from
+}
+
+// NewServiceCalendar indexes calendar.txt and calendar_dates.txt rows for lookup.
+func NewServiceCalendar(calendars []gtfsdb.Calendar, calendarDates []gtfsdb.CalendarDate) *ServiceCalendar {
+	serviceCalendar := &ServiceCalendar{
+		calendars:      make(map[string]gtfsdb.Calendar, len(calendars)),
+		exceptions:     make(map[string]map[string]int64),
+		servicesByDate: make(map[string][]string),
+		active:         make(map[string][]string),
+	}
+	for _, calendar := range calendars {
+		serviceCalendar.calendars[calendar.ID] = calendar
+	}
+	for _, calendarDate := range calendarDates {
+		exceptions := serviceCalendar.exceptions[calendarDate.ServiceID]
+		if exceptions == nil {
+			exceptions = make(map[string]int64)
+			serviceCalendar.exceptions[calendarDate.ServiceID] = exceptions
+		}
+		if _, seen := exceptions[calendarDate.Date]; !seen {
+			serviceCalendar.servicesByDate[calendarDate.Date] = append(
+				serviceCalendar.servicesByDate[calendarDate.Date], calendarDate.ServiceID)
+		}
+		exceptions[calendarDate.Date] = calendarDate.ExceptionType
+	}
+	return serviceCalendar
+}
+
+// HasService reports whether the calendar defines the service at all.
+func (c *ServiceCalendar) HasService(serviceID string) bool {
+	_, inCalendar := c.calendars[serviceID]
+	_, hasExceptions := c.exceptions[serviceID]
+	return inCalendar || hasExceptions
+}
+
+// IsActive reports whether the service runs on date, given as YYYYMMDD.
+func (c *ServiceCalendar) IsActive(serviceID, date string) bool {
+	if exceptionType, ok := c.exceptions[serviceID][date]; ok {
+		return exceptionType == 1
+	}
+
+	calendar, ok := c.calendars[serviceID]
+	if !ok || date < calendar.StartDate || date > calendar.EndDate {
+		return false
+	}
+	day, err := time.Parse(serviceDateLayout, date)
+	if err != nil {
+		return false
+	}
+
+	switch day.Weekday() {
+	case time.Sunday:
+		return calendar.Sunday == 1
+	case time.Monday:
+		return calendar.Monday == 1
+	case time.Tuesday:
+		return calendar.Tuesday == 1
+	case time.Wednesday:
+		return calendar.Wednesday == 1
+	case time.Thursday:
+		return calendar.Thursday == 1
+	case time.Friday:
+		return calendar.Friday == 1
+	case time.Saturday:
+		return calendar.Saturday == 1
+	default:
+		return false
+	}
+}
+
+// ActiveServiceIDs returns the IDs of the services running on date, given as YYYYMMDD, in
+// order. The result is shared between callers and must not be modified.
+func (c *ServiceCalendar) ActiveServiceIDs(date string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if serviceIDs, ok := c.active[date]; ok {
+		return serviceIDs
+	}
+
+	serviceIDs := []string{}
+	for serviceID := range c.calendars {
+		if c.IsActive(serviceID, date) {
+			serviceIDs = append(serviceIDs, serviceID)
+		}
+	}
+	for _, serviceID := range c.servicesByDate[date] {
+		if _, inCalendar := c.calendars[serviceID]; !inCalendar && c.IsActive(serviceID, date) {
+			serviceIDs = append(serviceIDs, serviceID)
+		}
+	}
+	sort.Strings(serviceIDs)
+
+	if len(c.active) >= serviceCalendarCacheSize {
+		c.active = make(map[string][]string)
+	}
+	c.active[date] = serviceIDs
+	return serviceIDs
+}
+
+// ServiceDates returns the dates the service runs on, as YYYYMMDD, in order.
+func (c *ServiceCalendar) ServiceDates(serviceID string) []string {
+	dates := []string{}
+
+	if calendar, ok := c.calendars[serviceID]; ok {
+		start, startErr := time.Parse(serviceDateLayout, calendar.StartDate)
+		end, endErr := time.Parse(serviceDateLayout, calendar.EndDate)
+		if startErr == nil && endErr == nil {
+			for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+				if date := day.Format(serviceDateLayout); c.IsActive(serviceID, date) {
+					dates = append(dates, date)
+				}
+			}
+		}
+	}
+
+	// Added dates may fall outside the calendar's range
+	calendar, inCalendar := c.calendars[serviceID]
+	for date, exceptionType := range c.exceptions[serviceID] {
+		if exceptionType == 1 && (!inCalendar || date < calendar.StartDate || date > calendar.EndDate) {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	return dates
+}
+
+// GetServiceCalendar returns the service calendar of the live database, loading it when the
+// database has changed since it was last loaded.
+func (manager *Manager) GetServiceCalendar(ctx context.Context) (*ServiceCalendar, error) {
+	client := manager.GtfsDB()
+	if serviceCalendar := manager.serviceCalendar.Load(); serviceCalendar != nil && serviceCalendar.client == client {
+		return serviceCalendar, nil
+	}
+
+	manager.serviceCalendarMutex.Lock()
+	defer manager.serviceCalendarMutex.Unlock()
+	if serviceCalendar := manager.serviceCalendar.Load(); serviceCalendar != nil && serviceCalendar.client == client {
+		return serviceCalendar, nil
+	}
+
+	calendars, err := client.Queries.ListCalendars(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading calendars: %w", err)
+	}
+	calendarDates, err := client.Queries.ListCalendarDates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading calendar dates: %w", err)
+	}
+
+	serviceCalendar := NewServiceCalendar(calendars, calendarDates)
+	serviceCalendar.client = client
+	manager.serviceCalendar.Store(serviceCalendar)
+	return serviceCalendar, nil
+}
+
+// GetActiveServiceIDsForDate returns the IDs of the services running on the service date
+// starting at date.
+func (manager *Manager) GetActiveServiceIDsForDate(ctx context.Context, date time.Time) ([]string, error) {
+	serviceCalendar, err := manager.GetServiceCalendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return serviceCalendar.ActiveServiceIDs(date.Format(serviceDateLayout)), nil
+}
+
+// IsServiceActiveOnDate returns 1 when the service runs on the service date starting at date,
+// and 0 otherwise.
+func (manager *Manager) IsServiceActiveOnDate(ctx context.Context, serviceID string, date time.Time) (int64, error) {
+	serviceCalendar, err := manager.GetServiceCalendar(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if serviceCalendar.IsActive(serviceID, date.Format(serviceDateLayout)) {
+		return 1, nil
+	}
+	return 0, nil
+}
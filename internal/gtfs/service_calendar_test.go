@@ -0,0 +1,116 @@
+package gtfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func newTestServiceCalendar() *ServiceCalendar {
+	return NewServiceCalendar(
+		[]gtfsdb.Calendar{
+			{ID: "weekday", Monday: 1, Tuesday: 1, Wednesday: 1, Thursday: 1, Friday: 1, StartDate: "20250101", EndDate: "20250131"},
+			{ID: "saturday", Saturday: 1, StartDate: "20250101", EndDate: "20250131"},
+		},
+		[]gtfsdb.CalendarDate{
+			// Monday 20 January runs the Saturday service instead
+			{ServiceID: "weekday", Date: "20250120", ExceptionType: 2},
+			{ServiceID: "saturday", Date: "20250120", ExceptionType: 1},
+			// Service added after the calendar ends
+			{ServiceID: "weekday", Date: "20250201", ExceptionType: 1},
+			// Service defined only by calendar_dates.txt
+			{ServiceID: "special", Date: "20250115", ExceptionType: 1},
+			{ServiceID: "special", Date: "20250116", ExceptionType: 2},
+		},
+	)
+}
+
+func TestServiceCalendarIsActive(t *testing.T) {
+	serviceCalendar := newTestServiceCalendar()
+
+	testCases := []struct {
+		serviceID, date string
+		expected        bool
+	}{
+		{"weekday", "20250113", true},
+		{"weekday", "20250118", false},
+		{"weekday", "20250120", false},
+		{"weekday", "20250201", true},
+		{"weekday", "20250203", false},
+		{"weekday", "20241231", false},
+		{"saturday", "20250118", true},
+		{"saturday", "20250120", true},
+		{"special", "20250115", true},
+		{"special", "20250116", false},
+		{"unknown", "20250113", false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, serviceCalendar.IsActive(tc.serviceID, tc.date), "%s on %s", tc.serviceID, tc.date)
+	}
+}
+
+func TestServiceCalendarActiveServiceIDs(t *testing.T) {
+	serviceCalendar := newTestServiceCalendar()
+
+	assert.Equal(t, []string{"weekday"}, serviceCalendar.ActiveServiceIDs("20250113"))
+	assert.Equal(t, []string{"saturday"}, serviceCalendar.ActiveServiceIDs("20250120"))
+	assert.Equal(t, []string{"special", "weekday"}, serviceCalendar.ActiveServiceIDs("20250115"))
+	assert.Equal(t, []string{"weekday"}, serviceCalendar.ActiveServiceIDs("20250201"))
+	assert.Empty(t, serviceCalendar.ActiveServiceIDs("20250202"))
+
+	// Repeated lookups are served from the cache
+	first := serviceCalendar.ActiveServiceIDs("20250113")
+	assert.Same(t, &first[0], &serviceCalendar.ActiveServiceIDs("20250113")[0])
+}
+
+func TestServiceCalendarServiceDates(t *testing.T) {
+	serviceCalendar := newTestServiceCalendar()
+
+	weekdays := serviceCalendar.ServiceDates("weekday")
+	assert.Len(t, weekdays, 23, "23 weekdays in January, less one removed, plus one added")
+	assert.Equal(t, "20250101", weekdays[0])
+	assert.Equal(t, "20250201", weekdays[len(weekdays)-1])
+	assert.NotContains(t, weekdays, "20250120")
+
+	assert.Equal(t, []string{"20250115"}, serviceCalendar.ServiceDates("special"))
+	assert.True(t, serviceCalendar.HasService("special"))
+	assert.False(t, serviceCalendar.HasService("unknown"))
+	assert.Empty(t, serviceCalendar.ServiceDates("unknown"))
+}
+
+// The manager's service days agree with the database's for every day of the RABA calendar,
+// which replaces weekday service with Saturday service on holidays.
+func TestManagerServiceCalendarMatchesDatabase(t *testing.T) {
+	manager, err := InitGTFSManager(Config{
+		GtfsURL:      models.GetFixturePath(t, "raba.zip"),
+		Env:          appconf.Test,
+		GTFSDataPath: ":memory:",
+	})
+	require.NoError(t, err)
+	defer manager.Shutdown()
+
+	ctx := context.Background()
+	holiday := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	serviceIDs, err := manager.GetActiveServiceIDsForDate(ctx, holiday)
+	require.NoError(t, err)
+	assert.NotContains(t, serviceIDs, "c_1658_b_18260_d_31", "weekday service is removed")
+	assert.Contains(t, serviceIDs, "c_1658_b_18260_d_32", "Saturday service is added")
+
+	active, err := manager.IsServiceActiveOnDate(ctx, "c_1658_b_18260_d_31", holiday)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), active)
+
+	for day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() < 2026; day = day.AddDate(0, 0, 1) {
+		fromDB, err := manager.GtfsDB().Queries.GetActiveServiceIDsForDate(ctx, day.Format("20060102"))
+		require.NoError(t, err)
+		fromCalendar, err := manager.GetActiveServiceIDsForDate(ctx, day)
+		require.NoError(t, err)
+		require.ElementsMatch(t, fromDB, fromCalendar, "services on %s", day.Format("20060102"))
+	}
+}
@@ -3,116 +3,83 @@ package gtfs
 import (
 	"context"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
 	"os"
 	"time"
 
 	"github.com/OneBusAway/go-gtfs"
 	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/logging"
+	"maglev.onebusaway.org/internal/utils"
 )
 
-func rawGtfsData(source string, isLocalFile bool) ([]byte, error) {
-	var b []byte
-	var err error
-
-	if isLocalFile {
-		b, err = os.ReadFile(source)
+// fetchFeed reads a feed from its local file or downloads it. Downloads are conditional on the
+// feed having changed when validators are given.
+func fetchFeed(ctx context.Context, feed FeedConfig, validators gtfsdb.FeedValidators, config gtfsdb.DownloadConfig) (*gtfsdb.FeedDownload, error) {
+	if feed.isLocalFile() {
+		b, err := os.ReadFile(feed.GtfsURL)
 		if err != nil {
 			return nil, fmt.Errorf("error reading local GTFS file: %w", err)
 		}
-	} else {
-		resp, err := http.Get(source)
-		if err != nil {
-			return nil, fmt.Errorf("error downloading GTFS data: %w", err)
-		}
-		defer logging.SafeCloseWithLogging(resp.Body,
-			slog.Default().With(slog.String("component", "gtfs_downloader")),
-			"http_response_body")
-
-		b, err = io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("error reading GTFS data: %w", err)
-		}
+		return &gtfsdb.FeedDownload{Data: b}, nil
 	}
-	return b, nil
+	return gtfsdb.DownloadFeed(ctx, feed.GtfsURL, validators, config)
 }
 
-func buildGtfsDB(config Config, isLocalFile bool) (*gtfsdb.Client, error) {
-	dbConfig := gtfsdb.NewConfig(config.GTFSDataPath, config.Env, config.Verbose)
-	client, err := gtfsdb.NewClient(dbConfig)
+// buildGtfsDB imports every feed into the database from the data already fetched for it, and
+// removes the data of feeds no longer configured.
+func buildGtfsDB(config Config, feeds []FeedConfig, downloads map[string]*gtfsdb.FeedDownload) (*gtfsdb.Client, error) {
+	client, err := gtfsdb.NewClient(config.dbConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GTFS database client: %w", err)
 	}
 
 	ctx := context.Background()
 
-	if isLocalFile {
-		err = client.ImportFromFile(ctx, config.GtfsURL)
-	} else {
-		err = client.DownloadAndStore(ctx, config.GtfsURL)
+	// Feeds no longer configured are removed first, so that their rows can't collide with those
+	// of the feeds imported in their place
+	feedIDs := make([]string, 0, len(feeds))
+	for _, feed := range feeds {
+		feedIDs = append(feedIDs, feed.ID)
 	}
-
-	return client, err
-}
-
-// loadGTFSData loads and parses GTFS data from either a URL or a local file
-func loadGTFSData(source string, isLocalFile bool) (*gtfs.Static, error) {
-	b, err := rawGtfsData(source, isLocalFile)
-	if err != nil {
-		return nil, fmt.Errorf("error reading GTFS data: %w", err)
+	if err := client.RemoveFeedsExcept(ctx, feedIDs); err != nil {
+		return client, err
 	}
 
-	staticData, err := gtfs.ParseStatic(b, gtfs.ParseStaticOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error parsing GTFS data: %w", err)
+	for _, feed := range feeds {
+		if err := client.ImportFeedDownload(ctx, feed.ID, downloads[feed.ID], feed.GtfsURL); err != nil {
+			return client, fmt.Errorf("error importing feed %s: %w", feed.ID, err)
+		}
 	}
-
-	return staticData, nil
+	return client, nil
 }
 
-// UpdateGTFSPeriodically updates the GTFS data on a regular schedule
+// updateStaticGTFS updates a feed's GTFS data on its refresh schedule
 // Only updates if the source is a URL, not a local file
-func (manager *Manager) updateStaticGTFS() { // nolint
+func (manager *Manager) updateStaticGTFS(feed FeedConfig) { // nolint
 	defer manager.wg.Done()
 
 	// Create a logger for this goroutine
-	logger := slog.Default().With(slog.String("component", "gtfs_static_updater"))
+	logger := slog.Default().With(slog.String("component", "gtfs_static_updater"), slog.String("feed_id", feed.ID))
 
 	// If it's a local file, don't update periodically
-	if manager.isLocalFile {
+	if feed.isLocalFile() {
 		logging.LogOperation(logger, "gtfs_source_is_local_file_skipping_periodic_updates",
-			slog.String("source", manager.gtfsSource))
+			slog.String("source", feed.GtfsURL))
 		return
 	}
 
-	// Update every 24 hours
-	ticker := time.NewTicker(24 * time.Hour)
+	ticker := time.NewTicker(feed.staticRefreshInterval())
 	defer ticker.Stop()
 
 	for { // nolint
 		select {
 		case <-ticker.C:
-			// Create a context with timeout for the download
-			_, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-
-			// Download and parse the GTFS feed
-			staticData, err := loadGTFSData(manager.gtfsSource, false)
-			cancel() // Always cancel the context when done
-
-			if err != nil {
+			if err := manager.refreshStaticGTFS(feed); err != nil {
 				// Log error but don't crash the application
 				logging.LogError(logger, "Error updating GTFS data", err,
-					slog.String("source", manager.gtfsSource))
-				continue
+					slog.String("source", feed.GtfsURL))
 			}
-
-			// Update the GTFS data in the manager
-			logging.LogOperation(logger, "gtfs_static_data_updated",
-				slog.String("source", manager.gtfsSource))
-			manager.setStaticGTFS(staticData)
 		case <-manager.shutdownChan:
 			logging.LogOperation(logger, "shutting_down_static_gtfs_updates")
 			return
@@ -120,11 +87,74 @@ func (manager *Manager) updateStaticGTFS() { // nolint
 	}
 }
 
-func (manager *Manager) setStaticGTFS(staticData *gtfs.Static) {
+// refreshStaticGTFS downloads a feed again, and when it has changed rebuilds the database with
+// it before replacing the feed's in-memory data, so both serve the same schedule.
+func (manager *Manager) refreshStaticGTFS(feed FeedConfig) error {
+	logger := slog.Default().With(slog.String("component", "gtfs_static_updater"), slog.String("feed_id", feed.ID))
+
+	ctx := context.Background()
+	live := manager.GtfsDB()
+	var validators gtfsdb.FeedValidators
+	metadata, metadataErr := live.Queries.GetImportMetadata(ctx, feed.ID)
+	if metadataErr == nil && metadata.FileSource == feed.GtfsURL {
+		validators = metadata.Validators()
+	}
+
+	download, err := fetchFeed(ctx, feed, validators, manager.config.StaticDownload)
+	if err != nil {
+		return err
+	}
+	if download.NotModified {
+		logging.LogOperation(logger, "gtfs_static_data_not_modified",
+			slog.String("source", feed.GtfsURL))
+		return nil
+	}
+	if metadataErr == nil && metadata.FileHash == gtfsdb.HashFeedData(download.Data) {
+		// Servers that don't support conditional requests, or changed their validators,
+		// send the same feed again
+		logging.LogOperation(logger, "gtfs_static_data_unchanged",
+			slog.String("source", feed.GtfsURL))
+		return live.UpdateFeedValidators(ctx, feed.ID, download.FeedValidators)
+	}
+
+	staticData, err := gtfs.ParseStatic(download.Data, gtfs.ParseStaticOptions{})
+	if err != nil {
+		return fmt.Errorf("error parsing GTFS data: %w", err)
+	}
+
+	if err := manager.rebuildGtfsDB(ctx, feed.ID, download); err != nil {
+		return fmt.Errorf("error rebuilding GTFS database: %w", err)
+	}
+	manager.setStaticGTFS(feed.ID, staticData)
+	manager.checkFeedValidity(ctx, time.Now())
+	manager.loadTranslations(ctx)
+
+	logging.LogOperation(logger, "gtfs_static_data_updated",
+		slog.String("source", feed.GtfsURL))
+	return nil
+}
+
+// setStaticGTFS replaces the static data of one feed, leaving the other feeds' data in place.
+// The feed's IDs are moved into its namespace, matching its rows in the database.
+func (manager *Manager) setStaticGTFS(feedID string, staticData *gtfs.Static) {
 	manager.staticMutex.Lock()
 	defer manager.staticMutex.Unlock()
 
-	manager.gtfsData = staticData
+	scopeStaticIDs(staticData, gtfsdb.FeedNamespace(feedID))
+	if manager.feedData == nil {
+		manager.feedData = make(map[string]*gtfs.Static)
+	}
+	manager.feedData[feedID] = staticData
+	manager.gtfsData = manager.mergeStaticData()
+
+	// Combined IDs are resolved to the namespace of their agency's feed
+	agencyNamespaces := make(map[string]string)
+	for id, data := range manager.feedData {
+		for _, agency := range data.Agencies {
+			agencyNamespaces[agency.Id] = gtfsdb.FeedNamespace(id)
+		}
+	}
+	utils.SetAgencyNamespaces(agencyNamespaces)
 	manager.lastUpdated = time.Now()
 
 	// perform post-processing here!
@@ -132,6 +162,33 @@ func (manager *Manager) setStaticGTFS(staticData *gtfs.Static) {
 	if manager.config.Verbose {
 		logger := slog.Default().With(slog.String("component", "gtfs_manager"))
 		logging.LogOperation(logger, "gtfs_data_updated_successfully",
-			slog.String("source", manager.gtfsSource))
+			slog.String("feed_id", feedID))
+	}
+}
+
+// mergeStaticData combines the static data of every loaded feed, in the order the feeds are
+// configured. A single feed's data is used as it is.
+func (manager *Manager) mergeStaticData() *gtfs.Static {
+	if len(manager.feedData) == 1 {
+		for _, staticData := range manager.feedData {
+			return staticData
+		}
+	}
+
+	merged := &gtfs.Static{}
+	for _, feed := range manager.feeds {
+		staticData := manager.feedData[feed.ID]
+		if staticData == nil {
+			continue
+		}
+		merged.Agencies = append(merged.Agencies, staticData.Agencies...)
+		merged.Routes = append(merged.Routes, staticData.Routes...)
+		merged.Stops = append(merged.Stops, staticData.Stops...)
+		merged.Transfers = append(merged.Transfers, staticData.Transfers...)
+		merged.Services = append(merged.Services, staticData.Services...)
+		merged.Trips = append(merged.Trips, staticData.Trips...)
+		merged.Shapes = append(merged.Shapes, staticData.Shapes...)
+		merged.Warnings = append(merged.Warnings, staticData.Warnings...)
 	}
+	return merged
 }
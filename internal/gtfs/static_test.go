@@ -0,0 +1,110 @@
+package gtfs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+// feedServer serves a static feed with an ETag, honouring conditional requests.
+type feedServer struct {
+	mu            sync.Mutex
+	data          []byte
+	etag          string
+	fullResponses int
+}
+
+func (s *feedServer) set(data []byte, etag string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data, s.etag = data, etag
+}
+
+func (s *feedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.fullResponses++
+	w.Header().Set("ETag", s.etag)
+	_, _ = w.Write(s.data)
+}
+
+func (s *feedServer) downloads() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fullResponses
+}
+
+func TestRemoteFeedDownloads(t *testing.T) {
+	shuttlePath := models.BuildShuttleFeed(t)
+	original, err := os.ReadFile(shuttlePath)
+	require.NoError(t, err)
+
+	feeds := &feedServer{}
+	feeds.set(original, `"1"`)
+	server := httptest.NewServer(feeds)
+	defer server.Close()
+
+	feed := FeedConfig{ID: "shuttle", GtfsURL: server.URL + "/shuttle.zip"}
+	manager, err := InitGTFSManager(Config{Feeds: []FeedConfig{feed}, GTFSDataPath: ":memory:", Env: appconf.Test})
+	require.NoError(t, err)
+	defer manager.Shutdown()
+
+	assert.Equal(t, 1, feeds.downloads(), "one download feeds both the parser and the database")
+	ctx := context.Background()
+	metadata, err := manager.GtfsDB().Queries.GetImportMetadata(ctx, "shuttle")
+	require.NoError(t, err)
+	assert.Equal(t, `"1"`, metadata.Etag.String)
+
+	t.Run("not modified", func(t *testing.T) {
+		liveDB := manager.GtfsDB()
+		require.NoError(t, manager.refreshStaticGTFS(feed))
+		assert.Equal(t, 1, feeds.downloads())
+		assert.Same(t, liveDB, manager.GtfsDB())
+	})
+
+	t.Run("same contents with new validators", func(t *testing.T) {
+		liveDB := manager.GtfsDB()
+		feeds.set(original, `"2"`)
+		require.NoError(t, manager.refreshStaticGTFS(feed))
+		assert.Same(t, liveDB, manager.GtfsDB())
+
+		metadata, err := manager.GtfsDB().Queries.GetImportMetadata(ctx, "shuttle")
+		require.NoError(t, err)
+		assert.Equal(t, `"2"`, metadata.Etag.String)
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		changed, err := os.ReadFile(models.BuildFeedWithFiles(t, shuttlePath, map[string]string{
+			"routes.txt": "route_id,agency_id,route_short_name,route_long_name,route_type\n" +
+				"LOOP,SHUTTLE,L,Uptown Loop,3\n",
+		}))
+		require.NoError(t, err)
+		feeds.set(changed, `"3"`)
+		downloads := feeds.downloads()
+
+		require.NoError(t, manager.refreshStaticGTFS(feed))
+		assert.Equal(t, downloads+1, feeds.downloads())
+
+		route, err := manager.GtfsDB().Queries.GetRoute(ctx, gtfsdb.ScopedID("shuttle", "LOOP"))
+		require.NoError(t, err)
+		assert.Equal(t, "Uptown Loop", route.LongName.String)
+		assert.Equal(t, "Uptown Loop", manager.GetStaticData().Routes[0].LongName)
+
+		metadata, err := manager.GtfsDB().Queries.GetImportMetadata(ctx, "shuttle")
+		require.NoError(t, err)
+		assert.Equal(t, `"3"`, metadata.Etag.String)
+	})
+}
@@ -0,0 +1,147 @@
+package gtfs
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/logging"
+)
+
+// Translations holds the translations.txt entries of the static feed. Language tags are
+// compared case-insensitively and are reported in lower case.
+type Translations struct {
+	// FeedLanguage is the language the feed's own text is written in, if known
+	FeedLanguage string
+
+	records   map[translationKey]string
+	values    map[translationKey]string
+	languages []string
+}
+
+// translationKey identifies a translated field, either by record ID and sub-ID or, for
+// translations given by field_value, by the original text in id.
+type translationKey struct {
+	language, table, field, id, subID string
+}
+
+// NewTranslations indexes translations.txt rows for lookup.
+func NewTranslations(rows []gtfsdb.Translation, feedLanguage string) *Translations {
+	translations := &Translations{
+		FeedLanguage: strings.ToLower(feedLanguage),
+		records:      make(map[translationKey]string),
+		values:       make(map[translationKey]string),
+	}
+	seen := make(map[string]bool)
+	for _, row := range rows {
+		language := strings.ToLower(row.Language)
+		if !seen[language] {
+			seen[language] = true
+			translations.languages = append(translations.languages, language)
+		}
+		key := translationKey{language: language, table: row.TableName, field: row.FieldName}
+		if row.RecordID.Valid || row.TableName == "feed_info" {
+			key.id, key.subID = row.RecordID.String, row.RecordSubID.String
+			translations.records[key] = row.Translation
+		} else {
+			key.id = row.FieldValue.String
+			translations.values[key] = row.Translation
+		}
+	}
+	sort.Strings(translations.languages)
+	return translations
+}
+
+// Languages returns the languages the feed has translations for.
+func (t *Translations) Languages() []string {
+	if t == nil {
+		return nil
+	}
+	return t.languages
+}
+
+// Translate returns the translation of a field of one record, identified by its table and
+// field names in translations.txt, falling back to a translation of the field's value and
+// then to value itself.
+func (t *Translations) Translate(language, table, field, recordID, recordSubID, value string) string {
+	if t == nil || language == "" || value == "" {
+		return value
+	}
+	language = strings.ToLower(language)
+	if recordID != "" {
+		if translation, ok := t.records[translationKey{language, table, field, recordID, recordSubID}]; ok {
+			return translation
+		}
+	}
+	if translation, ok := t.values[translationKey{language: language, table: table, field: field, id: value}]; ok {
+		return translation
+	}
+	return value
+}
+
+// Negotiate picks the language to respond in from the rider's preferences, most preferred
+// first. A preference matches a language with the same tag, or failing that the same
+// primary subtag, so "fr-CA" falls back to "fr". It returns "" when the best match is the
+// feed's own language or nothing matches, meaning the original text should be used.
+func (t *Translations) Negotiate(preferences []string) string {
+	if t == nil || len(t.languages) == 0 {
+		return ""
+	}
+	for _, preference := range preferences {
+		preference = strings.ToLower(preference)
+		base, _, _ := strings.Cut(preference, "-")
+		if preference == t.FeedLanguage || base == t.FeedLanguage {
+			return ""
+		}
+		var baseMatch string
+		for _, language := range t.languages {
+			if language == preference {
+				return language
+			}
+			if languageBase, _, _ := strings.Cut(language, "-"); baseMatch == "" && languageBase == base {
+				baseMatch = language
+			}
+		}
+		if baseMatch != "" {
+			return baseMatch
+		}
+	}
+	return ""
+}
+
+// GetTranslations returns the translations of the static feed, or nil when it has none.
+func (manager *Manager) GetTranslations() *Translations {
+	manager.staticMutex.RLock()
+	defer manager.staticMutex.RUnlock()
+	return manager.translations
+}
+
+// loadTranslations reads translations.txt entries from the database, taking the feed
+// language from feed_info.txt or else the first agency.
+func (manager *Manager) loadTranslations(ctx context.Context) {
+	logger := slog.Default().With(slog.String("component", "gtfs_manager"))
+
+	rows, err := manager.GtfsDB().Queries.ListTranslations(ctx)
+	if err != nil {
+		logging.LogError(logger, "Error loading translations", err)
+		return
+	}
+
+	var feedLanguage string
+	if feedInfo, err := manager.GtfsDB().Queries.GetFeedInfo(ctx); err == nil && feedInfo.FeedLang != "mul" {
+		feedLanguage = feedInfo.FeedLang
+	} else if agencies := manager.GetAgencies(); len(agencies) > 0 {
+		feedLanguage = agencies[0].Language
+	}
+
+	var translations *Translations
+	if len(rows) > 0 {
+		translations = NewTranslations(rows, feedLanguage)
+	}
+
+	manager.staticMutex.Lock()
+	manager.translations = translations
+	manager.staticMutex.Unlock()
+}
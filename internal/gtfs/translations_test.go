@@ -0,0 +1,118 @@
+package gtfs
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func newTestTranslations() *Translations {
+	record := func(table, field, language, translation, recordID, recordSubID string) gtfsdb.Translation {
+		return gtfsdb.Translation{
+			TableName:   table,
+			FieldName:   field,
+			Language:    language,
+			Translation: translation,
+			RecordID:    sql.NullString{String: recordID, Valid: recordID != ""},
+			RecordSubID: sql.NullString{String: recordSubID, Valid: recordSubID != ""},
+		}
+	}
+	value := func(table, field, language, translation, fieldValue string) gtfsdb.Translation {
+		return gtfsdb.Translation{
+			TableName:   table,
+			FieldName:   field,
+			Language:    language,
+			Translation: translation,
+			FieldValue:  sql.NullString{String: fieldValue, Valid: true},
+		}
+	}
+	return NewTranslations([]gtfsdb.Translation{
+		record("stops", "stop_name", "es", "Estación Central", "STN", ""),
+		record("stop_times", "stop_headsign", "es", "Centro (por 5)", "T1", "5"),
+		value("stops", "stop_name", "es", "Calle Principal", "Main St"),
+		value("trips", "trip_headsign", "ES", "Centro", "Downtown"),
+		record("stops", "stop_name", "fr-CA", "Gare centrale", "STN", ""),
+		record("stops", "stop_name", "pt-BR", "Estação Central", "STN", ""),
+	}, "EN")
+}
+
+func TestTranslate(t *testing.T) {
+	translations := newTestTranslations()
+
+	tests := []struct {
+		name                   string
+		language, table, field string
+		recordID, subID, value string
+		expected               string
+	}{
+		{"by record", "es", "stops", "stop_name", "STN", "", "Central Station", "Estación Central"},
+		{"language is case-insensitive", "ES", "stops", "stop_name", "STN", "", "Central Station", "Estación Central"},
+		{"by record and sub-ID", "es", "stop_times", "stop_headsign", "T1", "5", "Downtown via 5", "Centro (por 5)"},
+		{"sub-ID must match", "es", "stop_times", "stop_headsign", "T1", "6", "Downtown via 6", "Downtown via 6"},
+		{"by field value", "es", "stops", "stop_name", "S2", "", "Main St", "Calle Principal"},
+		{"by field value without a record", "es", "trips", "trip_headsign", "", "", "Downtown", "Centro"},
+		{"field value is per field", "es", "routes", "route_long_name", "", "", "Downtown", "Downtown"},
+		{"untranslated record", "es", "stops", "stop_name", "OTHER", "", "Elm St", "Elm St"},
+		{"untranslated language", "de", "stops", "stop_name", "STN", "", "Central Station", "Central Station"},
+		{"no language", "", "stops", "stop_name", "STN", "", "Central Station", "Central Station"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, translations.Translate(tt.language, tt.table, tt.field, tt.recordID, tt.subID, tt.value))
+		})
+	}
+
+	t.Run("nil translations keep the original", func(t *testing.T) {
+		var none *Translations
+		assert.Equal(t, "Central Station", none.Translate("es", "stops", "stop_name", "STN", "", "Central Station"))
+		assert.Empty(t, none.Languages())
+	})
+}
+
+func TestNegotiateLanguage(t *testing.T) {
+	translations := newTestTranslations()
+	assert.Equal(t, []string{"es", "fr-ca", "pt-br"}, translations.Languages())
+
+	tests := []struct {
+		name        string
+		preferences []string
+		expected    string
+	}{
+		{"exact match", []string{"es"}, "es"},
+		{"region falls back to the language", []string{"es-MX"}, "es"},
+		{"language matches a regional translation", []string{"fr"}, "fr-ca"},
+		{"first supported preference wins", []string{"de", "pt-BR", "es"}, "pt-br"},
+		{"feed language needs no translation", []string{"en-US", "es"}, ""},
+		{"no match", []string{"de", "it"}, ""},
+		{"no preference", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, translations.Negotiate(tt.preferences))
+		})
+	}
+}
+
+func TestManagerLoadsTranslations(t *testing.T) {
+	manager, err := InitGTFSManager(Config{
+		GtfsURL:      models.BuildTranslationFeed(t, models.GetFixturePath(t, "raba.zip")),
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	})
+	require.NoError(t, err)
+	t.Cleanup(manager.Shutdown)
+
+	translations := manager.GetTranslations()
+	require.NotNil(t, translations)
+	assert.Equal(t, "en", translations.FeedLanguage)
+	assert.Equal(t, []string{"es", "fr-ca"}, translations.Languages())
+	assert.Equal(t, "Ruta 1", translations.Translate("es", "routes", "route_long_name", "151", "", "Route 1"))
+	assert.Equal(t, "Lago Shasta", translations.Translate("es", "trips", "trip_headsign", "84f4520e-88b6-4ee6-8975-856799bc1359", "", "Shasta Lake"))
+}
@@ -0,0 +1,235 @@
+package gtfs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+)
+
+// IsTripCanceled reports whether realtime data cancels or deletes the run of a trip on the
+// service date starting at serviceMidnight.
+func (manager *Manager) IsTripCanceled(tripID string, serviceMidnight time.Time) bool {
+	trip := manager.tripUpdateForServiceDate(tripID, serviceMidnight)
+	if trip == nil {
+		return false
+	}
+	relationship := trip.ID.ScheduleRelationship
+	return relationship == gtfsrt.TripDescriptor_CANCELED || relationship == gtfsrt.TripDescriptor_DELETED
+}
+
+// IsStopSkipped reports whether realtime data has the run of a trip on the service date starting
+// at serviceMidnight skip its visit to a stop.
+func (manager *Manager) IsStopSkipped(tripID string, serviceMidnight time.Time, stopID string, stopSequence int64) bool {
+	trip := manager.tripUpdateForServiceDate(tripID, serviceMidnight)
+	if trip == nil {
+		return false
+	}
+	for _, update := range trip.StopTimeUpdates {
+		if update.ScheduleRelationship != gtfsrt.TripUpdate_StopTimeUpdate_SKIPPED {
+			continue
+		}
+		if update.StopSequence != nil {
+			if int64(*update.StopSequence) == stopSequence {
+				return true
+			}
+		} else if update.StopID != nil && *update.StopID == stopID {
+			return true
+		}
+	}
+	return false
+}
+
+// RealtimeTripStop is a visit to a stop by a trip that exists only in realtime data: a trip
+// ADDED to the schedule, or a trip DUPLICATED from a scheduled one.
+type RealtimeTripStop struct {
+	StopTimePrediction
+	TripID               string
+	RouteID              string
+	SourceTripID         string // The scheduled trip a DUPLICATED trip copies
+	ScheduleRelationship gtfs.TripScheduleRelationship
+	ServiceMidnight      time.Time
+	StopIndex            int // Index of the visit among the trip's stops
+	TotalStops           int
+}
+
+// RealtimeTripStopsForStop returns the visits to a stop by trips that exist only in realtime
+// data. ADDED trips with a counterpart in the static feed are left out, as are ADDED stops
+// without an explicit time, since there is no schedule to apply a delay to. A DUPLICATED trip
+// follows the stop pattern of the trip it copies, shifted to start at the start time of its trip
+// properties. Service dates are resolved in loc.
+func (manager *Manager) RealtimeTripStopsForStop(ctx context.Context, stopID string, loc *time.Location) ([]RealtimeTripStop, error) {
+	snapshot := manager.realTimeData()
+	var visits []RealtimeTripStop
+	for _, i := range snapshot.realtimeTripsByStop[stopID] {
+		trip := snapshot.trips[i]
+		var tripVisits []RealtimeTripStop
+		var err error
+		switch trip.ID.ScheduleRelationship {
+		case gtfsrt.TripDescriptor_ADDED:
+			tripVisits, err = manager.addedTripStops(ctx, trip, stopID, loc)
+		case gtfsrt.TripDescriptor_DUPLICATED:
+			properties := snapshot.tripProperties[tripDescriptorKeyFor(trip.ID)]
+			tripVisits, err = manager.duplicatedTripStops(ctx, trip, properties, stopID, loc)
+		}
+		if err != nil {
+			return nil, err
+		}
+		visits = append(visits, tripVisits...)
+	}
+	return visits, nil
+}
+
+// addedTripStops returns the visits to a stop by an ADDED trip, whose stops and times come
+// entirely from its stop time updates.
+func (manager *Manager) addedTripStops(ctx context.Context, trip gtfs.Trip, stopID string, loc *time.Location) ([]RealtimeTripStop, error) {
+	if trip.ID.ID == "" {
+		return nil, nil
+	}
+	_, err := manager.GtfsDB().Queries.GetTrip(ctx, trip.ID.ID)
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	var stops []StopTimePrediction
+	for i, update := range trip.StopTimeUpdates {
+		if update.StopID == nil || update.ScheduleRelationship == gtfsrt.TripUpdate_StopTimeUpdate_SKIPPED {
+			continue
+		}
+		arrival, departure := update.GetArrival().Time, update.GetDeparture().Time
+		if arrival == nil {
+			arrival = departure
+		}
+		if departure == nil {
+			departure = arrival
+		}
+		if arrival == nil {
+			continue
+		}
+		stopSequence := int64(i + 1)
+		if update.StopSequence != nil {
+			stopSequence = int64(*update.StopSequence)
+		}
+		stops = append(stops, StopTimePrediction{
+			StopID:             *update.StopID,
+			StopSequence:       stopSequence,
+			ScheduledArrival:   *arrival,
+			ScheduledDeparture: *departure,
+			PredictedArrival:   *arrival,
+			PredictedDeparture: *departure,
+			Predicted:          true,
+		})
+	}
+
+	var visits []RealtimeTripStop
+	for i, stop := range stops {
+		if stop.StopID != stopID {
+			continue
+		}
+		serviceMidnight := startOfDay(stop.ScheduledArrival.In(loc))
+		if trip.ID.HasStartDate {
+			serviceMidnight = startOfDay(trip.ID.StartDate.In(loc))
+		}
+		visits = append(visits, RealtimeTripStop{
+			StopTimePrediction:   stop,
+			TripID:               trip.ID.ID,
+			RouteID:              trip.ID.RouteID,
+			ScheduleRelationship: trip.ID.ScheduleRelationship,
+			ServiceMidnight:      serviceMidnight,
+			StopIndex:            i,
+			TotalStops:           len(stops),
+		})
+	}
+	return visits, nil
+}
+
+// duplicatedTripStops returns the visits to a stop by a DUPLICATED trip. Its trip properties
+// name the new trip and give its start date and time; the trip descriptor names the trip it
+// copies.
+func (manager *Manager) duplicatedTripStops(
+	ctx context.Context,
+	trip gtfs.Trip,
+	properties *gtfsrt.TripUpdate_TripProperties,
+	stopID string,
+	loc *time.Location,
+) ([]RealtimeTripStop, error) {
+	if properties.GetTripId() == "" {
+		return nil, nil
+	}
+
+	queries := manager.GtfsDB().Queries
+	stopTimes, err := queries.GetStopTimesForTrip(ctx, trip.ID.ID)
+	if err != nil {
+		return nil, err
+	}
+	servesStop := false
+	for _, st := range stopTimes {
+		servesStop = servesStop || st.StopID == stopID
+	}
+	if !servesStop {
+		return nil, nil
+	}
+	sourceTrip, err := queries.GetTrip(ctx, trip.ID.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	startDate := properties.GetStartDate()
+	if startDate == "" && trip.ID.HasStartDate {
+		startDate = trip.ID.StartDate.Format("20060102")
+	}
+	serviceDate, err := time.ParseInLocation("20060102", startDate, loc)
+	if err != nil {
+		return nil, nil
+	}
+
+	// The copy keeps the source trip's running times, moved to start at its own start time
+	var shift time.Duration
+	if startTime, ok := parseStartTime(properties.GetStartTime()); ok {
+		shift = startTime - time.Duration(stopTimes[0].DepartureTime)
+	}
+	prediction := PredictStopTimes(serviceDate.Add(shift), stopTimes, trip.StopTimeUpdates)
+
+	var visits []RealtimeTripStop
+	for i, stop := range prediction.Stops {
+		if stop.StopID != stopID {
+			continue
+		}
+		visits = append(visits, RealtimeTripStop{
+			StopTimePrediction:   stop,
+			TripID:               properties.GetTripId(),
+			RouteID:              sourceTrip.RouteID,
+			SourceTripID:         sourceTrip.ID,
+			ScheduleRelationship: trip.ID.ScheduleRelationship,
+			ServiceMidnight:      serviceDate,
+			StopIndex:            i,
+			TotalStops:           len(prediction.Stops),
+		})
+	}
+	return visits, nil
+}
+
+// parseStartTime parses a GTFS-realtime HH:MM:SS start time, which may exceed 24:00:00, into an
+// offset from midnight.
+func parseStartTime(value string) (time.Duration, bool) {
+	var hours, minutes, seconds int
+	if _, err := fmt.Sscanf(value, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, false
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second, true
+}
+
+// startOfDay returns midnight at the start of t's day, in t's location.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,173 @@
+package gtfs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// firstStopTimeAt returns the stop time of the first trip, by ID, to serve a stop.
+func firstStopTimeAt(t *testing.T, manager *Manager, stopID string) gtfsdb.StopTime {
+	t.Helper()
+	var stopTime gtfsdb.StopTime
+	row := manager.GtfsDB().DB.QueryRowContext(context.Background(),
+		"SELECT trip_id, stop_id, stop_sequence, arrival_time, departure_time FROM stop_times WHERE stop_id = ? ORDER BY trip_id LIMIT 1", stopID)
+	require.NoError(t, row.Scan(&stopTime.TripID, &stopTime.StopID, &stopTime.StopSequence, &stopTime.ArrivalTime, &stopTime.DepartureTime))
+	return stopTime
+}
+
+func TestTripCancellationsAndSkippedStops(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	stopTime := firstStopTimeAt(t, manager, "1030")
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 12, 0, 0, 0, 0, loc)
+	sequence := uint32(stopTime.StopSequence)
+
+	setRealTimeData(manager, realTimeFeedData{trips: []gtfs.Trip{
+		{ID: gtfs.TripID{
+			ID:                   stopTime.TripID,
+			HasStartDate:         true,
+			StartDate:            serviceDate,
+			ScheduleRelationship: gtfsrt.TripDescriptor_CANCELED,
+		}},
+		{
+			ID: gtfs.TripID{ID: "other"},
+			StopTimeUpdates: []gtfs.StopTimeUpdate{{
+				StopSequence:         &sequence,
+				ScheduleRelationship: gtfsrt.TripUpdate_StopTimeUpdate_SKIPPED,
+			}},
+		},
+	}})
+
+	assert.True(t, manager.IsTripCanceled(stopTime.TripID, serviceDate))
+	assert.False(t, manager.IsTripCanceled(stopTime.TripID, serviceDate.AddDate(0, 0, 1)), "only the run on the start date is cancelled")
+	assert.False(t, manager.IsTripCanceled("other", serviceDate))
+
+	assert.True(t, manager.IsStopSkipped("other", serviceDate, "1030", stopTime.StopSequence))
+	assert.False(t, manager.IsStopSkipped("other", serviceDate, "1030", stopTime.StopSequence+1))
+	assert.False(t, manager.IsStopSkipped(stopTime.TripID, serviceDate, "1030", stopTime.StopSequence))
+}
+
+func TestRealtimeTripStopsForStop(t *testing.T) {
+	manager := newAlarmTestManager(t)
+	ctx := context.Background()
+	source := firstStopTimeAt(t, manager, "1030")
+	sourceStopTimes, err := manager.GtfsDB().Queries.GetStopTimesForTrip(ctx, source.TripID)
+	require.NoError(t, err)
+	sourceTrip, err := manager.GtfsDB().Queries.GetTrip(ctx, source.TripID)
+	require.NoError(t, err)
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 12, 0, 0, 0, 0, loc)
+	stopID := func(s string) *string { return &s }
+	addedArrival := serviceDate.Add(20 * time.Hour)
+
+	trips := []gtfs.Trip{
+		{
+			ID: gtfs.TripID{ID: "added", RouteID: sourceTrip.RouteID, ScheduleRelationship: gtfsrt.TripDescriptor_ADDED},
+			StopTimeUpdates: []gtfs.StopTimeUpdate{
+				{StopID: stopID("1030"), Arrival: &gtfs.StopTimeEvent{Time: &addedArrival}},
+				{StopID: stopID("elsewhere"), Arrival: &gtfs.StopTimeEvent{Time: &addedArrival}},
+			},
+		},
+		{
+			// An ADDED update for a trip in the static feed isn't a new trip
+			ID: gtfs.TripID{ID: source.TripID, ScheduleRelationship: gtfsrt.TripDescriptor_ADDED},
+			StopTimeUpdates: []gtfs.StopTimeUpdate{
+				{StopID: stopID("1030"), Arrival: &gtfs.StopTimeEvent{Time: &addedArrival}},
+			},
+		},
+		{ID: gtfs.TripID{ID: source.TripID, ScheduleRelationship: gtfsrt.TripDescriptor_DUPLICATED}},
+	}
+	properties := map[tripDescriptorKey]*gtfsrt.TripUpdate_TripProperties{
+		{tripID: source.TripID}: {
+			TripId:    proto.String("duplicate"),
+			StartDate: proto.String("20250612"),
+			StartTime: proto.String("21:00:00"),
+		},
+	}
+	setRealTimeData(manager, realTimeFeedData{trips: trips, tripProperties: properties})
+
+	snapshot := manager.realTimeData()
+	assert.Equal(t, []int{0, 1, 2}, snapshot.realtimeTripsByStop["1030"])
+	assert.Equal(t, []int{0}, snapshot.realtimeTripsByStop["elsewhere"], "only the trips visiting a stop are looked up")
+
+	visits, err := manager.RealtimeTripStopsForStop(ctx, "1030", loc)
+	require.NoError(t, err)
+	require.Len(t, visits, 2)
+
+	added := visits[0]
+	assert.Equal(t, "added", added.TripID)
+	assert.Equal(t, gtfsrt.TripDescriptor_ADDED, added.ScheduleRelationship)
+	assert.Equal(t, addedArrival, added.ScheduledArrival)
+	assert.Equal(t, serviceDate, added.ServiceMidnight)
+	assert.Equal(t, 0, added.StopIndex)
+	assert.Equal(t, 2, added.TotalStops)
+
+	duplicate := visits[1]
+	assert.Equal(t, "duplicate", duplicate.TripID)
+	assert.Equal(t, source.TripID, duplicate.SourceTripID)
+	assert.Equal(t, sourceTrip.RouteID, duplicate.RouteID)
+	assert.Equal(t, len(sourceStopTimes), duplicate.TotalStops)
+	shift := 21*time.Hour - time.Duration(sourceStopTimes[0].DepartureTime)
+	assert.Equal(t, serviceDate.Add(time.Duration(source.ArrivalTime)+shift), duplicate.ScheduledArrival,
+		"the duplicate keeps the source trip's running times from its own start time")
+
+	// The duplicate's update says nothing about the trip it copies
+	setRealTimeData(manager, realTimeFeedData{trips: trips[2:], tripProperties: properties})
+	assert.Nil(t, manager.tripUpdateForServiceDate(source.TripID, serviceDate))
+}
+
+func TestParseTripProperties(t *testing.T) {
+	feed := &gtfsrt.FeedMessage{
+		Header: &gtfsrt.FeedHeader{GtfsRealtimeVersion: proto.String("2.0")},
+		Entity: []*gtfsrt.FeedEntity{
+			{
+				Id: proto.String("1"),
+				TripUpdate: &gtfsrt.TripUpdate{
+					Trip: &gtfsrt.TripDescriptor{
+						TripId:               proto.String("source"),
+						StartDate:            proto.String("20250612"),
+						StartTime:            proto.String("08:00:00"),
+						ScheduleRelationship: gtfsrt.TripDescriptor_DUPLICATED.Enum(),
+					},
+					TripProperties: &gtfsrt.TripUpdate_TripProperties{TripId: proto.String("copy")},
+				},
+			},
+			{
+				Id: proto.String("2"),
+				TripUpdate: &gtfsrt.TripUpdate{
+					Trip:           &gtfsrt.TripDescriptor{TripId: proto.String("scheduled")},
+					TripProperties: &gtfsrt.TripUpdate_TripProperties{TripId: proto.String("ignored")},
+				},
+			},
+		},
+	}
+	b, err := proto.Marshal(feed)
+	require.NoError(t, err)
+
+	properties := parseTripProperties(b)
+	require.Len(t, properties, 1)
+
+	realtime, err := gtfs.ParseRealtime(b, &gtfs.ParseRealtimeOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, realtime.Trips)
+	found := false
+	for _, trip := range realtime.Trips {
+		if p, ok := properties[tripDescriptorKeyFor(trip.ID)]; ok {
+			assert.Equal(t, "copy", p.GetTripId())
+			found = true
+		}
+	}
+	assert.True(t, found, "the parsed trip update finds its properties")
+}
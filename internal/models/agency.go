@@ -7,6 +7,8 @@ type AgencyCoverage struct {
 	LatSpan  float64 `json:"latSpan"`
 	Lon      float64 `json:"lon"`
 	LonSpan  float64 `json:"lonSpan"`
+	// FeedInfo identifies the static feed the agency was loaded from, when it has feed_info.txt
+	FeedInfo *FeedInfo `json:"feedInfo,omitempty"`
 }
 
 // NewAgencyCoverage creates a new AgencyCoverage instance with the provided values
@@ -0,0 +1,6 @@
+package models
+
+// RegisteredAlarm identifies an arrival alarm so that it can later be cancelled
+type RegisteredAlarm struct {
+	ID string `json:"id"`
+}
@@ -84,3 +84,11 @@ func NewArrivalAndDeparture(
 		VehicleID:                  vehicleID,
 	}
 }
+
+// StopWithArrivalsAndDepartures is the entry returned by the arrivals-and-departures-for-stop endpoint.
+type StopWithArrivalsAndDepartures struct {
+	ArrivalsAndDepartures []ArrivalAndDeparture `json:"arrivalsAndDepartures"`
+	NearbyStopIDs         []string              `json:"nearbyStopIds"`
+	SituationIDs          []string              `json:"situationIds"`
+	StopID                string                `json:"stopId"`
+}
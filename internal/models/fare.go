@@ -0,0 +1,48 @@
+package models
+
+// Fare is a GTFS Fares v1 fare class from fare_attributes.txt. Transfers is null when
+// unlimited transfers are allowed, and TransferDuration is in seconds. FareURL is the
+// agency's fare information page.
+type Fare struct {
+	ID               string  `json:"id"`
+	AgencyID         string  `json:"agencyId"`
+	Price            float64 `json:"price"`
+	CurrencyType     string  `json:"currencyType"`
+	PaymentMethod    int     `json:"paymentMethod"`
+	Transfers        *int64  `json:"transfers"`
+	TransferDuration *int64  `json:"transferDuration,omitempty"`
+	FareURL          string  `json:"fareUrl"`
+}
+
+// ItineraryFare is the GTFS Fares v2 price of a multi-leg itinerary. Amounts are in
+// Currency, and TotalAmount includes any transfer products.
+type ItineraryFare struct {
+	Currency    string              `json:"currency"`
+	TotalAmount float64             `json:"totalAmount"`
+	Legs        []FareLegPrice      `json:"legs"`
+	Transfers   []FareTransferPrice `json:"transfers"`
+}
+
+// FareLegPrice is the fare product chosen for one leg. Amount is what the leg costs after
+// transfer rules, which may be less than ProductAmount.
+type FareLegPrice struct {
+	TripID          string  `json:"tripId"`
+	FromStopID      string  `json:"fromStopId"`
+	ToStopID        string  `json:"toStopId"`
+	LegGroupID      string  `json:"legGroupId,omitempty"`
+	FareProductID   string  `json:"fareProductId"`
+	FareProductName string  `json:"fareProductName,omitempty"`
+	FareMediaID     string  `json:"fareMediaId,omitempty"`
+	ProductAmount   float64 `json:"productAmount"`
+	Amount          float64 `json:"amount"`
+}
+
+// FareTransferPrice is a transfer discount applied between two legs, identified by their
+// index in the itinerary.
+type FareTransferPrice struct {
+	FromLegIndex     int     `json:"fromLegIndex"`
+	ToLegIndex       int     `json:"toLegIndex"`
+	FareTransferType int     `json:"fareTransferType"`
+	FareProductID    string  `json:"fareProductId,omitempty"`
+	Amount           float64 `json:"amount"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// FeedInfo describes the static feed being served, from feed_info.txt. Dates are YYYYMMDD
+// as published.
+type FeedInfo struct {
+	FeedID        string `json:"feedId,omitempty"`
+	PublisherName string `json:"publisherName"`
+	PublisherURL  string `json:"publisherUrl"`
+	Lang          string `json:"lang"`
+	DefaultLang   string `json:"defaultLang,omitempty"`
+	StartDate     string `json:"startDate,omitempty"`
+	EndDate       string `json:"endDate,omitempty"`
+	Version       string `json:"version,omitempty"`
+	ContactEmail  string `json:"contactEmail,omitempty"`
+	ContactURL    string `json:"contactUrl,omitempty"`
+}
+
+// Config is the entry of the config endpoint, identifying the feed a server is running.
+// ServiceDateFrom and ServiceDateTo are the validity window in milliseconds since epoch.
+type Config struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	ServiceDateFrom int64     `json:"serviceDateFrom"`
+	ServiceDateTo   int64     `json:"serviceDateTo"`
+	FeedInfo        *FeedInfo `json:"feedInfo,omitempty"`
+	FileHash        string    `json:"fileHash"`
+	ImportTime      int64     `json:"importTime"`
+	// Feeds are the static feeds being served, of which FileHash and ImportTime describe the
+	// most recently imported
+	Feeds []FeedImport `json:"feeds"`
+}
+
+// FeedImport is the import state of one static feed. ImportTime is in milliseconds since epoch.
+type FeedImport struct {
+	ID         string `json:"id"`
+	FileHash   string `json:"fileHash"`
+	ImportTime int64  `json:"importTime"`
+}
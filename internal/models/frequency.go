@@ -1,7 +1,9 @@
 package models
 
+// Frequency describes the headway-based service window of a trip instance.
+// StartTime and EndTime are milliseconds since the epoch.
 type Frequency struct {
-	StartTime int64 `json:"startTime"`
-	EndTime   int64 `json:"endTime"`
-	Headway   int   `json:"headway"`
+	StartTime   int64 `json:"startTime"`
+	EndTime     int64 `json:"endTime"`
+	HeadwaySecs int64 `json:"headwaySecs"`
 }
@@ -0,0 +1,9 @@
+package models
+
+// GtfsDBRebuilds counts the rebuilds of the GTFS database, as reported by the admin API
+type GtfsDBRebuilds struct {
+	Succeeded           int64   `json:"succeeded"`
+	Failed              int64   `json:"failed"`
+	LastSwapTime        *int64  `json:"lastSwapTime,omitempty"` // Unix milliseconds, absent until the first swap
+	LastDurationSeconds float64 `json:"lastDurationSeconds"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// ProblemReport is a rider-submitted report about a trip or stop, as listed by the admin API
+type ProblemReport struct {
+	ID                   int64    `json:"id"`
+	Type                 string   `json:"type"`
+	TripID               string   `json:"tripId,omitempty"`
+	StopID               string   `json:"stopId,omitempty"`
+	Code                 string   `json:"code"`
+	ServiceDate          *int64   `json:"serviceDate,omitempty"`
+	VehicleID            string   `json:"vehicleId,omitempty"`
+	UserComment          string   `json:"userComment,omitempty"`
+	UserOnVehicle        *bool    `json:"userOnVehicle,omitempty"`
+	UserVehicleNumber    string   `json:"userVehicleNumber,omitempty"`
+	UserLat              *float64 `json:"userLat,omitempty"`
+	UserLon              *float64 `json:"userLon,omitempty"`
+	UserLocationAccuracy *float64 `json:"userLocationAccuracy,omitempty"`
+	Status               string   `json:"status"`
+	CreatedAt            int64    `json:"createdAt"`
+	ResolvedAt           *int64   `json:"resolvedAt,omitempty"`
+	ResolutionNote       string   `json:"resolutionNote,omitempty"`
+}
@@ -8,6 +8,7 @@ type ReferencesModel struct {
 	StopTimes  []interface{}     `json:"stopTimes"`
 	Stops      []Stop            `json:"stops"`
 	Trips      []interface{}     `json:"trips"`
+	Fares      []Fare            `json:"fares,omitempty"`
 }
 
 // NewEmptyReferences creates a new empty References model with initialized empty slices
@@ -0,0 +1,54 @@
+package models
+
+// RouteScheduleStopTime represents a single stop time of a trip in a route schedule
+type RouteScheduleStopTime struct {
+	ArrivalEnabled   bool   `json:"arrivalEnabled"`
+	ArrivalTime      int64  `json:"arrivalTime"`
+	DepartureEnabled bool   `json:"departureEnabled"`
+	DepartureTime    int64  `json:"departureTime"`
+	ServiceID        string `json:"serviceId"`
+	StopHeadsign     string `json:"stopHeadsign"`
+	StopID           string `json:"stopId"`
+	TripID           string `json:"tripId"`
+	Status           string `json:"status,omitempty"`
+	StopSequence     int64  `json:"-"` // Names the stop time to translations, and isn't returned
+}
+
+// TripWithStopTimes holds the stop times of one trip, ordered along the trip
+type TripWithStopTimes struct {
+	StopTimes []RouteScheduleStopTime `json:"stopTimes"`
+	TripID    string                  `json:"tripId"`
+	Status    string                  `json:"status,omitempty"`
+}
+
+// StopTripGrouping is the timetable for one direction of a route: the ordered stops
+// served in that direction and, for each trip, its times at those stops
+type StopTripGrouping struct {
+	DirectionID        string              `json:"directionId"`
+	StopIDs            []string            `json:"stopIds"`
+	TripHeadsigns      []string            `json:"tripHeadsigns"`
+	TripIDs            []string            `json:"tripIds"`
+	TripsWithStopTimes []TripWithStopTimes `json:"tripsWithStopTimes"`
+}
+
+// ScheduleForRouteEntry represents the main data entry for schedule-for-route
+type ScheduleForRouteEntry struct {
+	RouteID           string             `json:"routeId"`
+	ScheduleDate      int64              `json:"scheduleDate"`
+	ServiceIDs        []string           `json:"serviceIds"`
+	StopTripGroupings []StopTripGrouping `json:"stopTripGroupings"`
+}
+
+// NewRouteScheduleStopTime creates a new RouteScheduleStopTime
+func NewRouteScheduleStopTime(arrivalTime, departureTime int64, serviceID, stopHeadsign, stopID, tripID string) RouteScheduleStopTime {
+	return RouteScheduleStopTime{
+		ArrivalEnabled:   true,
+		ArrivalTime:      arrivalTime,
+		DepartureEnabled: true,
+		DepartureTime:    departureTime,
+		ServiceID:        serviceID,
+		StopHeadsign:     stopHeadsign,
+		StopID:           stopID,
+		TripID:           tripID,
+	}
+}
@@ -0,0 +1,8 @@
+package models
+
+// ServiceDates lists the days a service runs on
+type ServiceDates struct {
+	ServiceID string `json:"serviceId"`
+	// ServiceDates are the midnights starting each service day, in milliseconds since the epoch
+	ServiceDates []int64 `json:"serviceDates"`
+}
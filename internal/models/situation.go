@@ -0,0 +1,41 @@
+package models
+
+// Situation describes a service alert affecting agencies, routes, trips or stops
+type Situation struct {
+	ActiveWindows      []TimeWindow           `json:"activeWindows"`
+	AllAffects         []SituationAffects     `json:"allAffects"`
+	Consequences       []SituationConsequence `json:"consequences"`
+	CreationTime       int64                  `json:"creationTime"`
+	Description        *NaturalLanguageString `json:"description,omitempty"`
+	ID                 string                 `json:"id"`
+	PublicationWindows []TimeWindow           `json:"publicationWindows"`
+	Reason             string                 `json:"reason"`
+	Severity           string                 `json:"severity"`
+	Summary            *NaturalLanguageString `json:"summary,omitempty"`
+	URL                *NaturalLanguageString `json:"url,omitempty"`
+}
+
+// TimeWindow is a period in milliseconds since the epoch. A zero bound is open-ended.
+type TimeWindow struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// SituationAffects identifies an entity informed by a situation. Empty fields match any value.
+type SituationAffects struct {
+	AgencyID      string `json:"agencyId"`
+	ApplicationID string `json:"applicationId"`
+	DirectionID   string `json:"directionId"`
+	RouteID       string `json:"routeId"`
+	StopID        string `json:"stopId"`
+	TripID        string `json:"tripId"`
+}
+
+type SituationConsequence struct {
+	Condition string `json:"condition"`
+}
+
+type NaturalLanguageString struct {
+	Lang  string `json:"lang"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,67 @@
+package models
+
+// Values of pathway_mode in pathways.txt
+const (
+	WalkwayPathway        = 1
+	StairsPathway         = 2
+	MovingSidewalkPathway = 3
+	EscalatorPathway      = 4
+	ElevatorPathway       = 5
+	FareGatePathway       = 6 // entering the paid area of the station
+	ExitGatePathway       = 7 // leaving the paid area of the station
+)
+
+// Values of location_type in stops.txt
+const (
+	StopLocation         = 0 // a stop or platform
+	StationLocation      = 1
+	EntranceExitLocation = 2
+	GenericNodeLocation  = 3
+	BoardingAreaLocation = 4
+)
+
+// Station is a station with everything inside it needed for navigation. The stops
+// themselves are in the references, with Parent linking each to the stop that contains it.
+type Station struct {
+	ID                 string  `json:"id"`
+	Code               string  `json:"code"`
+	Name               string  `json:"name"`
+	Lat                float64 `json:"lat"`
+	Lon                float64 `json:"lon"`
+	WheelchairBoarding string  `json:"wheelchairBoarding"`
+	// StopIDs are the platforms, boarding areas and generic nodes in the station
+	StopIDs     []string  `json:"stopIds"`
+	EntranceIDs []string  `json:"entranceIds"`
+	Levels      []Level   `json:"levels"`
+	Pathways    []Pathway `json:"pathways"`
+}
+
+// Level is a floor of a station. Index orders levels vertically, with 0 at ground level.
+type Level struct {
+	ID      string   `json:"id"`
+	Index   float64  `json:"index"`
+	Name    string   `json:"name,omitempty"`
+	StopIDs []string `json:"stopIds"`
+}
+
+// Pathway is a directed edge of a station's pathway graph, traversable in both directions
+// when IsBidirectional is set. Length and MinWidth are in meters and TraversalTime in
+// seconds. StairCount is negative for stairs going down.
+type Pathway struct {
+	ID                   string   `json:"id"`
+	FromStopID           string   `json:"fromStopId"`
+	ToStopID             string   `json:"toStopId"`
+	PathwayMode          int      `json:"pathwayMode"`
+	IsBidirectional      bool     `json:"isBidirectional"`
+	Length               *float64 `json:"length,omitempty"`
+	TraversalTime        *int64   `json:"traversalTime,omitempty"`
+	StairCount           *int64   `json:"stairCount,omitempty"`
+	MaxSlope             *float64 `json:"maxSlope,omitempty"`
+	MinWidth             *float64 `json:"minWidth,omitempty"`
+	SignpostedAs         string   `json:"signpostedAs,omitempty"`
+	ReversedSignpostedAs string   `json:"reversedSignpostedAs,omitempty"`
+	IsElevator           bool     `json:"isElevator"`
+	HasStairs            bool     `json:"hasStairs"`
+	// WheelchairAccessible is false for stairs, escalators and ramps steeper than 1:12
+	WheelchairAccessible bool `json:"wheelchairAccessible"`
+}
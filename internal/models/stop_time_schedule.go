@@ -9,13 +9,29 @@ type ScheduleStopTime struct {
 	ServiceID        string `json:"serviceId"`
 	StopHeadsign     string `json:"stopHeadsign"`
 	TripID           string `json:"tripId"`
+	Status           string `json:"status,omitempty"`
+	StopSequence     int64  `json:"-"` // Names the stop time to translations, and isn't returned
+}
+
+// ScheduleFrequency represents a headway-based service window of a trip at a stop
+type ScheduleFrequency struct {
+	ArrivalEnabled   bool   `json:"arrivalEnabled"`
+	DepartureEnabled bool   `json:"departureEnabled"`
+	EndTime          int64  `json:"endTime"`
+	HeadwaySecs      int64  `json:"headwaySecs"`
+	ServiceDate      int64  `json:"serviceDate"`
+	ServiceID        string `json:"serviceId"`
+	StartTime        int64  `json:"startTime"`
+	StopHeadsign     string `json:"stopHeadsign"`
+	TripID           string `json:"tripId"`
+	StopSequence     int64  `json:"-"` // Names the stop time to translations, and isn't returned
 }
 
 // StopRouteDirectionSchedule represents schedule for a specific direction of a route
 type StopRouteDirectionSchedule struct {
-	ScheduleFrequencies []interface{}      `json:"scheduleFrequencies"`
-	ScheduleStopTimes   []ScheduleStopTime `json:"scheduleStopTimes"`
-	TripHeadsign        string             `json:"tripHeadsign"`
+	ScheduleFrequencies []ScheduleFrequency `json:"scheduleFrequencies"`
+	ScheduleStopTimes   []ScheduleStopTime  `json:"scheduleStopTimes"`
+	TripHeadsign        string              `json:"tripHeadsign"`
 }
 
 // StopRouteSchedule represents the schedule for a route at a stop
@@ -44,10 +60,28 @@ func NewScheduleStopTime(arrivalTime, departureTime int64, serviceID, stopHeadsi
 	}
 }
 
+// NewScheduleFrequency creates a new ScheduleFrequency
+func NewScheduleFrequency(serviceDate, startTime, endTime, headwaySecs int64, serviceID, stopHeadsign, tripID string) ScheduleFrequency {
+	return ScheduleFrequency{
+		ArrivalEnabled:   true,
+		DepartureEnabled: true,
+		EndTime:          endTime,
+		HeadwaySecs:      headwaySecs,
+		ServiceDate:      serviceDate,
+		ServiceID:        serviceID,
+		StartTime:        startTime,
+		StopHeadsign:     stopHeadsign,
+		TripID:           tripID,
+	}
+}
+
 // NewStopRouteDirectionSchedule creates a new StopRouteDirectionSchedule
-func NewStopRouteDirectionSchedule(tripHeadsign string, stopTimes []ScheduleStopTime) StopRouteDirectionSchedule {
+func NewStopRouteDirectionSchedule(tripHeadsign string, stopTimes []ScheduleStopTime, frequencies []ScheduleFrequency) StopRouteDirectionSchedule {
+	if frequencies == nil {
+		frequencies = []ScheduleFrequency{}
+	}
 	return StopRouteDirectionSchedule{
-		ScheduleFrequencies: []interface{}{}, // Always empty array in the API
+		ScheduleFrequencies: frequencies,
 		ScheduleStopTimes:   stopTimes,
 		TripHeadsign:        tripHeadsign,
 	}
@@ -13,6 +13,10 @@ type StopTime struct {
 	StopHeadsign        string  `json:"stopHeadsign"`
 	DistanceAlongTrip   float64 `json:"distanceAlongTrip"`
 	HistoricalOccupancy string  `json:"historicalOccupancy"`
+	Status              string  `json:"status,omitempty"`
+	// The trip and stop sequence name the stop time to translations, and aren't returned
+	TripID       string `json:"-"`
+	StopSequence int64  `json:"-"`
 }
 
 func NewStopTime(arrivalTime, departureTime int, stopID, stopHeadsign string, distanceAlongTrip float64, historicalOccupancy string) StopTime {
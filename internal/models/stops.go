@@ -12,6 +12,8 @@ type Stop struct {
 	RouteIDs           []string `json:"routeIds"`
 	StaticRouteIDs     []string `json:"staticRouteIds"`
 	WheelchairBoarding string   `json:"wheelchairBoarding"`
+	// TransferHints are the transfers.txt rules for connections leaving this stop
+	TransferHints []Transfer `json:"transferHints,omitempty"`
 }
 
 func NewStop(code, direction, id, name, parent, wheelchairBoarding string, lat, lon float64, locationType int, routeIDs, staticRouteIDs []string) Stop {
@@ -1,7 +1,11 @@
 package models
 
 import (
+	"archive/zip"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -16,3 +20,179 @@ func GetFixturePath(t *testing.T, fixturePath string) string {
 
 	return absPath
 }
+
+// BuildFeedWithFiles copies the zipped GTFS feed at sourcePath into a temporary file, adding or
+// replacing the given files (name to CSV contents), and returns the path of the new feed.
+func BuildFeedWithFiles(t *testing.T, sourcePath string, files map[string]string) string {
+	t.Helper()
+
+	source, err := zip.OpenReader(sourcePath)
+	if err != nil {
+		t.Fatalf("Failed to open feed %s: %v", sourcePath, err)
+	}
+	defer func() { _ = source.Close() }()
+
+	outputPath := filepath.Join(t.TempDir(), filepath.Base(sourcePath))
+	output, err := os.Create(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to create feed %s: %v", outputPath, err)
+	}
+	defer func() { _ = output.Close() }()
+
+	writer := zip.NewWriter(output)
+	for _, file := range source.File {
+		if _, replaced := files[file.Name]; replaced {
+			continue
+		}
+		if err := writer.Copy(file); err != nil {
+			t.Fatalf("Failed to copy %s: %v", file.Name, err)
+		}
+	}
+	for name, contents := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		if _, err := io.WriteString(w, contents); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to finish feed %s: %v", outputPath, err)
+	}
+
+	return outputPath
+}
+
+// ReadFeedFile returns the contents of one file in the zipped GTFS feed at sourcePath.
+func ReadFeedFile(t *testing.T, sourcePath, name string) string {
+	t.Helper()
+
+	source, err := zip.OpenReader(sourcePath)
+	if err != nil {
+		t.Fatalf("Failed to open feed %s: %v", sourcePath, err)
+	}
+	defer func() { _ = source.Close() }()
+
+	file, err := source.Open(name)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	contents, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return string(contents)
+}
+
+// BuildStationFeed adds station STN ("Central Station") to the feed at sourcePath, with
+// entrance STN_E on level L0, platforms STN_P1 and STN_P2 on level L1, generic node STN_N
+// and boarding area STN_P1_B, the last two without coordinates of their own. Pathways
+// PW_WALK (entrance to node), PW_STAIRS and PW_ELEVATOR (node to platform 1), PW_ESCALATOR
+// (node to platform 2) and PW_RAMP (platform 1 to its boarding area) connect them.
+func BuildStationFeed(t *testing.T, sourcePath string) string {
+	t.Helper()
+
+	lines := strings.Split(strings.TrimSpace(ReadFeedFile(t, sourcePath, "stops.txt")), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if i == 0 {
+			lines[i] = line + ",level_id"
+		} else {
+			lines[i] = line + ","
+		}
+	}
+	lines = append(lines,
+		"STN,STN,Central Station,,40.5865,-122.3917,25,,1,,America/Los_Angeles,1,,",
+		"STN_E,,Main St Entrance,,40.5866,-122.3915,,,2,STN,,1,,L0",
+		"STN_P1,,Platform 1,,40.5864,-122.3918,25,,0,STN,,1,1,L1",
+		"STN_P2,,Platform 2,,40.5864,-122.3919,25,,0,STN,,2,2,L1",
+		"STN_N,,Concourse,,,,,,3,STN,,,,L0",
+		"STN_P1_B,,Platform 1 Front,,,,,,4,STN_P1,,,,L1",
+	)
+
+	return BuildFeedWithFiles(t, sourcePath, map[string]string{
+		"stops.txt": strings.Join(lines, "\n") + "\n",
+		"levels.txt": "level_id,level_index,level_name\n" +
+			"L0,0,Street\n" +
+			"L1,-1,Concourse\n",
+		"pathways.txt": "pathway_id,from_stop_id,to_stop_id,pathway_mode,is_bidirectional,length,traversal_time,stair_count,max_slope,min_width,signposted_as,reversed_signposted_as\n" +
+			"PW_WALK,STN_E,STN_N,1,1,25.5,,,,,To trains,Exit\n" +
+			"PW_STAIRS,STN_N,STN_P1,2,1,,,-24,,,,\n" +
+			"PW_ELEVATOR,STN_N,STN_P1,5,1,,60,,,1.2,,\n" +
+			"PW_ESCALATOR,STN_N,STN_P2,4,0,,,,,,,\n" +
+			"PW_RAMP,STN_P1,STN_P1_B,1,1,,,,0.2,,,\n" +
+			"PW_MISSING,STN_E,NO_SUCH_STOP,1,1,,,,,,,\n",
+	})
+}
+
+// BuildTranslationFeed adds a translations.txt to the feed at sourcePath. It translates stop
+// 1001, route 151's long name, agency 25 and the feed publisher into Spanish by record, the
+// "Shasta Lake" headsign into Spanish by field value, and stop 1001 into Canadian French. Two
+// invalid rows, one without a language and one without a record or value, are included.
+func BuildTranslationFeed(t *testing.T, sourcePath string) string {
+	t.Helper()
+
+	return BuildFeedWithFiles(t, sourcePath, map[string]string{
+		"translations.txt": "table_name,field_name,language,translation,record_id,record_sub_id,field_value\n" +
+			"stops,stop_name,es,Northpoint Dr en Lake Blvd,1001,,\n" +
+			"routes,route_long_name,es,Ruta 1,151,,\n" +
+			"trips,trip_headsign,es,Lago Shasta,,,Shasta Lake\n" +
+			"agency,agency_name,es,Autoridad de Autobuses de Redding,25,,\n" +
+			"feed_info,feed_publisher_name,es,Arcadis S.A.,,,\n" +
+			"stops,stop_name,fr-CA,Northpoint Dr à Lake Blvd,1001,,\n" +
+			"routes,route_long_name,,Missing language,151,,\n" +
+			"stops,stop_name,de,Nirgendwo,,,\n",
+	})
+}
+
+// BuildShuttleFeed writes a small feed of its own, for serving alongside another: agency
+// SHUTTLE ("Downtown Shuttle") runs route LOOP with trip LOOP_1 from stop SH_A to SH_B on
+// weekdays of 2025 under service SH_WEEKDAY, except 20250704.
+func BuildShuttleFeed(t *testing.T) string {
+	t.Helper()
+
+	files := map[string]string{
+		"agency.txt": "agency_id,agency_name,agency_url,agency_timezone,agency_lang\n" +
+			"SHUTTLE,Downtown Shuttle,https://shuttle.example.com,America/Los_Angeles,en\n",
+		"stops.txt": "stop_id,stop_code,stop_name,stop_lat,stop_lon\n" +
+			"SH_A,A,Shuttle Stop A,40.5870,-122.3920\n" +
+			"SH_B,B,Shuttle Stop B,40.5880,-122.3930\n",
+		"routes.txt": "route_id,agency_id,route_short_name,route_long_name,route_type\n" +
+			"LOOP,SHUTTLE,L,Downtown Loop,3\n",
+		"calendar.txt": "service_id,monday,tuesday,wednesday,thursday,friday,saturday,sunday,start_date,end_date\n" +
+			"SH_WEEKDAY,1,1,1,1,1,0,0,20250101,20251231\n",
+		"calendar_dates.txt": "service_id,date,exception_type\n" +
+			"SH_WEEKDAY,20250704,2\n",
+		"trips.txt": "route_id,service_id,trip_id,trip_headsign\n" +
+			"LOOP,SH_WEEKDAY,LOOP_1,Shuttle Stop B\n",
+		"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+			"LOOP_1,08:00:00,08:00:00,SH_A,1\n" +
+			"LOOP_1,08:10:00,08:10:00,SH_B,2\n",
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "shuttle.zip")
+	output, err := os.Create(outputPath)
+	if err != nil {
+		t.Fatalf("Failed to create feed %s: %v", outputPath, err)
+	}
+	defer func() { _ = output.Close() }()
+
+	writer := zip.NewWriter(output)
+	for name, contents := range files {
+		w, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		if _, err := io.WriteString(w, contents); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to finish feed %s: %v", outputPath, err)
+	}
+
+	return outputPath
+}
@@ -0,0 +1,24 @@
+package models
+
+// Values of transfer_type in transfers.txt
+const (
+	RecommendedTransfer = 0 // recommended transfer point between routes
+	TimedTransfer       = 1 // the departing vehicle waits for the arriving one
+	MinimumTimeTransfer = 2 // requires at least minTransferTime seconds
+	NoTransfer          = 3 // transfers are not possible
+	InSeatTransfer      = 4 // rider stays on board between trips
+	NoInSeatTransfer    = 5 // rider must alight between trips
+)
+
+// Transfer is a transfers.txt rule for connecting from one stop to another, optionally
+// restricted to particular routes or trips. MinTransferTime is in seconds.
+type Transfer struct {
+	FromStopID      string `json:"fromStopId,omitempty"`
+	ToStopID        string `json:"toStopId,omitempty"`
+	FromRouteID     string `json:"fromRouteId,omitempty"`
+	ToRouteID       string `json:"toRouteId,omitempty"`
+	FromTripID      string `json:"fromTripId,omitempty"`
+	ToTripID        string `json:"toTripId,omitempty"`
+	TransferType    int    `json:"transferType"`
+	MinTransferTime *int64 `json:"minTransferTime,omitempty"`
+}
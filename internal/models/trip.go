@@ -41,7 +41,7 @@ func NewTripReference(id, routeID, serviceID, headSign, shortName string, direct
 }
 
 type TripsSchedule struct {
-	Frequency      *int64     `json:"frequency"`
+	Frequency      *Frequency `json:"frequency"`
 	NextTripId     string     `json:"nextTripId"`
 	PreviousTripId string     `json:"previousTripId"`
 	StopTimes      []StopTime `json:"stopTimes"`
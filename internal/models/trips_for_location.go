@@ -12,7 +12,7 @@ type TripsForLocationData struct {
 }
 
 type TripsForLocationListEntry struct {
-	Frequency    *int64         `json:"frequency"`
+	Frequency    *Frequency     `json:"frequency"`
 	Schedule     *TripsSchedule `json:"schedule,omitempty"`
 	ServiceDate  int64          `json:"serviceDate"`
 	SituationIds []string       `json:"situationIds"`
@@ -12,7 +12,7 @@ type TripsForRouteData struct {
 }
 
 type TripsForRouteListEntry struct {
-	Frequency    *int64                    `json:"frequency"`
+	Frequency    *Frequency                `json:"frequency"`
 	Schedule     *TripsSchedule            `json:"schedule,omitempty"`
 	Status       *TripStatusForTripDetails `json:"status,omitempty"`
 	ServiceDate  int64                     `json:"serviceDate"`
@@ -0,0 +1,13 @@
+package models
+
+// ValidationFinding is a problem found in a static feed when it was imported, as listed by the
+// admin API
+type ValidationFinding struct {
+	ID       int64  `json:"id"`
+	FeedID   string `json:"feedId,omitempty"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	FileName string `json:"fileName,omitempty"`
+	EntityID string `json:"entityId,omitempty"`
+	Message  string `json:"message"`
+}
@@ -0,0 +1,25 @@
+package restapi
+
+import (
+	"net/http"
+
+	"maglev.onebusaway.org/internal/models"
+)
+
+// adminGtfsDBRebuildsHandler reports how many times the GTFS database has been rebuilt and when
+// it was last swapped.
+func (api *RestAPI) adminGtfsDBRebuildsHandler(w http.ResponseWriter, r *http.Request) {
+	stats := api.GtfsManager.RebuildStats()
+
+	entry := models.GtfsDBRebuilds{
+		Succeeded:           stats.Succeeded,
+		Failed:              stats.Failed,
+		LastDurationSeconds: stats.LastDuration.Seconds(),
+	}
+	if !stats.LastSwapTime.IsZero() {
+		lastSwapTime := stats.LastSwapTime.UnixMilli()
+		entry.LastSwapTime = &lastSwapTime
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(entry, models.NewEmptyReferences()))
+}
@@ -0,0 +1,30 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestAdminGtfsDBRebuilds(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/admin/gtfs-db-rebuilds.json?key=TEST")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, body := doRequest(t, http.MethodGet, server.URL+"/api/admin/gtfs-db-rebuilds.json?key=ADMIN")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var decoded struct {
+		Data struct {
+			Entry models.GtfsDBRebuilds `json:"entry"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Zero(t, decoded.Data.Entry.Succeeded)
+	assert.Zero(t, decoded.Data.Entry.Failed)
+	assert.Nil(t, decoded.Data.Entry.LastSwapTime, "no swap has happened yet")
+}
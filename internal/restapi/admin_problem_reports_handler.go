@@ -0,0 +1,262 @@
+package restapi
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+)
+
+const (
+	defaultProblemReportsLimit = 100
+	maxProblemReportsLimit     = 1000
+)
+
+var problemReportCSVHeader = []string{
+	"id", "type", "tripId", "stopId", "code", "serviceDate", "vehicleId", "userComment",
+	"userOnVehicle", "userVehicleNumber", "userLat", "userLon", "userLocationAccuracy",
+	"status", "createdAt", "resolvedAt", "resolutionNote",
+}
+
+// parseProblemReportFilters reads the filters shared by the admin list and export endpoints.
+func parseProblemReportFilters(r *http.Request) (gtfsdb.ListProblemReportsParams, map[string][]string) {
+	query := r.URL.Query()
+	fieldErrors := map[string][]string{}
+	params := gtfsdb.ListProblemReportsParams{
+		MaxResults: defaultProblemReportsLimit,
+	}
+
+	optionalString := func(field string, allowed ...string) sql.NullString {
+		value := query.Get(field)
+		if value == "" {
+			return sql.NullString{}
+		}
+		if len(allowed) > 0 {
+			valid := false
+			for _, a := range allowed {
+				valid = valid || value == a
+			}
+			if !valid {
+				fieldErrors[field] = invalidFieldValue(field)
+				return sql.NullString{}
+			}
+		}
+		return sql.NullString{String: value, Valid: true}
+	}
+	optionalInt := func(field string) sql.NullInt64 {
+		value := query.Get(field)
+		if value == "" {
+			return sql.NullInt64{}
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			fieldErrors[field] = invalidFieldValue(field)
+			return sql.NullInt64{}
+		}
+		return sql.NullInt64{Int64: parsed, Valid: true}
+	}
+
+	params.ReportType = optionalString("type", problemReportTypeTrip, problemReportTypeStop)
+	params.Status = optionalString("status", problemReportStatusOpen, problemReportStatusResolved)
+	params.Code = optionalString("code")
+	params.TripID = optionalString("tripId")
+	params.StopID = optionalString("stopId")
+	params.CreatedAfter = optionalInt("since")
+	params.CreatedBefore = optionalInt("until")
+
+	if limit := optionalInt("limit"); limit.Valid {
+		if limit.Int64 == 0 {
+			fieldErrors["limit"] = invalidFieldValue("limit")
+		}
+		params.MaxResults = min(limit.Int64, maxProblemReportsLimit)
+	}
+	if offset := optionalInt("offset"); offset.Valid {
+		params.Skip = offset.Int64
+	}
+
+	if len(fieldErrors) > 0 {
+		return params, fieldErrors
+	}
+	return params, nil
+}
+
+func (api *RestAPI) adminListProblemReportsHandler(w http.ResponseWriter, r *http.Request) {
+	params, fieldErrors := parseProblemReportFilters(r)
+	if fieldErrors != nil {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	reports, err := api.GtfsManager.GtfsDB().UserQueries.ListProblemReports(r.Context(), params)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	list := make([]models.ProblemReport, 0, len(reports))
+	for _, report := range reports {
+		list = append(list, newProblemReportModel(report))
+	}
+
+	api.sendResponse(w, r, models.NewListResponse(list, models.NewEmptyReferences()))
+}
+
+// adminExportProblemReportsHandler downloads every report matching the filters as CSV (the
+// default) or JSON, ignoring the list endpoint's page size.
+func (api *RestAPI) adminExportProblemReportsHandler(w http.ResponseWriter, r *http.Request) {
+	params, fieldErrors := parseProblemReportFilters(r)
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		if fieldErrors == nil {
+			fieldErrors = map[string][]string{}
+		}
+		fieldErrors["format"] = invalidFieldValue("format")
+	}
+	if fieldErrors != nil {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	// A negative LIMIT removes the limit in SQLite
+	if r.URL.Query().Get("limit") == "" {
+		params.MaxResults = -1
+	}
+
+	reports, err := api.GtfsManager.GtfsDB().UserQueries.ListProblemReports(r.Context(), params)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	filename := "problem-reports-" + time.Now().UTC().Format("20060102T150405Z") + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+	if format == "json" {
+		list := make([]models.ProblemReport, 0, len(reports))
+		for _, report := range reports {
+			list = append(list, newProblemReportModel(report))
+		}
+		setJSONResponseType(&w)
+		if err := json.NewEncoder(w).Encode(list); err != nil {
+			api.Logger.Error("failed to encode problem report export", "error", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	writer := csv.NewWriter(w)
+	_ = writer.Write(problemReportCSVHeader)
+	for _, report := range reports {
+		_ = writer.Write(problemReportCSVRecord(report))
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		api.Logger.Error("failed to write problem report export", "error", err)
+	}
+}
+
+func (api *RestAPI) adminResolveProblemReportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		fieldErrors := map[string][]string{
+			"id": invalidFieldValue("id"),
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	ctx := r.Context()
+	note := r.FormValue("note")
+	if len(note) > maxProblemReportCommentLength {
+		fieldErrors := map[string][]string{
+			"note": {"note too long (max 1000 characters)"},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	updated, err := api.GtfsManager.GtfsDB().UserQueries.ResolveProblemReport(ctx, gtfsdb.ResolveProblemReportParams{
+		ResolvedAt:     sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		ResolutionNote: sql.NullString{String: note, Valid: note != ""},
+		ID:             id,
+	})
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	if updated == 0 {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	report, err := api.GtfsManager.GtfsDB().UserQueries.GetProblemReport(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		api.sendNotFound(w, r)
+		return
+	} else if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(newProblemReportModel(report), models.NewEmptyReferences()))
+}
+
+func problemReportCSVRecord(report gtfsdb.ProblemReport) []string {
+	nullInt := func(v sql.NullInt64) string {
+		if !v.Valid {
+			return ""
+		}
+		return strconv.FormatInt(v.Int64, 10)
+	}
+	nullFloat := func(v sql.NullFloat64) string {
+		if !v.Valid {
+			return ""
+		}
+		return strconv.FormatFloat(v.Float64, 'f', -1, 64)
+	}
+	onVehicle := ""
+	if report.UserOnVehicle.Valid {
+		onVehicle = strconv.FormatBool(report.UserOnVehicle.Int64 != 0)
+	}
+
+	return []string{
+		strconv.FormatInt(report.ID, 10),
+		csvText(report.ReportType),
+		csvText(report.TripID.String),
+		csvText(report.StopID.String),
+		csvText(report.Code),
+		nullInt(report.ServiceDate),
+		csvText(report.VehicleID.String),
+		csvText(report.UserComment.String),
+		onVehicle,
+		csvText(report.UserVehicleNumber.String),
+		nullFloat(report.UserLat),
+		nullFloat(report.UserLon),
+		nullFloat(report.UserLocationAccuracy),
+		csvText(report.Status),
+		strconv.FormatInt(report.CreatedAt, 10),
+		nullInt(report.ResolvedAt),
+		csvText(report.ResolutionNote.String),
+	}
+}
+
+// csvText escapes a text cell of the CSV export. Spreadsheets evaluate cells starting with =, +,
+// -, @, a tab or a carriage return as formulas, so riders could otherwise plant formulas in the
+// export through their reports. Prefixing such cells with ' makes spreadsheets show them as text.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
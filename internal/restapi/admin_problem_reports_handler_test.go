@@ -0,0 +1,221 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+// newProblemReportTestServer returns an API configured with an admin key and a server for it.
+// The general rate limit is lifted so that only the problem report limit applies.
+func newProblemReportTestServer(t *testing.T) (*RestAPI, *httptest.Server) {
+	api := createTestApi(t)
+	api.Config.AdminApiKeys = []string{"ADMIN"}
+	api.rateLimiter = NewRateLimitMiddleware(1000, time.Second)
+
+	mux := http.NewServeMux()
+	api.SetRoutes(mux)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func doRequest(t *testing.T, method, url string) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestReportProblemValidation(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	cases := map[string]string{
+		"unknown trip code":    "/api/where/report-problem-with-trip/25_1.json?key=TEST&code=bus_was_pink",
+		"stop code for trip":   "/api/where/report-problem-with-trip/25_1.json?key=TEST&code=stop_name_wrong",
+		"missing code":         "/api/where/report-problem-with-stop/25_1.json?key=TEST",
+		"latitude range":       "/api/where/report-problem-with-stop/25_1.json?key=TEST&code=other&userLat=91&userLon=0",
+		"latitude only":        "/api/where/report-problem-with-stop/25_1.json?key=TEST&code=other&userLat=47",
+		"negative accuracy":    "/api/where/report-problem-with-stop/25_1.json?key=TEST&code=other&userLat=47&userLon=-122&userLocationAccuracy=-1",
+		"invalid service date": "/api/where/report-problem-with-trip/25_1.json?key=TEST&code=other&serviceDate=yesterday",
+		"invalid vehicle":      "/api/where/report-problem-with-trip/25_1.json?key=TEST&code=other&vehicleId=bad%20id",
+	}
+
+	for name, endpoint := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp, _ := doRequest(t, http.MethodGet, server.URL+endpoint)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestProblemReportSubmissionIsRateLimited(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	var lastStatus int
+	for i := 0; i <= problemReportsPerMinute; i++ {
+		resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/where/report-problem-with-stop/25_1.json?key=test&code=other")
+		lastStatus = resp.StatusCode
+		if i < problemReportsPerMinute {
+			require.Equal(t, http.StatusOK, resp.StatusCode, "report %d should be accepted", i)
+		}
+	}
+	assert.Equal(t, http.StatusTooManyRequests, lastStatus)
+
+	// Other API calls from the same key are unaffected
+	resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/where/current-time.json?key=test")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAdminProblemReportsRequireAdminKey(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	for _, key := range []string{"", "TEST", "wrong"} {
+		resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports.json?key="+key)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "key %q", key)
+	}
+
+	resp, _ := doRequest(t, http.MethodPost, server.URL+"/api/admin/problem-reports/1/resolve?key=TEST")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAdminProblemReportsListFilterResolveExport(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	submissions := []string{
+		"/api/where/report-problem-with-trip/25_trip1.json?key=TEST&code=vehicle_never_came&serviceDate=1749711600000&vehicleId=25_v1&stopId=25_1030&userOnVehicle=false&userLat=40.58&userLon=-122.39&userLocationAccuracy=12.5&userComment=Never+showed+up",
+		"/api/where/report-problem-with-stop/25_1030.json?key=TEST&code=stop_name_wrong&userComment=Sign+says+Lake+Blvd",
+		"/api/where/report-problem-with-stop/25_1001.json?key=TEST&code=other",
+	}
+	for _, endpoint := range submissions {
+		resp, _ := doRequest(t, http.MethodGet, server.URL+endpoint)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	listReports := func(query string) []models.ProblemReport {
+		resp, body := doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports.json?key=ADMIN"+query)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var response struct {
+			Data struct {
+				List []models.ProblemReport `json:"list"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &response))
+		return response.Data.List
+	}
+
+	all := listReports("")
+	require.Len(t, all, 3)
+	// Newest first
+	assert.Equal(t, "25_1001", all[0].StopID)
+
+	trips := listReports("&type=trip")
+	require.Len(t, trips, 1)
+	trip := trips[0]
+	assert.Equal(t, "25_trip1", trip.TripID)
+	assert.Equal(t, "25_1030", trip.StopID)
+	assert.Equal(t, "vehicle_never_came", trip.Code)
+	assert.Equal(t, "25_v1", trip.VehicleID)
+	require.NotNil(t, trip.ServiceDate)
+	assert.Equal(t, int64(1749711600000), *trip.ServiceDate)
+	require.NotNil(t, trip.UserOnVehicle)
+	assert.False(t, *trip.UserOnVehicle)
+	require.NotNil(t, trip.UserLocationAccuracy)
+	assert.Equal(t, 12.5, *trip.UserLocationAccuracy)
+	assert.Equal(t, "Never showed up", trip.UserComment)
+	assert.Equal(t, "open", trip.Status)
+
+	assert.Len(t, listReports("&stopId=25_1030"), 2)
+	assert.Len(t, listReports("&code=other"), 1)
+	assert.Len(t, listReports("&limit=2"), 2)
+	assert.Len(t, listReports("&limit=2&offset=2"), 1)
+
+	resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports.json?key=ADMIN&status=closed")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// Resolve the trip report
+	resolveURL := server.URL + "/api/admin/problem-reports/" + strconv.FormatInt(trip.ID, 10) +
+		"/resolve?key=ADMIN&note=" + url.QueryEscape("Driver was reassigned")
+	resp, body := doRequest(t, http.MethodPost, resolveURL)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var resolved struct {
+		Data struct {
+			Entry models.ProblemReport `json:"entry"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resolved))
+	assert.Equal(t, "resolved", resolved.Data.Entry.Status)
+	assert.Equal(t, "Driver was reassigned", resolved.Data.Entry.ResolutionNote)
+	assert.NotNil(t, resolved.Data.Entry.ResolvedAt)
+
+	assert.Len(t, listReports("&status=open"), 2)
+	assert.Len(t, listReports("&status=resolved"), 1)
+
+	resp, _ = doRequest(t, http.MethodPost, server.URL+"/api/admin/problem-reports/99999/resolve?key=ADMIN")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// CSV export
+	resp, body = doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports/export?key=ADMIN")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, problemReportCSVHeader, records[0])
+
+	// JSON export with a filter
+	resp, body = doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports/export?key=ADMIN&format=json&type=stop")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var exported []models.ProblemReport
+	require.NoError(t, json.Unmarshal(body, &exported))
+	assert.Len(t, exported, 2)
+
+	resp, _ = doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports/export?key=ADMIN&format=xml")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestProblemReportCSVExportEscapesFormulas(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	endpoint := "/api/where/report-problem-with-trip/25_trip1.json?key=TEST&code=other&userLat=40.58&userLon=-122.39" +
+		"&userComment=" + url.QueryEscape(`=HYPERLINK("http://example.com","click")`) +
+		"&userVehicleNumber=" + url.QueryEscape("@SUM(1+1)")
+	resp, _ := doRequest(t, http.MethodGet, server.URL+endpoint)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, body := doRequest(t, http.MethodGet, server.URL+"/api/admin/problem-reports/export?key=ADMIN")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	column := func(name string) string {
+		for i, header := range problemReportCSVHeader {
+			if header == name {
+				return records[1][i]
+			}
+		}
+		t.Fatalf("no %s column", name)
+		return ""
+	}
+	assert.Equal(t, `'=HYPERLINK("http://example.com","click")`, column("userComment"))
+	assert.Equal(t, "'@SUM(1+1)", column("userVehicleNumber"))
+	assert.Equal(t, "-122.39", column("userLon"), "numbers are exported as they are")
+}
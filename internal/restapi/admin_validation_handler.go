@@ -0,0 +1,49 @@
+package restapi
+
+import (
+	"database/sql"
+	"net/http"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+)
+
+// adminListValidationFindingsHandler lists the problems found in the static feeds when they were
+// imported, optionally only those of one feed or severity.
+func (api *RestAPI) adminListValidationFindingsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := gtfsdb.ListValidationFindingsParams{}
+
+	if feedID := query.Get("feedId"); feedID != "" {
+		params.FeedID = sql.NullString{String: feedID, Valid: true}
+	}
+	switch severity := query.Get("severity"); severity {
+	case "":
+	case gtfsdb.SeverityError, gtfsdb.SeverityWarning, gtfsdb.SeverityInfo:
+		params.Severity = sql.NullString{String: severity, Valid: true}
+	default:
+		api.validationErrorResponse(w, r, map[string][]string{"severity": invalidFieldValue("severity")})
+		return
+	}
+
+	findings, err := api.GtfsManager.GtfsDB().Queries.ListValidationFindings(r.Context(), params)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	list := make([]models.ValidationFinding, 0, len(findings))
+	for _, finding := range findings {
+		list = append(list, models.ValidationFinding{
+			ID:       finding.ID,
+			FeedID:   finding.SourceFeedID.String,
+			Severity: finding.Severity,
+			Code:     finding.Code,
+			FileName: finding.FileName.String,
+			EntityID: finding.EntityID.String,
+			Message:  finding.Message,
+		})
+	}
+
+	api.sendResponse(w, r, models.NewListResponse(list, models.NewEmptyReferences()))
+}
@@ -0,0 +1,47 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestAdminValidationFindings(t *testing.T) {
+	_, server := newProblemReportTestServer(t)
+
+	resp, _ := doRequest(t, http.MethodGet, server.URL+"/api/admin/validation.json?key=TEST")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	listFindings := func(query string) []models.ValidationFinding {
+		resp, body := doRequest(t, http.MethodGet, server.URL+"/api/admin/validation.json?key=ADMIN"+query)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var decoded struct {
+			Data struct {
+				List []models.ValidationFinding `json:"list"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		return decoded.Data.List
+	}
+
+	// The RABA feed has stops that no trip serves
+	findings := listFindings("")
+	require.NotEmpty(t, findings)
+	finding := findings[0]
+	assert.Equal(t, "default", finding.FeedID)
+	assert.Equal(t, "warning", finding.Severity)
+	assert.Equal(t, "stop_without_service", finding.Code)
+	assert.Equal(t, "stops.txt", finding.FileName)
+	assert.NotEmpty(t, finding.EntityID)
+
+	assert.Len(t, listFindings("&severity=warning"), len(findings))
+	assert.Empty(t, listFindings("&severity=error"))
+	assert.Empty(t, listFindings("&feedId=other"))
+
+	resp, _ = doRequest(t, http.MethodGet, server.URL+"/api/admin/validation.json?key=ADMIN&severity=fatal")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -14,7 +14,13 @@ func (api *RestAPI) agenciesWithCoverageHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	agencies, err := api.GtfsManager.GtfsDB.Queries.ListAgencies(ctx)
+	agencies, err := api.GtfsManager.GtfsDB().Queries.ListAgencies(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	feedInfo, err := api.currentFeedInfo(ctx)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -25,10 +31,9 @@ func (api *RestAPI) agenciesWithCoverageHandler(w http.ResponseWriter, r *http.R
 	var agencyReferences []models.AgencyReference
 
 	for _, a := range agencies {
-		agenciesWithCoverage = append(
-			agenciesWithCoverage,
-			models.NewAgencyCoverage(a.ID, lat, latSpan, lon, lonSpan),
-		)
+		coverage := models.NewAgencyCoverage(a.ID, lat, latSpan, lon, lonSpan)
+		coverage.FeedInfo = feedInfo
+		agenciesWithCoverage = append(agenciesWithCoverage, coverage)
 
 		agencyReferences = append(
 			agencyReferences,
@@ -37,6 +37,13 @@ func TestAgenciesWithCoverageHandlerEndToEnd(t *testing.T) {
 	assert.InDelta(t, -122.101745, agencyCoverage["lon"], 1e-8)
 	assert.InDelta(t, 0.9914899999999989, agencyCoverage["lonSpan"], 1e-8)
 
+	feedInfo, ok := agencyCoverage["feedInfo"].(map[string]interface{})
+	require.True(t, ok, "feedInfo should be present")
+	assert.Equal(t, "Arcadis, Inc.", feedInfo["publisherName"])
+	assert.Equal(t, "20250421", feedInfo["version"])
+	assert.Equal(t, "20250101", feedInfo["startDate"])
+	assert.Equal(t, "20251231", feedInfo["endDate"])
+
 	refs, ok := data["references"].(map[string]interface{})
 	require.True(t, ok)
 
@@ -108,31 +108,31 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 		return
 	}
 
-	stop, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopCode)
+	stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopCode)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
-	trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, tripID)
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	route, err := api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, trip.RouteID)
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, trip.RouteID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
-	stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesForTrip(ctx, tripID)
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -184,10 +184,11 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 	serviceDateMillis := serviceDate.Unix() * 1000
 
 	// Service date is a "date" only, so get midnight in agency's TZ
+	localServiceDate := serviceDate.In(loc)
 	serviceMidnight := time.Date(
-		serviceDate.Year(),
-		serviceDate.Month(),
-		serviceDate.Day(),
+		localServiceDate.Year(),
+		localServiceDate.Month(),
+		localServiceDate.Day(),
 		0, 0, 0, 0,
 		loc,
 	)
@@ -232,27 +233,38 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 	}
 
 	if vehicle != nil && vehicle.Trip != nil {
-		vehicleID = vehicle.ID.ID
+		vehicleID = gtfsdb.UnscopedID(vehicle.ID.ID)
 		predicted = true
 	}
 
 	status, _ := api.BuildTripStatus(ctx, agencyID, tripID, serviceDate, currentTime)
-	// TODO: Currently we set the predicted time to the scheduled time, this is not accurate
 	if status != nil {
 		tripStatus = status
-		predictedArrivalTime = scheduledArrivalTimeMs
-		predictedDepartureTime = scheduledDepartureTimeMs
+	}
 
-		if vehicle != nil && vehicle.Position != nil {
-			// TODO: Calculate actual distance and stops away
-			distanceFromStop = 0
-			numberOfStopsAway = 0
-		}
+	if distance, stopsAway, ok := api.calculateDistanceAndStopsAway(ctx, vehicle, tripID, targetStopTime.StopSequence, serviceMidnight); ok {
+		distanceFromStop = distance
+		numberOfStopsAway = stopsAway
 	}
 
-	if !predicted {
-		predictedArrivalTime = 0
-		predictedDepartureTime = 0
+	prediction, err := api.GtfsManager.PredictTrip(ctx, tripID, serviceMidnight, currentTime)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	arrivalTime, departureTime, hasPrediction := predictedTimesAtStop(
+		prediction, stopCode, targetStopTime.StopSequence, scheduledArrivalTime, scheduledDepartureTime, tripStatus)
+	if hasPrediction {
+		predicted = true
+	}
+	// Visits that won't happen have nothing to predict
+	visitStatus := api.arrivalStatus(tripID, serviceMidnight, stopCode, targetStopTime.StopSequence)
+	if visitStatus != arrivalStatusDefault {
+		predicted = false
+	}
+	if predicted {
+		predictedArrivalTime = arrivalTime.UnixMilli()
+		predictedDepartureTime = departureTime.UnixMilli()
 	}
 
 	totalStopsInTrip := len(stopTimes)
@@ -281,13 +293,14 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 		numberOfStopsAway,
 		blockTripSequence,
 		distanceFromStop,
-		"default", // status
-		"",        // occupancyStatus
-		"",        // predictedOccupancy
-		"",        // historicalOccupancy
+		visitStatus,
+		"", // occupancyStatus
+		"", // predictedOccupancy
+		"", // historicalOccupancy
 		tripStatus,
-		[]string{},
+		api.GetSituationIDsForTrip(tripID),
 	)
+	arrival.Frequency = api.frequencyForTripInstance(ctx, tripID, serviceMidnight, currentTime)
 
 	references := models.NewEmptyReferences()
 
@@ -320,7 +333,7 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 	if tripStatus != nil && tripStatus.ActiveTripID != "" {
 		_, activeTripID, err := utils.ExtractAgencyIDAndCodeID(tripStatus.ActiveTripID)
 		if err == nil && activeTripID != tripID {
-			activeTrip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, activeTripID)
+			activeTrip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, activeTripID)
 			if err == nil {
 				activeTripRef := models.NewTripReference(
 					utils.FormCombinedID(agencyID, activeTripID),
@@ -365,12 +378,12 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 	}
 
 	for stopID := range stopIDSet {
-		stopData, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
+		stopData, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
 		if err != nil {
 			continue
 		}
 
-		routesForThisStop, _ := api.GtfsManager.GtfsDB.Queries.GetRoutesForStops(ctx, []string{stopID})
+		routesForThisStop, _ := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, []string{stopID})
 		combinedRouteIDs := make([]string, len(routesForThisStop))
 		for i, route := range routesForThisStop {
 			combinedRouteIDs[i] = utils.FormCombinedID(agencyID, route.ID)
@@ -396,6 +409,7 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 			Code:               stopData.Code.String,
 			Direction:          api.calculateStopDirection(r.Context(), stopData.ID),
 			LocationType:       int(stopData.LocationType.Int64),
+			Parent:             utils.FormCombinedID(agencyID, stopData.ParentStation.String),
 			WheelchairBoarding: models.UnknownValue,
 			RouteIDs:           combinedRouteIDs,
 			StaticRouteIDs:     combinedRouteIDs,
@@ -420,6 +434,8 @@ func (api *RestAPI) arrivalAndDepartureForStopHandler(w http.ResponseWriter, r *
 		references.Routes = append(references.Routes, routeRef)
 	}
 
+	references.Situations = api.BuildSituationReferences(ctx, arrival.SituationIDs)
+
 	response := models.NewEntryResponse(arrival, references)
 	api.sendResponse(w, r, response)
 }
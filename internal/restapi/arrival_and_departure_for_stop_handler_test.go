@@ -248,3 +248,82 @@ func TestArrivalAndDepartureForStopHandlerRequiresServiceDate(t *testing.T) {
 	assert.Len(t, errorResponse.FieldErrors["serviceDate"], 1)
 	assert.Equal(t, "missingRequiredField", errorResponse.FieldErrors["serviceDate"][0])
 }
+
+func TestArrivalAndDepartureForStopHandlerPredictsFromTripUpdates(t *testing.T) {
+	api, cleanup := createTestApiWithRealTimeData(t)
+	defer cleanup()
+
+	// The fixture predicts the trip reaches stop 9902, scheduled at 14:25, at 14:34:01
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 8, 0, 0, 0, 0, loc)
+	currentTime := time.Date(2025, 6, 8, 14, 0, 0, 0, loc)
+
+	// The next stop is scheduled at 15:25 with a ten minute dwell, which absorbs the delay
+	_, model := serveApiAndRetrieveEndpoint(t, api, fmt.Sprintf(
+		"/api/where/arrival-and-departure-for-stop/25_9901.json?key=TEST&tripId=25_28c61524-6da8-4506-9a92-22f2f6e91872&serviceDate=%d&time=%d",
+		serviceDate.UnixMilli(), currentTime.UnixMilli()))
+	require.Equal(t, http.StatusOK, model.Code)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+
+	assert.Equal(t, true, entry["predicted"])
+	assert.Equal(t, float64(time.Date(2025, 6, 8, 15, 25, 0, 0, loc).UnixMilli()), entry["scheduledArrivalTime"])
+	assert.Equal(t, float64(time.Date(2025, 6, 8, 15, 34, 1, 0, loc).UnixMilli()), entry["predictedArrivalTime"])
+	assert.Equal(t, float64(time.Date(2025, 6, 8, 15, 35, 0, 0, loc).UnixMilli()), entry["predictedDepartureTime"])
+
+	tripStatus, ok := entry["tripStatus"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(9*60+1), tripStatus["scheduleDeviation"], "on the way to stop 9902")
+}
+
+func TestArrivalAndDepartureForStopHandlerMarksSkippedStops(t *testing.T) {
+	api, cleanup := createTestApiWithRealTimeData(t)
+	defer cleanup()
+
+	// The fixture has the trip skip stops 3027 through 3031 on June 9
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 9, 0, 0, 0, 0, loc)
+	currentTime := time.Date(2025, 6, 9, 6, 0, 0, 0, loc)
+	tripID := "25_03969589-98dc-4fcd-a1c2-ce084b4ca5d2"
+
+	_, model := serveApiAndRetrieveEndpoint(t, api, fmt.Sprintf(
+		"/api/where/arrival-and-departure-for-stop/25_3027.json?key=TEST&tripId=%s&serviceDate=%d&time=%d",
+		tripID, serviceDate.UnixMilli(), currentTime.UnixMilli()))
+	require.Equal(t, http.StatusOK, model.Code)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "SKIPPED", entry["status"])
+	assert.Equal(t, false, entry["predicted"])
+	assert.Equal(t, float64(0), entry["predictedArrivalTime"])
+
+	_, model = serveApiAndRetrieveEndpoint(t, api, fmt.Sprintf(
+		"/api/where/trip-details/%s.json?key=TEST&includeSchedule=true&serviceDate=%d&time=%d",
+		tripID, serviceDate.UnixMilli(), currentTime.UnixMilli()))
+	require.Equal(t, http.StatusOK, model.Code)
+
+	data, ok = model.Data.(map[string]interface{})
+	require.True(t, ok)
+	entry, ok = data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	schedule, ok := entry["schedule"].(map[string]interface{})
+	require.True(t, ok)
+	stopTimes, ok := schedule["stopTimes"].([]interface{})
+	require.True(t, ok)
+	statuses := make(map[string]interface{})
+	for _, st := range stopTimes {
+		stopTime := st.(map[string]interface{})
+		statuses[stopTime["stopId"].(string)] = stopTime["status"]
+	}
+	assert.Equal(t, "SKIPPED", statuses["25_3027"])
+	assert.Equal(t, "SKIPPED", statuses["25_3031"])
+	require.Contains(t, statuses, "25_3026")
+	assert.Nil(t, statuses["25_3026"], "stops the trip serves have no status")
+}
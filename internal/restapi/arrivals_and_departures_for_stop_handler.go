@@ -0,0 +1,370 @@
+package restapi
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// nearbyStopRadius is the search radius, in meters, used to populate nearbyStopIds.
+const nearbyStopRadius = 100
+
+// realtimeLookback widens the scheduled query window so that late-running trips whose
+// predicted times fall inside the requested window are still considered.
+const realtimeLookback = 30 * time.Minute
+
+func (api *RestAPI) arrivalsAndDeparturesForStopHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	// Validate ID
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, stopCode, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	params := api.parseArrivalAndDepartureParams(r)
+
+	stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopCode)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	var currentTime time.Time
+	if params.Time != nil {
+		currentTime = params.Time.In(loc)
+	} else {
+		currentTime = time.Now().In(loc)
+	}
+
+	windowStart := currentTime.Add(-time.Duration(params.MinutesBefore) * time.Minute)
+	windowEnd := currentTime.Add(time.Duration(params.MinutesAfter) * time.Minute)
+
+	arrivals := make([]models.ArrivalAndDeparture, 0)
+	tripRefs := make(map[string]*models.Trip)
+	statusStopIDs := []string{}
+
+	// Trips that run past midnight belong to the previous service date, so look at
+	// every service date that could have a stop time inside the window.
+	firstServiceDate := time.Date(windowStart.Year(), windowStart.Month(), windowStart.Day()-1, 0, 0, 0, 0, loc)
+	lastServiceDate := time.Date(windowEnd.Year(), windowEnd.Month(), windowEnd.Day(), 0, 0, 0, 0, loc)
+
+	for serviceMidnight := firstServiceDate; !serviceMidnight.After(lastServiceDate); serviceMidnight = serviceMidnight.AddDate(0, 0, 1) {
+		serviceIDs, err := api.GtfsManager.GetActiveServiceIDsForDate(ctx, serviceMidnight)
+		if err != nil {
+			api.serverErrorResponse(w, r, err)
+			return
+		}
+		if len(serviceIDs) == 0 {
+			continue
+		}
+
+		// Stop times are stored in nanoseconds since midnight of the service date
+		rows, err := api.GtfsManager.GtfsDB().Queries.GetArrivalsAndDeparturesForStop(ctx, gtfsdb.GetArrivalsAndDeparturesForStopParams{
+			StopID:      stopCode,
+			ServiceIds:  serviceIDs,
+			WindowStart: int64(windowStart.Add(-realtimeLookback).Sub(serviceMidnight)),
+			WindowEnd:   int64(windowEnd.Sub(serviceMidnight)),
+		})
+		if err != nil {
+			api.serverErrorResponse(w, r, err)
+			return
+		}
+
+		serviceDateMillis := serviceMidnight.UnixMilli()
+
+		for _, row := range rows {
+			visits := []frequencyStopTime{{
+				ArrivalTime:   time.Duration(row.ArrivalTime),
+				DepartureTime: time.Duration(row.DepartureTime),
+			}}
+			frequency := frequencyFromColumns(row.TripID, row.FrequencyStartTime, row.FrequencyEndTime, row.FrequencyHeadwaySecs, row.FrequencyExactTimes)
+			if frequency != nil {
+				visits = expandFrequencyStopTime(*frequency,
+					time.Duration(row.TripStartTime),
+					time.Duration(row.ArrivalTime),
+					time.Duration(row.DepartureTime),
+					windowStart.Add(-realtimeLookback).Sub(serviceMidnight),
+					windowEnd.Sub(serviceMidnight),
+					currentTime.Sub(serviceMidnight),
+				)
+			}
+
+			for _, visit := range visits {
+				scheduledArrivalTime := serviceMidnight.Add(visit.ArrivalTime)
+				scheduledDepartureTime := serviceMidnight.Add(visit.DepartureTime)
+
+				var (
+					predictedArrivalTime, predictedDepartureTime int64
+					predicted                                    bool
+					vehicleID                                    string
+					tripStatus                                   *models.TripStatusForTripDetails
+				)
+
+				// Only trips with a vehicle assigned carry realtime status
+				vehicle := api.GtfsManager.GetVehicleForTrip(row.TripID)
+				if frequency != nil && frequency.ExactTimes == scheduleBased && !vehicleServesFrequencyRun(vehicle, visit.TripStartTime) {
+					vehicle = nil
+				}
+				if vehicle != nil && vehicle.Trip != nil {
+					if vehicle.ID != nil {
+						vehicleID = gtfsdb.UnscopedID(vehicle.ID.ID)
+					}
+					predicted = true
+
+					status, err := api.BuildTripStatus(ctx, agencyID, row.TripID, serviceMidnight, currentTime)
+					if err == nil && status != nil {
+						tripStatus = status
+					}
+				}
+
+				// Frequency-based runs don't follow the stop times the prediction is built on
+				var prediction *gtfs.TripPrediction
+				if frequency == nil {
+					prediction, err = api.GtfsManager.PredictTrip(ctx, row.TripID, serviceMidnight, currentTime)
+					if err != nil {
+						api.serverErrorResponse(w, r, err)
+						return
+					}
+				}
+				arrivalTime, departureTime, hasPrediction := predictedTimesAtStop(
+					prediction, stopCode, row.StopSequence, scheduledArrivalTime, scheduledDepartureTime, tripStatus)
+				if hasPrediction {
+					predicted = true
+				}
+				// Visits that won't happen have nothing to predict
+				status := api.arrivalStatus(row.TripID, serviceMidnight, stopCode, row.StopSequence)
+				if status != arrivalStatusDefault {
+					predicted = false
+				}
+				if predicted {
+					predictedArrivalTime = arrivalTime.UnixMilli()
+					predictedDepartureTime = departureTime.UnixMilli()
+				}
+
+				if !isArrivalInWindow(scheduledArrivalTime.UnixMilli(), scheduledDepartureTime.UnixMilli(), windowStart, windowEnd) &&
+					!(predicted && isArrivalInWindow(predictedArrivalTime, predictedDepartureTime, windowStart, windowEnd)) {
+					continue
+				}
+
+				distanceFromStop, numberOfStopsAway, _ := api.calculateDistanceAndStopsAway(ctx, vehicle, row.TripID, row.StopSequence, serviceMidnight)
+
+				if tripStatus != nil {
+					if tripStatus.ClosestStop != "" {
+						if _, closestStopID, err := utils.ExtractAgencyIDAndCodeID(tripStatus.ClosestStop); err == nil {
+							statusStopIDs = append(statusStopIDs, closestStopID)
+						}
+					}
+					if tripStatus.NextStop != "" {
+						if _, nextStopID, err := utils.ExtractAgencyIDAndCodeID(tripStatus.NextStop); err == nil {
+							statusStopIDs = append(statusStopIDs, nextStopID)
+						}
+					}
+					if tripStatus.ActiveTripID != "" {
+						if _, activeTripID, err := utils.ExtractAgencyIDAndCodeID(tripStatus.ActiveTripID); err == nil && activeTripID != row.TripID {
+							if activeTrip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, activeTripID); err == nil {
+								tripRefs[activeTripID] = models.NewTripReference(
+									utils.FormCombinedID(agencyID, activeTrip.ID),
+									utils.FormCombinedID(agencyID, activeTrip.RouteID),
+									utils.FormCombinedID(agencyID, activeTrip.ServiceID),
+									activeTrip.TripHeadsign.String,
+									activeTrip.TripShortName.String,
+									activeTrip.DirectionID.Int64,
+									utils.FormCombinedID(agencyID, activeTrip.BlockID.String),
+									utils.FormCombinedID(agencyID, activeTrip.ShapeID.String),
+								)
+							}
+						}
+					}
+				}
+
+				tripHeadsign := row.TripHeadsign.String
+				if row.StopHeadsign.Valid && row.StopHeadsign.String != "" {
+					tripHeadsign = row.StopHeadsign.String
+				}
+
+				arrival := models.NewArrivalAndDeparture(
+					utils.FormCombinedID(agencyID, row.RouteID),
+					row.RouteShortName.String,
+					row.RouteLongName.String,
+					utils.FormCombinedID(agencyID, row.TripID),
+					tripHeadsign,
+					utils.FormCombinedID(agencyID, stopCode),
+					vehicleID,
+					serviceDateMillis,
+					scheduledArrivalTime.UnixMilli(),
+					scheduledDepartureTime.UnixMilli(),
+					predictedArrivalTime,
+					predictedDepartureTime,
+					currentTime.UnixMilli(),
+					predicted,
+					true,                    // arrivalEnabled
+					true,                    // departureEnabled
+					int(row.StopSequence)-1, // Zero-based index
+					int(row.TotalStopsInTrip),
+					numberOfStopsAway,
+					api.calculateBlockTripSequence(ctx, row.TripID, serviceMidnight),
+					distanceFromStop,
+					status,
+					"", // occupancyStatus
+					"", // predictedOccupancy
+					"", // historicalOccupancy
+					tripStatus,
+					api.GetSituationIDsForTrip(row.TripID),
+				)
+				if frequency != nil && frequency.ExactTimes == frequencyBased {
+					arrival.Frequency = newFrequencyModel(serviceMidnight, *frequency)
+				}
+				arrivals = append(arrivals, *arrival)
+
+				tripRefs[row.TripID] = models.NewTripReference(
+					utils.FormCombinedID(agencyID, row.TripID),
+					utils.FormCombinedID(agencyID, row.RouteID),
+					utils.FormCombinedID(agencyID, row.ServiceID),
+					row.TripHeadsign.String,
+					row.TripShortName.String,
+					row.DirectionID.Int64,
+					utils.FormCombinedID(agencyID, row.BlockID.String),
+					utils.FormCombinedID(agencyID, row.ShapeID.String),
+				)
+			}
+		}
+	}
+
+	realtimeArrivals, realtimeTripRefs, err := api.realtimeOnlyArrivals(ctx, agencyID, stopCode, loc, windowStart, windowEnd, currentTime)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	arrivals = append(arrivals, realtimeArrivals...)
+	for tripID, tripRef := range realtimeTripRefs {
+		tripRefs[tripID] = tripRef
+	}
+
+	sort.SliceStable(arrivals, func(i, j int) bool {
+		return arrivalSortTime(arrivals[i]) < arrivalSortTime(arrivals[j])
+	})
+
+	nearbyStopIDs := []string{}
+	nearbyStops := api.GtfsManager.GetStopsForLocation(ctx, stop.Lat, stop.Lon, nearbyStopRadius, 0, 0, "", 100, false)
+	for _, nearbyStop := range nearbyStops {
+		if nearbyStop.Id == stop.ID {
+			continue
+		}
+		nearbyStopIDs = append(nearbyStopIDs, nearbyStop.Id)
+	}
+
+	situationIDs := []string{}
+	for _, alert := range api.GtfsManager.GetAlertsForStop(stop.ID) {
+		if alert.ID != "" {
+			situationIDs = append(situationIDs, alert.ID)
+		}
+	}
+
+	combinedNearbyStopIDs := make([]string, len(nearbyStopIDs))
+	for i, nearbyStopID := range nearbyStopIDs {
+		combinedNearbyStopIDs[i] = utils.FormCombinedID(agencyID, nearbyStopID)
+	}
+
+	entry := models.StopWithArrivalsAndDepartures{
+		ArrivalsAndDepartures: arrivals,
+		NearbyStopIDs:         combinedNearbyStopIDs,
+		SituationIDs:          situationIDs,
+		StopID:                utils.FormCombinedID(agencyID, stop.ID),
+	}
+
+	// Build references
+	references := models.NewEmptyReferences()
+
+	references.Agencies = append(references.Agencies, models.NewAgencyReference(
+		agency.ID,
+		agency.Name,
+		agency.Url,
+		agency.Timezone,
+		agency.Lang.String,
+		agency.Phone.String,
+		agency.Email.String,
+		agency.FareUrl.String,
+		"",
+		false,
+	))
+
+	referenceStopIDs := append([]string{stop.ID}, nearbyStopIDs...)
+	referenceStopIDs = append(referenceStopIDs, statusStopIDs...)
+
+	stops, uniqueRouteMap, err := BuildStopReferencesAndRouteIDsForStops(api, ctx, agencyID, referenceStopIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	references.Stops = stops
+
+	for _, route := range uniqueRouteMap {
+		references.Routes = append(references.Routes, models.NewRoute(
+			utils.FormCombinedID(agencyID, route.ID),
+			agencyID,
+			route.ShortName.String,
+			route.LongName.String,
+			route.Desc.String,
+			models.RouteType(route.Type),
+			route.Url.String,
+			route.Color.String,
+			route.TextColor.String,
+			route.ShortName.String,
+		))
+	}
+
+	for _, tripRef := range tripRefs {
+		references.Trips = append(references.Trips, tripRef)
+	}
+
+	referencedSituationIDs := append([]string{}, situationIDs...)
+	for _, arrival := range arrivals {
+		referencedSituationIDs = append(referencedSituationIDs, arrival.SituationIDs...)
+	}
+	references.Situations = api.BuildSituationReferences(ctx, referencedSituationIDs)
+
+	api.sendResponse(w, r, models.NewEntryResponse(entry, references))
+}
+
+// isArrivalInWindow reports whether the interval between arrival and departure, in
+// milliseconds since the epoch, overlaps the given window.
+func isArrivalInWindow(arrivalMillis, departureMillis int64, windowStart, windowEnd time.Time) bool {
+	return departureMillis >= windowStart.UnixMilli() && arrivalMillis <= windowEnd.UnixMilli()
+}
+
+// arrivalSortTime returns the time used to order arrivals: the predicted arrival when
+// one is available, otherwise the scheduled arrival.
+func arrivalSortTime(arrival models.ArrivalAndDeparture) int64 {
+	if arrival.Predicted && arrival.PredictedArrivalTime > 0 {
+		return arrival.PredictedArrivalTime
+	}
+	return arrival.ScheduledArrivalTime
+}
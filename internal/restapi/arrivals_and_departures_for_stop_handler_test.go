@@ -0,0 +1,143 @@
+package restapi
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// arrivalsTestTime is a weekday morning inside the RABA fixture's service period.
+func arrivalsTestTime(t *testing.T) time.Time {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	return time.Date(2025, 6, 12, 6, 0, 0, 0, loc)
+}
+
+func TestArrivalsAndDeparturesForStopHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/arrivals-and-departures-for-stop/25_1030.json?key=invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestArrivalsAndDeparturesForStopHandlerEndToEnd(t *testing.T) {
+	currentTime := arrivalsTestTime(t)
+	timeMs := currentTime.UnixMilli()
+
+	_, resp, model := serveAndRetrieveEndpoint(t,
+		"/api/where/arrivals-and-departures-for-stop/25_1030.json?key=TEST&minutesBefore=10&minutesAfter=60&time="+
+			strconv.FormatInt(timeMs, 10))
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+	assert.Equal(t, "OK", model.Text)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_1030", entry["stopId"])
+	assert.NotNil(t, entry["nearbyStopIds"])
+	assert.NotNil(t, entry["situationIds"])
+
+	arrivals, ok := entry["arrivalsAndDepartures"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, arrivals)
+
+	windowStart := currentTime.Add(-10 * time.Minute).UnixMilli()
+	windowEnd := currentTime.Add(60 * time.Minute).UnixMilli()
+
+	var previousArrival float64
+	for _, a := range arrivals {
+		arrival, ok := a.(map[string]interface{})
+		require.True(t, ok)
+
+		assert.Equal(t, "25_1030", arrival["stopId"])
+		assert.NotEmpty(t, arrival["tripId"])
+		assert.NotEmpty(t, arrival["routeId"])
+		assert.NotZero(t, arrival["serviceDate"])
+		assert.NotZero(t, arrival["totalStopsInTrip"])
+
+		scheduledArrival := arrival["scheduledArrivalTime"].(float64)
+		scheduledDeparture := arrival["scheduledDepartureTime"].(float64)
+		assert.GreaterOrEqual(t, scheduledDeparture, float64(windowStart))
+		assert.LessOrEqual(t, scheduledArrival, float64(windowEnd))
+		assert.GreaterOrEqual(t, scheduledArrival, previousArrival, "arrivals should be ordered by time")
+		previousArrival = scheduledArrival
+	}
+
+	references, ok := data["references"].(map[string]interface{})
+	require.True(t, ok)
+
+	agencies, ok := references["agencies"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, agencies)
+
+	routes, ok := references["routes"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, routes)
+
+	trips, ok := references["trips"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, trips)
+
+	stops, ok := references["stops"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, stops)
+
+	stopIDs := make([]string, 0, len(stops))
+	for _, s := range stops {
+		stop, ok := s.(map[string]interface{})
+		require.True(t, ok)
+		stopIDs = append(stopIDs, stop["id"].(string))
+	}
+	assert.Contains(t, stopIDs, "25_1030")
+}
+
+func TestArrivalsAndDeparturesForStopHandlerRespectsWindow(t *testing.T) {
+	api := createTestApi(t)
+	timeMs := strconv.FormatInt(arrivalsTestTime(t).UnixMilli(), 10)
+
+	countArrivals := func(query string) int {
+		resp, model := serveApiAndRetrieveEndpoint(t, api,
+			"/api/where/arrivals-and-departures-for-stop/25_1030.json?key=TEST&time="+timeMs+query)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		data := model.Data.(map[string]interface{})
+		entry := data["entry"].(map[string]interface{})
+		return len(entry["arrivalsAndDepartures"].([]interface{}))
+	}
+
+	narrow := countArrivals("&minutesBefore=0&minutesAfter=5")
+	wide := countArrivals("&minutesBefore=30&minutesAfter=240")
+
+	assert.Greater(t, wide, narrow)
+}
+
+func TestArrivalsAndDeparturesForStopHandlerOutsideServicePeriod(t *testing.T) {
+	timeMs := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC).UnixMilli()
+
+	_, resp, model := serveAndRetrieveEndpoint(t,
+		"/api/where/arrivals-and-departures-for-stop/25_1030.json?key=TEST&time="+strconv.FormatInt(timeMs, 10))
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	entry := data["entry"].(map[string]interface{})
+	assert.Empty(t, entry["arrivalsAndDepartures"])
+}
+
+func TestArrivalsAndDeparturesForStopHandlerWithInvalidStopID(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/arrivals-and-departures-for-stop/25_invalid.json?key=TEST")
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, model.Code)
+	assert.Equal(t, "resource not found", model.Text)
+	assert.Nil(t, model.Data)
+}
@@ -20,7 +20,7 @@ func (api *RestAPI) blockHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := api.GtfsManager.GtfsDB.Queries.GetBlockDetails(ctx, sql.NullString{String: blockID, Valid: true})
+	block, err := api.GtfsManager.GtfsDB().Queries.GetBlockDetails(ctx, sql.NullString{String: blockID, Valid: true})
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -109,6 +109,8 @@ func transformBlockToEntry(block []gtfsdb.GetBlockDetailsRow, blockID, agencyID
 						DropOffType:   int(stop.DropOffType.Int64),
 						PickupType:    int(stop.PickupType.Int64),
 						StopID:        utils.FormCombinedID(agencyID, stop.StopID),
+						TripID:        utils.FormCombinedID(agencyID, tripID),
+						StopSequence:  stop.StopSequence,
 					},
 				}
 				blockStopTimes = append(blockStopTimes, blockStopTime)
@@ -155,12 +157,12 @@ func (api *RestAPI) getReferences(ctx context.Context, agencyID string, block []
 	for stopID := range stopIDs {
 		stopIDsArr = append(stopIDsArr, stopID)
 	}
-	agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 	if err != nil {
 		return models.ReferencesModel{}, err
 	}
 
-	routesArr, err := api.GtfsManager.GtfsDB.Queries.GetRoutesForStops(ctx, stopIDsArr)
+	routesArr, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, stopIDsArr)
 
 	if err != nil {
 		return models.ReferencesModel{}, err
@@ -187,7 +189,7 @@ func (api *RestAPI) getReferences(ctx context.Context, agencyID string, block []
 
 	var stops []models.Stop
 	for stopID := range stopIDs {
-		stop, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
+		stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
 		if err != nil {
 			return models.ReferencesModel{}, err
 		}
@@ -198,12 +200,13 @@ func (api *RestAPI) getReferences(ctx context.Context, agencyID string, block []
 			Lat:       stop.Lat,
 			Lon:       stop.Lon,
 			Direction: api.calculateStopDirection(ctx, stop.ID),
+			Parent:    utils.FormCombinedID(agencyID, stop.ParentStation.String),
 		})
 	}
 
 	var trips []interface{}
 	for tripID := range tripIDs {
-		trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, tripID)
+		trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
 		if err != nil {
 			return models.ReferencesModel{}, err
 		}
@@ -0,0 +1,38 @@
+package restapi
+
+import (
+	"net/http"
+
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) cancelAlarmHandler(w http.ResponseWriter, r *http.Request) {
+	id := utils.ExtractIDFromParams(r)
+
+	if err := utils.ValidateID(id); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	_, alarmID, err := utils.ExtractAgencyIDAndCodeID(id)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	deleted, err := api.GtfsManager.GtfsDB().UserQueries.DeleteArrivalAlarm(r.Context(), alarmID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	if deleted == 0 {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	api.sendResponse(w, r, models.NewOKResponse(nil))
+}
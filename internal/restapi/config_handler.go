@@ -0,0 +1,104 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"maglev.onebusaway.org/internal/models"
+)
+
+// configHandler identifies the static feed the server is running: its version, publisher and
+// the service dates it covers.
+func (api *RestAPI) configHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	feedInfo, err := api.currentFeedInfo(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	imports, err := api.GtfsManager.GtfsDB().Queries.ListImportMetadata(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	agencies, err := api.GtfsManager.GtfsDB().Queries.ListAgencies(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	loc := time.UTC
+	config := models.Config{
+		FeedInfo: feedInfo,
+		Feeds:    make([]models.FeedImport, 0, len(imports)),
+	}
+	for _, metadata := range imports {
+		importTime := time.Unix(metadata.ImportTime, 0).UnixMilli()
+		config.Feeds = append(config.Feeds, models.FeedImport{
+			ID:         metadata.FeedID,
+			FileHash:   metadata.FileHash,
+			ImportTime: importTime,
+		})
+		if importTime >= config.ImportTime {
+			config.ID = metadata.FileHash
+			config.FileHash = metadata.FileHash
+			config.ImportTime = importTime
+		}
+	}
+	if len(agencies) > 0 {
+		config.Name = agencies[0].Name
+		if agencyLoc, err := time.LoadLocation(agencies[0].Timezone); err == nil {
+			loc = agencyLoc
+		}
+	}
+	if feedInfo != nil {
+		config.Name = feedInfo.PublisherName
+		if feedInfo.Version != "" {
+			config.ID = feedInfo.Version
+		}
+	}
+
+	startDate, endDate, err := api.GtfsManager.GtfsDB().FeedValidityWindow(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	if date, err := time.ParseInLocation("20060102", startDate, loc); err == nil {
+		config.ServiceDateFrom = date.UnixMilli()
+	}
+	if date, err := time.ParseInLocation("20060102", endDate, loc); err == nil {
+		config.ServiceDateTo = date.UnixMilli()
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(config, models.NewEmptyReferences()))
+}
+
+// currentFeedInfo returns the feed_info.txt entry of the imported feed, or nil when the feed
+// does not have one.
+func (api *RestAPI) currentFeedInfo(ctx context.Context) (*models.FeedInfo, error) {
+	feedInfo, err := api.GtfsManager.GtfsDB().Queries.GetFeedInfo(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &models.FeedInfo{
+		FeedID:        feedInfo.FeedID.String,
+		PublisherName: feedInfo.FeedPublisherName,
+		PublisherURL:  feedInfo.FeedPublisherUrl,
+		Lang:          feedInfo.FeedLang,
+		DefaultLang:   feedInfo.DefaultLang.String,
+		StartDate:     feedInfo.FeedStartDate.String,
+		EndDate:       feedInfo.FeedEndDate.String,
+		Version:       feedInfo.FeedVersion.String,
+		ContactEmail:  feedInfo.FeedContactEmail.String,
+		ContactURL:    feedInfo.FeedContactUrl.String,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/config.json?key=invalid")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+}
+
+func TestConfigHandlerEndToEnd(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/config.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.Equal(t, "20250421", entry["id"], "the feed version identifies the feed")
+	assert.Equal(t, "Arcadis, Inc.", entry["name"])
+	assert.NotEmpty(t, entry["fileHash"])
+	assert.Greater(t, entry["importTime"], float64(0))
+
+	feeds := entry["feeds"].([]interface{})
+	require.Len(t, feeds, 1)
+	feed := feeds[0].(map[string]interface{})
+	assert.Equal(t, "default", feed["id"])
+	assert.Equal(t, entry["fileHash"], feed["fileHash"])
+	assert.Equal(t, entry["importTime"], feed["importTime"])
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	assert.Equal(t, float64(time.Date(2025, 1, 1, 0, 0, 0, 0, loc).UnixMilli()), entry["serviceDateFrom"])
+	assert.Equal(t, float64(time.Date(2025, 12, 31, 0, 0, 0, 0, loc).UnixMilli()), entry["serviceDateTo"])
+
+	feedInfo := entry["feedInfo"].(map[string]interface{})
+	assert.Equal(t, "redding-ca-us", feedInfo["feedId"])
+	assert.Equal(t, "http://arcadis.com/", feedInfo["publisherUrl"])
+	assert.Equal(t, "20250101", feedInfo["startDate"])
+	assert.Equal(t, "20251231", feedInfo["endDate"])
+	assert.Equal(t, "otpdtsupport@arcadis.com", feedInfo["contactEmail"])
+}
@@ -136,7 +136,7 @@ func TestContextCancellationDuringDatabaseQueries(t *testing.T) {
 		cancel()
 
 		// Try to execute a database query with cancelled context
-		_, err := api.GtfsManager.GtfsDB.Queries.ListAgencies(ctx)
+		_, err := api.GtfsManager.GtfsDB().Queries.ListAgencies(ctx)
 
 		// The query should either succeed (if fast enough) or return a context error
 		if err != nil {
@@ -153,7 +153,7 @@ func TestContextCancellationDuringDatabaseQueries(t *testing.T) {
 		time.Sleep(time.Microsecond)
 
 		// Try to execute a database query with timeout context
-		_, err := api.GtfsManager.GtfsDB.Queries.ListAgencies(ctx)
+		_, err := api.GtfsManager.GtfsDB().Queries.ListAgencies(ctx)
 
 		// The query should either succeed (if very fast) or return a timeout error
 		if err != nil {
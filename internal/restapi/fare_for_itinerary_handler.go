@@ -0,0 +1,158 @@
+package restapi
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// maxFareItineraryLegs bounds the work a single fare-for-itinerary request can cause.
+const maxFareItineraryLegs = 10
+
+// fareForItineraryHandler prices a multi-leg itinerary with GTFS Fares v2. Each leg is given
+// by repeating the tripId, fromStopId and toStopId parameters in order; serviceDate applies
+// to every leg and defaults to today.
+func (api *RestAPI) fareForItineraryHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tripIDs, fromStopIDs, toStopIDs := query["tripId"], query["fromStopId"], query["toStopId"]
+
+	fieldErrors := make(map[string][]string)
+	if len(tripIDs) == 0 {
+		fieldErrors["tripId"] = []string{"missingRequiredField"}
+	} else if len(tripIDs) > maxFareItineraryLegs {
+		fieldErrors["tripId"] = []string{fmt.Sprintf("at most %d legs are allowed", maxFareItineraryLegs)}
+	}
+	if len(fromStopIDs) != len(tripIDs) || len(toStopIDs) != len(tripIDs) {
+		fieldErrors["legs"] = []string{"each leg needs a tripId, fromStopId and toStopId"}
+	}
+
+	var serviceDateMillis int64
+	if serviceDate := query.Get("serviceDate"); serviceDate != "" {
+		parsed, err := strconv.ParseInt(serviceDate, 10, 64)
+		if err != nil {
+			fieldErrors["serviceDate"] = []string{"invalid serviceDate"}
+		}
+		serviceDateMillis = parsed
+	}
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	ctx := r.Context()
+	legs := make([]gtfs.FareLeg, len(tripIDs))
+	locations := make(map[string]*time.Location)
+	for i := range tripIDs {
+		legField := fmt.Sprintf("legs[%d]", i)
+		ids := make([]string, 3)
+		for j, combinedID := range []string{tripIDs[i], fromStopIDs[i], toStopIDs[i]} {
+			if err := utils.ValidateID(combinedID); err != nil {
+				fieldErrors[legField] = append(fieldErrors[legField], err.Error())
+				continue
+			}
+			agencyID, id, err := utils.ExtractAgencyIDAndCodeID(combinedID)
+			if err != nil {
+				fieldErrors[legField] = append(fieldErrors[legField], err.Error())
+				continue
+			}
+			ids[j] = id
+			if j == 0 {
+				if _, ok := locations[agencyID]; !ok {
+					locations[agencyID] = api.agencyLocation(r, agencyID)
+				}
+				legs[i].ServiceDate = serviceDateMidnight(serviceDateMillis, locations[agencyID])
+			}
+		}
+		legs[i].TripID, legs[i].FromStopID, legs[i].ToStopID = ids[0], ids[1], ids[2]
+	}
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	fare, err := api.GtfsManager.PriceItinerary(ctx, legs)
+	var legErr *gtfs.FareLegError
+	switch {
+	case errors.Is(err, gtfs.ErrNoFaresV2Data):
+		api.sendErrorResponse(w, r, http.StatusNotFound, "no Fares v2 data is available")
+		return
+	case errors.Is(err, gtfs.ErrNoFareForLeg) && errors.As(err, &legErr):
+		api.sendErrorResponse(w, r, http.StatusNotFound, fmt.Sprintf("no fare applies to leg %d", legErr.Leg))
+		return
+	case errors.Is(err, gtfs.ErrMixedCurrencies):
+		api.sendErrorResponse(w, r, http.StatusUnprocessableEntity, err.Error())
+		return
+	case errors.As(err, &legErr) && (errors.Is(err, gtfs.ErrStopsNotOnTrip) || errors.Is(err, sql.ErrNoRows)):
+		api.validationErrorResponse(w, r, map[string][]string{
+			fmt.Sprintf("legs[%d]", legErr.Leg): {legErr.Err.Error()},
+		})
+		return
+	case err != nil:
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	entry := models.ItineraryFare{
+		Currency:    fare.Currency,
+		TotalAmount: fare.Total,
+		Legs:        make([]models.FareLegPrice, 0, len(fare.Legs)),
+		Transfers:   make([]models.FareTransferPrice, 0, len(fare.Transfers)),
+	}
+	for i, leg := range fare.Legs {
+		entry.Legs = append(entry.Legs, models.FareLegPrice{
+			TripID:          tripIDs[i],
+			FromStopID:      fromStopIDs[i],
+			ToStopID:        toStopIDs[i],
+			LegGroupID:      leg.LegGroupID,
+			FareProductID:   leg.Product.FareProductID,
+			FareProductName: leg.Product.FareProductName.String,
+			FareMediaID:     leg.Product.FareMediaID,
+			ProductAmount:   leg.Product.Amount,
+			Amount:          leg.Amount,
+		})
+	}
+	for _, transfer := range fare.Transfers {
+		price := models.FareTransferPrice{
+			FromLegIndex:     transfer.FromLeg,
+			ToLegIndex:       transfer.ToLeg,
+			FareTransferType: int(transfer.Rule.FareTransferType),
+			Amount:           transfer.Amount,
+		}
+		if transfer.Product != nil {
+			price.FareProductID = transfer.Product.FareProductID
+		}
+		entry.Transfers = append(entry.Transfers, price)
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(entry, models.NewEmptyReferences()))
+}
+
+// agencyLocation returns the timezone of an agency, falling back to UTC when it is unknown.
+func (api *RestAPI) agencyLocation(r *http.Request, agencyID string) *time.Location {
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(r.Context(), agencyID)
+	if err != nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// serviceDateMidnight returns midnight of the service date given in milliseconds, or of
+// today when it is zero, in loc.
+func serviceDateMidnight(serviceDateMillis int64, loc *time.Location) time.Time {
+	date := time.Now().In(loc)
+	if serviceDateMillis != 0 {
+		date = time.UnixMilli(serviceDateMillis).In(loc)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,115 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// addTestFaresV2 gives route 151 a local bus fare with free transfers within 90 minutes.
+func addTestFaresV2(t *testing.T, api *RestAPI) {
+	ctx := context.Background()
+	queries := api.GtfsManager.GtfsDB().Queries
+
+	_, err := queries.CreateFareProduct(ctx, gtfsdb.CreateFareProductParams{
+		FareProductID:   "local",
+		FareProductName: sql.NullString{String: "Local fare", Valid: true},
+		Amount:          2.0,
+		Currency:        "USD",
+	})
+	require.NoError(t, err)
+	_, err = queries.CreateNetwork(ctx, gtfsdb.CreateNetworkParams{NetworkID: "bus"})
+	require.NoError(t, err)
+	_, err = queries.CreateRouteNetwork(ctx, gtfsdb.CreateRouteNetworkParams{RouteID: "151", NetworkID: "bus"})
+	require.NoError(t, err)
+	_, err = queries.CreateFareLegRule(ctx, gtfsdb.CreateFareLegRuleParams{
+		LegGroupID:    sql.NullString{String: "local", Valid: true},
+		NetworkID:     sql.NullString{String: "bus", Valid: true},
+		FareProductID: "local",
+	})
+	require.NoError(t, err)
+	_, err = queries.CreateFareTransferRule(ctx, gtfsdb.CreateFareTransferRuleParams{
+		FromLegGroupID:    sql.NullString{String: "local", Valid: true},
+		ToLegGroupID:      sql.NullString{String: "local", Valid: true},
+		DurationLimit:     sql.NullInt64{Int64: 5400, Valid: true},
+		DurationLimitType: sql.NullInt64{Int64: 0, Valid: true},
+		FareTransferType:  0,
+	})
+	require.NoError(t, err)
+}
+
+func TestFareForItineraryHandler(t *testing.T) {
+	api := createTestApi(t)
+	addTestFaresV2(t, api)
+
+	stopIDs, err := api.GtfsManager.GtfsDB().Queries.GetOrderedStopIDsForTrip(context.Background(), frequencyTestTripID)
+	require.NoError(t, err)
+	require.Greater(t, len(stopIDs), 4)
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	serviceDate := time.Date(2025, 6, 12, 0, 0, 0, 0, loc).UnixMilli()
+
+	trip := "25_" + frequencyTestTripID
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST"+
+		"&serviceDate="+strconv.FormatInt(serviceDate, 10)+
+		"&tripId="+trip+"&fromStopId=25_"+stopIDs[0]+"&toStopId=25_"+stopIDs[2]+
+		"&tripId="+trip+"&fromStopId=25_"+stopIDs[2]+"&toStopId=25_"+stopIDs[4])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.Equal(t, "USD", entry["currency"])
+	assert.Equal(t, 2.0, entry["totalAmount"])
+
+	legs := entry["legs"].([]interface{})
+	require.Len(t, legs, 2)
+	first := legs[0].(map[string]interface{})
+	assert.Equal(t, trip, first["tripId"])
+	assert.Equal(t, "25_"+stopIDs[0], first["fromStopId"])
+	assert.Equal(t, "local", first["fareProductId"])
+	assert.Equal(t, "Local fare", first["fareProductName"])
+	assert.Equal(t, 2.0, first["amount"])
+	second := legs[1].(map[string]interface{})
+	assert.Equal(t, 2.0, second["productAmount"])
+	assert.Equal(t, 0.0, second["amount"], "the transfer is free")
+
+	transfers := entry["transfers"].([]interface{})
+	require.Len(t, transfers, 1)
+	transfer := transfers[0].(map[string]interface{})
+	assert.Equal(t, 0.0, transfer["fromLegIndex"])
+	assert.Equal(t, 1.0, transfer["toLegIndex"])
+}
+
+func TestFareForItineraryHandlerErrors(t *testing.T) {
+	api := createTestApi(t)
+
+	stopIDs, err := api.GtfsManager.GtfsDB().Queries.GetOrderedStopIDsForTrip(context.Background(), frequencyTestTripID)
+	require.NoError(t, err)
+	leg := "&tripId=25_" + frequencyTestTripID + "&fromStopId=25_" + stopIDs[0] + "&toStopId=25_" + stopIDs[2]
+
+	resp, _ := serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST"+leg)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "the test feed has no Fares v2 data")
+
+	addTestFaresV2(t, api)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "tripId is required")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST"+leg+"&tripId=25_"+frequencyTestTripID)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "every leg needs both stops")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST"+
+		"&tripId=25_"+frequencyTestTripID+"&fromStopId=25_"+stopIDs[2]+"&toStopId=25_"+stopIDs[0])
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "stops must be visited in order")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/fare-for-itinerary.json?key=TEST"+
+		"&tripId=25_nonexistent&fromStopId=25_"+stopIDs[0]+"&toStopId=25_"+stopIDs[2])
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
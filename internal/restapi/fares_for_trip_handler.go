@@ -0,0 +1,157 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// faresForTripHandler returns the fares that apply to riding a trip between the fromStopId
+// and toStopId query parameters.
+func (api *RestAPI) faresForTripHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	fieldErrors := make(map[string][]string)
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors["id"] = []string{err.Error()}
+	}
+
+	stopIDs := make(map[string]string, 2)
+	for _, param := range []string{"fromStopId", "toStopId"} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			fieldErrors[param] = []string{"missingRequiredField"}
+			continue
+		}
+		if err := utils.ValidateID(value); err != nil {
+			fieldErrors[param] = []string{err.Error()}
+			continue
+		}
+		_, stopID, err := utils.ExtractAgencyIDAndCodeID(value)
+		if err != nil {
+			fieldErrors[param] = []string{err.Error()}
+			continue
+		}
+		stopIDs[param] = stopID
+	}
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	_, tripID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	fareAttributes, err := api.GtfsManager.FaresForTrip(ctx, tripID, stopIDs["fromStopId"], stopIDs["toStopId"])
+	if errors.Is(err, gtfs.ErrStopsNotOnTrip) {
+		api.validationErrorResponse(w, r, map[string][]string{
+			"toStopId": {err.Error()},
+		})
+		return
+	} else if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, trip.RouteID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	fares, agencies, err := api.buildFares(ctx, route.AgencyID, fareAttributes)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	references := models.NewEmptyReferences()
+	references.Agencies = agencies
+	references.Routes = append(references.Routes, models.NewRoute(
+		utils.FormCombinedID(route.AgencyID, route.ID),
+		route.AgencyID,
+		route.ShortName.String,
+		route.LongName.String,
+		route.Desc.String,
+		models.RouteType(route.Type),
+		route.Url.String,
+		route.Color.String,
+		route.TextColor.String,
+		route.ShortName.String,
+	))
+
+	api.sendResponse(w, r, models.NewListResponse(fares, references))
+}
+
+// buildFares converts fare_attributes rows into fares linked to their agency's fare URL,
+// along with references for those agencies. Fares without an agency_id belong to
+// defaultAgencyID.
+func (api *RestAPI) buildFares(ctx context.Context, defaultAgencyID string, attributes []gtfsdb.FareAttribute) ([]models.Fare, []models.AgencyReference, error) {
+	fares := make([]models.Fare, 0, len(attributes))
+	agencies := []models.AgencyReference{}
+	agencyByID := make(map[string]gtfsdb.Agency)
+
+	for _, attribute := range attributes {
+		agencyID := defaultAgencyID
+		if attribute.AgencyID.Valid {
+			agencyID = attribute.AgencyID.String
+		}
+
+		agency, ok := agencyByID[agencyID]
+		if !ok {
+			var err error
+			agency, err = api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
+			if err != nil {
+				return nil, nil, err
+			}
+			agencyByID[agencyID] = agency
+			agencies = append(agencies, models.NewAgencyReference(
+				agency.ID,
+				agency.Name,
+				agency.Url,
+				agency.Timezone,
+				agency.Lang.String,
+				agency.Phone.String,
+				agency.Email.String,
+				agency.FareUrl.String,
+				"",
+				false,
+			))
+		}
+
+		fare := models.Fare{
+			ID:            utils.FormCombinedID(agencyID, attribute.FareID),
+			AgencyID:      agencyID,
+			Price:         attribute.Price,
+			CurrencyType:  attribute.CurrencyType,
+			PaymentMethod: int(attribute.PaymentMethod),
+			FareURL:       agency.FareUrl.String,
+		}
+		if attribute.Transfers.Valid {
+			transfers := attribute.Transfers.Int64
+			fare.Transfers = &transfers
+		}
+		if attribute.TransferDuration.Valid {
+			transferDuration := attribute.TransferDuration.Int64
+			fare.TransferDuration = &transferDuration
+		}
+		fares = append(fares, fare)
+	}
+	return fares, agencies, nil
+}
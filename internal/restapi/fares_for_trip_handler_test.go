@@ -0,0 +1,73 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaresForTripHandler(t *testing.T) {
+	api := createTestApi(t)
+
+	stopIDs, err := api.GtfsManager.GtfsDB().Queries.GetOrderedStopIDsForTrip(context.Background(), frequencyTestTripID)
+	require.NoError(t, err)
+	require.Greater(t, len(stopIDs), 3)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/fares-for-trip/25_"+frequencyTestTripID+".json?key=TEST&fromStopId=25_"+stopIDs[0]+"&toStopId=25_"+stopIDs[3])
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	list := data["list"].([]interface{})
+	require.Len(t, list, 1)
+
+	fare := list[0].(map[string]interface{})
+	assert.Equal(t, "25_63", fare["id"])
+	assert.Equal(t, "25", fare["agencyId"])
+	assert.Equal(t, 2.0, fare["price"])
+	assert.Equal(t, "USD", fare["currencyType"])
+	assert.Nil(t, fare["transfers"], "unlimited transfers are null")
+	assert.Contains(t, fare, "fareUrl")
+
+	references := data["references"].(map[string]interface{})
+	assert.Len(t, references["agencies"], 1)
+	routes := references["routes"].([]interface{})
+	require.Len(t, routes, 1)
+	assert.Equal(t, "25_151", routes[0].(map[string]interface{})["id"])
+}
+
+func TestFaresForTripHandlerErrors(t *testing.T) {
+	api := createTestApi(t)
+
+	stopIDs, err := api.GtfsManager.GtfsDB().Queries.GetOrderedStopIDsForTrip(context.Background(), frequencyTestTripID)
+	require.NoError(t, err)
+
+	tripURL := "/api/where/fares-for-trip/25_" + frequencyTestTripID + ".json?key=TEST"
+
+	resp, _ := serveApiAndRetrieveEndpoint(t, api, tripURL+"&fromStopId=25_"+stopIDs[0])
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "toStopId is required")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, tripURL+"&fromStopId=25_"+stopIDs[3]+"&toStopId=25_"+stopIDs[0])
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "stops must be visited in order")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/fares-for-trip/25_nonexistent.json?key=TEST&fromStopId=25_"+stopIDs[0]+"&toStopId=25_"+stopIDs[3])
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRouteHandlerIncludesFareReferences(t *testing.T) {
+	api := createTestApi(t)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/route/25_161.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	references := model.Data.(map[string]interface{})["references"].(map[string]interface{})
+	fares := references["fares"].([]interface{})
+	require.Len(t, fares, 1)
+	fare := fares[0].(map[string]interface{})
+	assert.Equal(t, "25_64", fare["id"])
+	assert.Equal(t, 4.0, fare["price"])
+}
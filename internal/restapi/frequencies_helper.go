@@ -0,0 +1,166 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+)
+
+// Values of exact_times in frequencies.txt
+const (
+	frequencyBased = 0 // headway-based service without fixed departures
+	scheduleBased  = 1 // fixed departures every headway_secs
+)
+
+// frequencyStopTime is one visit of a frequency-based trip to a stop. All times are
+// offsets from midnight of the service date.
+type frequencyStopTime struct {
+	ArrivalTime   time.Duration
+	DepartureTime time.Duration
+	// TripStartTime is the departure from the trip's first stop of the run making this
+	// visit. It is only meaningful for schedule-based entries.
+	TripStartTime time.Duration
+	Frequency     gtfsdb.Frequency
+}
+
+// frequencyFromColumns rebuilds a frequencies.txt entry from the nullable columns of a
+// LEFT JOIN, returning nil when the trip has no frequency.
+func frequencyFromColumns(tripID string, startTime, endTime, headwaySecs, exactTimes sql.NullInt64) *gtfsdb.Frequency {
+	if !startTime.Valid || !endTime.Valid || !headwaySecs.Valid {
+		return nil
+	}
+	return &gtfsdb.Frequency{
+		TripID:      tripID,
+		StartTime:   startTime.Int64,
+		EndTime:     endTime.Int64,
+		HeadwaySecs: headwaySecs.Int64,
+		ExactTimes:  exactTimes.Int64,
+	}
+}
+
+// newFrequencyModel anchors a frequencies.txt window to a service date.
+func newFrequencyModel(serviceMidnight time.Time, frequency gtfsdb.Frequency) *models.Frequency {
+	return &models.Frequency{
+		StartTime:   serviceMidnight.Add(time.Duration(frequency.StartTime)).UnixMilli(),
+		EndTime:     serviceMidnight.Add(time.Duration(frequency.EndTime)).UnixMilli(),
+		HeadwaySecs: frequency.HeadwaySecs,
+	}
+}
+
+// frequencyTripStartTimes returns the first-stop departure of every run of a
+// schedule-based frequency: start_time, start_time + headway, ... up to end_time.
+func frequencyTripStartTimes(frequency gtfsdb.Frequency) []time.Duration {
+	headway := time.Duration(frequency.HeadwaySecs) * time.Second
+	if headway <= 0 {
+		return nil
+	}
+
+	var starts []time.Duration
+	for start := time.Duration(frequency.StartTime); start < time.Duration(frequency.EndTime); start += headway {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// expandFrequencyStopTime expands a stop time of a frequency-based trip into the visits
+// that overlap [windowStart, windowEnd]. tripStartTime is the departure from the first stop
+// in stop_times, which the stop time is measured from.
+//
+// Schedule-based entries produce one visit per run. Headway-based entries have no fixed
+// runs, so a single visit is produced at now, clamped to the span during which vehicles
+// serve the stop.
+func expandFrequencyStopTime(frequency gtfsdb.Frequency, tripStartTime, arrivalTime, departureTime, windowStart, windowEnd, now time.Duration) []frequencyStopTime {
+	arrivalOffset := arrivalTime - tripStartTime
+	dwell := departureTime - arrivalTime
+
+	var visits []frequencyStopTime
+	if frequency.ExactTimes == scheduleBased {
+		for _, start := range frequencyTripStartTimes(frequency) {
+			arrival := start + arrivalOffset
+			if arrival+dwell < windowStart || arrival > windowEnd {
+				continue
+			}
+			visits = append(visits, frequencyStopTime{
+				ArrivalTime:   arrival,
+				DepartureTime: arrival + dwell,
+				TripStartTime: start,
+				Frequency:     frequency,
+			})
+		}
+		return visits
+	}
+
+	first := max(time.Duration(frequency.StartTime)+arrivalOffset, windowStart)
+	last := min(time.Duration(frequency.EndTime)+arrivalOffset, windowEnd)
+	if first > last {
+		return nil
+	}
+	arrival := min(max(now, first), last)
+	return append(visits, frequencyStopTime{
+		ArrivalTime:   arrival,
+		DepartureTime: arrival + dwell,
+		TripStartTime: arrival - arrivalOffset,
+		Frequency:     frequency,
+	})
+}
+
+// selectFrequency returns the entry in effect at the given offset from service midnight:
+// the one whose window contains it, else the next to start, else the last to end.
+func selectFrequency(frequencies []gtfsdb.Frequency, offset time.Duration) *gtfsdb.Frequency {
+	var next, last *gtfsdb.Frequency
+	for i := range frequencies {
+		frequency := &frequencies[i]
+		start, end := time.Duration(frequency.StartTime), time.Duration(frequency.EndTime)
+		if offset >= start && offset < end {
+			return frequency
+		}
+		if start > offset && (next == nil || start < time.Duration(next.StartTime)) {
+			next = frequency
+		}
+		if end <= offset && (last == nil || end > time.Duration(last.EndTime)) {
+			last = frequency
+		}
+	}
+	if next != nil {
+		return next
+	}
+	return last
+}
+
+// frequencyForTripInstance returns the frequency block for a trip running at the given
+// time, or nil when the trip is not headway-based.
+func (api *RestAPI) frequencyForTripInstance(ctx context.Context, tripID string, serviceMidnight, at time.Time) *models.Frequency {
+	frequencies, err := api.GtfsManager.GtfsDB().Queries.GetFrequenciesForTrip(ctx, tripID)
+	if err != nil || len(frequencies) == 0 {
+		return nil
+	}
+
+	frequency := selectFrequency(frequencies, at.Sub(serviceMidnight))
+	if frequency == nil || frequency.ExactTimes != frequencyBased {
+		return nil
+	}
+	return newFrequencyModel(serviceMidnight, *frequency)
+}
+
+// vehicleServesFrequencyRun reports whether a vehicle assigned to a schedule-based frequency
+// trip is making the run that departs at tripStartTime. Vehicles whose trip descriptor
+// carries no start time cannot be told apart and are assumed to match.
+func vehicleServesFrequencyRun(vehicle *gtfs.Vehicle, tripStartTime time.Duration) bool {
+	if vehicle == nil || vehicle.Trip == nil || !vehicle.Trip.ID.HasStartTime {
+		return true
+	}
+	return vehicle.Trip.ID.StartTime == tripStartTime
+}
+
+// tripStartTimeForVehicle returns the time to use when looking up the frequency of the trip
+// a vehicle is serving: its trip start time when reported, otherwise fallback.
+func tripStartTimeForVehicle(vehicle gtfs.Vehicle, serviceMidnight, fallback time.Time) time.Time {
+	if vehicle.Trip != nil && vehicle.Trip.ID.HasStartTime {
+		return serviceMidnight.Add(vehicle.Trip.ID.StartTime)
+	}
+	return fallback
+}
@@ -0,0 +1,204 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// frequencyTestTripID is a weekday trip whose first stop is 25_1030, departing at 05:51.
+const frequencyTestTripID = "84f4520e-88b6-4ee6-8975-856799bc1359"
+
+func addTestFrequency(t *testing.T, api *RestAPI, start, end time.Duration, headwaySecs, exactTimes int64) {
+	t.Helper()
+	_, err := api.GtfsManager.GtfsDB().Queries.CreateFrequency(context.Background(), gtfsdb.CreateFrequencyParams{
+		TripID:      frequencyTestTripID,
+		StartTime:   int64(start),
+		EndTime:     int64(end),
+		HeadwaySecs: headwaySecs,
+		ExactTimes:  exactTimes,
+	})
+	require.NoError(t, err)
+}
+
+func TestFrequencyTripStartTimes(t *testing.T) {
+	frequency := gtfsdb.Frequency{
+		StartTime:   int64(6 * time.Hour),
+		EndTime:     int64(7 * time.Hour),
+		HeadwaySecs: 1200,
+		ExactTimes:  scheduleBased,
+	}
+
+	assert.Equal(t, []time.Duration{
+		6 * time.Hour,
+		6*time.Hour + 20*time.Minute,
+		6*time.Hour + 40*time.Minute,
+	}, frequencyTripStartTimes(frequency), "end_time is exclusive")
+
+	frequency.HeadwaySecs = 0
+	assert.Empty(t, frequencyTripStartTimes(frequency))
+}
+
+func TestExpandFrequencyStopTime(t *testing.T) {
+	tripStart := 5*time.Hour + 51*time.Minute
+	// The stop is visited ten minutes into the trip with a one minute dwell
+	arrival := tripStart + 10*time.Minute
+	departure := arrival + time.Minute
+
+	t.Run("schedule based", func(t *testing.T) {
+		frequency := gtfsdb.Frequency{
+			StartTime:   int64(6 * time.Hour),
+			EndTime:     int64(8 * time.Hour),
+			HeadwaySecs: 1800,
+			ExactTimes:  scheduleBased,
+		}
+		visits := expandFrequencyStopTime(frequency, tripStart, arrival, departure, 6*time.Hour+15*time.Minute, 7*time.Hour+15*time.Minute, 6*time.Hour+30*time.Minute)
+
+		require.Len(t, visits, 2)
+		assert.Equal(t, 6*time.Hour+40*time.Minute, visits[0].ArrivalTime)
+		assert.Equal(t, 6*time.Hour+41*time.Minute, visits[0].DepartureTime)
+		assert.Equal(t, 6*time.Hour+30*time.Minute, visits[0].TripStartTime)
+		assert.Equal(t, 7*time.Hour+10*time.Minute, visits[1].ArrivalTime)
+	})
+
+	t.Run("headway based", func(t *testing.T) {
+		frequency := gtfsdb.Frequency{
+			StartTime:   int64(6 * time.Hour),
+			EndTime:     int64(8 * time.Hour),
+			HeadwaySecs: 600,
+			ExactTimes:  frequencyBased,
+		}
+
+		visits := expandFrequencyStopTime(frequency, tripStart, arrival, departure, 6*time.Hour+25*time.Minute, 7*time.Hour, 6*time.Hour+30*time.Minute)
+		require.Len(t, visits, 1)
+		assert.Equal(t, 6*time.Hour+30*time.Minute, visits[0].ArrivalTime, "the next vehicle is expected now")
+
+		visits = expandFrequencyStopTime(frequency, tripStart, arrival, departure, 5*time.Hour, 6*time.Hour+30*time.Minute, 5*time.Hour+30*time.Minute)
+		require.Len(t, visits, 1)
+		assert.Equal(t, 6*time.Hour+10*time.Minute, visits[0].ArrivalTime, "the first vehicle reaches the stop after start_time")
+
+		visits = expandFrequencyStopTime(frequency, tripStart, arrival, departure, 8*time.Hour+15*time.Minute, 9*time.Hour, 8*time.Hour+20*time.Minute)
+		assert.Empty(t, visits, "service has ended")
+	})
+}
+
+func TestSelectFrequency(t *testing.T) {
+	frequencies := []gtfsdb.Frequency{
+		{StartTime: int64(6 * time.Hour), EndTime: int64(9 * time.Hour)},
+		{StartTime: int64(15 * time.Hour), EndTime: int64(18 * time.Hour)},
+	}
+
+	assert.Equal(t, &frequencies[0], selectFrequency(frequencies, 7*time.Hour))
+	assert.Equal(t, &frequencies[1], selectFrequency(frequencies, 12*time.Hour), "next window to start")
+	assert.Equal(t, &frequencies[0], selectFrequency(frequencies, 5*time.Hour))
+	assert.Equal(t, &frequencies[1], selectFrequency(frequencies, 20*time.Hour), "last window to end")
+	assert.Nil(t, selectFrequency(nil, 7*time.Hour))
+}
+
+func TestArrivalsAndDeparturesForHeadwayBasedTrip(t *testing.T) {
+	api := createTestApi(t)
+	addTestFrequency(t, api, 5*time.Hour+30*time.Minute, 9*time.Hour, 600, frequencyBased)
+
+	now := arrivalsTestTime(t)
+	serviceMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	resp, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/arrivals-and-departures-for-stop/25_1030.json?key=TEST&time="+strconv.FormatInt(now.UnixMilli(), 10))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	var found []map[string]interface{}
+	for _, a := range entry["arrivalsAndDepartures"].([]interface{}) {
+		arrival := a.(map[string]interface{})
+		if arrival["tripId"] == "25_"+frequencyTestTripID {
+			found = append(found, arrival)
+		}
+	}
+
+	require.Len(t, found, 1, "a headway-based trip is reported once per window")
+	frequency, ok := found[0]["frequency"].(map[string]interface{})
+	require.True(t, ok, "frequency block should be present")
+	assert.Equal(t, float64(600), frequency["headwaySecs"])
+	assert.Equal(t, float64(serviceMidnight.Add(5*time.Hour+30*time.Minute).UnixMilli()), frequency["startTime"])
+	assert.Equal(t, float64(serviceMidnight.Add(9*time.Hour).UnixMilli()), frequency["endTime"])
+	assert.Equal(t, float64(now.UnixMilli()), found[0]["scheduledArrivalTime"])
+}
+
+func TestArrivalsAndDeparturesForExactTimesTrip(t *testing.T) {
+	api := createTestApi(t)
+	addTestFrequency(t, api, 5*time.Hour+45*time.Minute, 7*time.Hour, 900, scheduleBased)
+
+	now := arrivalsTestTime(t)
+	serviceMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	resp, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/arrivals-and-departures-for-stop/25_1030.json?key=TEST&minutesBefore=5&minutesAfter=35&time="+strconv.FormatInt(now.UnixMilli(), 10))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	var scheduled []float64
+	for _, a := range entry["arrivalsAndDepartures"].([]interface{}) {
+		arrival := a.(map[string]interface{})
+		if arrival["tripId"] == "25_"+frequencyTestTripID {
+			assert.Nil(t, arrival["frequency"], "exact-times runs are reported as scheduled trips")
+			scheduled = append(scheduled, arrival["scheduledArrivalTime"].(float64))
+		}
+	}
+
+	// Runs leave stop 1030 (the first stop) at 05:45, 06:00, 06:15 and 06:30; the window is 05:55-06:35
+	assert.Equal(t, []float64{
+		float64(serviceMidnight.Add(6 * time.Hour).UnixMilli()),
+		float64(serviceMidnight.Add(6*time.Hour + 15*time.Minute).UnixMilli()),
+		float64(serviceMidnight.Add(6*time.Hour + 30*time.Minute).UnixMilli()),
+	}, scheduled)
+}
+
+func TestTripDetailsIncludesFrequency(t *testing.T) {
+	api := createTestApi(t)
+	addTestFrequency(t, api, 5*time.Hour+30*time.Minute, 9*time.Hour, 600, frequencyBased)
+
+	now := arrivalsTestTime(t)
+	resp, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/trip-details/25_"+frequencyTestTripID+".json?key=TEST&time="+strconv.FormatInt(now.UnixMilli(), 10))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	frequency, ok := entry["frequency"].(map[string]interface{})
+	require.True(t, ok, "frequency block should be present")
+	assert.Equal(t, float64(600), frequency["headwaySecs"])
+
+	schedule := entry["schedule"].(map[string]interface{})
+	assert.Equal(t, frequency, schedule["frequency"])
+}
+
+func TestScheduleForStopIncludesFrequencies(t *testing.T) {
+	api := createTestApi(t)
+	addTestFrequency(t, api, 5*time.Hour+30*time.Minute, 9*time.Hour, 600, frequencyBased)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/schedule-for-stop/25_1030.json?key=TEST&date=2025-06-12")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	var frequencies []map[string]interface{}
+	for _, rs := range entry["stopRouteSchedules"].([]interface{}) {
+		for _, ds := range rs.(map[string]interface{})["stopRouteDirectionSchedules"].([]interface{}) {
+			directionSchedule := ds.(map[string]interface{})
+			for _, st := range directionSchedule["scheduleStopTimes"].([]interface{}) {
+				assert.NotEqual(t, "25_"+frequencyTestTripID, st.(map[string]interface{})["tripId"],
+					"headway-based trips are listed as frequencies, not stop times")
+			}
+			for _, f := range directionSchedule["scheduleFrequencies"].([]interface{}) {
+				frequencies = append(frequencies, f.(map[string]interface{}))
+			}
+		}
+	}
+
+	require.Len(t, frequencies, 1)
+	assert.Equal(t, "25_"+frequencyTestTripID, frequencies[0]["tripId"])
+	assert.Equal(t, float64(600), frequencies[0]["headwaySecs"])
+	assert.Less(t, frequencies[0]["startTime"].(float64), frequencies[0]["endTime"].(float64))
+}
@@ -23,9 +23,16 @@ import (
 
 // createTestApi creates a new restAPI instance with a GTFS manager initialized for use in tests.
 func createTestApi(t *testing.T) *RestAPI {
+	return createTestApiWithFeed(t, filepath.Join("../../testdata", "raba.zip"))
+}
+
+// createTestApiWithFeed is createTestApi for the GTFS feed at feedPath.
+func createTestApiWithFeed(t *testing.T, feedPath string) *RestAPI {
 	gtfsConfig := gtfs.Config{
-		GtfsURL:      filepath.Join("../../testdata", "raba.zip"),
+		GtfsURL:      feedPath,
 		GTFSDataPath: ":memory:",
+		// Alarm tests register callbacks on localhost
+		AlarmCallbackAllowedHosts: []string{"localhost"},
 	}
 	gtfsManager, err := gtfs.InitGTFSManager(gtfsConfig)
 	require.NoError(t, err)
@@ -0,0 +1,228 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+type languageKey struct{}
+
+// requestLanguage is the rider's language preference for one request.
+type requestLanguage struct {
+	// preferences are the requested language tags, most preferred first
+	preferences []string
+	// translation is the translations.txt language responses are translated into, or "" for
+	// the feed's original text
+	translation string
+}
+
+// withRequestLanguage adds the languages requested by the lang query parameter, or failing
+// that the Accept-Language header, to the request context.
+func (api *RestAPI) withRequestLanguage(w http.ResponseWriter, r *http.Request) *http.Request {
+	var preferences []string
+	if lang := strings.TrimSpace(r.URL.Query().Get("lang")); lang != "" {
+		preferences = []string{lang}
+	} else {
+		preferences = parseAcceptLanguage(r.Header.Get("Accept-Language"))
+		w.Header().Add("Vary", "Accept-Language")
+	}
+	if len(preferences) == 0 {
+		return r
+	}
+
+	language := requestLanguage{preferences: preferences}
+	if api.GtfsManager != nil {
+		language.translation = api.GtfsManager.GetTranslations().Negotiate(preferences)
+	}
+	if language.translation != "" {
+		w.Header().Set("Content-Language", language.translation)
+	}
+	return r.WithContext(context.WithValue(r.Context(), languageKey{}, language))
+}
+
+// languageFromContext returns the language preference stored by withRequestLanguage.
+func languageFromContext(ctx context.Context) requestLanguage {
+	language, _ := ctx.Value(languageKey{}).(requestLanguage)
+	return language
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header ordered by
+// quality, dropping the wildcard and tags with a quality of zero.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, quality: quality})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+
+	preferences := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		preferences = append(preferences, tag.tag)
+	}
+	return preferences
+}
+
+// localizer translates the text of response models into one language.
+type localizer struct {
+	translations *gtfs.Translations
+	language     string
+}
+
+// localizeResponse returns the response with stop, route, trip, agency and level text
+// translated into the request's language. The response is copied rather than modified, as
+// handlers may share models between requests.
+func (api *RestAPI) localizeResponse(r *http.Request, response models.ResponseModel) models.ResponseModel {
+	language := languageFromContext(r.Context()).translation
+	if language == "" || api.GtfsManager == nil {
+		return response
+	}
+	l := localizer{translations: api.GtfsManager.GetTranslations(), language: language}
+	return l.localize(reflect.ValueOf(response)).Interface().(models.ResponseModel)
+}
+
+// localize deep-copies v, translating every model inside it that has translatable text.
+func (l localizer) localize(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(l.localize(v.Elem()))
+		return out
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(l.localize(v.Elem()))
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(l.localize(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), l.localize(iter.Value()))
+		}
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < out.NumField(); i++ {
+			if field := out.Field(i); field.CanSet() {
+				field.Set(l.localize(v.Field(i)))
+			}
+		}
+		l.translateModel(out.Addr().Interface())
+		return out
+	default:
+		return v
+	}
+}
+
+// translateModel translates the fields of a single model in place.
+func (l localizer) translateModel(model interface{}) {
+	switch m := model.(type) {
+	case *models.Stop:
+		m.Name = l.text("stops", "stop_name", m.ID, m.Name)
+	case *models.Station:
+		m.Name = l.text("stops", "stop_name", m.ID, m.Name)
+	case *models.Level:
+		m.Name = l.text("levels", "level_name", m.ID, m.Name)
+	case *models.AgencyReference:
+		m.Name = l.text("agency", "agency_name", m.ID, m.Name)
+	case *models.Route:
+		shortName := l.text("routes", "route_short_name", m.ID, m.ShortName)
+		longName := l.text("routes", "route_long_name", m.ID, m.LongName)
+		switch m.NullSafeShortName {
+		case "":
+		case m.ShortName:
+			m.NullSafeShortName = shortName
+		case m.LongName:
+			m.NullSafeShortName = longName
+		}
+		m.ShortName, m.LongName = shortName, longName
+		m.Description = l.text("routes", "route_desc", m.ID, m.Description)
+	case *models.Trip:
+		m.TripHeadsign = l.text("trips", "trip_headsign", m.ID, m.TripHeadsign)
+		m.TripShortName = l.text("trips", "trip_short_name", m.ID, m.TripShortName)
+		m.RouteShortName = l.text("routes", "route_short_name", m.RouteID, m.RouteShortName)
+	case *models.ArrivalAndDeparture:
+		m.TripHeadsign = l.text("trips", "trip_headsign", m.TripID, m.TripHeadsign)
+		m.RouteShortName = l.text("routes", "route_short_name", m.RouteID, m.RouteShortName)
+		m.RouteLongName = l.text("routes", "route_long_name", m.RouteID, m.RouteLongName)
+	case *models.StopTime:
+		m.StopHeadsign = l.stopTimeText(m.TripID, m.StopSequence, m.StopHeadsign)
+	case *models.ScheduleStopTime:
+		m.StopHeadsign = l.stopTimeText(m.TripID, m.StopSequence, m.StopHeadsign)
+	case *models.ScheduleFrequency:
+		m.StopHeadsign = l.stopTimeText(m.TripID, m.StopSequence, m.StopHeadsign)
+	case *models.RouteScheduleStopTime:
+		m.StopHeadsign = l.stopTimeText(m.TripID, m.StopSequence, m.StopHeadsign)
+	}
+}
+
+// text translates one field of the record with the given ID, which may be combined with
+// its agency ID or not, keeping value when there is no translation.
+func (l localizer) text(table, field, id, value string) string {
+	return l.subRecordText(table, field, id, "", value)
+}
+
+// stopTimeText translates the headsign of a stop time, which stop_times translations name by
+// its trip ID and stop sequence.
+func (l localizer) stopTimeText(tripID string, stopSequence int64, value string) string {
+	if tripID == "" {
+		return l.text("stop_times", "stop_headsign", "", value)
+	}
+	return l.subRecordText("stop_times", "stop_headsign", tripID, strconv.FormatInt(stopSequence, 10), value)
+}
+
+// subRecordText is text for a record that a translation names by its ID and a sub ID.
+func (l localizer) subRecordText(table, field, id, subID, value string) string {
+	if translated := l.translations.Translate(l.language, table, field, id, subID, value); translated != value {
+		return translated
+	}
+	if _, codeID, err := utils.ExtractAgencyIDAndCodeID(id); err == nil {
+		return l.translations.Translate(l.language, table, field, codeID, subID, value)
+	}
+	return value
+}
@@ -0,0 +1,194 @@
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/OneBusAway/go-gtfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+// serveApiWithLanguage is serveApiAndRetrieveEndpoint with an Accept-Language header.
+func serveApiWithLanguage(t *testing.T, api *RestAPI, endpoint, acceptLanguage string) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	api.SetRoutes(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+endpoint, nil)
+	require.NoError(t, err)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var model models.ResponseModel
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&model))
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	return resp, data
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{"es", []string{"es"}},
+		{"fr-CA, fr;q=0.9, en;q=0.8", []string{"fr-CA", "fr", "en"}},
+		{"en;q=0.5, es", []string{"es", "en"}},
+		{"de;q=0, *;q=0.1, it", []string{"it"}},
+		{"es;q=bad, pt", []string{"pt"}},
+		{"", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestSelectAlertTextPreferences(t *testing.T) {
+	texts := []gtfs.AlertText{
+		{Text: "Untagged", Language: ""},
+		{Text: "Desvío", Language: "es-MX"},
+		{Text: "Detour", Language: "en"},
+		{Text: "Déviation", Language: "fr"},
+	}
+
+	assert.Equal(t, "Detour", selectAlertText(texts, nil).Value)
+	assert.Equal(t, "Déviation", selectAlertText(texts, []string{"fr"}).Value)
+	assert.Equal(t, "Desvío", selectAlertText(texts, []string{"es"}).Value, "the primary subtag matches regional text")
+	assert.Equal(t, "Déviation", selectAlertText(texts, []string{"de", "fr-CA", "es"}).Value)
+	assert.Equal(t, "Detour", selectAlertText(texts, []string{"de"}).Value)
+	assert.Nil(t, selectAlertText(nil, []string{"es"}))
+}
+
+// TestLocalizedResponses uses the rate limit exempt key, as it makes more requests than the
+// test rate limit allows.
+func TestLocalizedResponses(t *testing.T) {
+	api := createTestApiWithFeed(t, models.BuildTranslationFeed(t, models.GetFixturePath(t, "raba.zip")))
+
+	stopName := func(t *testing.T, endpoint, acceptLanguage string) (*http.Response, string) {
+		resp, data := serveApiWithLanguage(t, api, endpoint, acceptLanguage)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp, data["entry"].(map[string]interface{})["name"].(string)
+	}
+
+	t.Run("lang parameter", func(t *testing.T) {
+		resp, name := stopName(t, "/api/where/stop/25_1001.json?key=org.onebusaway.iphone&lang=es", "")
+		assert.Equal(t, "Northpoint Dr en Lake Blvd", name)
+		assert.Equal(t, "es", resp.Header.Get("Content-Language"))
+	})
+
+	t.Run("lang parameter overrides Accept-Language", func(t *testing.T) {
+		_, name := stopName(t, "/api/where/stop/25_1001.json?key=org.onebusaway.iphone&lang=es", "fr-CA")
+		assert.Equal(t, "Northpoint Dr en Lake Blvd", name)
+	})
+
+	t.Run("Accept-Language", func(t *testing.T) {
+		resp, name := stopName(t, "/api/where/stop/25_1001.json?key=org.onebusaway.iphone", "de, fr;q=0.9")
+		assert.Equal(t, "Northpoint Dr à Lake Blvd", name)
+		assert.Equal(t, "fr-ca", resp.Header.Get("Content-Language"))
+		assert.Contains(t, resp.Header.Values("Vary"), "Accept-Language")
+	})
+
+	t.Run("falls back to the original text", func(t *testing.T) {
+		resp, name := stopName(t, "/api/where/stop/25_1002.json?key=org.onebusaway.iphone&lang=es", "")
+		assert.Equal(t, "Northpoint Dr at Redwood Blvd", name, "stops without a translation keep their name")
+		assert.Equal(t, "es", resp.Header.Get("Content-Language"))
+
+		resp, name = stopName(t, "/api/where/stop/25_1001.json?key=org.onebusaway.iphone", "de")
+		assert.Equal(t, "Northpoint Dr at Lake Blvd", name)
+		assert.Empty(t, resp.Header.Get("Content-Language"))
+
+		_, name = stopName(t, "/api/where/stop/25_1001.json?key=org.onebusaway.iphone", "en-US, es;q=0.5")
+		assert.Equal(t, "Northpoint Dr at Lake Blvd", name, "the feed language needs no translation")
+	})
+
+	t.Run("route and agency names", func(t *testing.T) {
+		resp, data := serveApiWithLanguage(t, api, "/api/where/route/25_151.json?key=org.onebusaway.iphone&lang=es", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		entry := data["entry"].(map[string]interface{})
+		assert.Equal(t, "Ruta 1", entry["longName"])
+		assert.Equal(t, "1", entry["shortName"])
+
+		agencies := data["references"].(map[string]interface{})["agencies"].([]interface{})
+		require.Len(t, agencies, 1)
+		assert.Equal(t, "Autoridad de Autobuses de Redding", agencies[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("headsigns by field value", func(t *testing.T) {
+		resp, data := serveApiWithLanguage(t, api, "/api/where/trip/25_84f4520e-88b6-4ee6-8975-856799bc1359.json?key=org.onebusaway.iphone&lang=es", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "Lago Shasta", data["entry"].(map[string]interface{})["tripHeadsign"])
+	})
+
+	t.Run("alert text", func(t *testing.T) {
+		addTestAlert(t, api, api.GtfsManager.GetTrips()[0].ID)
+
+		resp, data := serveApiWithLanguage(t, api, "/api/where/situation/detour-151.json?key=org.onebusaway.iphone", "es-419, en;q=0.5")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		summary := data["entry"].(map[string]interface{})["summary"].(map[string]interface{})
+		assert.Equal(t, "es", summary["lang"])
+		assert.Equal(t, "Desvío de la ruta 1", summary["value"])
+	})
+}
+
+func TestLocalizedStopTimeHeadsigns(t *testing.T) {
+	feed := models.BuildFeedWithFiles(t, models.BuildShuttleFeed(t), map[string]string{
+		"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence,stop_headsign\n" +
+			"LOOP_1,08:00:00,08:00:00,SH_A,1,To Stop B\n" +
+			"LOOP_1,08:10:00,08:10:00,SH_B,2,To Stop B\n",
+		"translations.txt": "table_name,field_name,language,translation,record_id,record_sub_id,field_value\n" +
+			"stop_times,stop_headsign,es,Hacia la parada B,LOOP_1,1,\n",
+	})
+	api := createTestApiWithFeed(t, feed)
+
+	// Only the stop time the translation names is translated
+	headsigns := func(stopTimes []interface{}) []interface{} {
+		var result []interface{}
+		for _, st := range stopTimes {
+			result = append(result, st.(map[string]interface{})["stopHeadsign"])
+		}
+		return result
+	}
+	expected := []interface{}{"Hacia la parada B", "To Stop B"}
+
+	t.Run("trip details", func(t *testing.T) {
+		resp, data := serveApiWithLanguage(t, api, "/api/where/trip-details/SHUTTLE_LOOP_1.json?key=org.onebusaway.iphone&lang=es&includeSchedule=true", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		schedule := data["entry"].(map[string]interface{})["schedule"].(map[string]interface{})
+		assert.Equal(t, expected, headsigns(schedule["stopTimes"].([]interface{})))
+	})
+
+	t.Run("schedule for route", func(t *testing.T) {
+		resp, data := serveApiWithLanguage(t, api, "/api/where/schedule-for-route/SHUTTLE_LOOP.json?key=org.onebusaway.iphone&lang=es&date=2025-06-12", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		groupings := data["entry"].(map[string]interface{})["stopTripGroupings"].([]interface{})
+		require.Len(t, groupings, 1)
+		trips := groupings[0].(map[string]interface{})["tripsWithStopTimes"].([]interface{})
+		require.Len(t, trips, 1)
+		assert.Equal(t, expected, headsigns(trips[0].(map[string]interface{})["stopTimes"].([]interface{})))
+	})
+
+	t.Run("schedule for stop", func(t *testing.T) {
+		resp, data := serveApiWithLanguage(t, api, "/api/where/schedule-for-stop/SHUTTLE_SH_A.json?key=org.onebusaway.iphone&lang=es&date=2025-06-12", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		routeSchedules := data["entry"].(map[string]interface{})["stopRouteSchedules"].([]interface{})
+		require.Len(t, routeSchedules, 1)
+		directions := routeSchedules[0].(map[string]interface{})["stopRouteDirectionSchedules"].([]interface{})
+		require.Len(t, directions, 1)
+		stopTimes := directions[0].(map[string]interface{})["scheduleStopTimes"].([]interface{})
+		assert.Equal(t, expected[:1], headsigns(stopTimes))
+	})
+}
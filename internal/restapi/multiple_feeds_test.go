@@ -0,0 +1,175 @@
+package restapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/app"
+	"maglev.onebusaway.org/internal/appconf"
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// createMultipleFeedsTestApi serves RABA, with its realtime feeds, alongside a shuttle feed that
+// reuses RABA's stop ID 1001 and serves it on trip LOOP_1.
+func createMultipleFeedsTestApi(t *testing.T) *RestAPI {
+	mux := http.NewServeMux()
+	for path, file := range map[string]string{
+		"/trip-updates":      "raba-trip-updates.pb",
+		"/vehicle-positions": "raba-vehicle-positions.pb",
+	} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			data, err := os.ReadFile(filepath.Join("../../testdata", file))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/x-protobuf")
+			_, _ = w.Write(data)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	shuttle := models.BuildFeedWithFiles(t, models.BuildShuttleFeed(t), map[string]string{
+		"stops.txt": "stop_id,stop_code,stop_name,stop_lat,stop_lon\n" +
+			"SH_A,A,Shuttle Stop A,40.5870,-122.3920\n" +
+			"1001,1001,Shuttle Stop 1001,40.5890,-122.3940\n",
+		"stop_times.txt": "trip_id,arrival_time,departure_time,stop_id,stop_sequence\n" +
+			"LOOP_1,08:00:00,08:00:00,SH_A,1\n" +
+			"LOOP_1,08:10:00,08:10:00,1001,2\n",
+	})
+	gtfsConfig := gtfs.Config{
+		Feeds: []gtfs.FeedConfig{
+			{
+				ID:                  "raba",
+				GtfsURL:             filepath.Join("../../testdata", "raba.zip"),
+				TripUpdatesURL:      server.URL + "/trip-updates",
+				VehiclePositionsURL: server.URL + "/vehicle-positions",
+			},
+			{ID: "shuttle", GtfsURL: shuttle},
+		},
+		GTFSDataPath: ":memory:",
+		Env:          appconf.Test,
+	}
+	gtfsManager, err := gtfs.InitGTFSManager(gtfsConfig)
+	require.NoError(t, err)
+	t.Cleanup(gtfsManager.Shutdown)
+
+	api := NewRestAPI(&app.Application{
+		Config: appconf.Config{
+			Env:       appconf.Test,
+			ApiKeys:   []string{"TEST"},
+			RateLimit: 1000,
+		},
+		GtfsConfig:  gtfsConfig,
+		GtfsManager: gtfsManager,
+	})
+	return api
+}
+
+func TestMultipleFeedsSharingIDs(t *testing.T) {
+	api := createMultipleFeedsTestApi(t)
+	mux := http.NewServeMux()
+	api.SetRoutes(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	get := func(t *testing.T, endpoint string) (int, string) {
+		separator := "?"
+		if strings.Contains(endpoint, "?") {
+			separator = "&"
+		}
+		resp, err := http.Get(server.URL + endpoint + separator + "key=TEST")
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	entry := func(t *testing.T, endpoint string) map[string]interface{} {
+		status, body := get(t, endpoint)
+		require.Equal(t, http.StatusOK, status, body)
+		var response models.ResponseModel
+		require.NoError(t, json.Unmarshal([]byte(body), &response))
+		data, ok := response.Data.(map[string]interface{})
+		require.True(t, ok)
+		entry, ok := data["entry"].(map[string]interface{})
+		require.True(t, ok)
+		return entry
+	}
+
+	t.Run("stops sharing an ID are told apart by agency", func(t *testing.T) {
+		rabaStop := entry(t, "/api/where/stop/25_1001.json")
+		assert.Equal(t, "25_1001", rabaStop["id"])
+		assert.NotEqual(t, "Shuttle Stop 1001", rabaStop["name"])
+		shuttleStop := entry(t, "/api/where/stop/SHUTTLE_1001.json")
+		assert.Equal(t, "SHUTTLE_1001", shuttleStop["id"])
+		assert.Equal(t, "Shuttle Stop 1001", shuttleStop["name"])
+	})
+
+	// Build endpoints from realtime data, so that realtime IDs are covered too
+	var tripID, routeID, vehicleID string
+	for _, vehicle := range api.GtfsManager.GetRealTimeVehicles() {
+		if vehicle.Trip != nil && vehicle.ID != nil {
+			tripID, routeID, vehicleID = vehicle.Trip.ID.ID, vehicle.Trip.ID.RouteID, vehicle.ID.ID
+			break
+		}
+	}
+	require.NotEmpty(t, tripID, "the realtime feeds are loaded")
+	assert.Equal(t, gtfsdb.ScopedID("raba", gtfsdb.UnscopedID(tripID)), tripID)
+	if routeID == "" {
+		trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(t.Context(), tripID)
+		require.NoError(t, err)
+		routeID = trip.RouteID
+	}
+	rabaTrip := utils.FormCombinedID("25", tripID)
+	rabaRoute := utils.FormCombinedID("25", routeID)
+	today := time.Now().Format("2006-01-02")
+
+	endpoints := []string{
+		"/api/where/agencies-with-coverage.json",
+		"/api/where/route/SHUTTLE_LOOP.json",
+		"/api/where/route/" + rabaRoute + ".json",
+		"/api/where/routes-for-agency/SHUTTLE.json",
+		"/api/where/route-ids-for-agency/SHUTTLE.json",
+		"/api/where/stop-ids-for-agency/SHUTTLE.json",
+		"/api/where/stops-for-route/SHUTTLE_LOOP.json",
+		"/api/where/stops-for-route/" + rabaRoute + ".json",
+		"/api/where/stops-for-location.json?lat=40.589&lon=-122.394",
+		"/api/where/routes-for-location.json?lat=40.589&lon=-122.394",
+		"/api/where/schedule-for-stop/SHUTTLE_1001.json?date=" + today,
+		"/api/where/schedule-for-route/SHUTTLE_LOOP.json?date=" + today,
+		"/api/where/arrivals-and-departures-for-stop/SHUTTLE_1001.json",
+		"/api/where/trip/SHUTTLE_LOOP_1.json",
+		"/api/where/trip-details/SHUTTLE_LOOP_1.json",
+		"/api/where/trip/" + rabaTrip + ".json",
+		"/api/where/trip-details/" + rabaTrip + ".json",
+		"/api/where/trips-for-route/" + rabaRoute + ".json",
+		"/api/where/trip-for-vehicle/" + utils.FormCombinedID("25", vehicleID) + ".json",
+		"/api/where/vehicles-for-agency/25.json",
+		"/api/where/trips-for-location.json?lat=40.589&lon=-122.394&latSpan=0.5&lonSpan=0.5",
+		"/api/where/search/stop.json?input=Shuttle",
+		"/api/where/search/route.json?input=Loop",
+		"/api/where/service-dates-for-service/SHUTTLE_SH_WEEKDAY.json",
+	}
+	for _, endpoint := range endpoints {
+		t.Run(endpoint, func(t *testing.T) {
+			status, body := get(t, endpoint)
+			assert.Equal(t, http.StatusOK, status, body)
+			assert.NotContains(t, body, `\u001f`, "stored IDs are given as written in their feeds")
+		})
+	}
+}
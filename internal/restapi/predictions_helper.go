@@ -0,0 +1,152 @@
+package restapi
+
+import (
+	"context"
+	"time"
+
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// predictedTimesAtStop returns the predicted arrival and departure of a trip at one of its
+// stops. Stops the realtime data doesn't cover get their scheduled times shifted by the trip's
+// schedule deviation, and false is returned for them.
+func predictedTimesAtStop(
+	prediction *gtfs.TripPrediction,
+	stopID string,
+	stopSequence int64,
+	scheduledArrival, scheduledDeparture time.Time,
+	tripStatus *models.TripStatusForTripDetails,
+) (time.Time, time.Time, bool) {
+	if stopPrediction, ok := prediction.ForStop(stopID, stopSequence); ok {
+		return stopPrediction.PredictedArrival, stopPrediction.PredictedDeparture, true
+	}
+
+	deviation := time.Duration(0)
+	if tripStatus != nil {
+		deviation = time.Duration(tripStatus.ScheduleDeviation) * time.Second
+	}
+	return scheduledArrival.Add(deviation), scheduledDeparture.Add(deviation), false
+}
+
+// Values of the status of an arrival and departure. Scheduled visits have the default status,
+// the others follow the GTFS-realtime schedule relationship of the trip or the stop.
+const (
+	arrivalStatusDefault    = "default"
+	arrivalStatusCanceled   = "CANCELED"
+	arrivalStatusSkipped    = "SKIPPED"
+	arrivalStatusAdded      = "ADDED"
+	arrivalStatusDuplicated = "DUPLICATED"
+)
+
+// arrivalStatus returns the status of a scheduled trip's visit to a stop on the service date
+// starting at serviceMidnight.
+func (api *RestAPI) arrivalStatus(tripID string, serviceMidnight time.Time, stopID string, stopSequence int64) string {
+	if api.GtfsManager.IsTripCanceled(tripID, serviceMidnight) {
+		return arrivalStatusCanceled
+	}
+	if api.GtfsManager.IsStopSkipped(tripID, serviceMidnight, stopID, stopSequence) {
+		return arrivalStatusSkipped
+	}
+	return arrivalStatusDefault
+}
+
+// realtimeOnlyArrivals returns the arrivals at a stop within a window of trips that exist only
+// in realtime data, along with references to those trips.
+func (api *RestAPI) realtimeOnlyArrivals(
+	ctx context.Context,
+	agencyID, stopID string,
+	loc *time.Location,
+	windowStart, windowEnd, currentTime time.Time,
+) ([]models.ArrivalAndDeparture, map[string]*models.Trip, error) {
+	visits, err := api.GtfsManager.RealtimeTripStopsForStop(ctx, stopID, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	arrivals := make([]models.ArrivalAndDeparture, 0, len(visits))
+	tripRefs := make(map[string]*models.Trip)
+	for _, visit := range visits {
+		if !isArrivalInWindow(visit.PredictedArrival.UnixMilli(), visit.PredictedDeparture.UnixMilli(), windowStart, windowEnd) {
+			continue
+		}
+
+		status := arrivalStatusAdded
+		if visit.ScheduleRelationship == gtfsrt.TripDescriptor_DUPLICATED {
+			status = arrivalStatusDuplicated
+		}
+		var predictedArrivalTime, predictedDepartureTime int64
+		if visit.Skipped {
+			status = arrivalStatusSkipped
+		} else if visit.Predicted {
+			predictedArrivalTime = visit.PredictedArrival.UnixMilli()
+			predictedDepartureTime = visit.PredictedDeparture.UnixMilli()
+		}
+
+		var vehicleID string
+		if vehicle := api.GtfsManager.GetVehicleServingTrip(visit.TripID); vehicle != nil && vehicle.ID != nil {
+			vehicleID = gtfsdb.UnscopedID(vehicle.ID.ID)
+		}
+
+		var routeShortName, routeLongName string
+		if route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, visit.RouteID); err == nil {
+			routeShortName, routeLongName = route.ShortName.String, route.LongName.String
+		}
+
+		// Duplicates describe themselves with their source trip
+		tripRef := &models.Trip{
+			ID:      utils.FormCombinedID(agencyID, visit.TripID),
+			RouteID: utils.FormCombinedID(agencyID, visit.RouteID),
+		}
+		if visit.SourceTripID != "" {
+			if sourceTrip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, visit.SourceTripID); err == nil {
+				tripRef = models.NewTripReference(
+					utils.FormCombinedID(agencyID, visit.TripID),
+					utils.FormCombinedID(agencyID, sourceTrip.RouteID),
+					utils.FormCombinedID(agencyID, sourceTrip.ServiceID),
+					sourceTrip.TripHeadsign.String,
+					sourceTrip.TripShortName.String,
+					sourceTrip.DirectionID.Int64,
+					utils.FormCombinedID(agencyID, sourceTrip.BlockID.String),
+					utils.FormCombinedID(agencyID, sourceTrip.ShapeID.String),
+				)
+			}
+		}
+		tripRefs[visit.TripID] = tripRef
+
+		arrival := models.NewArrivalAndDeparture(
+			utils.FormCombinedID(agencyID, visit.RouteID),
+			routeShortName,
+			routeLongName,
+			utils.FormCombinedID(agencyID, visit.TripID),
+			tripRef.TripHeadsign,
+			utils.FormCombinedID(agencyID, stopID),
+			vehicleID,
+			visit.ServiceMidnight.UnixMilli(),
+			visit.ScheduledArrival.UnixMilli(),
+			visit.ScheduledDeparture.UnixMilli(),
+			predictedArrivalTime,
+			predictedDepartureTime,
+			currentTime.UnixMilli(),
+			predictedArrivalTime != 0,
+			true, // arrivalEnabled
+			true, // departureEnabled
+			visit.StopIndex,
+			visit.TotalStops,
+			0, // numberOfStopsAway
+			0, // blockTripSequence
+			0, // distanceFromStop
+			status,
+			"", // occupancyStatus
+			"", // predictedOccupancy
+			"", // historicalOccupancy
+			nil,
+			api.GetSituationIDsForTrip(visit.TripID),
+		)
+		arrivals = append(arrivals, *arrival)
+	}
+	return arrivals, tripRefs, nil
+}
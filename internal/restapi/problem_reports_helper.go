@@ -0,0 +1,150 @@
+package restapi
+
+import (
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+const (
+	problemReportTypeTrip = "trip"
+	problemReportTypeStop = "stop"
+
+	problemReportStatusOpen     = "open"
+	problemReportStatusResolved = "resolved"
+
+	maxProblemReportCommentLength = 1000
+	maxVehicleNumberLength        = 50
+)
+
+// tripProblemCodes and stopProblemCodes are the problem codes defined by the OneBusAway API
+var (
+	tripProblemCodes = map[string]bool{
+		"vehicle_never_came":         true,
+		"vehicle_came_early":         true,
+		"vehicle_came_late":          true,
+		"wrong_headsign":             true,
+		"vehicle_does_not_stop_here": true,
+		"other":                      true,
+	}
+	stopProblemCodes = map[string]bool{
+		"stop_name_wrong":       true,
+		"stop_number_wrong":     true,
+		"stop_location_wrong":   true,
+		"route_or_trip_missing": true,
+		"other":                 true,
+	}
+)
+
+func invalidFieldValue(field string) []string {
+	return []string{"Invalid field value for field \"" + field + "\"."}
+}
+
+// parseProblemReportCommon validates the parameters shared by trip and stop problem reports and
+// fills them into params. Validation failures are added to fieldErrors.
+func parseProblemReportCommon(query url.Values, validCodes map[string]bool, params *gtfsdb.CreateProblemReportParams, fieldErrors map[string][]string) {
+	code := query.Get("code")
+	switch {
+	case code == "":
+		fieldErrors["code"] = []string{"missingRequiredField"}
+	case !validCodes[code]:
+		fieldErrors["code"] = invalidFieldValue("code")
+	default:
+		params.Code = code
+	}
+
+	if comment := strings.TrimSpace(query.Get("userComment")); comment != "" {
+		if utf8.RuneCountInString(comment) > maxProblemReportCommentLength {
+			fieldErrors["userComment"] = []string{"comment too long (max 1000 characters)"}
+		} else {
+			params.UserComment = sql.NullString{String: utils.SanitizeInput(comment), Valid: true}
+		}
+	}
+
+	latStr, lonStr := query.Get("userLat"), query.Get("userLon")
+	if (latStr == "") != (lonStr == "") {
+		fieldErrors["userLat"] = []string{"userLat and userLon must be provided together"}
+	} else if latStr != "" {
+		lat, err := strconv.ParseFloat(latStr, 64)
+		if err != nil || utils.ValidateLatitude(lat) != nil {
+			fieldErrors["userLat"] = invalidFieldValue("userLat")
+		}
+		lon, err := strconv.ParseFloat(lonStr, 64)
+		if err != nil || utils.ValidateLongitude(lon) != nil {
+			fieldErrors["userLon"] = invalidFieldValue("userLon")
+		}
+		params.UserLat = sql.NullFloat64{Float64: lat, Valid: true}
+		params.UserLon = sql.NullFloat64{Float64: lon, Valid: true}
+	}
+
+	if accuracyStr := query.Get("userLocationAccuracy"); accuracyStr != "" {
+		accuracy, err := strconv.ParseFloat(accuracyStr, 64)
+		if err != nil || accuracy < 0 {
+			fieldErrors["userLocationAccuracy"] = invalidFieldValue("userLocationAccuracy")
+		} else {
+			params.UserLocationAccuracy = sql.NullFloat64{Float64: accuracy, Valid: true}
+		}
+	}
+}
+
+// parseOptionalID validates an optional ID parameter, returning an invalid NullString when absent.
+func parseOptionalID(query url.Values, field string, fieldErrors map[string][]string) sql.NullString {
+	value := query.Get(field)
+	if value == "" {
+		return sql.NullString{}
+	}
+	if err := utils.ValidateID(value); err != nil {
+		fieldErrors[field] = []string{err.Error()}
+		return sql.NullString{}
+	}
+	return sql.NullString{String: value, Valid: true}
+}
+
+func newProblemReportModel(report gtfsdb.ProblemReport) models.ProblemReport {
+	model := models.ProblemReport{
+		ID:                report.ID,
+		Type:              report.ReportType,
+		TripID:            report.TripID.String,
+		StopID:            report.StopID.String,
+		Code:              report.Code,
+		VehicleID:         report.VehicleID.String,
+		UserComment:       report.UserComment.String,
+		UserVehicleNumber: report.UserVehicleNumber.String,
+		Status:            report.Status,
+		CreatedAt:         report.CreatedAt,
+		ResolutionNote:    report.ResolutionNote.String,
+	}
+	if report.ServiceDate.Valid {
+		model.ServiceDate = &report.ServiceDate.Int64
+	}
+	if report.UserOnVehicle.Valid {
+		onVehicle := report.UserOnVehicle.Int64 != 0
+		model.UserOnVehicle = &onVehicle
+	}
+	if report.UserLat.Valid {
+		model.UserLat = &report.UserLat.Float64
+	}
+	if report.UserLon.Valid {
+		model.UserLon = &report.UserLon.Float64
+	}
+	if report.UserLocationAccuracy.Valid {
+		model.UserLocationAccuracy = &report.UserLocationAccuracy.Float64
+	}
+	if report.ResolvedAt.Valid {
+		model.ResolvedAt = &report.ResolvedAt.Int64
+	}
+	return model
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
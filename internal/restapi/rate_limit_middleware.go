@@ -2,6 +2,7 @@ package restapi
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
 	"strconv"
 	"sync"
@@ -18,12 +19,27 @@ type RateLimitMiddleware struct {
 	burstSize   int
 	cleanupTick *time.Ticker
 	exemptKeys  map[string]bool
+	requestKey  func(r *http.Request) string // Key of the limiter a request counts against
 }
 
 // NewRateLimitMiddleware creates a new rate limiting middleware
 // ratePerSecond: number of requests allowed per second per API key
 // burstSize: number of requests allowed in a burst per API key
 func NewRateLimitMiddleware(ratePerSecond int, interval time.Duration) func(http.Handler) http.Handler {
+	return newRateLimitMiddleware(ratePerSecond, interval, apiKeyOf, map[string]bool{
+		"org.onebusaway.iphone": true, // Exempt OneBusAway iPhone app
+	})
+}
+
+// NewClientIPRateLimitMiddleware creates a rate limiting middleware that limits each client IP
+// address on its own, whatever API key it uses. It suits endpoints that riders call on their
+// own behalf, where an app's API key is shared by all of its riders and exempting it would
+// exempt them all.
+func NewClientIPRateLimitMiddleware(ratePerInterval int, interval time.Duration) func(http.Handler) http.Handler {
+	return newRateLimitMiddleware(ratePerInterval, interval, clientIPOf, map[string]bool{})
+}
+
+func newRateLimitMiddleware(ratePerSecond int, interval time.Duration, requestKey func(r *http.Request) string, exemptKeys map[string]bool) func(http.Handler) http.Handler {
 	// Handle zero rate limit case
 	var rateLimit rate.Limit
 	if ratePerSecond <= 0 {
@@ -40,9 +56,8 @@ func NewRateLimitMiddleware(ratePerSecond int, interval time.Duration) func(http
 		rateLimit:   rateLimit,
 		burstSize:   ratePerSecond,
 		cleanupTick: time.NewTicker(5 * time.Minute), // Cleanup old limiters every 5 minutes
-		exemptKeys: map[string]bool{
-			"org.onebusaway.iphone": true, // Exempt OneBusAway iPhone app
-		},
+		exemptKeys:  exemptKeys,
+		requestKey:  requestKey,
 	}
 
 	// Start cleanup goroutine
@@ -80,22 +95,16 @@ func (rl *RateLimitMiddleware) getLimiter(apiKey string) *rate.Limiter {
 // rateLimitHandler is the HTTP middleware function
 func (rl *RateLimitMiddleware) rateLimitHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Extract API key from query parameters
-		apiKey := r.URL.Query().Get("key")
-
-		// Use a default key for requests without an API key
-		if apiKey == "" {
-			apiKey = "__no_key__"
-		}
+		key := rl.requestKey(r)
 
-		// Check if this API key is exempted from rate limiting
-		if rl.exemptKeys[apiKey] {
+		// Check if this key is exempted from rate limiting
+		if rl.exemptKeys[key] {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		// Get the rate limiter for this API key
-		limiter := rl.getLimiter(apiKey)
+		// Get the rate limiter for this key
+		limiter := rl.getLimiter(key)
 
 		// Check if request is allowed
 		if !limiter.Allow() {
@@ -108,6 +117,27 @@ func (rl *RateLimitMiddleware) rateLimitHandler(next http.Handler) http.Handler
 	})
 }
 
+// apiKeyOf returns the API key of a request
+func apiKeyOf(r *http.Request) string {
+	apiKey := r.URL.Query().Get("key")
+
+	// Use a default key for requests without an API key
+	if apiKey == "" {
+		apiKey = "__no_key__"
+	}
+	return apiKey
+}
+
+// clientIPOf returns the IP address a request came from. Forwarding headers are ignored, since
+// clients can set them to anything.
+func clientIPOf(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 // sendRateLimitExceeded sends a 429 Too Many Requests response
 func (rl *RateLimitMiddleware) sendRateLimitExceeded(w http.ResponseWriter, r *http.Request) {
 	// Calculate retry-after based on rate limit
@@ -118,7 +148,8 @@ func (rl *RateLimitMiddleware) sendRateLimitExceeded(w http.ResponseWriter, r *h
 	case rate.Inf:
 		retryAfter = time.Second // Should not happen, but fallback
 	default:
-		retryAfter = time.Duration(1) / time.Duration(rl.rateLimit)
+		// Time until the next token; computed in floating point so sub-1/s rates don't truncate to zero
+		retryAfter = time.Duration(float64(time.Second) / float64(rl.rateLimit))
 	}
 
 	// Set headers
@@ -130,6 +130,33 @@ func TestRateLimitMiddleware_ExemptsOneBusAwayiPhone(t *testing.T) {
 	}
 }
 
+func TestClientIPRateLimitMiddleware_LimitsEachClientIP(t *testing.T) {
+	middleware := NewClientIPRateLimitMiddleware(2, time.Minute)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	limitedHandler := middleware(handler)
+
+	request := func(key, remoteAddr string) int {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/test?key=%s", key), nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		limitedHandler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// The exempted API key and a change of API key don't escape the limit
+	assert.Equal(t, http.StatusOK, request("org.onebusaway.iphone", "203.0.113.1:1234"))
+	assert.Equal(t, http.StatusOK, request("other-key", "203.0.113.1:5678"))
+	assert.Equal(t, http.StatusTooManyRequests, request("org.onebusaway.iphone", "203.0.113.1:1234"))
+	assert.Equal(t, http.StatusTooManyRequests, request("another-key", "203.0.113.1:1234"))
+
+	// Other riders of the same app have budgets of their own
+	assert.Equal(t, http.StatusOK, request("org.onebusaway.iphone", "203.0.113.2:1234"))
+}
+
 func TestRateLimitMiddleware_HandlesNoAPIKey(t *testing.T) {
 	middleware := NewRateLimitMiddleware(5, time.Second)
 
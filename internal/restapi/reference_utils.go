@@ -29,7 +29,7 @@ func (api *RestAPI) BuildRouteReferences(ctx context.Context, agencyID string, s
 		return []models.Route{}, nil
 	}
 
-	routes, err := api.GtfsManager.GtfsDB.Queries.GetRoutesByIDs(ctx, originalRouteIDs)
+	routes, err := api.GtfsManager.GtfsDB().Queries.GetRoutesByIDs(ctx, originalRouteIDs)
 	if err != nil {
 		return nil, err
 	}
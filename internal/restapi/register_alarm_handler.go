@@ -0,0 +1,163 @@
+package restapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) registerAlarmForArrivalAndDepartureAtStopHandler(w http.ResponseWriter, r *http.Request) {
+	stopID := utils.ExtractIDFromParams(r)
+
+	if err := utils.ValidateID(stopID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, stopCode, err := utils.ExtractAgencyIDAndCodeID(stopID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	params := api.parseArrivalAndDepartureParams(r)
+	query := r.URL.Query()
+
+	fieldErrors := map[string][]string{}
+	if params.TripID == "" {
+		fieldErrors["tripId"] = []string{"missingRequiredField"}
+	}
+	if params.ServiceDate == nil {
+		fieldErrors["serviceDate"] = []string{"missingRequiredField"}
+	} else if offset := time.Until(*params.ServiceDate); offset < -gtfs.ArrivalAlarmServiceDateWindow || offset > gtfs.ArrivalAlarmServiceDateWindow {
+		fieldErrors["serviceDate"] = []string{"Invalid field value for field \"serviceDate\"."}
+	}
+
+	callbackURL := query.Get("url")
+	if callbackURL == "" {
+		fieldErrors["url"] = []string{"missingRequiredField"}
+	} else if err := api.GtfsManager.ValidateAlarmCallbackURL(ctx, callbackURL); err != nil {
+		fieldErrors["url"] = []string{"Invalid field value for field \"url\"."}
+	}
+
+	var alarmTimeOffset int64
+	if offsetStr := query.Get("alarmTimeOffset"); offsetStr != "" {
+		alarmTimeOffset, err = strconv.ParseInt(offsetStr, 10, 64)
+		if err != nil || alarmTimeOffset < 0 {
+			fieldErrors["alarmTimeOffset"] = []string{"Invalid field value for field \"alarmTimeOffset\"."}
+		}
+	}
+
+	var onArrival bool
+	if onArrivalStr := query.Get("onArrival"); onArrivalStr != "" {
+		onArrival, err = strconv.ParseBool(onArrivalStr)
+		if err != nil {
+			fieldErrors["onArrival"] = []string{"Invalid field value for field \"onArrival\"."}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	_, tripID, err := utils.ExtractAgencyIDAndCodeID(params.TripID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	if _, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID); err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	var targetStopTime *gtfsdb.StopTime
+	for i, st := range stopTimes {
+		if st.StopID != stopCode {
+			continue
+		}
+		if params.StopSequence != nil && int64(*params.StopSequence) != st.StopSequence {
+			continue
+		}
+		targetStopTime = &stopTimes[i]
+		break
+	}
+
+	if targetStopTime == nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	vehicleID := ""
+	if params.VehicleID != "" {
+		if _, id, err := utils.ExtractAgencyIDAndCodeID(params.VehicleID); err == nil {
+			vehicleID = id
+		}
+	}
+
+	alarmID, err := newAlarmID()
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	alarmParams := gtfsdb.CreateArrivalAlarmParams{
+		ID:              alarmID,
+		AgencyID:        agencyID,
+		StopID:          stopCode,
+		TripID:          tripID,
+		ServiceDate:     params.ServiceDate.UnixMilli(),
+		StopSequence:    targetStopTime.StopSequence,
+		AlarmTimeOffset: alarmTimeOffset,
+		OnArrival:       0,
+		CallbackUrl:     callbackURL,
+		CreatedAt:       time.Now().UnixMilli(),
+	}
+	if vehicleID != "" {
+		alarmParams.VehicleID.String = vehicleID
+		alarmParams.VehicleID.Valid = true
+	}
+	if onArrival {
+		alarmParams.OnArrival = 1
+	}
+
+	alarm, err := api.GtfsManager.RegisterArrivalAlarm(ctx, clientIPOf(r), alarmParams)
+	if errors.Is(err, gtfs.ErrTooManyArrivalAlarms) {
+		api.sendErrorResponse(w, r, http.StatusTooManyRequests, "Too many arrival alarms. Please try again later.")
+		return
+	} else if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	entry := models.RegisteredAlarm{ID: utils.FormCombinedID(agencyID, alarm.ID)}
+	api.sendResponse(w, r, models.NewEntryResponse(entry, models.NewEmptyReferences()))
+}
+
+// newAlarmID returns a random identifier for an arrival alarm.
+func newAlarmID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
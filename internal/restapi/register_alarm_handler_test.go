@@ -0,0 +1,107 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func alarmTestTrip(t *testing.T, api *RestAPI) (string, int64) {
+	t.Helper()
+
+	var tripID string
+	var stopSequence int64
+	row := api.GtfsManager.GtfsDB().DB.QueryRowContext(context.Background(),
+		"SELECT trip_id, stop_sequence FROM stop_times WHERE stop_id = '1030' ORDER BY trip_id LIMIT 1")
+	require.NoError(t, row.Scan(&tripID, &stopSequence))
+	return tripID, stopSequence
+}
+
+// registerAlarmEndpoint returns the endpoint registering an alarm for the trip today, since
+// alarms are only accepted for service dates close to now.
+func registerAlarmEndpoint(tripID string, extra string) string {
+	return registerAlarmEndpointOn(tripID, time.Now(), extra)
+}
+
+func registerAlarmEndpointOn(tripID string, serviceDate time.Time, extra string) string {
+	return "/api/where/register-alarm-for-arrival-and-departure-at-stop/25_1030.json?key=TEST" +
+		"&tripId=25_" + tripID +
+		"&serviceDate=" + strconv.FormatInt(serviceDate.UnixMilli(), 10) + extra
+}
+
+func TestRegisterAlarmHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/register-alarm-for-arrival-and-departure-at-stop/25_1030.json?key=invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestRegisterAndCancelAlarm(t *testing.T) {
+	api := createTestApi(t)
+	tripID, stopSequence := alarmTestTrip(t, api)
+
+	callback := url.QueryEscape("http://localhost:9999/alarms?user=1")
+	resp, model := serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint(tripID, "&alarmTimeOffset=120&onArrival=true&url="+callback))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	entry := data["entry"].(map[string]interface{})
+	alarmID, ok := entry["id"].(string)
+	require.True(t, ok)
+	require.Contains(t, alarmID, "25_")
+
+	alarms, err := api.GtfsManager.GtfsDB().UserQueries.ListArrivalAlarms(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alarms, 1)
+	assert.Equal(t, tripID, alarms[0].TripID)
+	assert.Equal(t, "1030", alarms[0].StopID)
+	assert.Equal(t, stopSequence, alarms[0].StopSequence)
+	assert.Equal(t, int64(120), alarms[0].AlarmTimeOffset)
+	assert.Equal(t, int64(1), alarms[0].OnArrival)
+	assert.Equal(t, "http://localhost:9999/alarms?user=1", alarms[0].CallbackUrl)
+
+	resp, model = serveApiAndRetrieveEndpoint(t, api, "/api/where/cancel-alarm/"+alarmID+".json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+
+	alarms, err = api.GtfsManager.GtfsDB().UserQueries.ListArrivalAlarms(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, alarms)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/cancel-alarm/"+alarmID+".json?key=TEST")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRegisterAlarmValidation(t *testing.T) {
+	api := createTestApi(t)
+	api.rateLimiter = NewRateLimitMiddleware(1000, time.Second)
+	tripID, _ := alarmTestTrip(t, api)
+
+	resp, _ := serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint(tripID, ""))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "url is required")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint(tripID, "&url=ftp%3A%2F%2Fexample.com"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "only http callbacks are accepted")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint(tripID, "&url=http%3A%2F%2F203.0.113.10&alarmTimeOffset=-5"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	for _, internal := range []string{"http://127.0.0.1:9999/alarms", "http://169.254.169.254/latest", "http://10.0.0.1/alarms"} {
+		resp, _ = serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint(tripID, "&url="+url.QueryEscape(internal)))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "callbacks to %s are refused", internal)
+	}
+
+	for _, serviceDate := range []time.Time{arrivalsTestTime(t), time.Now().AddDate(0, 0, 30)} {
+		resp, _ = serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpointOn(tripID, serviceDate, "&url=http%3A%2F%2F203.0.113.10"))
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "service date %s is too far from now", serviceDate)
+	}
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, registerAlarmEndpoint("not-a-trip", "&url=http%3A%2F%2F203.0.113.10"))
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
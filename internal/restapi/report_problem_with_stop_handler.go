@@ -1,9 +1,12 @@
 package restapi
 
 import (
+	"database/sql"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/logging"
 	"maglev.onebusaway.org/internal/models"
 	"maglev.onebusaway.org/internal/utils"
@@ -12,29 +15,39 @@ import (
 func (api *RestAPI) reportProblemWithStopHandler(w http.ResponseWriter, r *http.Request) {
 	stopID := utils.ExtractIDFromParams(r)
 
-	// TODO: Add required validation
 	if stopID == "" {
 		api.sendNull(w, r)
 		return
 	}
 
-	query := r.URL.Query()
+	fieldErrors := map[string][]string{}
+	if err := utils.ValidateID(stopID); err != nil {
+		fieldErrors["id"] = []string{err.Error()}
+	}
+
+	params := gtfsdb.CreateProblemReportParams{
+		ReportType: problemReportTypeStop,
+		StopID:     sql.NullString{String: stopID, Valid: true},
+		CreatedAt:  time.Now().UnixMilli(),
+	}
+	parseProblemReportCommon(r.URL.Query(), stopProblemCodes, &params, fieldErrors)
+
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
 
-	code := query.Get("code")
-	userComment := query.Get("userComment")
-	userLat := query.Get("userLat")
-	userLon := query.Get("userLon")
-	userLocationAccuracy := query.Get("userLocationAccuracy")
+	report, err := api.GtfsManager.GtfsDB().UserQueries.CreateProblemReport(r.Context(), params)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
 
-	// TODO: Add storage logic for the problem report, I leave it as a log statement for now
 	logger := logging.FromContext(r.Context()).With(slog.String("component", "problem_reporting"))
 	logging.LogOperation(logger, "problem_report_received_for_stop",
+		slog.Int64("report_id", report.ID),
 		slog.String("stop_id", stopID),
-		slog.String("code", code),
-		slog.String("user_comment", userComment),
-		slog.String("user_lat", userLat),
-		slog.String("user_lon", userLon),
-		slog.String("user_location_accuracy", userLocationAccuracy))
+		slog.String("code", report.Code))
 
 	api.sendResponse(w, r, models.NewOKResponse(struct{}{}))
 }
@@ -1,9 +1,13 @@
 package restapi
 
 import (
+	"database/sql"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/logging"
 	"maglev.onebusaway.org/internal/models"
 	"maglev.onebusaway.org/internal/utils"
@@ -13,39 +17,69 @@ func (api *RestAPI) reportProblemWithTripHandler(w http.ResponseWriter, r *http.
 
 	tripID := utils.ExtractIDFromParams(r)
 
-	// TODO: Add required validation
 	if tripID == "" {
 		api.sendNull(w, r)
 		return
 	}
 
+	fieldErrors := map[string][]string{}
+	if err := utils.ValidateID(tripID); err != nil {
+		fieldErrors["id"] = []string{err.Error()}
+	}
+
 	query := r.URL.Query()
 
-	serviceDate := query.Get("serviceDate")
-	vehicleID := query.Get("vehicleId")
-	stopID := query.Get("stopId")
-	code := query.Get("code")
-	userComment := query.Get("userComment")
-	userOnVehicle := query.Get("userOnVehicle")
-	userVehicleNumber := query.Get("userVehicleNumber")
-	userLat := query.Get("userLat")
-	userLon := query.Get("userLon")
-	userLocationAccuracy := query.Get("userLocationAccuracy")
-
-	// TODO: Add storage logic for the problem report, I leave it as a log statement for now
+	params := gtfsdb.CreateProblemReportParams{
+		ReportType: problemReportTypeTrip,
+		TripID:     sql.NullString{String: tripID, Valid: true},
+		StopID:     parseOptionalID(query, "stopId", fieldErrors),
+		VehicleID:  parseOptionalID(query, "vehicleId", fieldErrors),
+		CreatedAt:  time.Now().UnixMilli(),
+	}
+	parseProblemReportCommon(query, tripProblemCodes, &params, fieldErrors)
+
+	if serviceDateStr := query.Get("serviceDate"); serviceDateStr != "" {
+		serviceDate, err := strconv.ParseInt(serviceDateStr, 10, 64)
+		if err != nil || serviceDate < 0 {
+			fieldErrors["serviceDate"] = invalidFieldValue("serviceDate")
+		} else {
+			params.ServiceDate = sql.NullInt64{Int64: serviceDate, Valid: true}
+		}
+	}
+
+	if userOnVehicleStr := query.Get("userOnVehicle"); userOnVehicleStr != "" {
+		userOnVehicle, err := strconv.ParseBool(userOnVehicleStr)
+		if err != nil {
+			fieldErrors["userOnVehicle"] = invalidFieldValue("userOnVehicle")
+		} else {
+			params.UserOnVehicle = sql.NullInt64{Int64: boolToInt64(userOnVehicle), Valid: true}
+		}
+	}
+
+	if vehicleNumber := query.Get("userVehicleNumber"); vehicleNumber != "" {
+		if len(vehicleNumber) > maxVehicleNumberLength {
+			fieldErrors["userVehicleNumber"] = invalidFieldValue("userVehicleNumber")
+		} else {
+			params.UserVehicleNumber = sql.NullString{String: utils.SanitizeInput(vehicleNumber), Valid: true}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	report, err := api.GtfsManager.GtfsDB().UserQueries.CreateProblemReport(r.Context(), params)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
 	logger := logging.FromContext(r.Context()).With(slog.String("component", "problem_reporting"))
 	logging.LogOperation(logger, "problem_report_received_for_trip",
+		slog.Int64("report_id", report.ID),
 		slog.String("trip_id", tripID),
-		slog.String("code", code),
-		slog.String("service_date", serviceDate),
-		slog.String("vehicle_id", vehicleID),
-		slog.String("stop_id", stopID),
-		slog.String("user_comment", userComment),
-		slog.String("user_on_vehicle", userOnVehicle),
-		slog.String("user_vehicle_number", userVehicleNumber),
-		slog.String("user_lat", userLat),
-		slog.String("user_lon", userLon),
-		slog.String("user_location_accuracy", userLocationAccuracy))
+		slog.String("code", report.Code))
 
 	api.sendResponse(w, r, models.NewOKResponse(struct{}{}))
 }
@@ -8,7 +8,7 @@ import (
 
 func (api *RestAPI) sendResponse(w http.ResponseWriter, r *http.Request, response models.ResponseModel) {
 	setJSONResponseType(&w)
-	err := json.NewEncoder(w).Encode(response)
+	err := json.NewEncoder(w).Encode(api.localizeResponse(r, response))
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -25,13 +25,18 @@ func (api *RestAPI) sendNull(w http.ResponseWriter, r *http.Request) { // nolint
 }
 
 func (api *RestAPI) sendNotFound(w http.ResponseWriter, r *http.Request) {
+	api.sendErrorResponse(w, r, http.StatusNotFound, "resource not found")
+}
+
+// sendErrorResponse sends a response with the given status code and an explanatory text.
+func (api *RestAPI) sendErrorResponse(w http.ResponseWriter, r *http.Request, status int, text string) {
 	setJSONResponseType(&w)
-	w.WriteHeader(http.StatusNotFound)
+	w.WriteHeader(status)
 
 	response := models.ResponseModel{
-		Code:        http.StatusNotFound,
+		Code:        status,
 		CurrentTime: models.ResponseCurrentTime(),
-		Text:        "resource not found",
+		Text:        text,
 		Version:     2,
 	}
 
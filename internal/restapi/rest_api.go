@@ -7,15 +7,21 @@ import (
 	"maglev.onebusaway.org/internal/app"
 )
 
+// problemReportsPerMinute is how many problem reports a single client IP address may submit per
+// minute
+const problemReportsPerMinute = 10
+
 type RestAPI struct {
 	*app.Application
-	rateLimiter func(http.Handler) http.Handler
+	rateLimiter              func(http.Handler) http.Handler
+	problemReportRateLimiter func(http.Handler) http.Handler
 }
 
-// NewRestAPI creates a new RestAPI instance with initialized rate limiter
+// NewRestAPI creates a new RestAPI instance with initialized rate limiters
 func NewRestAPI(app *app.Application) *RestAPI {
 	return &RestAPI{
-		Application: app,
-		rateLimiter: NewRateLimitMiddleware(app.Config.RateLimit, time.Second),
+		Application:              app,
+		rateLimiter:              NewRateLimitMiddleware(app.Config.RateLimit, time.Second),
+		problemReportRateLimiter: NewClientIPRateLimitMiddleware(problemReportsPerMinute, time.Minute),
 	}
 }
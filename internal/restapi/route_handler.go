@@ -0,0 +1,87 @@
+package restapi
+
+import (
+	"net/http"
+
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) routeHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	// Validate ID
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, routeID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, routeID)
+	if err != nil || route.AgencyID != agencyID {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	routeData := models.NewRoute(
+		utils.FormCombinedID(route.AgencyID, route.ID),
+		route.AgencyID,
+		route.ShortName.String,
+		route.LongName.String,
+		route.Desc.String,
+		models.RouteType(route.Type),
+		route.Url.String,
+		route.Color.String,
+		route.TextColor.String,
+		route.ShortName.String,
+	)
+
+	references := models.NewEmptyReferences()
+	references.Agencies = append(references.Agencies, models.NewAgencyReference(
+		agency.ID,
+		agency.Name,
+		agency.Url,
+		agency.Timezone,
+		agency.Lang.String,
+		agency.Phone.String,
+		agency.Email.String,
+		agency.FareUrl.String,
+		"",
+		false,
+	))
+
+	fareAttributes, err := api.GtfsManager.FaresForRoute(ctx, route.AgencyID, route.ID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	fares, fareAgencies, err := api.buildFares(ctx, route.AgencyID, fareAttributes)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	references.Fares = fares
+	for _, fareAgency := range fareAgencies {
+		if fareAgency.ID != agency.ID {
+			references.Agencies = append(references.Agencies, fareAgency)
+		}
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(routeData, references))
+}
@@ -0,0 +1,66 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/route/25_151.json?key=invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestRouteHandlerEndToEnd(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/route/25_151.json?key=TEST")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+	assert.Equal(t, "OK", model.Text)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_151", entry["id"])
+	assert.Equal(t, "25", entry["agencyId"])
+	assert.Equal(t, "1", entry["shortName"])
+	assert.Equal(t, "Route 1", entry["longName"])
+	assert.Equal(t, float64(3), entry["type"])
+	assert.Equal(t, "55d1b0", entry["color"])
+
+	references, ok := data["references"].(map[string]interface{})
+	require.True(t, ok)
+
+	agencies, ok := references["agencies"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, agencies, 1)
+
+	agency, ok := agencies[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25", agency["id"])
+}
+
+func TestRouteHandlerNotFound(t *testing.T) {
+	api := createTestApi(t)
+
+	for _, id := range []string{"25_nonexistent", "99_151", "151"} {
+		resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/route/"+id+".json?key=TEST")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, id)
+		assert.Equal(t, http.StatusNotFound, model.Code, id)
+		assert.Equal(t, "resource not found", model.Text, id)
+		assert.Nil(t, model.Data, id)
+	}
+}
+
+func TestRouteHandlerInvalidID(t *testing.T) {
+	_, resp, _ := serveAndRetrieveEndpoint(t, "/api/where/route/25_bad%20id.json?key=TEST")
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
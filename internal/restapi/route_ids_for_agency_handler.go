@@ -19,7 +19,7 @@ func (api *RestAPI) routeIDsForAgencyHandler(w http.ResponseWriter, r *http.Requ
 
 	ctx := r.Context()
 
-	routeIDs, err := api.GtfsManager.GtfsDB.Queries.GetRouteIDsForAgency(ctx, id)
+	routeIDs, err := api.GtfsManager.GtfsDB().Queries.GetRouteIDsForAgency(ctx, id)
 
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
@@ -32,11 +32,32 @@ func rateLimitAndValidateAPIKey(api *RestAPI, finalHandler handlerFunc) http.Han
 			api.invalidAPIKeyResponse(w, r)
 			return
 		}
-		// Then apply rate limiting and compression
-		rateLimitedHandler.ServeHTTP(w, r)
+		// Then apply rate limiting and compression, with the rider's language in the context
+		rateLimitedHandler.ServeHTTP(w, api.withRequestLanguage(w, r))
 	})
 }
 
+// validateAdminAPIKey guards operator-only endpoints, which require one of the configured admin API keys
+func validateAdminAPIKey(api *RestAPI, finalHandler handlerFunc) http.Handler {
+	compressedHandler := CompressionMiddleware(http.HandlerFunc(finalHandler))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if api.RequestHasInvalidAdminAPIKey(r) {
+			api.invalidAPIKeyResponse(w, r)
+			return
+		}
+		compressedHandler.ServeHTTP(w, r)
+	})
+}
+
+// limitProblemReports applies the stricter problem report submission limit in front of a handler
+func (api *RestAPI) limitProblemReports(finalHandler handlerFunc) handlerFunc {
+	if api.problemReportRateLimiter == nil {
+		return finalHandler
+	}
+	return api.problemReportRateLimiter(http.HandlerFunc(finalHandler)).ServeHTTP
+}
+
 func registerPprofHandlers(mux *http.ServeMux) { // nolint:unused
 	// Register pprof handlers
 	// import "net/http/pprof"
@@ -53,25 +74,45 @@ func (api *RestAPI) SetRoutes(mux *http.ServeMux) {
 	mux.Handle("GET /api/where/agencies-with-coverage.json", rateLimitAndValidateAPIKey(api, api.agenciesWithCoverageHandler))
 	mux.Handle("GET /api/where/agency/{id}", rateLimitAndValidateAPIKey(api, api.agencyHandler))
 	mux.Handle("GET /api/where/current-time.json", rateLimitAndValidateAPIKey(api, api.currentTimeHandler))
+	mux.Handle("GET /api/where/config.json", rateLimitAndValidateAPIKey(api, api.configHandler))
 	mux.Handle("GET /api/where/routes-for-agency/{id}", rateLimitAndValidateAPIKey(api, api.routesForAgencyHandler))
 	mux.Handle("GET /api/where/vehicles-for-agency/{id}", rateLimitAndValidateAPIKey(api, api.vehiclesForAgencyHandler))
 	mux.Handle("GET /api/where/stops-for-location.json", rateLimitAndValidateAPIKey(api, api.stopsForLocationHandler))
 	mux.Handle("GET /api/where/stop-ids-for-agency/{id}", rateLimitAndValidateAPIKey(api, api.stopIDsForAgencyHandler))
-	mux.Handle("GET /api/where/report-problem-with-trip/{id}", rateLimitAndValidateAPIKey(api, api.reportProblemWithTripHandler))
-	mux.Handle("GET /api/where/report-problem-with-stop/{id}", rateLimitAndValidateAPIKey(api, api.reportProblemWithStopHandler))
+	mux.Handle("GET /api/where/report-problem-with-trip/{id}", rateLimitAndValidateAPIKey(api, api.limitProblemReports(api.reportProblemWithTripHandler)))
+	mux.Handle("GET /api/where/report-problem-with-stop/{id}", rateLimitAndValidateAPIKey(api, api.limitProblemReports(api.reportProblemWithStopHandler)))
 	mux.Handle("GET /api/where/trip/{id}", rateLimitAndValidateAPIKey(api, api.tripHandler))
+	mux.Handle("GET /api/where/route/{id}", rateLimitAndValidateAPIKey(api, api.routeHandler))
 	mux.Handle("GET /api/where/route-ids-for-agency/{id}", rateLimitAndValidateAPIKey(api, api.routeIDsForAgencyHandler))
 	mux.Handle("GET /api/where/stop/{id}", rateLimitAndValidateAPIKey(api, api.stopHandler))
+	mux.Handle("GET /api/where/station/{id}", rateLimitAndValidateAPIKey(api, api.stationHandler))
 	mux.Handle("GET /api/where/shape/{id}", rateLimitAndValidateAPIKey(api, api.shapesHandler))
 	mux.Handle("GET /api/where/routes-for-location.json", rateLimitAndValidateAPIKey(api, api.routesForLocationHandler))
 	mux.Handle("GET /api/where/stops-for-route/{id}", rateLimitAndValidateAPIKey(api, api.stopsForRouteHandler))
+	mux.Handle("GET /api/where/transfers-for-stop/{id}", rateLimitAndValidateAPIKey(api, api.transfersForStopHandler))
 	mux.Handle("GET /api/where/schedule-for-stop/{id}", rateLimitAndValidateAPIKey(api, api.scheduleForStopHandler))
+	mux.Handle("GET /api/where/schedule-for-route/{id}", rateLimitAndValidateAPIKey(api, api.scheduleForRouteHandler))
+	mux.Handle("GET /api/where/service-dates-for-service/{id}", rateLimitAndValidateAPIKey(api, api.serviceDatesForServiceHandler))
 	mux.Handle("GET /api/where/trip-details/{id}", rateLimitAndValidateAPIKey(api, api.tripDetailsHandler))
+	mux.Handle("GET /api/where/fares-for-trip/{id}", rateLimitAndValidateAPIKey(api, api.faresForTripHandler))
+	mux.Handle("GET /api/where/fare-for-itinerary.json", rateLimitAndValidateAPIKey(api, api.fareForItineraryHandler))
 	mux.Handle("GET /api/where/block/{id}", rateLimitAndValidateAPIKey(api, api.blockHandler))
 	mux.Handle("GET /api/where/trip-for-vehicle/{id}", rateLimitAndValidateAPIKey(api, api.tripForVehicleHandler))
 	mux.Handle("GET /api/where/trips-for-location.json", rateLimitAndValidateAPIKey(api, api.tripsForLocationHandler))
 	mux.Handle("GET /api/where/arrival-and-departure-for-stop/{id}", rateLimitAndValidateAPIKey(api, api.arrivalAndDepartureForStopHandler))
+	mux.Handle("GET /api/where/arrivals-and-departures-for-stop/{id}", rateLimitAndValidateAPIKey(api, api.arrivalsAndDeparturesForStopHandler))
 	mux.Handle("GET /api/where/trips-for-route/{id}", rateLimitAndValidateAPIKey(api, api.tripsForRouteHandler))
+	mux.Handle("GET /api/where/search/stop.json", rateLimitAndValidateAPIKey(api, api.searchStopHandler))
+	mux.Handle("GET /api/where/search/route.json", rateLimitAndValidateAPIKey(api, api.searchRouteHandler))
+	mux.Handle("GET /api/where/situation/{id}", rateLimitAndValidateAPIKey(api, api.situationHandler))
+	mux.Handle("GET /api/where/register-alarm-for-arrival-and-departure-at-stop/{id}", rateLimitAndValidateAPIKey(api, api.registerAlarmForArrivalAndDepartureAtStopHandler))
+	mux.Handle("GET /api/where/cancel-alarm/{id}", rateLimitAndValidateAPIKey(api, api.cancelAlarmHandler))
+
+	mux.Handle("GET /api/admin/problem-reports.json", validateAdminAPIKey(api, api.adminListProblemReportsHandler))
+	mux.Handle("GET /api/admin/problem-reports/export", validateAdminAPIKey(api, api.adminExportProblemReportsHandler))
+	mux.Handle("POST /api/admin/problem-reports/{id}/resolve", validateAdminAPIKey(api, api.adminResolveProblemReportHandler))
+	mux.Handle("GET /api/admin/validation.json", validateAdminAPIKey(api, api.adminListValidationFindingsHandler))
+	mux.Handle("GET /api/admin/gtfs-db-rebuilds.json", validateAdminAPIKey(api, api.adminGtfsDBRebuildsHandler))
 }
 
 // SetupAPIRoutes creates and configures the API router with all middleware applied globally
@@ -85,7 +85,7 @@ func (api *RestAPI) routesForLocationHandler(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Batch query to get all routes for all stops
-	routesForStops, err := api.GtfsManager.GtfsDB.Queries.GetRoutesForStops(ctx, stopIDs)
+	routesForStops, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, stopIDs)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
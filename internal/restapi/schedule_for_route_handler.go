@@ -0,0 +1,331 @@
+package restapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) scheduleForRouteHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	// Validate ID
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, routeID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	dateParam := r.URL.Query().Get("date")
+	if err := utils.ValidateDate(dateParam); err != nil {
+		fieldErrors := map[string][]string{
+			"date": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, routeID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	var serviceDate time.Time
+	if dateParam != "" {
+		parsedDate, err := time.ParseInLocation("2006-01-02", dateParam, loc)
+		if err != nil {
+			fieldErrors := map[string][]string{
+				"date": {"Invalid date format. Use YYYY-MM-DD"},
+			}
+			api.validationErrorResponse(w, r, fieldErrors)
+			return
+		}
+		serviceDate = parsedDate
+	} else {
+		now := time.Now().In(loc)
+		serviceDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	}
+
+	serviceIDs, err := api.GtfsManager.GetActiveServiceIDsForDate(ctx, serviceDate)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	routeTrips, err := api.GtfsManager.GtfsDB().Queries.GetTripsForRouteInActiveServiceIDs(ctx, gtfsdb.GetTripsForRouteInActiveServiceIDsParams{
+		RouteID:    routeID,
+		ServiceIds: serviceIDs,
+	})
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	type scheduledTrip struct {
+		trip      gtfsdb.Trip
+		stopTimes []gtfsdb.StopTime
+	}
+
+	tripsByDirection := make(map[string][]scheduledTrip)
+	usedServiceIDs := make(map[string]bool)
+	for _, trip := range routeTrips {
+		stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, trip.ID)
+		if err != nil {
+			api.serverErrorResponse(w, r, err)
+			return
+		}
+		if len(stopTimes) == 0 {
+			continue
+		}
+		directionID := strconv.FormatInt(trip.DirectionID.Int64, 10)
+		tripsByDirection[directionID] = append(tripsByDirection[directionID], scheduledTrip{trip: trip, stopTimes: stopTimes})
+		usedServiceIDs[trip.ServiceID] = true
+	}
+
+	directionIDs := make([]string, 0, len(tripsByDirection))
+	for directionID := range tripsByDirection {
+		directionIDs = append(directionIDs, directionID)
+	}
+	sort.Strings(directionIDs)
+
+	references := models.NewEmptyReferences()
+	referencedStopIDs := []string{}
+	groupings := make([]models.StopTripGrouping, 0, len(directionIDs))
+
+	for _, directionID := range directionIDs {
+		trips := tripsByDirection[directionID]
+
+		// Trips are listed in the order they leave their first stop
+		sort.SliceStable(trips, func(i, j int) bool {
+			return trips[i].stopTimes[0].DepartureTime < trips[j].stopTimes[0].DepartureTime
+		})
+
+		patterns := make([][]string, len(trips))
+		for i, t := range trips {
+			pattern := make([]string, len(t.stopTimes))
+			for j, st := range t.stopTimes {
+				pattern[j] = st.StopID
+			}
+			patterns[i] = pattern
+		}
+		orderedStopIDs := mergeStopPatterns(patterns)
+
+		grouping := models.StopTripGrouping{
+			DirectionID:        directionID,
+			StopIDs:            make([]string, len(orderedStopIDs)),
+			TripHeadsigns:      []string{},
+			TripIDs:            make([]string, 0, len(trips)),
+			TripsWithStopTimes: make([]models.TripWithStopTimes, 0, len(trips)),
+		}
+		for i, stopID := range orderedStopIDs {
+			grouping.StopIDs[i] = utils.FormCombinedID(agencyID, stopID)
+		}
+		referencedStopIDs = append(referencedStopIDs, orderedStopIDs...)
+
+		seenHeadsigns := make(map[string]bool)
+		for _, t := range trips {
+			combinedTripID := utils.FormCombinedID(agencyID, t.trip.ID)
+			combinedServiceID := utils.FormCombinedID(agencyID, t.trip.ServiceID)
+
+			if headsign := t.trip.TripHeadsign.String; headsign != "" && !seenHeadsigns[headsign] {
+				seenHeadsigns[headsign] = true
+				grouping.TripHeadsigns = append(grouping.TripHeadsigns, headsign)
+			}
+
+			stopTimes := make([]models.RouteScheduleStopTime, 0, len(t.stopTimes))
+			for _, st := range t.stopTimes {
+				// Stop times are stored in nanoseconds since midnight of the service date
+				stopTime := models.NewRouteScheduleStopTime(
+					serviceDate.Add(time.Duration(st.ArrivalTime)).UnixMilli(),
+					serviceDate.Add(time.Duration(st.DepartureTime)).UnixMilli(),
+					combinedServiceID,
+					st.StopHeadsign.String,
+					utils.FormCombinedID(agencyID, st.StopID),
+					combinedTripID,
+				)
+				stopTime.StopSequence = st.StopSequence
+				// Realtime data can cancel the trip or skip the stop on the service date
+				if status := api.arrivalStatus(t.trip.ID, serviceDate, st.StopID, st.StopSequence); status != arrivalStatusDefault {
+					stopTime.Status = status
+				}
+				stopTimes = append(stopTimes, stopTime)
+			}
+
+			tripWithStopTimes := models.TripWithStopTimes{
+				StopTimes: stopTimes,
+				TripID:    combinedTripID,
+			}
+			if api.GtfsManager.IsTripCanceled(t.trip.ID, serviceDate) {
+				tripWithStopTimes.Status = arrivalStatusCanceled
+			}
+			grouping.TripIDs = append(grouping.TripIDs, combinedTripID)
+			grouping.TripsWithStopTimes = append(grouping.TripsWithStopTimes, tripWithStopTimes)
+
+			references.Trips = append(references.Trips, models.NewTripReference(
+				combinedTripID,
+				utils.FormCombinedID(agencyID, t.trip.RouteID),
+				combinedServiceID,
+				t.trip.TripHeadsign.String,
+				t.trip.TripShortName.String,
+				t.trip.DirectionID.Int64,
+				utils.FormCombinedID(agencyID, t.trip.BlockID.String),
+				utils.FormCombinedID(agencyID, t.trip.ShapeID.String),
+			))
+		}
+
+		groupings = append(groupings, grouping)
+	}
+
+	combinedServiceIDs := make([]string, 0, len(usedServiceIDs))
+	for serviceID := range usedServiceIDs {
+		combinedServiceIDs = append(combinedServiceIDs, utils.FormCombinedID(agencyID, serviceID))
+	}
+	sort.Strings(combinedServiceIDs)
+
+	entry := models.ScheduleForRouteEntry{
+		RouteID:           utils.FormCombinedID(agencyID, routeID),
+		ScheduleDate:      serviceDate.UnixMilli(),
+		ServiceIDs:        combinedServiceIDs,
+		StopTripGroupings: groupings,
+	}
+
+	stops, uniqueRouteMap, err := BuildStopReferencesAndRouteIDsForStops(api, ctx, agencyID, referencedStopIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	references.Stops = stops
+
+	for _, routeRow := range uniqueRouteMap {
+		references.Routes = append(references.Routes, models.NewRoute(
+			utils.FormCombinedID(agencyID, routeRow.ID),
+			routeRow.AgencyID,
+			routeRow.ShortName.String,
+			routeRow.LongName.String,
+			routeRow.Desc.String,
+			models.RouteType(routeRow.Type),
+			routeRow.Url.String,
+			routeRow.Color.String,
+			routeRow.TextColor.String,
+			routeRow.ShortName.String,
+		))
+	}
+
+	references.Agencies = append(references.Agencies, models.NewAgencyReference(
+		agency.ID,
+		agency.Name,
+		agency.Url,
+		agency.Timezone,
+		agency.Lang.String,
+		agency.Phone.String,
+		agency.Email.String,
+		agency.FareUrl.String,
+		"",
+		false,
+	))
+
+	api.sendResponse(w, r, models.NewEntryResponse(entry, references))
+}
+
+// mergeStopPatterns merges the stop sequences of several trips into a single ordering that
+// respects the order of every pattern wherever the patterns agree. Branches that leave and
+// rejoin a shared trunk are placed one after the other between the shared stops. A stop that a
+// trip visits more than once (a loop) appears once per visit.
+func mergeStopPatterns(patterns [][]string) []string {
+	type node struct {
+		stopID     string
+		occurrence int
+	}
+
+	// Longer patterns are visited first so that they determine the order among ties
+	ordered := make([][]string, len(patterns))
+	copy(ordered, patterns)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return len(ordered[i]) > len(ordered[j])
+	})
+
+	discovery := make(map[node]int)
+	var nodes []node
+	successors := make(map[node]map[node]bool)
+	inDegree := make(map[node]int)
+
+	for _, pattern := range ordered {
+		occurrences := make(map[string]int)
+		var previous *node
+		for _, stopID := range pattern {
+			n := node{stopID: stopID, occurrence: occurrences[stopID]}
+			occurrences[stopID]++
+
+			if _, seen := discovery[n]; !seen {
+				discovery[n] = len(nodes)
+				nodes = append(nodes, n)
+				successors[n] = make(map[node]bool)
+			}
+			if previous != nil && !successors[*previous][n] {
+				successors[*previous][n] = true
+				inDegree[n]++
+			}
+			current := n
+			previous = &current
+		}
+	}
+
+	// Kahn's algorithm, always emitting the earliest discovered available stop. When patterns
+	// disagree the graph has a cycle; it is broken by emitting the earliest remaining stop.
+	emitted := make(map[node]bool, len(nodes))
+	result := make([]string, 0, len(nodes))
+	for len(result) < len(nodes) {
+		next := -1
+		for i, n := range nodes {
+			if !emitted[n] && inDegree[n] <= 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			for i, n := range nodes {
+				if !emitted[n] {
+					next = i
+					break
+				}
+			}
+		}
+
+		n := nodes[next]
+		emitted[n] = true
+		result = append(result, n.stopID)
+		for successor := range successors[n] {
+			inDegree[successor]--
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,189 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func TestScheduleForRouteHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/schedule-for-route/25_151.json?key=invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestScheduleForRouteHandlerEndToEnd(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/schedule-for-route/25_151.json?key=TEST&date=2025-06-12")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+	assert.Equal(t, "OK", model.Text)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_151", entry["routeId"])
+	assert.NotZero(t, entry["scheduleDate"])
+	assert.NotEmpty(t, entry["serviceIds"])
+
+	groupings, ok := entry["stopTripGroupings"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, groupings)
+
+	for _, g := range groupings {
+		grouping, ok := g.(map[string]interface{})
+		require.True(t, ok)
+
+		assert.NotEmpty(t, grouping["directionId"])
+
+		stopIDs, ok := grouping["stopIds"].([]interface{})
+		require.True(t, ok)
+		require.NotEmpty(t, stopIDs)
+
+		stopPositions := make(map[string]int, len(stopIDs))
+		for i, id := range stopIDs {
+			if _, exists := stopPositions[id.(string)]; !exists {
+				stopPositions[id.(string)] = i
+			}
+		}
+
+		tripIDs, ok := grouping["tripIds"].([]interface{})
+		require.True(t, ok)
+
+		tripsWithStopTimes, ok := grouping["tripsWithStopTimes"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, tripsWithStopTimes, len(tripIDs))
+
+		for _, tws := range tripsWithStopTimes {
+			trip := tws.(map[string]interface{})
+			stopTimes := trip["stopTimes"].([]interface{})
+			require.NotEmpty(t, stopTimes)
+
+			previousTime := float64(0)
+			for _, st := range stopTimes {
+				stopTime := st.(map[string]interface{})
+				assert.Equal(t, trip["tripId"], stopTime["tripId"])
+
+				_, known := stopPositions[stopTime["stopId"].(string)]
+				assert.True(t, known, "every stop time should refer to a stop of the grouping")
+
+				departure := stopTime["departureTime"].(float64)
+				assert.GreaterOrEqual(t, departure, previousTime, "stop times should be in trip order")
+				previousTime = departure
+			}
+		}
+	}
+
+	references, ok := data["references"].(map[string]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, references["agencies"])
+	assert.NotEmpty(t, references["routes"])
+	assert.NotEmpty(t, references["stops"])
+	assert.NotEmpty(t, references["trips"])
+}
+
+func TestScheduleForRouteHandlerMarksSkippedStops(t *testing.T) {
+	api, cleanup := createTestApiWithRealTimeData(t)
+	defer cleanup()
+
+	// The fixture has the trip skip stops 3027 through 3031 on June 9
+	tripID := "03969589-98dc-4fcd-a1c2-ce084b4ca5d2"
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(context.Background(), tripID)
+	require.NoError(t, err)
+
+	_, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/schedule-for-route/"+utils.FormCombinedID("25", trip.RouteID)+".json?key=TEST&date=2025-06-09")
+	require.Equal(t, http.StatusOK, model.Code)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+
+	var statuses map[string]interface{}
+	for _, g := range entry["stopTripGroupings"].([]interface{}) {
+		for _, tws := range g.(map[string]interface{})["tripsWithStopTimes"].([]interface{}) {
+			tripWithStopTimes := tws.(map[string]interface{})
+			if tripWithStopTimes["tripId"] != "25_"+tripID {
+				continue
+			}
+			assert.Nil(t, tripWithStopTimes["status"], "the trip itself still runs")
+			statuses = make(map[string]interface{})
+			for _, st := range tripWithStopTimes["stopTimes"].([]interface{}) {
+				stopTime := st.(map[string]interface{})
+				statuses[stopTime["stopId"].(string)] = stopTime["status"]
+			}
+		}
+	}
+	require.NotNil(t, statuses, "the trip is scheduled on the date")
+	assert.Equal(t, "SKIPPED", statuses["25_3027"])
+	assert.Equal(t, "SKIPPED", statuses["25_3031"])
+	require.Contains(t, statuses, "25_3026")
+	assert.Nil(t, statuses["25_3026"], "stops the trip serves have no status")
+}
+
+func TestScheduleForRouteHandlerNoServiceOnDate(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/schedule-for-route/25_151.json?key=TEST&date=2030-01-01")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	entry := data["entry"].(map[string]interface{})
+	assert.Empty(t, entry["stopTripGroupings"])
+}
+
+func TestScheduleForRouteHandlerInvalidRequests(t *testing.T) {
+	api := createTestApi(t)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/schedule-for-route/25_nonexistent.json?key=TEST")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, model.Code)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/schedule-for-route/25_151.json?key=TEST&date=2025-13-45")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestMergeStopPatterns(t *testing.T) {
+	t.Run("single pattern", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "c"}, mergeStopPatterns([][]string{{"a", "b", "c"}}))
+	})
+
+	t.Run("short turn", func(t *testing.T) {
+		merged := mergeStopPatterns([][]string{{"b", "c"}, {"a", "b", "c", "d"}})
+		assert.Equal(t, []string{"a", "b", "c", "d"}, merged)
+	})
+
+	t.Run("branch that rejoins the trunk", func(t *testing.T) {
+		merged := mergeStopPatterns([][]string{
+			{"a", "b", "c", "d", "e"},
+			{"a", "b", "x", "y", "e"},
+		})
+		assert.Equal(t, []string{"a", "b", "c", "d", "x", "y", "e"}, merged)
+	})
+
+	t.Run("diverging terminals", func(t *testing.T) {
+		merged := mergeStopPatterns([][]string{
+			{"a", "b", "c"},
+			{"a", "b", "z"},
+		})
+		assert.Equal(t, []string{"a", "b", "c", "z"}, merged)
+	})
+
+	t.Run("loop visits a stop twice", func(t *testing.T) {
+		merged := mergeStopPatterns([][]string{{"a", "b", "c", "a"}})
+		assert.Equal(t, []string{"a", "b", "c", "a"}, merged)
+	})
+
+	t.Run("conflicting orders", func(t *testing.T) {
+		merged := mergeStopPatterns([][]string{{"a", "b", "c"}, {"c", "b", "a"}})
+		assert.ElementsMatch(t, []string{"a", "b", "c"}, merged)
+	})
+}
@@ -2,6 +2,7 @@ package restapi
 
 import (
 	"net/http"
+	"sort"
 	"time"
 
 	"maglev.onebusaway.org/internal/models"
@@ -57,28 +58,41 @@ func (api *RestAPI) scheduleForStopHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Verify stop exists
-	_, err = api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
+	_, err = api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
 	// Get schedule data for the stop
-	scheduleRows, err := api.GtfsManager.GtfsDB.Queries.GetScheduleForStop(ctx, stopID)
+	scheduleRows, err := api.GtfsManager.GtfsDB().Queries.GetScheduleForStop(ctx, stopID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
+	// Only trips whose service runs on the date are scheduled
+	serviceCalendar, err := api.GtfsManager.GetServiceCalendar(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	serviceDate := time.UnixMilli(date).UTC().Format("20060102")
+
 	// Build references maps
 	agencyRefs := make(map[string]models.AgencyReference)
 	routeRefs := make(map[string]models.Route)
 
 	// Group schedule data by route
 	routeScheduleMap := make(map[string][]models.ScheduleStopTime)
+	routeFrequencyMap := make(map[string][]models.ScheduleFrequency)
 	routeHeadsignMap := make(map[string]string)
 
 	for _, row := range scheduleRows {
+		if !serviceCalendar.IsActive(row.ServiceID, serviceDate) {
+			continue
+		}
+
 		combinedRouteID := utils.FormCombinedID(agencyID, row.RouteID)
 		combinedTripID := utils.FormCombinedID(agencyID, row.TripID)
 
@@ -87,18 +101,57 @@ func (api *RestAPI) scheduleForStopHandler(w http.ResponseWriter, r *http.Reques
 		startOfDay := time.Unix(date/1000, 0).Truncate(24 * time.Hour)
 		arrivalDuration := time.Duration(row.ArrivalTime)
 		departureDuration := time.Duration(row.DepartureTime)
-		arrivalTimeMs := startOfDay.Add(arrivalDuration).UnixMilli()
-		departureTimeMs := startOfDay.Add(departureDuration).UnixMilli()
 
-		stopTime := models.NewScheduleStopTime(
-			arrivalTimeMs,
-			departureTimeMs,
-			row.ServiceID,
-			row.StopHeadsign.String,
-			combinedTripID,
-		)
+		// Realtime data can cancel the trip or skip the stop on the requested date
+		status := api.arrivalStatus(row.TripID, startOfDay, stopID, row.StopSequence)
+		if status == arrivalStatusDefault {
+			status = ""
+		}
 
-		routeScheduleMap[combinedRouteID] = append(routeScheduleMap[combinedRouteID], stopTime)
+		frequency := frequencyFromColumns(row.TripID, row.FrequencyStartTime, row.FrequencyEndTime, row.FrequencyHeadwaySecs, row.FrequencyExactTimes)
+		if frequency != nil {
+			// Frequency-based stop times are measured from the departure at the trip's first stop
+			arrivalOffset := arrivalDuration - time.Duration(row.TripStartTime)
+			departureOffset := departureDuration - time.Duration(row.TripStartTime)
+
+			if frequency.ExactTimes == frequencyBased {
+				scheduleFrequency := models.NewScheduleFrequency(
+					startOfDay.UnixMilli(),
+					startOfDay.Add(time.Duration(frequency.StartTime)+arrivalOffset).UnixMilli(),
+					startOfDay.Add(time.Duration(frequency.EndTime)+departureOffset).UnixMilli(),
+					frequency.HeadwaySecs,
+					row.ServiceID,
+					row.StopHeadsign.String,
+					combinedTripID,
+				)
+				scheduleFrequency.StopSequence = row.StopSequence
+				routeFrequencyMap[combinedRouteID] = append(routeFrequencyMap[combinedRouteID], scheduleFrequency)
+			} else {
+				for _, start := range frequencyTripStartTimes(*frequency) {
+					stopTime := models.NewScheduleStopTime(
+						startOfDay.Add(start+arrivalOffset).UnixMilli(),
+						startOfDay.Add(start+departureOffset).UnixMilli(),
+						row.ServiceID,
+						row.StopHeadsign.String,
+						combinedTripID,
+					)
+					stopTime.Status = status
+					stopTime.StopSequence = row.StopSequence
+					routeScheduleMap[combinedRouteID] = append(routeScheduleMap[combinedRouteID], stopTime)
+				}
+			}
+		} else {
+			stopTime := models.NewScheduleStopTime(
+				startOfDay.Add(arrivalDuration).UnixMilli(),
+				startOfDay.Add(departureDuration).UnixMilli(),
+				row.ServiceID,
+				row.StopHeadsign.String,
+				combinedTripID,
+			)
+			stopTime.Status = status
+			stopTime.StopSequence = row.StopSequence
+			routeScheduleMap[combinedRouteID] = append(routeScheduleMap[combinedRouteID], stopTime)
+		}
 
 		// Store the trip headsign for this route
 		if row.TripHeadsign.Valid && row.TripHeadsign.String != "" {
@@ -107,7 +160,7 @@ func (api *RestAPI) scheduleForStopHandler(w http.ResponseWriter, r *http.Reques
 
 		// Add route to references if not already present
 		if _, exists := routeRefs[combinedRouteID]; !exists {
-			route, err := api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, row.RouteID)
+			route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, row.RouteID)
 			if err == nil {
 				routeModel := models.NewRoute(
 					combinedRouteID,
@@ -127,7 +180,7 @@ func (api *RestAPI) scheduleForStopHandler(w http.ResponseWriter, r *http.Reques
 
 		// Add agency to references if not already present
 		if _, exists := agencyRefs[row.AgencyID]; !exists {
-			agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, row.AgencyID)
+			agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, row.AgencyID)
 			if err == nil {
 				agencyModel := models.NewAgencyReference(
 					agency.ID,
@@ -147,10 +200,20 @@ func (api *RestAPI) scheduleForStopHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Build the route schedules
-	var routeSchedules []models.StopRouteSchedule
+	for routeID := range routeFrequencyMap {
+		if _, exists := routeScheduleMap[routeID]; !exists {
+			routeScheduleMap[routeID] = []models.ScheduleStopTime{}
+		}
+	}
+
+	routeSchedules := []models.StopRouteSchedule{}
 	for routeID, stopTimes := range routeScheduleMap {
+		// Expanded exact-times runs are appended out of order
+		sort.SliceStable(stopTimes, func(i, j int) bool {
+			return stopTimes[i].ArrivalTime < stopTimes[j].ArrivalTime
+		})
 		tripHeadsign := routeHeadsignMap[routeID]
-		directionSchedule := models.NewStopRouteDirectionSchedule(tripHeadsign, stopTimes)
+		directionSchedule := models.NewStopRouteDirectionSchedule(tripHeadsign, stopTimes, routeFrequencyMap[routeID])
 		routeSchedule := models.NewStopRouteSchedule(routeID, []models.StopRouteDirectionSchedule{directionSchedule})
 		routeSchedules = append(routeSchedules, routeSchedule)
 	}
@@ -0,0 +1,50 @@
+package restapi
+
+import (
+	"net/http"
+
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) searchRouteHandler(w http.ResponseWriter, r *http.Request) {
+	input, maxCount, fieldErrors := parseSearchParams(r)
+	if fieldErrors != nil {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	ctx := r.Context()
+
+	routes, err := api.GtfsManager.GtfsDB().SearchRoutes(ctx, input, maxCount)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	// Routes keep the relevance order returned by the search index
+	results := make([]models.Route, 0, len(routes))
+	agencyIDs := map[string]bool{}
+	for _, route := range routes {
+		agencyIDs[route.AgencyID] = true
+		results = append(results, models.NewRoute(
+			utils.FormCombinedID(route.AgencyID, route.ID),
+			route.AgencyID,
+			route.ShortName.String,
+			route.LongName.String,
+			route.Desc.String,
+			models.RouteType(route.Type),
+			route.Url.String,
+			route.Color.String,
+			route.TextColor.String,
+			route.ShortName.String,
+		))
+	}
+
+	references := models.NewEmptyReferences()
+	if agencies := utils.FilterAgencies(api.GtfsManager.GetAgencies(), agencyIDs); agencies != nil {
+		references.Agencies = agencies
+	}
+
+	api.sendResponse(w, r, models.NewListResponse(results, references))
+}
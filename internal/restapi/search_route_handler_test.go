@@ -0,0 +1,51 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRouteHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/route.json?key=invalid&input=299X")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestSearchRouteHandlerEndToEnd(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/route.json?key=TEST&input=299X")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+	assert.Equal(t, "OK", model.Text)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	list, ok := data["list"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, list)
+
+	first, ok := list[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_161", first["id"])
+	assert.Equal(t, "299X", first["shortName"])
+	assert.Equal(t, "25", first["agencyId"])
+
+	references, ok := data["references"].(map[string]interface{})
+	require.True(t, ok)
+
+	agencies, ok := references["agencies"].([]interface{})
+	require.True(t, ok)
+	assert.Len(t, agencies, 1)
+}
+
+func TestSearchRouteHandlerMissingInput(t *testing.T) {
+	_, resp, _ := serveAndRetrieveEndpoint(t, "/api/where/search/route.json?key=TEST")
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,174 @@
+package restapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+const (
+	defaultSearchMaxCount = 20
+	maxSearchMaxCount     = 250
+)
+
+// parseSearchParams reads the input and maxCount parameters shared by the search endpoints.
+func parseSearchParams(r *http.Request) (string, int, map[string][]string) {
+	queryParams := r.URL.Query()
+
+	input := queryParams.Get("input")
+	if input == "" {
+		return "", 0, map[string][]string{
+			"input": {"missingRequiredField"},
+		}
+	}
+
+	sanitizedInput, err := utils.ValidateAndSanitizeQuery(input)
+	if err != nil {
+		return "", 0, map[string][]string{
+			"input": {err.Error()},
+		}
+	}
+
+	maxCount := defaultSearchMaxCount
+	if maxCountStr := queryParams.Get("maxCount"); maxCountStr != "" {
+		parsed, err := strconv.Atoi(maxCountStr)
+		if err != nil || parsed <= 0 {
+			return "", 0, map[string][]string{
+				"maxCount": {"Invalid field value for field \"maxCount\"."},
+			}
+		}
+		maxCount = min(parsed, maxSearchMaxCount)
+	}
+
+	return sanitizedInput, maxCount, nil
+}
+
+func (api *RestAPI) searchStopHandler(w http.ResponseWriter, r *http.Request) {
+	input, maxCount, fieldErrors := parseSearchParams(r)
+	if fieldErrors != nil {
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	ctx := r.Context()
+
+	stops, err := api.GtfsManager.GtfsDB().SearchStops(ctx, input, maxCount)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	results := make([]models.Stop, 0, len(stops))
+	references := models.NewEmptyReferences()
+
+	if len(stops) == 0 {
+		api.sendResponse(w, r, models.NewListResponse(results, references))
+		return
+	}
+
+	stopIDs := make([]string, 0, len(stops))
+	for _, stop := range stops {
+		stopIDs = append(stopIDs, stop.ID)
+	}
+
+	routesForStops, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, stopIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	agenciesForStops, err := api.GtfsManager.GtfsDB().Queries.GetAgenciesForStops(ctx, stopIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	stopRouteIDs := make(map[string][]string)
+	routeRefs := make(map[string]models.Route)
+	agencyIDs := map[string]bool{}
+	for _, route := range routesForStops {
+		agencyIDs[route.AgencyID] = true
+		combinedRouteID := utils.FormCombinedID(route.AgencyID, route.ID)
+		stopRouteIDs[route.StopID] = append(stopRouteIDs[route.StopID], combinedRouteID)
+		routeRefs[combinedRouteID] = models.NewRoute(
+			combinedRouteID,
+			route.AgencyID,
+			route.ShortName.String,
+			route.LongName.String,
+			route.Desc.String,
+			models.RouteType(route.Type),
+			route.Url.String,
+			route.Color.String,
+			route.TextColor.String,
+			route.ShortName.String,
+		)
+	}
+
+	stopAgencyIDs := make(map[string]string)
+	for _, agencyRow := range agenciesForStops {
+		if _, exists := stopAgencyIDs[agencyRow.StopID]; !exists {
+			stopAgencyIDs[agencyRow.StopID] = agencyRow.ID
+			agencyIDs[agencyRow.ID] = true
+		}
+	}
+
+	// Stops no route serves, such as stations and entrances, take the first agency of their feed
+	var feedAgencyIDs map[string]string
+	for _, stop := range stops {
+		if _, ok := stopAgencyIDs[stop.ID]; ok {
+			continue
+		}
+		if feedAgencyIDs == nil {
+			agencies, err := api.GtfsManager.GtfsDB().Queries.ListAgencies(ctx)
+			if err != nil {
+				api.serverErrorResponse(w, r, err)
+				return
+			}
+			feedAgencyIDs = make(map[string]string)
+			for _, agency := range agencies {
+				if _, exists := feedAgencyIDs[agency.SourceFeedID.String]; !exists {
+					feedAgencyIDs[agency.SourceFeedID.String] = agency.ID
+				}
+			}
+		}
+		if agencyID, ok := feedAgencyIDs[stop.SourceFeedID.String]; ok {
+			stopAgencyIDs[stop.ID] = agencyID
+			agencyIDs[agencyID] = true
+		}
+	}
+
+	// Stops keep the relevance order returned by the search index
+	for _, stop := range stops {
+		agencyID := stopAgencyIDs[stop.ID]
+		rids := stopRouteIDs[stop.ID]
+		if rids == nil {
+			rids = []string{}
+		}
+
+		results = append(results, models.NewStop(
+			stop.Code.String,
+			api.calculateStopDirection(ctx, stop.ID),
+			utils.FormCombinedID(agencyID, stop.ID),
+			stop.Name.String,
+			utils.FormCombinedID(agencyID, stop.ParentStation.String),
+			utils.MapWheelchairBoarding(gtfs.WheelchairBoarding(stop.WheelchairBoarding.Int64)),
+			stop.Lat,
+			stop.Lon,
+			int(stop.LocationType.Int64),
+			rids,
+			rids,
+		))
+	}
+
+	if agencies := utils.FilterAgencies(api.GtfsManager.GetAgencies(), agencyIDs); agencies != nil {
+		references.Agencies = agencies
+	}
+	for _, route := range routeRefs {
+		references.Routes = append(references.Routes, route)
+	}
+
+	api.sendResponse(w, r, models.NewListResponse(results, references))
+}
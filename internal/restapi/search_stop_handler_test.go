@@ -0,0 +1,120 @@
+package restapi
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestSearchStopHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/stop.json?key=invalid&input=Northpoint")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestSearchStopHandlerEndToEnd(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/stop.json?key=TEST&input="+url.QueryEscape("Northpoint Lake"))
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+	assert.Equal(t, "OK", model.Text)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	list, ok := data["list"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, list)
+
+	first, ok := list[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_1001", first["id"])
+	assert.Equal(t, "1001", first["code"])
+	assert.Equal(t, "Northpoint Dr at Lake Blvd", first["name"])
+	assert.NotEmpty(t, first["routeIds"])
+
+	references, ok := data["references"].(map[string]interface{})
+	require.True(t, ok)
+
+	agencies, ok := references["agencies"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, agencies)
+
+	routes, ok := references["routes"].([]interface{})
+	require.True(t, ok)
+	assert.NotEmpty(t, routes)
+}
+
+func TestSearchStopHandlerToleratesTypos(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/stop.json?key=TEST&input=Nortpoint")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	list := data["list"].([]interface{})
+	require.NotEmpty(t, list)
+	assert.Contains(t, list[0].(map[string]interface{})["name"], "Northpoint")
+}
+
+func TestSearchStopHandlerMaxCount(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/stop.json?key=TEST&input=Blvd&maxCount=2")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	list := data["list"].([]interface{})
+	assert.NotEmpty(t, list)
+	assert.LessOrEqual(t, len(list), 2)
+}
+
+func TestSearchStopHandlerNoResults(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/search/stop.json?key=TEST&input=zzzzqqqq")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	assert.Empty(t, data["list"])
+}
+
+func TestSearchStopHandlerReturnsStopsWithoutRoutes(t *testing.T) {
+	api := createTestApiWithFeed(t, models.BuildStationFeed(t, models.GetFixturePath(t, "raba.zip")))
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/search/stop.json?key=TEST&input="+url.QueryEscape("Central Station"))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	list, ok := data["list"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, list)
+
+	// No trip calls at the station itself, so it takes the agency of its feed
+	station, ok := list[0].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_STN", station["id"])
+	assert.Equal(t, []interface{}{}, station["routeIds"])
+
+	agencies, ok := data["references"].(map[string]interface{})["agencies"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, agencies, 1)
+	assert.Equal(t, "25", agencies[0].(map[string]interface{})["id"])
+}
+
+func TestSearchStopHandlerValidation(t *testing.T) {
+	api := createTestApi(t)
+
+	resp, _ := serveApiAndRetrieveEndpoint(t, api, "/api/where/search/stop.json?key=TEST")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/search/stop.json?key=TEST&input=main&maxCount=abc")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/search/stop.json?key=TEST&input="+url.QueryEscape("<script>"))
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,67 @@
+package restapi
+
+import (
+	"net/http"
+	"time"
+
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) serviceDatesForServiceHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	// Validate ID
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, serviceID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+	loc, err := time.LoadLocation(agency.Timezone)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	serviceCalendar, err := api.GtfsManager.GetServiceCalendar(ctx)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	if !serviceCalendar.HasService(serviceID) {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	dates := serviceCalendar.ServiceDates(serviceID)
+	serviceDates := make([]int64, 0, len(dates))
+	for _, date := range dates {
+		serviceMidnight, err := time.ParseInLocation("20060102", date, loc)
+		if err != nil {
+			continue
+		}
+		serviceDates = append(serviceDates, serviceMidnight.UnixMilli())
+	}
+
+	entry := models.ServiceDates{
+		ServiceID:    utils.FormCombinedID(agencyID, serviceID),
+		ServiceDates: serviceDates,
+	}
+	api.sendResponse(w, r, models.NewEntryResponse(entry, models.NewEmptyReferences()))
+}
@@ -0,0 +1,47 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceDatesForServiceHandler(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/service-dates-for-service/25_c_1658_b_18260_d_31.json?key=TEST")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "25_c_1658_b_18260_d_31", entry["serviceId"])
+
+	serviceDates, ok := entry["serviceDates"].([]interface{})
+	require.True(t, ok)
+	dates := make(map[string]bool, len(serviceDates))
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+	for _, serviceDate := range serviceDates {
+		midnight := time.UnixMilli(int64(serviceDate.(float64))).In(loc)
+		assert.Zero(t, midnight.Hour(), "service dates start at midnight in the agency's time zone")
+		dates[midnight.Format("20060102")] = true
+	}
+
+	assert.True(t, dates["20250328"], "a Friday the weekday service runs")
+	assert.False(t, dates["20250329"], "a Saturday")
+	assert.False(t, dates["20250331"], "a holiday removed by calendar_dates.txt")
+	assert.True(t, dates["20240101"], "the first day of the calendar")
+}
+
+func TestServiceDatesForServiceHandlerNotFound(t *testing.T) {
+	api := createTestApi(t)
+
+	for _, id := range []string{"25_nonexistent", "99_c_1658_b_18260_d_31"} {
+		resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/service-dates-for-service/"+id+".json?key=TEST")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, id)
+		assert.Equal(t, http.StatusNotFound, model.Code, id)
+	}
+}
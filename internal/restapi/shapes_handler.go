@@ -20,14 +20,14 @@ func (api *RestAPI) shapesHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	_, err = api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	_, err = api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	shapes, err := api.GtfsManager.GtfsDB.Queries.GetShapeByID(ctx, shapeID)
+	shapes, err := api.GtfsManager.GtfsDB().Queries.GetShapeByID(ctx, shapeID)
 
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
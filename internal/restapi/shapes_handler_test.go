@@ -13,7 +13,7 @@ func TestShapesHandlerReturnsShapeWhenItExists(t *testing.T) {
 	api := createTestApi(t)
 
 	ctx := context.Background()
-	shapes, err := api.GtfsManager.GtfsDB.Queries.GetAllShapes(ctx)
+	shapes, err := api.GtfsManager.GtfsDB().Queries.GetAllShapes(ctx)
 	require.NoError(t, err)
 	require.NotEmpty(t, shapes)
 
@@ -52,7 +52,7 @@ func TestShapesHandlerRequiresValidApiKey(t *testing.T) {
 	api := createTestApi(t)
 
 	ctx := context.Background()
-	shapes, err := api.GtfsManager.GtfsDB.Queries.GetAllShapes(ctx)
+	shapes, err := api.GtfsManager.GtfsDB().Queries.GetAllShapes(ctx)
 	require.NoError(t, err)
 	require.NotEmpty(t, shapes)
 
@@ -0,0 +1,46 @@
+package restapi
+
+import (
+	"net/http"
+
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) situationHandler(w http.ResponseWriter, r *http.Request) {
+	id := utils.ExtractIDFromParams(r)
+
+	if err := utils.ValidateID(id); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	alert := api.GtfsManager.GetAlertByID(id)
+	if alert == nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	situation := api.buildSituation(r.Context(), *alert)
+
+	references := models.NewEmptyReferences()
+	agencyIDs := make(map[string]bool)
+	for _, affects := range situation.AllAffects {
+		for _, combinedID := range []string{affects.RouteID, affects.StopID, affects.TripID} {
+			if agencyID, _, err := utils.ExtractAgencyIDAndCodeID(combinedID); err == nil {
+				agencyIDs[agencyID] = true
+			}
+		}
+		if affects.AgencyID != "" {
+			agencyIDs[affects.AgencyID] = true
+		}
+	}
+	if agencies := utils.FilterAgencies(api.GtfsManager.GetAgencies(), agencyIDs); agencies != nil {
+		references.Agencies = agencies
+	}
+
+	api.sendResponse(w, r, models.NewEntryResponse(situation, references))
+}
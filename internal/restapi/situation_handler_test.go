@@ -0,0 +1,130 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	gtfsrt "github.com/OneBusAway/go-gtfs/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func addTestAlert(t *testing.T, api *RestAPI, tripID string) gtfs.Alert {
+	t.Helper()
+
+	routeID := "151"
+	stopID := "1030"
+	startsAt := time.Date(2025, 6, 12, 5, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(4 * time.Hour)
+
+	alert := gtfs.Alert{
+		ID:     "detour-151",
+		Cause:  gtfsrt.Alert_CONSTRUCTION,
+		Effect: gtfsrt.Alert_DETOUR,
+		ActivePeriods: []gtfs.AlertActivePeriod{
+			{StartsAt: &startsAt, EndsAt: &endsAt},
+		},
+		InformedEntities: []gtfs.AlertInformedEntity{
+			{RouteID: &routeID, DirectionID: gtfs.DirectionID_True},
+			{StopID: &stopID},
+			{TripID: &gtfs.TripID{ID: tripID}},
+		},
+		Header: []gtfs.AlertText{
+			{Text: "Desvío de la ruta 1", Language: "es"},
+			{Text: "Route 1 detour", Language: "en"},
+		},
+		Description: []gtfs.AlertText{{Text: "Buses are detoured around road works."}},
+	}
+	api.GtfsManager.MockAddAlert(alert)
+	api.GtfsManager.MockSetAlertSeverity(alert.ID, gtfsrt.Alert_WARNING)
+	return alert
+}
+
+func TestSituationHandlerRequiresValidApiKey(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/situation/detour-151.json?key=invalid")
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, model.Code)
+	assert.Equal(t, "permission denied", model.Text)
+}
+
+func TestSituationHandlerEndToEnd(t *testing.T) {
+	api := createTestApi(t)
+	tripID := api.GtfsManager.GetTrips()[0].ID
+	alert := addTestAlert(t, api, tripID)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/situation/detour-151.json?key=TEST")
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, http.StatusOK, model.Code)
+
+	data, ok := model.Data.(map[string]interface{})
+	require.True(t, ok)
+
+	entry, ok := data["entry"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "detour-151", entry["id"])
+	assert.Equal(t, "CONSTRUCTION", entry["reason"])
+	assert.Equal(t, "WARNING", entry["severity"])
+	assert.Equal(t, float64(alert.ActivePeriods[0].StartsAt.UnixMilli()), entry["creationTime"])
+
+	summary := entry["summary"].(map[string]interface{})
+	assert.Equal(t, "en", summary["lang"])
+	assert.Equal(t, "Route 1 detour", summary["value"])
+
+	description := entry["description"].(map[string]interface{})
+	assert.Equal(t, "Buses are detoured around road works.", description["value"])
+	assert.Nil(t, entry["url"])
+
+	windows := entry["activeWindows"].([]interface{})
+	require.Len(t, windows, 1)
+	window := windows[0].(map[string]interface{})
+	assert.Equal(t, float64(alert.ActivePeriods[0].StartsAt.UnixMilli()), window["from"])
+	assert.Equal(t, float64(alert.ActivePeriods[0].EndsAt.UnixMilli()), window["to"])
+
+	consequences := entry["consequences"].([]interface{})
+	require.Len(t, consequences, 1)
+	assert.Equal(t, "DETOUR", consequences[0].(map[string]interface{})["condition"])
+
+	affects := entry["allAffects"].([]interface{})
+	require.Len(t, affects, 3)
+	routeAffects := affects[0].(map[string]interface{})
+	assert.Equal(t, "25_151", routeAffects["routeId"])
+	assert.Equal(t, "1", routeAffects["directionId"])
+	assert.Equal(t, "25_1030", affects[1].(map[string]interface{})["stopId"])
+	assert.Equal(t, utils.FormCombinedID("25", tripID), affects[2].(map[string]interface{})["tripId"])
+
+	references := data["references"].(map[string]interface{})
+	agencies := references["agencies"].([]interface{})
+	require.Len(t, agencies, 1)
+	assert.Equal(t, "25", agencies[0].(map[string]interface{})["id"])
+}
+
+func TestSituationHandlerUnknownID(t *testing.T) {
+	_, resp, model := serveAndRetrieveEndpoint(t, "/api/where/situation/missing.json?key=TEST")
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, model.Code)
+}
+
+func TestTripDetailsHandlerIncludesSituationReferences(t *testing.T) {
+	api := createTestApi(t)
+	tripID := api.GtfsManager.GetTrips()[0].ID
+	addTestAlert(t, api, tripID)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api,
+		"/api/where/trip-details/"+utils.FormCombinedID("25", tripID)+".json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	entry := data["entry"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"detour-151"}, entry["situationIds"])
+
+	references := data["references"].(map[string]interface{})
+	situations := references["situations"].([]interface{})
+	require.Len(t, situations, 1)
+	assert.Equal(t, "detour-151", situations[0].(map[string]interface{})["id"])
+}
@@ -0,0 +1,172 @@
+package restapi
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// BuildSituationReferences returns the situations for the given situation IDs, in the order they
+// are first listed. Duplicates and IDs that no longer match an alert in the feed are skipped.
+func (api *RestAPI) BuildSituationReferences(ctx context.Context, situationIDs []string) []interface{} {
+	situations := []interface{}{}
+	seen := make(map[string]bool, len(situationIDs))
+	for _, situationID := range situationIDs {
+		if seen[situationID] {
+			continue
+		}
+		seen[situationID] = true
+
+		alert := api.GtfsManager.GetAlertByID(situationID)
+		if alert == nil {
+			continue
+		}
+		situations = append(situations, api.buildSituation(ctx, *alert))
+	}
+	return situations
+}
+
+// buildSituation maps a GTFS-realtime service alert onto the OneBusAway situation model.
+func (api *RestAPI) buildSituation(ctx context.Context, alert gtfs.Alert) models.Situation {
+	preferences := languageFromContext(ctx).preferences
+	situation := models.Situation{
+		ActiveWindows:      make([]models.TimeWindow, 0, len(alert.ActivePeriods)),
+		AllAffects:         make([]models.SituationAffects, 0, len(alert.InformedEntities)),
+		Consequences:       []models.SituationConsequence{{Condition: alert.Effect.String()}},
+		ID:                 alert.ID,
+		PublicationWindows: []models.TimeWindow{},
+		Reason:             alert.Cause.String(),
+		Summary:            selectAlertText(alert.Header, preferences),
+		Description:        selectAlertText(alert.Description, preferences),
+		URL:                selectAlertText(alert.URL, preferences),
+	}
+
+	if severity, ok := api.GtfsManager.GetAlertSeverity(alert.ID); ok {
+		situation.Severity = severity.String()
+	}
+
+	// GTFS-realtime alerts carry no creation time, so the start of the earliest active period is used
+	for _, period := range alert.ActivePeriods {
+		window := models.TimeWindow{}
+		if period.StartsAt != nil {
+			window.From = period.StartsAt.UnixMilli()
+			if situation.CreationTime == 0 || window.From < situation.CreationTime {
+				situation.CreationTime = window.From
+			}
+		}
+		if period.EndsAt != nil {
+			window.To = period.EndsAt.UnixMilli()
+		}
+		situation.ActiveWindows = append(situation.ActiveWindows, window)
+	}
+
+	for _, entity := range alert.InformedEntities {
+		agencyID := api.situationAgencyID(ctx, entity)
+		affects := models.SituationAffects{}
+		if entity.AgencyID != nil {
+			affects.AgencyID = *entity.AgencyID
+		}
+		if entity.RouteID != nil {
+			affects.RouteID = utils.FormCombinedID(agencyID, *entity.RouteID)
+		}
+		if entity.StopID != nil {
+			affects.StopID = utils.FormCombinedID(agencyID, *entity.StopID)
+		}
+		if entity.TripID != nil && entity.TripID.ID != "" {
+			affects.TripID = utils.FormCombinedID(agencyID, entity.TripID.ID)
+		}
+		switch entity.DirectionID {
+		case gtfs.DirectionID_False:
+			affects.DirectionID = "0"
+		case gtfs.DirectionID_True:
+			affects.DirectionID = "1"
+		}
+		situation.AllAffects = append(situation.AllAffects, affects)
+	}
+
+	return situation
+}
+
+// situationAgencyID works out which agency's IDs an informed entity refers to, so that its route,
+// stop and trip IDs can be combined with it. The first agency in the feed is the fallback.
+func (api *RestAPI) situationAgencyID(ctx context.Context, entity gtfs.AlertInformedEntity) string {
+	if entity.AgencyID != nil && *entity.AgencyID != "" {
+		return *entity.AgencyID
+	}
+
+	routeID := ""
+	if entity.RouteID != nil {
+		routeID = *entity.RouteID
+	} else if entity.TripID != nil {
+		routeID = entity.TripID.RouteID
+		if routeID == "" && entity.TripID.ID != "" {
+			if trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, entity.TripID.ID); err == nil {
+				routeID = trip.RouteID
+			}
+		}
+	}
+	if routeID != "" {
+		if route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, routeID); err == nil {
+			return route.AgencyID
+		}
+	}
+
+	if entity.StopID != nil {
+		agencies, err := api.GtfsManager.GtfsDB().Queries.GetAgenciesForStops(ctx, []string{*entity.StopID})
+		if err == nil && len(agencies) > 0 {
+			return agencies[0].ID
+		}
+	}
+
+	if agencies := api.GtfsManager.GetAgencies(); len(agencies) > 0 {
+		return agencies[0].Id
+	}
+	return ""
+}
+
+// selectAlertText picks the translation to report for an alert text, preferring the rider's
+// languages in order, then English and then translations without a language tag. A preferred
+// language also matches translations sharing its primary subtag, so "es" matches "es-MX". It
+// returns nil when the alert has no text.
+func selectAlertText(texts []gtfs.AlertText, preferences []string) *models.NaturalLanguageString {
+	if len(texts) == 0 {
+		return nil
+	}
+
+	ranked := make([]gtfs.AlertText, len(texts))
+	copy(ranked, texts)
+	rank := func(lang string) int {
+		lang = strings.ToLower(lang)
+		base, _, _ := strings.Cut(lang, "-")
+		for i, preference := range preferences {
+			preference = strings.ToLower(preference)
+			preferenceBase, _, _ := strings.Cut(preference, "-")
+			switch {
+			case lang != "" && lang == preference:
+				return 2 * i
+			case lang != "" && base == preferenceBase:
+				return 2*i + 1
+			}
+		}
+		switch {
+		case strings.HasPrefix(lang, "en"):
+			return 2 * len(preferences)
+		case lang == "":
+			return 2*len(preferences) + 1
+		default:
+			return 2*len(preferences) + 2
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rank(ranked[i].Language) < rank(ranked[j].Language)
+	})
+
+	return &models.NaturalLanguageString{
+		Lang:  ranked[0].Language,
+		Value: ranked[0].Text,
+	}
+}
@@ -0,0 +1,198 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"net/http"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// maxAccessibleSlope is the steepest ramp, as rise over run, usable by wheelchair users.
+const maxAccessibleSlope = 1.0 / 12
+
+// stationHandler returns a station's platforms, entrances, levels and pathway graph. A stop
+// inside a station resolves to that station.
+func (api *RestAPI) stationHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, stopID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+	queries := api.GtfsManager.GtfsDB().Queries
+
+	station, err := queries.GetStop(ctx, stopID)
+	if err != nil || station.ID == "" {
+		api.sendNotFound(w, r)
+		return
+	}
+	// Walk up from platforms and boarding areas, guarding against cyclic parent_station values
+	for seen := map[string]bool{station.ID: true}; station.ParentStation.Valid && !seen[station.ParentStation.String]; {
+		parent, err := queries.GetStop(ctx, station.ParentStation.String)
+		if err != nil {
+			break
+		}
+		seen[parent.ID] = true
+		station = parent
+	}
+	if station.LocationType.Int64 != models.StationLocation {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	children, err := api.stationChildren(ctx, station.ID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	stationIDs := make([]string, 0, len(children)+1)
+	stationIDs = append(stationIDs, station.ID)
+	for _, child := range children {
+		stationIDs = append(stationIDs, child.ID)
+	}
+
+	pathways, err := queries.GetPathwaysForStops(ctx, gtfsdb.GetPathwaysForStopsParams{
+		FromStopIds: stationIDs,
+		ToStopIds:   stationIDs,
+	})
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	entry := models.Station{
+		ID:                 utils.FormCombinedID(agencyID, station.ID),
+		Code:               station.Code.String,
+		Name:               station.Name.String,
+		Lat:                station.Lat,
+		Lon:                station.Lon,
+		WheelchairBoarding: utils.MapWheelchairBoarding(gtfs.WheelchairBoarding(station.WheelchairBoarding.Int64)),
+		StopIDs:            []string{},
+		EntranceIDs:        []string{},
+		Levels:             []models.Level{},
+		Pathways:           make([]models.Pathway, 0, len(pathways)),
+	}
+
+	levelStops := make(map[string][]string)
+	var levelIDs []string
+	for _, stop := range append([]gtfsdb.Stop{station}, children...) {
+		combinedID := utils.FormCombinedID(agencyID, stop.ID)
+		switch stop.LocationType.Int64 {
+		case models.StationLocation:
+		case models.EntranceExitLocation:
+			entry.EntranceIDs = append(entry.EntranceIDs, combinedID)
+		default:
+			entry.StopIDs = append(entry.StopIDs, combinedID)
+		}
+		if stop.LevelID.Valid {
+			if _, ok := levelStops[stop.LevelID.String]; !ok {
+				levelIDs = append(levelIDs, stop.LevelID.String)
+			}
+			levelStops[stop.LevelID.String] = append(levelStops[stop.LevelID.String], combinedID)
+		}
+	}
+
+	if len(levelIDs) > 0 {
+		levels, err := queries.GetLevelsByIDs(ctx, levelIDs)
+		if err != nil {
+			api.serverErrorResponse(w, r, err)
+			return
+		}
+		for _, level := range levels {
+			entry.Levels = append(entry.Levels, models.Level{
+				ID:      utils.FormCombinedID(agencyID, level.LevelID),
+				Index:   level.LevelIndex,
+				Name:    level.LevelName.String,
+				StopIDs: levelStops[level.LevelID],
+			})
+		}
+	}
+
+	for _, pathway := range pathways {
+		entry.Pathways = append(entry.Pathways, newPathwayModel(agencyID, pathway))
+	}
+
+	references := models.NewEmptyReferences()
+	stopRefs, err := api.buildStopReferencesByID(ctx, agencyID, stationIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	references.Stops = stopRefs
+
+	api.sendResponse(w, r, models.NewEntryResponse(entry, references))
+}
+
+// stationChildren returns every stop inside a station: its platforms, entrances and generic
+// nodes, and the boarding areas of its platforms.
+func (api *RestAPI) stationChildren(ctx context.Context, stationID string) ([]gtfsdb.Stop, error) {
+	var children []gtfsdb.Stop
+	seen := map[string]bool{stationID: true}
+	parents := []sql.NullString{{String: stationID, Valid: true}}
+	for len(parents) > 0 {
+		stops, err := api.GtfsManager.GtfsDB().Queries.GetChildStops(ctx, parents)
+		if err != nil {
+			return nil, err
+		}
+		parents = nil
+		for _, stop := range stops {
+			if seen[stop.ID] {
+				continue
+			}
+			seen[stop.ID] = true
+			children = append(children, stop)
+			parents = append(parents, sql.NullString{String: stop.ID, Valid: true})
+		}
+	}
+	return children, nil
+}
+
+func newPathwayModel(agencyID string, pathway gtfsdb.Pathway) models.Pathway {
+	model := models.Pathway{
+		ID:                   utils.FormCombinedID(agencyID, pathway.PathwayID),
+		FromStopID:           utils.FormCombinedID(agencyID, pathway.FromStopID),
+		ToStopID:             utils.FormCombinedID(agencyID, pathway.ToStopID),
+		PathwayMode:          int(pathway.PathwayMode),
+		IsBidirectional:      pathway.IsBidirectional == 1,
+		SignpostedAs:         pathway.SignpostedAs.String,
+		ReversedSignpostedAs: pathway.ReversedSignpostedAs.String,
+		IsElevator:           pathway.PathwayMode == models.ElevatorPathway,
+		HasStairs:            pathway.PathwayMode == models.StairsPathway,
+	}
+	if pathway.Length.Valid {
+		model.Length = &pathway.Length.Float64
+	}
+	if pathway.TraversalTime.Valid {
+		model.TraversalTime = &pathway.TraversalTime.Int64
+	}
+	if pathway.StairCount.Valid {
+		model.StairCount = &pathway.StairCount.Int64
+	}
+	if pathway.MaxSlope.Valid {
+		model.MaxSlope = &pathway.MaxSlope.Float64
+	}
+	if pathway.MinWidth.Valid {
+		model.MinWidth = &pathway.MinWidth.Float64
+	}
+	model.WheelchairAccessible = pathway.PathwayMode != models.StairsPathway &&
+		pathway.PathwayMode != models.EscalatorPathway &&
+		(!pathway.MaxSlope.Valid || math.Abs(pathway.MaxSlope.Float64) <= maxAccessibleSlope)
+	return model
+}
@@ -0,0 +1,83 @@
+package restapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestStationHandler(t *testing.T) {
+	api := createTestApiWithFeed(t, models.BuildStationFeed(t, models.GetFixturePath(t, "raba.zip")))
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/station/25_STN.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	entry := data["entry"].(map[string]interface{})
+	assert.Equal(t, "25_STN", entry["id"])
+	assert.Equal(t, "Central Station", entry["name"])
+	assert.Equal(t, "ACCESSIBLE", entry["wheelchairBoarding"])
+	assert.ElementsMatch(t, []interface{}{"25_STN_N", "25_STN_P1", "25_STN_P2", "25_STN_P1_B"}, entry["stopIds"])
+	assert.Equal(t, []interface{}{"25_STN_E"}, entry["entranceIds"])
+
+	levels := entry["levels"].([]interface{})
+	require.Len(t, levels, 2)
+	concourse := levels[0].(map[string]interface{})
+	assert.Equal(t, "25_L1", concourse["id"])
+	assert.Equal(t, -1.0, concourse["index"])
+	assert.ElementsMatch(t, []interface{}{"25_STN_P1", "25_STN_P2", "25_STN_P1_B"}, concourse["stopIds"])
+
+	pathways := make(map[string]map[string]interface{})
+	for _, p := range entry["pathways"].([]interface{}) {
+		pathway := p.(map[string]interface{})
+		pathways[pathway["id"].(string)] = pathway
+	}
+	require.Len(t, pathways, 5)
+
+	walk := pathways["25_PW_WALK"]
+	assert.Equal(t, "25_STN_E", walk["fromStopId"])
+	assert.Equal(t, "25_STN_N", walk["toStopId"])
+	assert.Equal(t, 25.5, walk["length"])
+	assert.Equal(t, true, walk["wheelchairAccessible"])
+
+	stairs := pathways["25_PW_STAIRS"]
+	assert.Equal(t, true, stairs["hasStairs"])
+	assert.Equal(t, false, stairs["wheelchairAccessible"])
+	assert.Equal(t, -24.0, stairs["stairCount"])
+
+	elevator := pathways["25_PW_ELEVATOR"]
+	assert.Equal(t, true, elevator["isElevator"])
+	assert.Equal(t, true, elevator["wheelchairAccessible"])
+
+	assert.Equal(t, false, pathways["25_PW_ESCALATOR"]["isBidirectional"])
+	assert.Equal(t, false, pathways["25_PW_ESCALATOR"]["wheelchairAccessible"])
+	assert.Equal(t, false, pathways["25_PW_RAMP"]["wheelchairAccessible"], "the ramp is steeper than 1:12")
+
+	stops := make(map[string]map[string]interface{})
+	for _, s := range data["references"].(map[string]interface{})["stops"].([]interface{}) {
+		stop := s.(map[string]interface{})
+		stops[stop["id"].(string)] = stop
+	}
+	require.Len(t, stops, 6)
+	assert.Equal(t, "", stops["25_STN"]["parent"])
+	assert.Equal(t, "25_STN", stops["25_STN_P1"]["parent"])
+	assert.Equal(t, "25_STN_P1", stops["25_STN_P1_B"]["parent"])
+	assert.Equal(t, 2.0, stops["25_STN_E"]["locationType"])
+}
+
+func TestStationHandlerResolvesChildStops(t *testing.T) {
+	api := createTestApiWithFeed(t, models.BuildStationFeed(t, models.GetFixturePath(t, "raba.zip")))
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/station/25_STN_P1_B.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "25_STN", model.Data.(map[string]interface{})["entry"].(map[string]interface{})["id"])
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/station/25_1001.json?key=TEST")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "a stop outside any station is not a station")
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/station/25_nonexistent.json?key=TEST")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
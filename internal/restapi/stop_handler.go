@@ -27,13 +27,13 @@ func (api *RestAPI) stopHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	stop, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
+	stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
 	if err != nil || stop.ID == "" {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	routes, err := api.GtfsManager.GtfsDB.Queries.GetRoutesForStop(ctx, stopID)
+	routes, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStop(ctx, stopID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -44,6 +44,12 @@ func (api *RestAPI) stopHandler(w http.ResponseWriter, r *http.Request) {
 		combinedRouteIDs[i] = utils.FormCombinedID(agencyID, route.ID)
 	}
 
+	transferHints, err := api.transferHintsForStops(ctx, map[string]string{stop.ID: agencyID})
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
 	stopData := &models.Stop{
 		ID:                 utils.FormCombinedID(agencyID, stop.ID),
 		Name:               stop.Name.String,
@@ -52,9 +58,11 @@ func (api *RestAPI) stopHandler(w http.ResponseWriter, r *http.Request) {
 		Code:               stop.Code.String,
 		Direction:          "",
 		LocationType:       int(stop.LocationType.Int64),
+		Parent:             utils.FormCombinedID(agencyID, stop.ParentStation.String),
 		WheelchairBoarding: models.UnknownValue,
 		RouteIDs:           combinedRouteIDs,
 		StaticRouteIDs:     combinedRouteIDs,
+		TransferHints:      transferHints[stop.ID],
 	}
 
 	references := models.NewEmptyReferences()
@@ -77,7 +85,7 @@ func (api *RestAPI) stopHandler(w http.ResponseWriter, r *http.Request) {
 
 	if len(routes) > 0 {
 		route := routes[0]
-		agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, route.AgencyID)
+		agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
 		if err == nil {
 			agencyModel := models.NewAgencyReference(
 				agency.ID,
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"maglev.onebusaway.org/internal/models"
 	"maglev.onebusaway.org/internal/utils"
 )
@@ -88,3 +89,17 @@ func TestInvalidStopID(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, model.Code)
 	assert.Equal(t, "resource not found", model.Text)
 }
+
+func TestStopHandlerIncludesParentStation(t *testing.T) {
+	api := createStationTestApi(t)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/stop/25_STN_P1.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.Equal(t, "25_STN", entry["parent"])
+
+	resp, model = serveApiAndRetrieveEndpoint(t, api, "/api/where/stop/25_1001.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	entry = model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.Equal(t, "", entry["parent"])
+}
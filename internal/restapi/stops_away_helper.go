@@ -0,0 +1,114 @@
+package restapi
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+// tripStopDistances holds a trip's shape and how far along it each of the trip's stops is.
+type tripStopDistances struct {
+	stopTimes   []gtfsdb.StopTime
+	shapePoints []gtfs.ShapePoint
+	distances   []float64 // Distance along the shape of each stop time, in meters
+	length      float64   // Length of the shape, in meters
+}
+
+// loadTripStopDistances places the stops of a trip along its shape. It returns false when the
+// trip has no shape or no stop times.
+func (api *RestAPI) loadTripStopDistances(ctx context.Context, tripID string) (*tripStopDistances, bool) {
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
+	if err != nil || len(stopTimes) == 0 {
+		return nil, false
+	}
+	shapePoints, distances, err := api.GtfsManager.StopDistancesForTrip(ctx, tripID, stopTimes)
+	if err != nil || shapePoints == nil {
+		return nil, false
+	}
+
+	trip := &tripStopDistances{
+		stopTimes:   stopTimes,
+		shapePoints: shapePoints,
+		distances:   distances,
+		length:      utils.DistanceAlongShape(shapePoints, len(shapePoints)-1, 0),
+	}
+	return trip, true
+}
+
+// nextStopIndex returns the index of the first stop a vehicle at the given distance along the
+// shape has yet to reach. A vehicle at a stop hasn't passed it yet.
+func (t *tripStopDistances) nextStopIndex(distance float64) int {
+	for i, stopDistance := range t.distances {
+		if stopDistance+utils.AtStopTolerance >= distance {
+			return i
+		}
+	}
+	return len(t.distances)
+}
+
+// calculateDistanceAndStopsAway returns how far, in meters along the trip shapes, a vehicle has to
+// travel to reach the stop with the given sequence on a trip, and how many stops it reaches
+// first. A vehicle still serving an earlier trip of the block finishes that trip and any trips in
+// between before it starts the requested one. It returns false when the vehicle's progress
+// towards the stop can't be worked out.
+func (api *RestAPI) calculateDistanceAndStopsAway(
+	ctx context.Context,
+	vehicle *gtfs.Vehicle,
+	tripID string,
+	stopSequence int64,
+	serviceDate time.Time,
+) (float64, int, bool) {
+	if vehicle == nil || vehicle.Trip == nil || vehicle.Position == nil ||
+		vehicle.Position.Latitude == nil || vehicle.Position.Longitude == nil {
+		return 0, 0, false
+	}
+
+	tripIDs := []string{tripID}
+	if vehicleTripID := vehicle.Trip.ID.ID; vehicleTripID != tripID {
+		blockTripIDs := api.orderedBlockTripIDs(ctx, tripID, serviceDate)
+		vehicleIndex := slices.Index(blockTripIDs, vehicleTripID)
+		tripIndex := slices.Index(blockTripIDs, tripID)
+		if vehicleIndex < 0 || tripIndex < vehicleIndex {
+			return 0, 0, false
+		}
+		tripIDs = blockTripIDs[vehicleIndex : tripIndex+1]
+	}
+
+	var distance float64
+	var stopsAway int
+	for i, id := range tripIDs {
+		trip, ok := api.loadTripStopDistances(ctx, id)
+		if !ok {
+			return 0, 0, false
+		}
+
+		// The vehicle starts from its position on its current trip, and from the beginning of
+		// every later one
+		var startDistance float64
+		var startIndex int
+		if i == 0 {
+			startDistance = projectOntoShape(float64(*vehicle.Position.Latitude), float64(*vehicle.Position.Longitude), trip.shapePoints)
+			startIndex = trip.nextStopIndex(startDistance)
+		}
+
+		if i < len(tripIDs)-1 {
+			distance += trip.length - startDistance
+			stopsAway += len(trip.stopTimes) - startIndex
+			continue
+		}
+
+		targetIndex := slices.IndexFunc(trip.stopTimes, func(st gtfsdb.StopTime) bool {
+			return st.StopSequence == stopSequence
+		})
+		if targetIndex < 0 {
+			return 0, 0, false
+		}
+		distance += trip.distances[targetIndex] - startDistance
+		stopsAway += targetIndex - startIndex
+	}
+	return distance, stopsAway, true
+}
@@ -0,0 +1,77 @@
+package restapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/OneBusAway/go-gtfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testVehicleAtStop returns a vehicle serving a trip, positioned at one of the trip's stops.
+func testVehicleAtStop(t *testing.T, api *RestAPI, tripID, stopID string) *gtfs.Vehicle {
+	t.Helper()
+	stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(context.Background(), stopID)
+	require.NoError(t, err)
+	lat, lon := float32(stop.Lat), float32(stop.Lon)
+	return &gtfs.Vehicle{
+		Trip:     &gtfs.Trip{ID: gtfs.TripID{ID: tripID}},
+		Position: &gtfs.Position{Latitude: &lat, Longitude: &lon},
+	}
+}
+
+func TestCalculateDistanceAndStopsAway(t *testing.T) {
+	api := createTestApi(t)
+	ctx := context.Background()
+
+	// A weekday the block's service runs on
+	serviceDate := time.Date(2025, 6, 12, 0, 0, 0, 0, time.UTC)
+	blockTripIDs := api.orderedBlockTripIDs(ctx, frequencyTestTripID, serviceDate)
+	require.GreaterOrEqual(t, len(blockTripIDs), 2)
+
+	first, ok := api.loadTripStopDistances(ctx, blockTripIDs[0])
+	require.True(t, ok)
+	second, ok := api.loadTripStopDistances(ctx, blockTripIDs[1])
+	require.True(t, ok)
+	require.Greater(t, len(first.stopTimes), 3)
+	require.Greater(t, len(second.stopTimes), 2)
+
+	vehicle := testVehicleAtStop(t, api, blockTripIDs[0], first.stopTimes[1].StopID)
+
+	t.Run("same trip", func(t *testing.T) {
+		target := first.stopTimes[3]
+		distance, stopsAway, ok := api.calculateDistanceAndStopsAway(ctx, vehicle, blockTripIDs[0], target.StopSequence, serviceDate)
+		require.True(t, ok)
+		assert.Equal(t, 2, stopsAway)
+		assert.InDelta(t, first.distances[3]-first.distances[1], distance, 1)
+		assert.Greater(t, distance, 0.0)
+	})
+
+	t.Run("vehicle at the stop", func(t *testing.T) {
+		distance, stopsAway, ok := api.calculateDistanceAndStopsAway(ctx, vehicle, blockTripIDs[0], first.stopTimes[1].StopSequence, serviceDate)
+		require.True(t, ok)
+		assert.Equal(t, 0, stopsAway)
+		assert.InDelta(t, 0, distance, 1)
+	})
+
+	t.Run("vehicle still on the previous trip of the block", func(t *testing.T) {
+		target := second.stopTimes[2]
+		distance, stopsAway, ok := api.calculateDistanceAndStopsAway(ctx, vehicle, blockTripIDs[1], target.StopSequence, serviceDate)
+		require.True(t, ok)
+		assert.Equal(t, len(first.stopTimes)-1+2, stopsAway)
+		assert.InDelta(t, first.length-first.distances[1]+second.distances[2], distance, 1)
+	})
+
+	t.Run("vehicle already on a later trip", func(t *testing.T) {
+		laterVehicle := testVehicleAtStop(t, api, blockTripIDs[1], second.stopTimes[0].StopID)
+		_, _, ok := api.calculateDistanceAndStopsAway(ctx, laterVehicle, blockTripIDs[0], first.stopTimes[3].StopSequence, serviceDate)
+		assert.False(t, ok)
+	})
+
+	t.Run("vehicle without a position", func(t *testing.T) {
+		_, _, ok := api.calculateDistanceAndStopsAway(ctx, &gtfs.Vehicle{Trip: vehicle.Trip}, blockTripIDs[0], first.stopTimes[3].StopSequence, serviceDate)
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,7 @@
 package restapi
 
 import (
+	"database/sql"
 	"net/http"
 
 	"maglev.onebusaway.org/gtfsdb"
@@ -19,6 +20,7 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 	latSpan, _ := utils.ParseFloatParam(queryParams, "latSpan", fieldErrors)
 	lonSpan, _ := utils.ParseFloatParam(queryParams, "lonSpan", fieldErrors)
 	query := queryParams.Get("query")
+	collapsePlatforms := queryParams.Get("collapsePlatforms") == "true"
 
 	if len(fieldErrors) > 0 {
 		api.validationErrorResponse(w, r, fieldErrors)
@@ -52,6 +54,9 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 	}
 
 	stops := api.GtfsManager.GetStopsForLocation(ctx, lat, lon, radius, latSpan, lonSpan, query, 100, false)
+	if collapsePlatforms {
+		stops = collapseToStations(stops)
+	}
 
 	var results []models.Stop
 	routeIDs := map[string]bool{}
@@ -68,7 +73,7 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 	if len(stopIDs) == 0 {
 		// Return empty response if no stops found
 		agencies := utils.FilterAgencies(api.GtfsManager.GetAgencies(), agencyIDs)
-		routes := utils.FilterRoutes(api.GtfsManager.GtfsDB.Queries, ctx, routeIDs)
+		routes := utils.FilterRoutes(api.GtfsManager.GtfsDB().Queries, ctx, routeIDs)
 		references := models.ReferencesModel{
 			Agencies:   agencies,
 			Routes:     routes,
@@ -82,15 +87,40 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Stations have no stop times of their own, so they take their routes and agency from
+	// the stops inside them
+	lookupIDs := stopIDs
+	stationChildren := make(map[string][]string)
+	if collapsePlatforms {
+		var stationIDs []sql.NullString
+		for _, stop := range stops {
+			if stop.Type == gtfs.StopType_Station {
+				stationIDs = append(stationIDs, sql.NullString{String: stop.Id, Valid: true})
+			}
+		}
+		if len(stationIDs) > 0 {
+			children, err := api.GtfsManager.GtfsDB().Queries.GetChildStops(ctx, stationIDs)
+			if err != nil {
+				api.serverErrorResponse(w, r, err)
+				return
+			}
+			lookupIDs = append([]string(nil), stopIDs...)
+			for _, child := range children {
+				stationChildren[child.ParentStation.String] = append(stationChildren[child.ParentStation.String], child.ID)
+				lookupIDs = append(lookupIDs, child.ID)
+			}
+		}
+	}
+
 	// Batch query to get route IDs for all stops
-	routeIDsForStops, err := api.GtfsManager.GtfsDB.Queries.GetRouteIDsForStops(ctx, stopIDs)
+	routeIDsForStops, err := api.GtfsManager.GtfsDB().Queries.GetRouteIDsForStops(ctx, lookupIDs)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
 	// Batch query to get agencies for all stops
-	agenciesForStops, err := api.GtfsManager.GtfsDB.Queries.GetAgenciesForStops(ctx, stopIDs)
+	agenciesForStops, err := api.GtfsManager.GtfsDB().Queries.GetAgenciesForStops(ctx, lookupIDs)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -122,6 +152,31 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	for stationID, childIDs := range stationChildren {
+		seen := make(map[string]bool)
+		for _, childID := range childIDs {
+			for _, routeID := range stopRouteIDs[childID] {
+				if !seen[routeID] {
+					seen[routeID] = true
+					stopRouteIDs[stationID] = append(stopRouteIDs[stationID], routeID)
+				}
+			}
+			if _, exists := stopAgency[stationID]; !exists && stopAgency[childID] != nil {
+				stopAgency[stationID] = stopAgency[childID]
+			}
+		}
+	}
+
+	stopAgencyIDs := make(map[string]string, len(stopAgency))
+	for stopID, agency := range stopAgency {
+		stopAgencyIDs[stopID] = agency.ID
+	}
+	transferHints, err := api.transferHintsForStops(ctx, stopAgencyIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
 	// Build results using the pre-fetched data
 	for _, stopID := range stopIDs {
 		stop := stopMap[stopID]
@@ -132,23 +187,34 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 			continue
 		}
 
-		results = append(results, models.NewStop(
-			stop.Id,
+		locationType := models.StopLocation
+		if stop.Type == gtfs.StopType_Station {
+			locationType = models.StationLocation
+		}
+		var parentID string
+		if stop.Parent != nil {
+			parentID = utils.FormCombinedID(agency.ID, stop.Parent.Id)
+		}
+
+		result := models.NewStop(
+			gtfsdb.UnscopedID(stop.Id),
 			models.UnknownValue,
 			utils.FormCombinedID(agency.ID, stop.Id),
 			stop.Name,
-			"",
+			parentID,
 			utils.MapWheelchairBoarding(stop.WheelchairBoarding),
 			*stop.Latitude,
 			*stop.Longitude,
-			0,
+			locationType,
 			rids,
 			rids,
-		))
+		)
+		result.TransferHints = transferHints[stopID]
+		results = append(results, result)
 	}
 
 	agencies := utils.FilterAgencies(api.GtfsManager.GetAgencies(), agencyIDs)
-	routes := utils.FilterRoutes(api.GtfsManager.GtfsDB.Queries, ctx, routeIDs)
+	routes := utils.FilterRoutes(api.GtfsManager.GtfsDB().Queries, ctx, routeIDs)
 
 	references := models.ReferencesModel{
 		Agencies:   agencies,
@@ -162,3 +228,21 @@ func (api *RestAPI) stopsForLocationHandler(w http.ResponseWriter, r *http.Reque
 	response := models.NewListResponseWithRange(results, references, len(results) == 0)
 	api.sendResponse(w, r, response)
 }
+
+// collapseToStations replaces stops inside a station with the station itself, keeping the
+// position of the nearest one. Stops whose station has no coordinates are kept as they are.
+func collapseToStations(stops []*gtfs.Stop) []*gtfs.Stop {
+	collapsed := make([]*gtfs.Stop, 0, len(stops))
+	seen := make(map[string]bool)
+	for _, stop := range stops {
+		if root := stop.Root(); root.Type == gtfs.StopType_Station && root.Latitude != nil && root.Longitude != nil {
+			stop = root
+		}
+		if seen[stop.Id] {
+			continue
+		}
+		seen[stop.Id] = true
+		collapsed = append(collapsed, stop)
+	}
+	return collapsed
+}
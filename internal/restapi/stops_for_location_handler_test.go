@@ -1,11 +1,14 @@
 package restapi
 
 import (
+	"context"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
 )
 
 func TestStopsForLocationHandlerRequiresValidApiKey(t *testing.T) {
@@ -142,3 +145,52 @@ func TestStopsForLocationHandlerValidatesRadius(t *testing.T) {
 	_, resp, _ := serveAndRetrieveEndpoint(t, "/api/where/stops-for-location.json?key=TEST&lat=40.583321&lon=-122.426966&radius=invalid")
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
+
+// createStationTestApi serves the station test feed with routes 151 and 161 calling at its
+// two platforms.
+func createStationTestApi(t *testing.T) *RestAPI {
+	api := createTestApiWithFeed(t, models.BuildStationFeed(t, models.GetFixturePath(t, "raba.zip")))
+	for i, stopTime := range []gtfsdb.CreateStopTimeParams{
+		{TripID: frequencyTestTripID, StopID: "STN_P1", StopSequence: 1000},
+		{TripID: "t_404_b_18260_tn_0", StopID: "STN_P2", StopSequence: 1000},
+	} {
+		_, err := api.GtfsManager.GtfsDB().Queries.CreateStopTime(context.Background(), stopTime)
+		require.NoError(t, err, "stop time %d", i)
+	}
+	return api
+}
+
+func TestStopsForLocationCollapsePlatforms(t *testing.T) {
+	api := createStationTestApi(t)
+	const location = "/api/where/stops-for-location.json?key=TEST&lat=40.5864&lon=-122.3918&radius=50"
+
+	stopsByID := func(model models.ResponseModel) map[string]map[string]interface{} {
+		stops := make(map[string]map[string]interface{})
+		for _, s := range model.Data.(map[string]interface{})["list"].([]interface{}) {
+			stop := s.(map[string]interface{})
+			stops[stop["id"].(string)] = stop
+		}
+		return stops
+	}
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, location)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	stops := stopsByID(model)
+	require.Contains(t, stops, "25_STN_P1")
+	require.Contains(t, stops, "25_STN_P2")
+	assert.NotContains(t, stops, "25_STN")
+	assert.Equal(t, "25_STN", stops["25_STN_P1"]["parent"])
+	assert.Equal(t, []interface{}{"25_151"}, stops["25_STN_P1"]["routeIds"])
+
+	resp, model = serveApiAndRetrieveEndpoint(t, api, location+"&collapsePlatforms=true")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	stops = stopsByID(model)
+	assert.NotContains(t, stops, "25_STN_P1")
+	assert.NotContains(t, stops, "25_STN_P2")
+	require.Contains(t, stops, "25_STN")
+	station := stops["25_STN"]
+	assert.Equal(t, "Central Station", station["name"])
+	assert.Equal(t, 1.0, station["locationType"])
+	assert.Equal(t, "", station["parent"])
+	assert.ElementsMatch(t, []interface{}{"25_151", "25_161"}, station["routeIds"], "a station serves the routes of all its platforms")
+}
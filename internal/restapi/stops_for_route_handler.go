@@ -51,7 +51,7 @@ func (api *RestAPI) stopsForRouteHandler(w http.ResponseWriter, r *http.Request)
 
 	params := api.parseStopsForRouteParams(r)
 
-	currentAgency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	currentAgency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 
 	if err != nil {
 		api.sendNotFound(w, r)
@@ -61,20 +61,20 @@ func (api *RestAPI) stopsForRouteHandler(w http.ResponseWriter, r *http.Request)
 	currentLocation, _ := time.LoadLocation(currentAgency.Timezone)
 	timeParam := r.URL.Query().Get("time")
 
-	formattedDate, _, fieldErrors, success := utils.ParseTimeParameter(timeParam, currentLocation)
+	_, serviceDate, fieldErrors, success := utils.ParseTimeParameter(timeParam, currentLocation)
 	if !success {
 		api.validationErrorResponse(w, r, fieldErrors)
 		return
 	}
 
-	_, err = api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, routeID)
+	_, err = api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, routeID)
 
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	serviceIDs, err := api.GtfsManager.GtfsDB.Queries.GetActiveServiceIDsForDate(ctx, formattedDate)
+	serviceIDs, err := api.GtfsManager.GetActiveServiceIDsForDate(ctx, serviceDate)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -95,7 +95,7 @@ func (api *RestAPI) processRouteStops(ctx context.Context, agencyID string, rout
 	var stopGroupings []models.StopGrouping
 
 	// Get trips for route that are active on the service date
-	trips, err := api.GtfsManager.GtfsDB.Queries.GetTripsForRouteInActiveServiceIDs(ctx, gtfsdb.GetTripsForRouteInActiveServiceIDsParams{
+	trips, err := api.GtfsManager.GtfsDB().Queries.GetTripsForRouteInActiveServiceIDs(ctx, gtfsdb.GetTripsForRouteInActiveServiceIDsParams{
 		RouteID:    routeID,
 		ServiceIds: serviceIDs,
 	})
@@ -106,7 +106,7 @@ func (api *RestAPI) processRouteStops(ctx context.Context, agencyID string, rout
 
 	if len(trips) == 0 {
 		// Fallback: get all trips for this route regardless of service date
-		allTrips, err := api.GtfsManager.GtfsDB.Queries.GetAllTripsForRoute(ctx, routeID)
+		allTrips, err := api.GtfsManager.GtfsDB().Queries.GetAllTripsForRoute(ctx, routeID)
 		if err != nil {
 			return models.RouteEntry{}, nil, err
 		}
@@ -139,12 +139,12 @@ func (api *RestAPI) processRouteStops(ctx context.Context, agencyID string, rout
 func buildStopsList(ctx context.Context, api *RestAPI, agencyID string, allStops map[string]bool) ([]models.Stop, error) {
 	stopsList := make([]models.Stop, 0, len(allStops))
 	for stopID := range allStops {
-		stop, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
+		stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
 		if err != nil {
 			continue
 		}
 
-		routeIds, err := api.GtfsManager.GtfsDB.Queries.GetRouteIDsForStop(ctx, stop.ID)
+		routeIds, err := api.GtfsManager.GtfsDB().Queries.GetRouteIDsForStop(ctx, stop.ID)
 		if err != nil {
 			continue
 		}
@@ -162,6 +162,7 @@ func buildStopsList(ctx context.Context, api *RestAPI, agencyID string, allStops
 			LocationType:       int(stop.LocationType.Int64),
 			Lon:                stop.Lon,
 			Name:               stop.Name.String,
+			Parent:             utils.FormCombinedID(agencyID, stop.ParentStation.String),
 			RouteIDs:           routeIdsString,
 			StaticRouteIDs:     routeIdsString,
 			WheelchairBoarding: utils.MapWheelchairBoarding(gtfs.WheelchairBoarding(stop.WheelchairBoarding.Int64)),
@@ -242,7 +243,7 @@ func processTripGroups(
 	for _, key := range keys {
 		tripsInGroup := tripGroups[key]
 		representativeTrip := tripsInGroup[0]
-		stopsList, err := api.GtfsManager.GtfsDB.Queries.GetOrderedStopIDsForTrip(ctx, representativeTrip.ID)
+		stopsList, err := api.GtfsManager.GtfsDB().Queries.GetOrderedStopIDsForTrip(ctx, representativeTrip.ID)
 		if err != nil {
 			continue
 		}
@@ -253,7 +254,7 @@ func processTripGroups(
 			allStops[stopID] = true
 		}
 
-		shape, err := api.GtfsManager.GtfsDB.Queries.GetShapesGroupedByTripHeadSign(ctx,
+		shape, err := api.GtfsManager.GtfsDB().Queries.GetShapesGroupedByTripHeadSign(ctx,
 			gtfsdb.GetShapesGroupedByTripHeadSignParams{
 				RouteID:      routeID,
 				TripHeadsign: representativeTrip.TripHeadsign,
@@ -0,0 +1,255 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+	"maglev.onebusaway.org/internal/utils"
+)
+
+func (api *RestAPI) transfersForStopHandler(w http.ResponseWriter, r *http.Request) {
+	queryParamID := utils.ExtractIDFromParams(r)
+
+	if err := utils.ValidateID(queryParamID); err != nil {
+		fieldErrors := map[string][]string{
+			"id": {err.Error()},
+		}
+		api.validationErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	agencyID, stopID, err := utils.ExtractAgencyIDAndCodeID(queryParamID)
+	if err != nil {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	ctx := r.Context()
+
+	stop, err := api.GtfsManager.GtfsDB().Queries.GetStop(ctx, stopID)
+	if err != nil || stop.ID == "" {
+		api.sendNotFound(w, r)
+		return
+	}
+
+	transfers, err := api.GtfsManager.GtfsDB().Queries.GetTransfersForStop(ctx, sql.NullString{String: stopID, Valid: true})
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	routes, transferAgencies, err := api.loadTransferAgencies(ctx, transfers)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+
+	results := make([]models.Transfer, 0, len(transfers))
+	stopIDs := []string{stopID}
+	seenStops := map[string]bool{stopID: true}
+	for _, transfer := range transfers {
+		results = append(results, newTransferModel(agencyID, transferAgencies, transfer))
+
+		for _, id := range []sql.NullString{transfer.FromStopID, transfer.ToStopID} {
+			if id.Valid && !seenStops[id.String] {
+				seenStops[id.String] = true
+				stopIDs = append(stopIDs, id.String)
+			}
+		}
+	}
+
+	references := models.NewEmptyReferences()
+
+	stopRefs, err := api.buildStopReferencesByID(ctx, agencyID, stopIDs)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return
+	}
+	references.Stops = stopRefs
+
+	for _, route := range routes {
+		references.Routes = append(references.Routes, models.NewRoute(
+			utils.FormCombinedID(route.AgencyID, route.ID),
+			route.AgencyID,
+			route.ShortName.String,
+			route.LongName.String,
+			route.Desc.String,
+			models.RouteType(route.Type),
+			route.Url.String,
+			route.Color.String,
+			route.TextColor.String,
+			route.ShortName.String,
+		))
+	}
+
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
+	if err == nil {
+		references.Agencies = append(references.Agencies, models.NewAgencyReference(
+			agency.ID,
+			agency.Name,
+			agency.Url,
+			agency.Timezone,
+			agency.Lang.String,
+			agency.Phone.String,
+			agency.Email.String,
+			agency.FareUrl.String,
+			"",
+			false,
+		))
+	}
+
+	api.sendResponse(w, r, models.NewListResponse(results, references))
+}
+
+// buildStopReferencesByID returns stop references, with the routes serving each stop, for the
+// given stop IDs of one agency.
+func (api *RestAPI) buildStopReferencesByID(ctx context.Context, agencyID string, stopIDs []string) ([]models.Stop, error) {
+	stops, err := api.GtfsManager.GtfsDB().Queries.GetStopsByIDs(ctx, stopIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	routeIDRows, err := api.GtfsManager.GtfsDB().Queries.GetRouteIDsForStops(ctx, stopIDs)
+	if err != nil {
+		return nil, err
+	}
+	stopRouteIDs := make(map[string][]string)
+	for _, row := range routeIDRows {
+		if routeID, ok := row.RouteID.(string); ok {
+			stopRouteIDs[row.StopID] = append(stopRouteIDs[row.StopID], routeID)
+		}
+	}
+
+	stopRefs := make([]models.Stop, 0, len(stops))
+	for _, stop := range stops {
+		routeIDs := stopRouteIDs[stop.ID]
+		if routeIDs == nil {
+			routeIDs = []string{}
+		}
+		stopRefs = append(stopRefs, models.NewStop(
+			stop.Code.String,
+			models.UnknownValue,
+			utils.FormCombinedID(agencyID, stop.ID),
+			stop.Name.String,
+			utils.FormCombinedID(agencyID, stop.ParentStation.String),
+			models.UnknownValue,
+			stop.Lat,
+			stop.Lon,
+			int(stop.LocationType.Int64),
+			routeIDs,
+			routeIDs,
+		))
+	}
+	return stopRefs, nil
+}
+
+// transferHintsForStops returns the transfers leaving each of the given stops, keyed by stop
+// ID, with IDs combined using the agency of each stop.
+func (api *RestAPI) transferHintsForStops(ctx context.Context, stopAgencyIDs map[string]string) (map[string][]models.Transfer, error) {
+	if len(stopAgencyIDs) == 0 {
+		return nil, nil
+	}
+
+	stopIDs := make([]sql.NullString, 0, len(stopAgencyIDs))
+	for stopID := range stopAgencyIDs {
+		stopIDs = append(stopIDs, sql.NullString{String: stopID, Valid: true})
+	}
+
+	transfers, err := api.GtfsManager.GtfsDB().Queries.GetTransfersFromStops(ctx, stopIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	_, transferAgencies, err := api.loadTransferAgencies(ctx, transfers)
+	if err != nil {
+		return nil, err
+	}
+
+	hints := make(map[string][]models.Transfer)
+	for _, transfer := range transfers {
+		stopID := transfer.FromStopID.String
+		hints[stopID] = append(hints[stopID], newTransferModel(stopAgencyIDs[stopID], transferAgencies, transfer))
+	}
+	return hints, nil
+}
+
+// transferAgencies holds the agency of each route and trip that transfers name, keyed by route
+// and trip ID. A transfer between stops of one agency can name the routes and trips of another.
+type transferAgencies struct {
+	routes map[string]string
+	trips  map[string]string
+}
+
+// loadTransferAgencies returns the routes the given transfers name and the agencies of the
+// routes and trips they name.
+func (api *RestAPI) loadTransferAgencies(ctx context.Context, transfers []gtfsdb.Transfer) ([]gtfsdb.Route, transferAgencies, error) {
+	agencies := transferAgencies{routes: make(map[string]string), trips: make(map[string]string)}
+
+	var routeIDs, tripIDs []string
+	seenRoutes := make(map[string]bool)
+	seenTrips := make(map[string]bool)
+	for _, transfer := range transfers {
+		for _, id := range []sql.NullString{transfer.FromRouteID, transfer.ToRouteID} {
+			if id.Valid && !seenRoutes[id.String] {
+				seenRoutes[id.String] = true
+				routeIDs = append(routeIDs, id.String)
+			}
+		}
+		for _, id := range []sql.NullString{transfer.FromTripID, transfer.ToTripID} {
+			if id.Valid && !seenTrips[id.String] {
+				seenTrips[id.String] = true
+				tripIDs = append(tripIDs, id.String)
+			}
+		}
+	}
+
+	var routes []gtfsdb.Route
+	if len(routeIDs) > 0 {
+		var err error
+		routes, err = api.GtfsManager.GtfsDB().Queries.GetRoutesByIDs(ctx, routeIDs)
+		if err != nil {
+			return nil, agencies, err
+		}
+		for _, route := range routes {
+			agencies.routes[route.ID] = route.AgencyID
+		}
+	}
+	if len(tripIDs) > 0 {
+		rows, err := api.GtfsManager.GtfsDB().Queries.GetAgencyIDsForTrips(ctx, tripIDs)
+		if err != nil {
+			return nil, agencies, err
+		}
+		for _, row := range rows {
+			agencies.trips[row.ID] = row.AgencyID
+		}
+	}
+	return routes, agencies, nil
+}
+
+// newTransferModel returns a transfer with its stop IDs combined with the agency of the stop,
+// and its route and trip IDs with the agencies of the routes and trips where they are known.
+func newTransferModel(agencyID string, agencies transferAgencies, transfer gtfsdb.Transfer) models.Transfer {
+	combinedID := func(byID map[string]string, id string) string {
+		if owner, ok := byID[id]; ok {
+			return utils.FormCombinedID(owner, id)
+		}
+		return utils.FormCombinedID(agencyID, id)
+	}
+	model := models.Transfer{
+		FromStopID:   utils.FormCombinedID(agencyID, transfer.FromStopID.String),
+		ToStopID:     utils.FormCombinedID(agencyID, transfer.ToStopID.String),
+		FromRouteID:  combinedID(agencies.routes, transfer.FromRouteID.String),
+		ToRouteID:    combinedID(agencies.routes, transfer.ToRouteID.String),
+		FromTripID:   combinedID(agencies.trips, transfer.FromTripID.String),
+		ToTripID:     combinedID(agencies.trips, transfer.ToTripID.String),
+		TransferType: int(transfer.TransferType),
+	}
+	if transfer.MinTransferTime.Valid {
+		minTransferTime := transfer.MinTransferTime.Int64
+		model.MinTransferTime = &minTransferTime
+	}
+	return model
+}
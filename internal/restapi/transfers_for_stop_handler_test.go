@@ -0,0 +1,133 @@
+package restapi
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maglev.onebusaway.org/gtfsdb"
+	"maglev.onebusaway.org/internal/models"
+)
+
+func TestTransfersForStopHandler(t *testing.T) {
+	api := createTestApi(t)
+
+	_, err := api.GtfsManager.GtfsDB().Queries.CreateTransfer(context.Background(), gtfsdb.CreateTransferParams{
+		FromStopID:      sql.NullString{String: "1000", Valid: true},
+		ToStopID:        sql.NullString{String: "2000", Valid: true},
+		FromRouteID:     sql.NullString{String: "151", Valid: true},
+		TransferType:    models.MinimumTimeTransfer,
+		MinTransferTime: sql.NullInt64{Int64: 120, Valid: true},
+	})
+	require.NoError(t, err)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/transfers-for-stop/25_1000.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	list := data["list"].([]interface{})
+	require.Len(t, list, 2)
+
+	// The fixture's same-stop timed transfer sorts before the one to stop 2000
+	timed := list[0].(map[string]interface{})
+	assert.Equal(t, "25_1000", timed["fromStopId"])
+	assert.Equal(t, "25_1000", timed["toStopId"])
+	assert.Equal(t, float64(models.TimedTransfer), timed["transferType"])
+	assert.NotContains(t, timed, "minTransferTime")
+	assert.NotContains(t, timed, "fromRouteId")
+
+	minimum := list[1].(map[string]interface{})
+	assert.Equal(t, "25_2000", minimum["toStopId"])
+	assert.Equal(t, "25_151", minimum["fromRouteId"])
+	assert.Equal(t, float64(120), minimum["minTransferTime"])
+
+	references := data["references"].(map[string]interface{})
+	var stopIDs []string
+	for _, s := range references["stops"].([]interface{}) {
+		stopIDs = append(stopIDs, s.(map[string]interface{})["id"].(string))
+	}
+	assert.ElementsMatch(t, []string{"25_1000", "25_2000"}, stopIDs)
+
+	routes := references["routes"].([]interface{})
+	require.Len(t, routes, 1)
+	assert.Equal(t, "25_151", routes[0].(map[string]interface{})["id"])
+	assert.Len(t, references["agencies"], 1)
+}
+
+func TestTransfersForStopHandlerUsesTheAgencyOfRoutesAndTrips(t *testing.T) {
+	api := createTestApi(t)
+	ctx := context.Background()
+	queries := api.GtfsManager.GtfsDB().Queries
+
+	// A route and trip of another agency, serving a stop of agency 25
+	_, err := queries.CreateAgency(ctx, gtfsdb.CreateAgencyParams{
+		ID: "other", Name: "Other Transit", Url: "https://example.com", Timezone: "America/Los_Angeles",
+	})
+	require.NoError(t, err)
+	_, err = queries.CreateRoute(ctx, gtfsdb.CreateRouteParams{ID: "X1", AgencyID: "other", Type: 3})
+	require.NoError(t, err)
+	var serviceID string
+	require.NoError(t, api.GtfsManager.GtfsDB().DB.QueryRowContext(ctx, "SELECT service_id FROM trips LIMIT 1").Scan(&serviceID))
+	_, err = queries.CreateTrip(ctx, gtfsdb.CreateTripParams{ID: "XT", RouteID: "X1", ServiceID: serviceID})
+	require.NoError(t, err)
+
+	_, err = queries.CreateTransfer(ctx, gtfsdb.CreateTransferParams{
+		FromStopID:   sql.NullString{String: "1001", Valid: true},
+		ToStopID:     sql.NullString{String: "1001", Valid: true},
+		FromRouteID:  sql.NullString{String: "151", Valid: true},
+		ToRouteID:    sql.NullString{String: "X1", Valid: true},
+		ToTripID:     sql.NullString{String: "XT", Valid: true},
+		TransferType: models.TimedTransfer,
+	})
+	require.NoError(t, err)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/transfers-for-stop/25_1001.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	data := model.Data.(map[string]interface{})
+	list := data["list"].([]interface{})
+	require.Len(t, list, 1)
+	transfer := list[0].(map[string]interface{})
+	assert.Equal(t, "25_1001", transfer["fromStopId"])
+	assert.Equal(t, "25_151", transfer["fromRouteId"])
+	assert.Equal(t, "other_X1", transfer["toRouteId"])
+	assert.Equal(t, "other_XT", transfer["toTripId"])
+
+	var routeIDs []string
+	for _, route := range data["references"].(map[string]interface{})["routes"].([]interface{}) {
+		routeIDs = append(routeIDs, route.(map[string]interface{})["id"].(string))
+	}
+	assert.ElementsMatch(t, []string{"25_151", "other_X1"}, routeIDs)
+}
+
+func TestTransfersForStopHandlerErrors(t *testing.T) {
+	api := createTestApi(t)
+
+	resp, _ := serveApiAndRetrieveEndpoint(t, api, "/api/where/transfers-for-stop/25_nonexistent.json?key=TEST")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = serveApiAndRetrieveEndpoint(t, api, "/api/where/transfers-for-stop/25_1000.json?key=invalid")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestStopHandlerIncludesTransferHints(t *testing.T) {
+	api := createTestApi(t)
+
+	resp, model := serveApiAndRetrieveEndpoint(t, api, "/api/where/stop/25_1000.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entry := model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	hints, ok := entry["transferHints"].([]interface{})
+	require.True(t, ok, "transferHints should be present")
+	require.Len(t, hints, 1)
+	assert.Equal(t, "25_1000", hints[0].(map[string]interface{})["toStopId"])
+	assert.Equal(t, float64(models.TimedTransfer), hints[0].(map[string]interface{})["transferType"])
+
+	resp, model = serveApiAndRetrieveEndpoint(t, api, "/api/where/stop/25_1030.json?key=TEST")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	entry = model.Data.(map[string]interface{})["entry"].(map[string]interface{})
+	assert.NotContains(t, entry, "transferHints", "stops without transfers omit hints")
+}
@@ -67,19 +67,19 @@ func (api *RestAPI) tripDetailsHandler(w http.ResponseWriter, r *http.Request) {
 
 	params := api.parseTripIdDetailsParams(r)
 
-	trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, tripID)
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	route, err := api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, trip.RouteID)
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, trip.RouteID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
-	agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, route.AgencyID)
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -98,11 +98,15 @@ func (api *RestAPI) tripDetailsHandler(w http.ResponseWriter, r *http.Request) {
 	if params.ServiceDate != nil {
 		serviceDate = *params.ServiceDate
 	} else {
-		serviceDate = currentTime.Truncate(24 * time.Hour)
+		serviceDate = time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, loc)
 	}
 
 	serviceDateMillis := serviceDate.Unix() * 1000
 
+	localServiceDate := serviceDate.In(loc)
+	serviceMidnight := time.Date(localServiceDate.Year(), localServiceDate.Month(), localServiceDate.Day(), 0, 0, 0, 0, loc)
+	frequency := api.frequencyForTripInstance(ctx, trip.ID, serviceMidnight, currentTime)
+
 	var schedule *models.Schedule
 	var status *models.TripStatusForTripDetails
 
@@ -116,13 +120,14 @@ func (api *RestAPI) tripDetailsHandler(w http.ResponseWriter, r *http.Request) {
 			api.serverErrorResponse(w, r, err)
 			return
 		}
+		schedule.Frequency = frequency
 	}
 
 	tripDetails := &models.TripDetails{
 		TripID:       utils.FormCombinedID(agencyID, trip.ID),
 		ServiceDate:  serviceDateMillis,
 		Schedule:     schedule,
-		Frequency:    nil,
+		Frequency:    frequency,
 		SituationIDs: api.GetSituationIDsForTrip(tripID),
 	}
 
@@ -196,6 +201,8 @@ func (api *RestAPI) tripDetailsHandler(w http.ResponseWriter, r *http.Request) {
 		references.Routes = routesIface
 	}
 
+	references.Situations = api.BuildSituationReferences(ctx, tripDetails.SituationIDs)
+
 	response := models.NewEntryResponse(tripDetails, references)
 	api.sendResponse(w, r, response)
 }
@@ -213,12 +220,12 @@ func (api *RestAPI) buildReferencedTrips(ctx context.Context, agencyID string, t
 			continue
 		}
 
-		refTrip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, refTripID)
+		refTrip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, refTripID)
 		if err != nil {
 			continue
 		}
 
-		refRoute, err := api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, refTrip.RouteID)
+		refRoute, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, refTrip.RouteID)
 		if err != nil {
 			continue
 		}
@@ -268,7 +275,7 @@ func (api *RestAPI) buildStopReferences(ctx context.Context, agencyID string, st
 		return []models.Stop{}, nil
 	}
 
-	stops, err := api.GtfsManager.GtfsDB.Queries.GetStopsByIDs(ctx, originalStopIDs)
+	stops, err := api.GtfsManager.GtfsDB().Queries.GetStopsByIDs(ctx, originalStopIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -278,7 +285,7 @@ func (api *RestAPI) buildStopReferences(ctx context.Context, agencyID string, st
 		stopMap[stop.ID] = stop
 	}
 
-	allRoutes, err := api.GtfsManager.GtfsDB.Queries.GetRoutesForStops(ctx, originalStopIDs)
+	allRoutes, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, originalStopIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -332,6 +339,7 @@ func (api *RestAPI) buildStopReferences(ctx context.Context, agencyID string, st
 			Code:               stop.Code.String,
 			Direction:          api.calculateStopDirection(ctx, stop.ID),
 			LocationType:       int(stop.LocationType.Int64),
+			Parent:             utils.FormCombinedID(agencyID, stop.ParentStation.String),
 			WheelchairBoarding: models.UnknownValue,
 			RouteIDs:           combinedRouteIDs,
 			StaticRouteIDs:     combinedRouteIDs,
@@ -365,7 +373,7 @@ func (api *RestAPI) BuildRouteReference(ctx context.Context, agencyID string, st
 		return []models.Route{}, nil
 	}
 
-	routes, err := api.GtfsManager.GtfsDB.Queries.GetRoutesByIDs(ctx, originalRouteIDs)
+	routes, err := api.GtfsManager.GtfsDB().Queries.GetRoutesByIDs(ctx, originalRouteIDs)
 	if err != nil {
 		return nil, err
 	}
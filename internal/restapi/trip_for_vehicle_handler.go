@@ -81,7 +81,7 @@ func (api *RestAPI) tripForVehicleHandler(w http.ResponseWriter, r *http.Request
 
 	tripID := vehicle.Trip.ID.ID
 
-	agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -100,7 +100,7 @@ func (api *RestAPI) tripForVehicleHandler(w http.ResponseWriter, r *http.Request
 	if params.ServiceDate != nil {
 		serviceDate = *params.ServiceDate
 	} else {
-		serviceDate = currentTime.Truncate(24 * time.Hour)
+		serviceDate = time.Date(currentTime.Year(), currentTime.Month(), currentTime.Day(), 0, 0, 0, 0, loc)
 	}
 	serviceDateMillis := serviceDate.Unix() * 1000
 
@@ -109,7 +109,7 @@ func (api *RestAPI) tripForVehicleHandler(w http.ResponseWriter, r *http.Request
 		status, _ = api.BuildTripStatus(ctx, agencyID, tripID, serviceDate, currentTime)
 	}
 
-	trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, tripID)
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		fmt.Println("GetTrip error:", err)
@@ -133,7 +133,7 @@ func (api *RestAPI) tripForVehicleHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	entry := &models.TripDetails{
-		TripID:       tripID,
+		TripID:       gtfsdb.UnscopedID(tripID),
 		ServiceDate:  serviceDateMillis,
 		Frequency:    nil,
 		Status:       status,
@@ -218,6 +218,7 @@ func (api *RestAPI) tripForVehicleHandler(w http.ResponseWriter, r *http.Request
 		utils.FormCombinedID(agencyID, trip.ShapeID.String),
 	)
 	references.Trips = append(references.Trips, tripRef)
+	references.Situations = api.BuildSituationReferences(ctx, situationIDs)
 	response := models.NewEntryResponse(entry, references)
 	api.sendResponse(w, r, response)
 
@@ -238,7 +239,7 @@ func BuildStopReferencesAndRouteIDsForStops(api *RestAPI, ctx context.Context, a
 		}
 	}
 
-	stopsDB, err := api.GtfsManager.GtfsDB.Queries.GetStopsByIDs(ctx, uniqueStopIDs)
+	stopsDB, err := api.GtfsManager.GtfsDB().Queries.GetStopsByIDs(ctx, uniqueStopIDs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -247,7 +248,7 @@ func BuildStopReferencesAndRouteIDsForStops(api *RestAPI, ctx context.Context, a
 		stopMap[stop.ID] = stop
 	}
 
-	allRoutes, err := api.GtfsManager.GtfsDB.Queries.GetRoutesForStops(ctx, uniqueStopIDs)
+	allRoutes, err := api.GtfsManager.GtfsDB().Queries.GetRoutesForStops(ctx, uniqueStopIDs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -290,6 +291,7 @@ func BuildStopReferencesAndRouteIDsForStops(api *RestAPI, ctx context.Context, a
 			Code:               stop.Code.String,
 			Direction:          api.calculateStopDirection(ctx, stop.ID),
 			LocationType:       int(stop.LocationType.Int64),
+			Parent:             utils.FormCombinedID(agencyID, stop.ParentStation.String),
 			WheelchairBoarding: models.UnknownValue,
 			RouteIDs:           combinedRouteIDs,
 			StaticRouteIDs:     combinedRouteIDs,
@@ -27,19 +27,19 @@ func (api *RestAPI) tripHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, id)
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, id)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
 	}
 
-	route, err := api.GtfsManager.GtfsDB.Queries.GetRoute(ctx, trip.RouteID)
+	route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(ctx, trip.RouteID)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
-	agency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, route.AgencyID)
+	agency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
 	if err != nil {
 		api.sendNotFound(w, r)
 		return
@@ -21,7 +21,7 @@ func (api *RestAPI) tripsForLocationHandler(w http.ResponseWriter, r *http.Reque
 
 	stops := api.GtfsManager.GetStopsForLocation(ctx, lat, lon, -1, latSpan, lonSpan, "", 100, false)
 	stopIDs := extractStopIDs(stops)
-	stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesByStopIDs(ctx, stopIDs)
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesByStopIDs(ctx, stopIDs)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
@@ -45,6 +45,11 @@ func (api *RestAPI) tripsForLocationHandler(w http.ResponseWriter, r *http.Reque
 		Stops:       stops,
 		Trips:       result,
 	})
+	situationIDs := []string{}
+	for _, entry := range result {
+		situationIDs = append(situationIDs, entry.SituationIds...)
+	}
+	references.Situations = api.BuildSituationReferences(ctx, situationIDs)
 	response := models.NewListResponseWithRange(result, references, len(result) == 0)
 	api.sendResponse(w, r, response)
 }
@@ -117,12 +122,12 @@ func boundingBox(lat, lon, latSpan, lonSpan float64) boundingBoxStruct {
 }
 
 func (api *RestAPI) getAllRoutesAndTrips(ctx context.Context, w http.ResponseWriter, r *http.Request) ([]gtfsdb.Route, []gtfsdb.Trip, error) {
-	allRoutes, err := api.GtfsManager.GtfsDB.Queries.ListRoutes(ctx)
+	allRoutes, err := api.GtfsManager.GtfsDB().Queries.ListRoutes(ctx)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return nil, nil, err
 	}
-	allTrips, err := api.GtfsManager.GtfsDB.Queries.ListTrips(ctx)
+	allTrips, err := api.GtfsManager.GtfsDB().Queries.ListTrips(ctx)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return nil, nil, err
@@ -161,8 +166,12 @@ func (api *RestAPI) buildTripsForLocationEntries(
 				continue
 			}
 		}
+		frequency := api.frequencyForTripInstance(ctx, tripID, todayMidnight, tripStartTimeForVehicle(vehicle, todayMidnight, serviceDate))
+		if schedule != nil {
+			schedule.Frequency = frequency
+		}
 		entry := models.TripsForLocationListEntry{
-			Frequency:    nil,
+			Frequency:    frequency,
 			Schedule:     schedule,
 			ServiceDate:  todayMidnight.UnixMilli(),
 			SituationIds: api.GetSituationIDsForTrip(tripID),
@@ -180,28 +189,25 @@ func (api *RestAPI) buildScheduleForTrip(
 	w http.ResponseWriter,
 	r *http.Request,
 ) *models.TripsSchedule {
-	shapeRows, _ := api.GtfsManager.GtfsDB.Queries.GetShapePointsByTripID(ctx, tripID)
-	var shapePoints []gtfs.ShapePoint
-	if len(shapeRows) > 1 {
-		shapePoints = make([]gtfs.ShapePoint, len(shapeRows))
-		for i, sp := range shapeRows {
-			shapePoints[i] = gtfs.ShapePoint{Latitude: sp.Lat, Longitude: sp.Lon}
-		}
+	trip, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, tripID)
+	if err != nil {
+		api.serverErrorResponse(w, r, err)
+		return nil
 	}
 
-	trip, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, tripID)
+	nextTripID, previousTripID, stopTimes, err := api.GetNextAndPreviousTripIDs(ctx, &trip, agencyID, serviceDate)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return nil
 	}
 
-	nextTripID, previousTripID, stopTimes, err := api.GetNextAndPreviousTripIDs(ctx, &trip, agencyID, serviceDate)
+	_, distances, err := api.GtfsManager.StopDistancesForTrip(ctx, tripID, stopTimes)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return nil
 	}
 
-	stopTimesList := buildStopTimesList(api, ctx, stopTimes, shapePoints, agencyID)
+	stopTimesList := buildStopTimesList(stopTimes, distances, agencyID)
 	return &models.TripsSchedule{
 		Frequency:      nil,
 		NextTripId:     nextTripID,
@@ -211,17 +217,18 @@ func (api *RestAPI) buildScheduleForTrip(
 	}
 }
 
-func buildStopTimesList(api *RestAPI, ctx context.Context, stopTimes []gtfsdb.StopTime, shapePoints []gtfs.ShapePoint, agencyID string) []models.StopTime {
+func buildStopTimesList(stopTimes []gtfsdb.StopTime, distances []float64, agencyID string) []models.StopTime {
 	stopTimesList := make([]models.StopTime, 0, len(stopTimes))
-	for _, stopTime := range stopTimes {
-		distanceAlongTrip := api.calculatePreciseDistanceAlongTrip(ctx, stopTime.StopID, shapePoints)
+	for i, stopTime := range stopTimes {
 		stopTimesList = append(stopTimesList, models.StopTime{
 			StopID:              utils.FormCombinedID(agencyID, stopTime.StopID),
 			ArrivalTime:         int(stopTime.ArrivalTime),
 			DepartureTime:       int(stopTime.DepartureTime),
 			StopHeadsign:        stopTime.StopHeadsign.String,
-			DistanceAlongTrip:   distanceAlongTrip,
+			DistanceAlongTrip:   distances[i],
 			HistoricalOccupancy: "",
+			TripID:              utils.FormCombinedID(agencyID, stopTime.TripID),
+			StopSequence:        stopTime.StopSequence,
 		})
 	}
 	return stopTimesList
@@ -304,7 +311,7 @@ func (rb *referenceBuilder) buildStopList(stops []*gtfs.Stop) {
 			continue
 		}
 
-		routeIds, err := rb.api.GtfsManager.GtfsDB.Queries.GetRouteIDsForStop(rb.ctx, stop.Id)
+		routeIds, err := rb.api.GtfsManager.GtfsDB().Queries.GetRouteIDsForStop(rb.ctx, stop.Id)
 		if err != nil {
 			continue
 		}
@@ -325,15 +332,19 @@ func (rb *referenceBuilder) processRouteIds(routeIds []interface{}) []string {
 }
 
 func (rb *referenceBuilder) createStop(stop *gtfs.Stop, routeIds []string) models.Stop {
+	var parent string
+	if stop.Parent != nil {
+		parent = gtfsdb.UnscopedID(stop.Parent.Id)
+	}
 	return models.Stop{
 		Code:               stop.Code,
 		Direction:          "NA", // TODO add direction to GTFS Stop
-		ID:                 stop.Id,
+		ID:                 gtfsdb.UnscopedID(stop.Id),
 		Lat:                *stop.Latitude,
 		Lon:                *stop.Longitude,
 		LocationType:       0,
 		Name:               stop.Name,
-		Parent:             "",
+		Parent:             parent,
 		RouteIDs:           routeIds,
 		StaticRouteIDs:     routeIds,
 		WheelchairBoarding: utils.MapWheelchairBoarding(stop.WheelchairBoarding),
@@ -357,7 +368,7 @@ func (rb *referenceBuilder) createTrip(trip gtfsdb.Trip) models.Trip {
 		TripHeadsign:  trip.TripHeadsign.String,
 		TripShortName: trip.TripShortName.String,
 		DirectionID:   trip.DirectionID.Int64,
-		BlockID:       trip.BlockID.String,
+		BlockID:       gtfsdb.UnscopedID(trip.BlockID.String),
 		ShapeID:       trip.ShapeID.String,
 		PeakOffPeak:   0,
 		TimeZone:      "",
@@ -396,7 +407,7 @@ func (rb *referenceBuilder) createRoute(route gtfsdb.Route) models.Route {
 }
 
 func (rb *referenceBuilder) addAgency(agencyID string) error {
-	agency, err := rb.api.GtfsManager.GtfsDB.Queries.GetAgency(rb.ctx, agencyID)
+	agency, err := rb.api.GtfsManager.GtfsDB().Queries.GetAgency(rb.ctx, agencyID)
 	if err != nil {
 		return err
 	}
@@ -420,7 +431,7 @@ func (rb *referenceBuilder) buildTripReferences() error {
 	rb.tripsRefList = make([]interface{}, 0, len(rb.presentTrips))
 
 	for _, trip := range rb.presentTrips {
-		tripDetails, err := rb.api.GtfsManager.GtfsDB.Queries.GetTrip(rb.ctx, trip.ID)
+		tripDetails, err := rb.api.GtfsManager.GtfsDB().Queries.GetTrip(rb.ctx, trip.ID)
 		if err != nil {
 			continue
 		}
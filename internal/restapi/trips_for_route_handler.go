@@ -23,7 +23,7 @@ func (api *RestAPI) tripsForRouteHandler(w http.ResponseWriter, r *http.Request)
 	includeSchedule := r.URL.Query().Get("includeSchedule") != "false"
 	includeStatus := r.URL.Query().Get("includeStatus") != "false"
 
-	currentAgency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, agencyID)
+	currentAgency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, agencyID)
 	if err != nil {
 		http.Error(w, "null", http.StatusNotFound)
 		return
@@ -36,19 +36,19 @@ func (api *RestAPI) tripsForRouteHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	timeParam := r.URL.Query().Get("time")
-	formattedDate, currentTime, fieldErrors, success := utils.ParseTimeParameter(timeParam, currentLocation)
+	_, currentTime, fieldErrors, success := utils.ParseTimeParameter(timeParam, currentLocation)
 	if !success {
 		api.validationErrorResponse(w, r, fieldErrors)
 		return
 	}
 
-	serviceIDs, err := api.GtfsManager.GtfsDB.Queries.GetActiveServiceIDsForDate(ctx, formattedDate)
+	serviceIDs, err := api.GtfsManager.GetActiveServiceIDsForDate(ctx, currentTime)
 	if err != nil {
 		api.serverErrorResponse(w, r, err)
 		return
 	}
 
-	routeTrips, err := api.GtfsManager.GtfsDB.Queries.GetTripsForRouteInActiveServiceIDs(ctx, gtfsdb.GetTripsForRouteInActiveServiceIDsParams{
+	routeTrips, err := api.GtfsManager.GtfsDB().Queries.GetTripsForRouteInActiveServiceIDs(ctx, gtfsdb.GetTripsForRouteInActiveServiceIDsParams{
 		RouteID:    routeID,
 		ServiceIds: serviceIDs,
 	})
@@ -66,7 +66,7 @@ func (api *RestAPI) tripsForRouteHandler(w http.ResponseWriter, r *http.Request)
 
 	var allRelatedTrips []gtfsdb.GetTripsByBlockIDRow
 	for blockID := range blockIDs {
-		relatedTrips, err := api.GtfsManager.GtfsDB.Queries.GetTripsByBlockID(ctx, sql.NullString{String: blockID, Valid: true})
+		relatedTrips, err := api.GtfsManager.GtfsDB().Queries.GetTripsByBlockID(ctx, sql.NullString{String: blockID, Valid: true})
 		if err != nil {
 			api.serverErrorResponse(w, r, err)
 			return
@@ -114,6 +114,11 @@ func (api *RestAPI) tripsForRouteHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	references := buildTripReferences(api, w, r, ctx, includeSchedule, allRoutes, allTrips, nil, result)
+	situationIDs := []string{}
+	for _, entry := range result {
+		situationIDs = append(situationIDs, entry.SituationIds...)
+	}
+	references.Situations = api.BuildSituationReferences(ctx, situationIDs)
 	response := models.NewListResponseWithRange(result, references, false)
 	api.sendResponse(w, r, response)
 }
@@ -155,8 +160,12 @@ func (api *RestAPI) buildTripsForRouteEntries(
 				continue
 			}
 		}
+		frequency := api.frequencyForTripInstance(ctx, tripID, todayMidnight, tripStartTimeForVehicle(vehicle, todayMidnight, currentTime))
+		if schedule != nil {
+			schedule.Frequency = frequency
+		}
 		entry := models.TripsForRouteListEntry{
-			Frequency:    nil,
+			Frequency:    frequency,
 			Schedule:     schedule,
 			Status:       status,
 			ServiceDate:  todayMidnight.UnixMilli(),
@@ -180,7 +189,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 		if stop.Latitude == nil || stop.Longitude == nil {
 			continue
 		}
-		routeIds, err := api.GtfsManager.GtfsDB.Queries.GetRouteIDsForStop(ctx, stop.Id)
+		routeIds, err := api.GtfsManager.GtfsDB().Queries.GetRouteIDsForStop(ctx, stop.Id)
 		if err != nil {
 			continue
 		}
@@ -191,6 +200,10 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 			routeIdsString[i] = id.(string)
 		}
 
+		var parent string
+		if stop.Parent != nil {
+			parent = stop.Parent.Id
+		}
 		stopList = append(stopList, models.Stop{
 			Code:               stop.Code,
 			Direction:          "NA", // TODO add direction
@@ -199,7 +212,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 			Lon:                *stop.Longitude,
 			LocationType:       0,
 			Name:               stop.Name,
-			Parent:             "",
+			Parent:             parent,
 			RouteIDs:           routeIdsString,
 			StaticRouteIDs:     routeIdsString,
 			WheelchairBoarding: utils.MapWheelchairBoarding(stop.WheelchairBoarding),
@@ -216,7 +229,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 				TripHeadsign:  trip.TripHeadsign.String,
 				TripShortName: trip.TripShortName.String,
 				DirectionID:   trip.DirectionID.Int64,
-				BlockID:       trip.BlockID.String,
+				BlockID:       gtfsdb.UnscopedID(trip.BlockID.String),
 				ShapeID:       trip.ShapeID.String,
 				PeakOffPeak:   0,
 				TimeZone:      "",
@@ -241,7 +254,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 				route.TextColor.String,
 				route.ShortName.String,
 			)
-			currentAgency, err := api.GtfsManager.GtfsDB.Queries.GetAgency(ctx, route.AgencyID)
+			currentAgency, err := api.GtfsManager.GtfsDB().Queries.GetAgency(ctx, route.AgencyID)
 			if err != nil {
 				api.serverErrorResponse(w, r, err)
 				return models.ReferencesModel{}
@@ -265,7 +278,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 	tripsRefList := make([]interface{}, 0, len(presentTrips))
 	if includeTrip {
 		for _, trip := range presentTrips {
-			tripDetails, err := api.GtfsManager.GtfsDB.Queries.GetTrip(ctx, trip.ID)
+			tripDetails, err := api.GtfsManager.GtfsDB().Queries.GetTrip(ctx, trip.ID)
 			if err == nil {
 				var currentAgency = presentRoutes[tripDetails.RouteID].AgencyID
 				tripsRefList = append(tripsRefList, models.Trip{
@@ -275,7 +288,7 @@ func buildTripReferences[T interface{ GetTripId() string }](api *RestAPI, w http
 					TripHeadsign:  tripDetails.TripHeadsign.String,
 					TripShortName: tripDetails.TripShortName.String,
 					DirectionID:   tripDetails.DirectionID.Int64,
-					BlockID:       tripDetails.BlockID.String,
+					BlockID:       gtfsdb.UnscopedID(tripDetails.BlockID.String),
 					ShapeID:       utils.FormCombinedID(currentAgency, tripDetails.ShapeID.String),
 					PeakOffPeak:   0,
 					TimeZone:      "",
@@ -30,7 +30,7 @@ func (api *RestAPI) BuildTripStatus(
 		}
 
 		if vehicle.ID != nil {
-			vehicleID = vehicle.ID.ID
+			vehicleID = gtfsdb.UnscopedID(vehicle.ID.ID)
 		}
 	}
 
@@ -47,15 +47,26 @@ func (api *RestAPI) BuildTripStatus(
 		status.OccupancyCapacity = int(*vehicle.OccupancyPercentage)
 	}
 
-	scheduleDeviation := api.calculateScheduleDeviationFromTripUpdates(tripID)
-	status.ScheduleDeviation = scheduleDeviation
+	// Stop times count from midnight of the service date in the agency's time zone
+	localServiceDate := serviceDate.In(currentTime.Location())
+	serviceMidnight := time.Date(localServiceDate.Year(), localServiceDate.Month(), localServiceDate.Day(), 0, 0, 0, 0, currentTime.Location())
+	if api.GtfsManager.IsTripCanceled(tripID, serviceMidnight) {
+		status.Status = arrivalStatusCanceled
+	}
+	prediction, err := api.GtfsManager.PredictTrip(ctx, tripID, serviceMidnight, currentTime)
+	if err == nil {
+		if deviation, ok := prediction.ScheduleDeviation(currentTime); ok {
+			// Schedule deviation is reported in seconds
+			status.ScheduleDeviation = int(deviation.Seconds())
+		}
+	}
 
 	blockTripSequence := api.setBlockTripSequence(ctx, tripID, serviceDate, status)
 	if blockTripSequence > 0 {
 		status.BlockTripSequence = blockTripSequence
 	}
 
-	shapeRows, err := api.GtfsManager.GtfsDB.Queries.GetShapePointsByTripID(ctx, tripID)
+	shapeRows, err := api.GtfsManager.GtfsDB().Queries.GetShapePointsByTripID(ctx, tripID)
 	if err == nil && len(shapeRows) > 1 {
 		shapePoints := make([]gtfs.ShapePoint, len(shapeRows))
 		for i, sp := range shapeRows {
@@ -71,14 +82,14 @@ func (api *RestAPI) BuildTripStatus(
 		}
 	}
 
-	stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesForTrip(ctx, tripID)
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, tripID)
 	if err == nil {
 		stopTimesPtrs := make([]*gtfsdb.StopTime, len(stopTimes))
 		for i := range stopTimes {
 			stopTimesPtrs[i] = &stopTimes[i]
 		}
 
-		shapeRows, err := api.GtfsManager.GtfsDB.Queries.GetShapePointsByTripID(ctx, tripID)
+		shapeRows, err := api.GtfsManager.GtfsDB().Queries.GetShapePointsByTripID(ctx, tripID)
 		if err != nil {
 			shapeRows = []gtfsdb.Shape{}
 		}
@@ -117,21 +128,14 @@ func (api *RestAPI) BuildTripStatus(
 }
 
 func (api *RestAPI) BuildTripSchedule(ctx context.Context, agencyID string, serviceDate time.Time, trip *gtfsdb.Trip, loc *time.Location) (*models.Schedule, error) {
-	stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesForTrip(ctx, trip.ID)
+	stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, trip.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	shapeRows, err := api.GtfsManager.GtfsDB.Queries.GetShapePointsByTripID(ctx, trip.ID)
-	var shapePoints []gtfs.ShapePoint
-	if err == nil && len(shapeRows) > 0 {
-		shapePoints = make([]gtfs.ShapePoint, len(shapeRows))
-		for i, sp := range shapeRows {
-			shapePoints[i] = gtfs.ShapePoint{
-				Latitude:  sp.Lat,
-				Longitude: sp.Lon,
-			}
-		}
+	_, distances, err := api.GtfsManager.StopDistancesForTrip(ctx, trip.ID, stopTimes)
+	if err != nil {
+		return nil, err
 	}
 
 	var nextTripID, previousTripID string
@@ -140,24 +144,31 @@ func (api *RestAPI) BuildTripSchedule(ctx context.Context, agencyID string, serv
 		return nil, err
 	}
 
+	localServiceDate := serviceDate.In(loc)
+	serviceMidnight := time.Date(localServiceDate.Year(), localServiceDate.Month(), localServiceDate.Day(), 0, 0, 0, 0, loc)
+
 	stopTimesVals := make([]models.StopTime, len(stopTimes))
 	for i, st := range stopTimes {
-		distanceAlongTrip := api.calculatePreciseDistanceAlongTrip(ctx, st.StopID, shapePoints)
-
 		stopTimesVals[i] = models.StopTime{
 			ArrivalTime:         int(st.ArrivalTime),
 			DepartureTime:       int(st.DepartureTime),
 			StopID:              utils.FormCombinedID(agencyID, st.StopID),
 			StopHeadsign:        st.StopHeadsign.String,
-			DistanceAlongTrip:   distanceAlongTrip,
+			DistanceAlongTrip:   distances[i],
 			HistoricalOccupancy: "",
+			TripID:              utils.FormCombinedID(agencyID, trip.ID),
+			StopSequence:        st.StopSequence,
+		}
+		// Realtime data can cancel the trip or skip the stop on the service date
+		if status := api.arrivalStatus(trip.ID, serviceMidnight, st.StopID, st.StopSequence); status != arrivalStatusDefault {
+			stopTimesVals[i].Status = status
 		}
 	}
 
 	return &models.Schedule{
 		StopTimes:      stopTimesVals,
 		TimeZone:       loc.String(),
-		Frequency:      0,
+		Frequency:      nil,
 		NextTripID:     nextTripID,
 		PreviousTripID: previousTripID,
 	}, nil
@@ -168,7 +179,7 @@ func (api *RestAPI) GetNextAndPreviousTripIDs(ctx context.Context, trip *gtfsdb.
 		return "", "", nil, nil
 	}
 
-	blockTrips, err := api.GtfsManager.GtfsDB.Queries.GetTripsByBlockID(ctx, trip.BlockID)
+	blockTrips, err := api.GtfsManager.GtfsDB().Queries.GetTripsByBlockID(ctx, trip.BlockID)
 	if err != nil {
 		return "", "", nil, err
 	}
@@ -188,7 +199,7 @@ func (api *RestAPI) GetNextAndPreviousTripIDs(ctx context.Context, trip *gtfsdb.
 	var tripsWithDetails []TripWithDetails
 
 	for _, blockTrip := range blockTrips {
-		stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesForTrip(ctx, blockTrip.ID)
+		stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, blockTrip.ID)
 		if err != nil || len(stopTimes) == 0 {
 			continue
 		}
@@ -281,7 +292,7 @@ func findNextStop(
 		stopIDs[i] = st.StopID
 	}
 
-	stops, err := api.GtfsManager.GtfsDB.Queries.GetStopsByIDs(ctx, stopIDs)
+	stops, err := api.GtfsManager.GtfsDB().Queries.GetStopsByIDs(ctx, stopIDs)
 	if err != nil {
 		return "", 0
 	}
@@ -319,7 +330,7 @@ func findClosestStop(api *RestAPI, ctx context.Context, pos *gtfs.Position, stop
 		stopIDs[i] = st.StopID
 	}
 
-	stops, err := api.GtfsManager.GtfsDB.Queries.GetStopsByIDs(ctx, stopIDs)
+	stops, err := api.GtfsManager.GtfsDB().Queries.GetStopsByIDs(ctx, stopIDs)
 	if err != nil {
 		return "", 0
 	}
@@ -430,15 +441,26 @@ func (api *RestAPI) setBlockTripSequence(ctx context.Context, tripID string, ser
 // calculateBlockTripSequence calculates the index of a trip within its block's ordered trip sequence
 // for trips that are active on the given service date
 func (api *RestAPI) calculateBlockTripSequence(ctx context.Context, tripID string, serviceDate time.Time) int {
-	blockID, err := api.GtfsManager.GtfsDB.Queries.GetBlockIDByTripID(ctx, tripID)
+	for i, blockTripID := range api.orderedBlockTripIDs(ctx, tripID, serviceDate) {
+		if blockTripID == tripID {
+			return i
+		}
+	}
+	return 0
+}
+
+// orderedBlockTripIDs returns the IDs of the trips of a trip's block that are active on the given
+// service date, in the order they run
+func (api *RestAPI) orderedBlockTripIDs(ctx context.Context, tripID string, serviceDate time.Time) []string {
+	blockID, err := api.GtfsManager.GtfsDB().Queries.GetBlockIDByTripID(ctx, tripID)
 
 	if err != nil || !blockID.Valid || blockID.String == "" {
-		return 0
+		return nil
 	}
 
-	blockTrips, err := api.GtfsManager.GtfsDB.Queries.GetTripsByBlockID(ctx, blockID)
+	blockTrips, err := api.GtfsManager.GtfsDB().Queries.GetTripsByBlockID(ctx, blockID)
 	if err != nil {
-		return 0
+		return nil
 	}
 
 	type TripWithDetails struct {
@@ -456,7 +478,7 @@ func (api *RestAPI) calculateBlockTripSequence(ctx context.Context, tripID strin
 		}
 
 		// Second, get the start time for this trip
-		stopTimes, err := api.GtfsManager.GtfsDB.Queries.GetStopTimesForTrip(ctx, blockTrip.ID)
+		stopTimes, err := api.GtfsManager.GtfsDB().Queries.GetStopTimesForTrip(ctx, blockTrip.ID)
 		if err != nil || len(stopTimes) == 0 {
 			continue
 		}
@@ -481,123 +503,18 @@ func (api *RestAPI) calculateBlockTripSequence(ctx context.Context, tripID strin
 		return activeTrips[i].StartTime < activeTrips[j].StartTime
 	})
 
+	tripIDs := make([]string, len(activeTrips))
 	for i, trip := range activeTrips {
-		if trip.TripID == tripID {
-			return i
-		}
+		tripIDs[i] = trip.TripID
 	}
-	return 0
+	return tripIDs
 }
 
-func (api *RestAPI) calculateScheduleDeviationFromTripUpdates(
-	tripID string,
-) int {
-	tripUpdates := api.GtfsManager.GetTripUpdatesForTrip(tripID)
-	if len(tripUpdates) == 0 {
-		return 0
-	}
-
-	tripUpdate := tripUpdates[0]
-
-	var bestDeviation int64 = 0
-	var foundRelevantUpdate bool
-
-	for _, stopTimeUpdate := range tripUpdate.StopTimeUpdates {
-		if stopTimeUpdate.Arrival != nil && stopTimeUpdate.Arrival.Delay != nil {
-			bestDeviation = int64(*stopTimeUpdate.Arrival.Delay)
-			foundRelevantUpdate = true
-		} else if stopTimeUpdate.Departure != nil && stopTimeUpdate.Departure.Delay != nil {
-			bestDeviation = int64(*stopTimeUpdate.Departure.Delay)
-			foundRelevantUpdate = true
-		}
-
-		if foundRelevantUpdate {
-			break
-		}
-	}
-
-	return int(bestDeviation)
-}
-
-func (api *RestAPI) calculatePreciseDistanceAlongTrip(ctx context.Context, stopID string, shapePoints []gtfs.ShapePoint) float64 {
-	if len(shapePoints) == 0 {
-		return 0.0
-	}
-
-	// Get stop coordinates
-	stop, err := api.GtfsManager.GtfsDB.Queries.GetStop(ctx, stopID)
-	if err != nil {
-		return 0.0
-	}
-
-	stopLat, stopLon := stop.Lat, stop.Lon
-
-	// Find the closest point on the shape to this stop
-	var minDistance float64 = math.Inf(1)
-	var closestSegmentIndex int
-	var projectionRatio float64
-
-	for i := 0; i < len(shapePoints)-1; i++ {
-		// Calculate distance from stop to this line segment
-		distance, ratio := distanceToLineSegment(
-			stopLat, stopLon,
-			shapePoints[i].Latitude, shapePoints[i].Longitude,
-			shapePoints[i+1].Latitude, shapePoints[i+1].Longitude,
-		)
-
-		if distance < minDistance {
-			minDistance = distance
-			closestSegmentIndex = i
-			projectionRatio = ratio
-		}
-	}
-
-	// Calculate cumulative distance up to the closest segment
-	var cumulativeDistance float64
-	for i := 1; i <= closestSegmentIndex; i++ {
-		cumulativeDistance += utils.Haversine(
-			shapePoints[i-1].Latitude, shapePoints[i-1].Longitude,
-			shapePoints[i].Latitude, shapePoints[i].Longitude,
-		)
-	}
-
-	// Add the projection distance within the closest segment
-	if closestSegmentIndex < len(shapePoints)-1 {
-		segmentDistance := utils.Haversine(
-			shapePoints[closestSegmentIndex].Latitude, shapePoints[closestSegmentIndex].Longitude,
-			shapePoints[closestSegmentIndex+1].Latitude, shapePoints[closestSegmentIndex+1].Longitude,
-		)
-		cumulativeDistance += segmentDistance * projectionRatio
-	}
-
-	return cumulativeDistance
-}
-
-// Helper function to calculate distance from point to line segment
-func distanceToLineSegment(px, py, x1, y1, x2, y2 float64) (distance, ratio float64) {
-	dx := x2 - x1
-	dy := y2 - y1
-
-	if dx == 0 && dy == 0 {
-		// Line segment is a point
-		return utils.Haversine(px, py, x1, y1), 0
-	}
-
-	// Calculate the parameter t for the projection of point onto the line
-	t := ((px-x1)*dx + (py-y1)*dy) / (dx*dx + dy*dy)
-
-	// Clamp t to [0, 1] to stay within the line segment
-	if t < 0 {
-		t = 0
-	} else if t > 1 {
-		t = 1
-	}
-
-	// Find the closest point on the line segment
-	closestX := x1 + t*dx
-	closestY := y1 + t*dy
-
-	return utils.Haversine(px, py, closestX, closestY), t
+// projectOntoShape returns the distance along the shape, in meters, of the point on the shape
+// closest to the given position.
+func projectOntoShape(lat, lon float64, shapePoints []gtfs.ShapePoint) float64 {
+	segment, ratio := utils.ProjectOntoShape(lat, lon, shapePoints)
+	return utils.DistanceAlongShape(shapePoints, segment, ratio)
 }
 
 func (api *RestAPI) GetSituationIDsForTrip(tripID string) []string {
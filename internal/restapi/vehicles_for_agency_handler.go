@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/models"
 	"maglev.onebusaway.org/internal/utils"
 )
@@ -28,7 +29,7 @@ func (api *RestAPI) vehiclesForAgencyHandler(w http.ResponseWriter, r *http.Requ
 
 	for _, vehicle := range vehiclesForAgency {
 		vehicleStatus := models.VehicleStatus{
-			VehicleID: vehicle.ID.ID,
+			VehicleID: gtfsdb.UnscopedID(vehicle.ID.ID),
 		}
 
 		// Set timestamps
@@ -51,7 +52,7 @@ func (api *RestAPI) vehiclesForAgencyHandler(w http.ResponseWriter, r *http.Requ
 		// Build trip status if trip is available
 		if vehicle.Trip != nil {
 			tripStatus := &models.TripStatus{
-				ActiveTripID:      vehicle.Trip.ID.ID,
+				ActiveTripID:      gtfsdb.UnscopedID(vehicle.Trip.ID.ID),
 				BlockTripSequence: 0,
 				Scheduled:         true,
 				Phase:             vehicleStatus.Phase,
@@ -84,12 +85,12 @@ func (api *RestAPI) vehiclesForAgencyHandler(w http.ResponseWriter, r *http.Requ
 
 			// Add trip to references (basic trip reference)
 			tripRefs[vehicle.Trip.ID.ID] = map[string]interface{}{
-				"id":      vehicle.Trip.ID.ID,
-				"routeId": vehicle.Trip.ID.RouteID,
+				"id":      gtfsdb.UnscopedID(vehicle.Trip.ID.ID),
+				"routeId": gtfsdb.UnscopedID(vehicle.Trip.ID.RouteID),
 			}
 
 			// Find and add route to references
-			if route, err := api.GtfsManager.GtfsDB.Queries.GetRoute(r.Context(), vehicle.Trip.ID.RouteID); err == nil {
+			if route, err := api.GtfsManager.GtfsDB().Queries.GetRoute(r.Context(), vehicle.Trip.ID.RouteID); err == nil {
 				shortName := ""
 				if route.ShortName.Valid {
 					shortName = route.ShortName.String
@@ -116,7 +117,7 @@ func (api *RestAPI) vehiclesForAgencyHandler(w http.ResponseWriter, r *http.Requ
 				}
 
 				routeRefs[route.ID] = models.NewRoute(
-					route.ID, route.AgencyID, shortName, longName,
+					gtfsdb.UnscopedID(route.ID), route.AgencyID, shortName, longName,
 					desc, models.RouteType(route.Type),
 					url, color, textColor, shortName,
 				)
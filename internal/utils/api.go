@@ -5,19 +5,41 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/OneBusAway/go-gtfs"
+	"maglev.onebusaway.org/gtfsdb"
 	"maglev.onebusaway.org/internal/models"
 )
 
-// ExtractCodeID extracts the `code_id` from a string in the format `{agency_id}_{code_id}`.
+// agencyNamespaces maps the ID of every served agency to the namespace its feed's IDs were
+// imported into, see gtfsdb.FeedNamespace.
+var agencyNamespaces atomic.Pointer[map[string]string]
+
+// SetAgencyNamespaces sets the namespace of the IDs of each agency's feed, used to resolve the
+// code IDs of combined IDs.
+func SetAgencyNamespaces(namespaces map[string]string) {
+	agencyNamespaces.Store(&namespaces)
+}
+
+// scopedCodeID returns the stored ID of a code ID of the given agency.
+func scopedCodeID(agencyID, codeID string) string {
+	namespaces := agencyNamespaces.Load()
+	if namespaces == nil {
+		return codeID
+	}
+	return gtfsdb.ScopedID((*namespaces)[agencyID], codeID)
+}
+
+// ExtractCodeID extracts the `code_id` from a string in the format `{agency_id}_{code_id}`, as
+// stored for the agency's feed.
 func ExtractCodeID(combinedID string) (string, error) {
 	parts := strings.SplitN(combinedID, "_", 2)
 	if len(parts) != 2 {
 		return "", fmt.Errorf("invalid format: %s", combinedID)
 	}
-	return parts[1], nil
+	return scopedCodeID(parts[0], parts[1]), nil
 }
 
 // ExtractAgencyID extracts the `agency_id` from a string in the format `{agency_id}_{code_id}`.
@@ -30,20 +52,22 @@ func ExtractAgencyID(combinedID string) (string, error) {
 }
 
 // ExtractAgencyIDAndCodeID Extract AgencyIDAndCodeID extracts both `agency_id` and `code_id` from a string in the format `{agency_id}_{code_id}`.
+// The code ID is the one stored for the agency's feed.
 func ExtractAgencyIDAndCodeID(combinedID string) (string, string, error) {
 	parts := strings.SplitN(combinedID, "_", 2)
 	if len(parts) != 2 {
 		return "", "", fmt.Errorf("invalid format: %s", combinedID)
 	}
-	return parts[0], parts[1], nil
+	return parts[0], scopedCodeID(parts[0], parts[1]), nil
 }
 
 // FormCombinedID forms a combined ID in the format `{agency_id}_{code_id}` using the given `agencyID` and `codeID`.
+// A stored code ID is given as it is written in its feed.
 func FormCombinedID(agencyID, codeID string) string {
 	if codeID == "" || agencyID == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s_%s", agencyID, codeID)
+	return fmt.Sprintf("%s_%s", agencyID, gtfsdb.UnscopedID(codeID))
 }
 
 // MapWheelchairBoarding converts GTFS wheelchair boarding values to our API format
@@ -31,7 +31,7 @@ func FilterRoutes(q *gtfsdb.Queries, ctx context.Context, present map[string]boo
 	for _, r := range routes {
 		if present[r.ID] {
 			refs = append(refs, models.NewRoute(
-				r.ID, r.AgencyID, r.ShortName.String, r.LongName.String,
+				gtfsdb.UnscopedID(r.ID), r.AgencyID, r.ShortName.String, r.LongName.String,
 				r.Desc.String, models.RouteType(r.Type), r.Url.String,
 				r.Color.String, r.TextColor.String, r.ShortName.String,
 			))
@@ -1,6 +1,10 @@
 package utils
 
-import "math"
+import (
+	"math"
+
+	"github.com/OneBusAway/go-gtfs"
+)
 
 // Haversine calculates the great-circle distance between two points on the Earth.
 func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
@@ -21,3 +25,83 @@ func Haversine(lat1, lon1, lat2, lon2 float64) float64 {
 	distance := R * c
 	return distance
 }
+
+// AtStopTolerance is how far from a stop along a shape, in meters, a vehicle can be and still
+// count as at the stop. It covers the precision of reported positions.
+const AtStopTolerance = 10.0
+
+// ProjectOntoShape finds the point of a shape closest to a position. It returns the index of the
+// shape segment the point is on, and how far along that segment it is, from 0 to 1.
+func ProjectOntoShape(lat, lon float64, shapePoints []gtfs.ShapePoint) (segment int, ratio float64) {
+	return ProjectOntoShapeFrom(lat, lon, shapePoints, 0, 0)
+}
+
+// ProjectOntoShapeFrom is ProjectOntoShape limited to the part of the shape from the given
+// segment and ratio on. Shapes that loop or double back pass some positions more than once, so
+// placing each stop of a trip from where the previous one was keeps them in order.
+func ProjectOntoShapeFrom(lat, lon float64, shapePoints []gtfs.ShapePoint, fromSegment int, fromRatio float64) (segment int, ratio float64) {
+	segment, ratio = fromSegment, fromRatio
+	minDistance := math.Inf(1)
+	for i := fromSegment; i < len(shapePoints)-1; i++ {
+		x1, y1 := shapePoints[i].Latitude, shapePoints[i].Longitude
+		x2, y2 := shapePoints[i+1].Latitude, shapePoints[i+1].Longitude
+		distance, t := distanceToLineSegment(lat, lon, x1, y1, x2, y2)
+		if i == fromSegment && t < fromRatio {
+			t = fromRatio
+			distance = Haversine(lat, lon, x1+t*(x2-x1), y1+t*(y2-y1))
+		}
+		if distance < minDistance {
+			minDistance = distance
+			segment = i
+			ratio = t
+		}
+	}
+	return segment, ratio
+}
+
+// DistanceAlongShape returns the distance in meters from the start of a shape to the point the
+// given fraction of the way along one of its segments.
+func DistanceAlongShape(shapePoints []gtfs.ShapePoint, segment int, ratio float64) float64 {
+	var distance float64
+	for i := 1; i <= segment && i < len(shapePoints); i++ {
+		distance += Haversine(
+			shapePoints[i-1].Latitude, shapePoints[i-1].Longitude,
+			shapePoints[i].Latitude, shapePoints[i].Longitude,
+		)
+	}
+	if segment < len(shapePoints)-1 {
+		distance += ratio * Haversine(
+			shapePoints[segment].Latitude, shapePoints[segment].Longitude,
+			shapePoints[segment+1].Latitude, shapePoints[segment+1].Longitude,
+		)
+	}
+	return distance
+}
+
+// distanceToLineSegment returns the distance in meters from a point to a line segment, and how far
+// along the segment, from 0 to 1, the closest point of the segment is.
+func distanceToLineSegment(px, py, x1, y1, x2, y2 float64) (distance, ratio float64) {
+	dx := x2 - x1
+	dy := y2 - y1
+
+	if dx == 0 && dy == 0 {
+		// Line segment is a point
+		return Haversine(px, py, x1, y1), 0
+	}
+
+	// Calculate the parameter t for the projection of point onto the line
+	t := ((px-x1)*dx + (py-y1)*dy) / (dx*dx + dy*dy)
+
+	// Clamp t to [0, 1] to stay within the line segment
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+
+	// Find the closest point on the line segment
+	closestX := x1 + t*dx
+	closestY := y1 + t*dy
+
+	return Haversine(px, py, closestX, closestY), t
+}
@@ -0,0 +1,39 @@
+package webui
+
+import (
+	"database/sql"
+	"net/http"
+
+	"maglev.onebusaway.org/gtfsdb"
+)
+
+// validationReport is the validation page's view of the findings
+type validationReport struct {
+	Summary  []gtfsdb.CountValidationFindingsRow
+	Findings []gtfsdb.ValidationFinding
+}
+
+func (webUI *WebUI) debugValidationHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := gtfsdb.ListValidationFindingsParams{}
+	if feedID := query.Get("feedId"); feedID != "" {
+		params.FeedID = sql.NullString{String: feedID, Valid: true}
+	}
+	if severity := query.Get("severity"); severity != "" {
+		params.Severity = sql.NullString{String: severity, Valid: true}
+	}
+
+	queries := webUI.GtfsManager.GtfsDB().Queries
+	summary, err := queries.CountValidationFindings(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	findings, err := queries.ListValidationFindings(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeDebugData(w, "GTFS Static - Validation", validationReport{Summary: summary, Findings: findings})
+}
@@ -1,7 +1,10 @@
 package webui
 
-import "net/http"
+import (
+	"net/http"
+)
 
 func (webUI *WebUI) SetWebUIRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /debug/", webUI.debugIndexHandler)
+	mux.HandleFunc("GET /debug/validation", webUI.debugValidationHandler)
 }